@@ -0,0 +1,42 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"rivercam/ptz"
+)
+
+// PTZAuditSource is the log of PTZ commands and whether the camera accepted them (satisfied by *ptz.AuditLog)
+type PTZAuditSource interface {
+	Entries(filter ptz.AuditFilter) []ptz.AuditEntry
+}
+
+// handlePTZAudit serves the recent PTZ commands, oldest first. Query parameters narrow them down: source,
+// object_id, since (RFC 3339) and limit (most recent n).
+func (s *Server) handlePTZAudit(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := ptz.AuditFilter{
+		Source:   query.Get("source"),
+		ObjectID: query.Get("object_id"),
+	}
+	if since := query.Get("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("since must be an RFC 3339 time: %v", err), http.StatusBadRequest)
+			return
+		}
+		filter.Since = t
+	}
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			http.Error(w, "limit must be a non-negative number", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = n
+	}
+	writeJSON(w, http.StatusOK, s.Audit.Entries(filter))
+}
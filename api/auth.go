@@ -0,0 +1,325 @@
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Global debug function for api package
+var debugMsgFunc func(string, string, ...string)
+
+// SetDebugFunction allows main package to provide debug function
+func SetDebugFunction(fn func(string, string, ...string)) {
+	debugMsgFunc = fn
+}
+
+// debugMsg is a wrapper that handles nil checks
+func debugMsg(component, message string, boatID ...string) {
+	if debugMsgFunc != nil {
+		debugMsgFunc(component, message, boatID...)
+	}
+}
+
+// Role is a control API permission level. Higher roles include all lower permissions.
+type Role int
+
+const (
+	RoleViewer   Role = iota // Status and video streams
+	RoleOperator             // Pin targets, pause/resume scanning
+	RoleAdmin                // Tracking parameters and PTZ limits
+)
+
+func (r Role) String() string {
+	switch r {
+	case RoleViewer:
+		return "viewer"
+	case RoleOperator:
+		return "operator"
+	case RoleAdmin:
+		return "admin"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseRole converts a role name from the auth config into a Role
+func ParseRole(name string) (Role, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "viewer":
+		return RoleViewer, nil
+	case "operator":
+		return RoleOperator, nil
+	case "admin":
+		return RoleAdmin, nil
+	default:
+		return RoleViewer, fmt.Errorf("unknown role %q (valid roles are: viewer, operator, admin)", name)
+	}
+}
+
+// User is an API client identified by a bearer token
+type User struct {
+	Name  string `json:"name"`
+	Token string `json:"token"`
+	Role  string `json:"role"`
+	role  Role
+}
+
+// AuthConfig represents the structure of the API users file
+type AuthConfig struct {
+	Users []User `json:"users"`
+}
+
+// Authenticator checks API tokens against configured users and enforces role requirements
+type Authenticator struct {
+	users []User
+	audit *AuditLog
+}
+
+// LoadAuthenticator reads the API users file and opens the audit log
+func LoadAuthenticator(usersPath, auditPath string) (*Authenticator, error) {
+	data, err := os.ReadFile(usersPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API users file: %v", err)
+	}
+
+	var config AuthConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse API users file: %v", err)
+	}
+
+	if len(config.Users) == 0 {
+		return nil, fmt.Errorf("API users file %s defines no users", usersPath)
+	}
+
+	seenTokens := make(map[string]string)
+	for i := range config.Users {
+		user := &config.Users[i]
+		if user.Name == "" || user.Token == "" {
+			return nil, fmt.Errorf("API user #%d is missing a name or token", i+1)
+		}
+		if other, exists := seenTokens[user.Token]; exists {
+			return nil, fmt.Errorf("API users %s and %s share the same token", other, user.Name)
+		}
+		seenTokens[user.Token] = user.Name
+
+		role, err := ParseRole(user.Role)
+		if err != nil {
+			return nil, fmt.Errorf("API user %s: %v", user.Name, err)
+		}
+		user.role = role
+	}
+
+	audit, err := NewAuditLog(auditPath)
+	if err != nil {
+		return nil, err
+	}
+
+	debugMsg("API_AUTH", fmt.Sprintf("🔐 Loaded %d API users from %s (audit log: %s)", len(config.Users), usersPath, auditPath))
+
+	return &Authenticator{
+		users: config.Users,
+		audit: audit,
+	}, nil
+}
+
+// AuditLog returns the audit log access decisions are recorded to
+func (a *Authenticator) AuditLog() *AuditLog {
+	return a.audit
+}
+
+// Close closes the audit log
+func (a *Authenticator) Close() error {
+	return a.audit.Close()
+}
+
+// Authentication failures returned by Authorize
+var (
+	ErrUnauthenticated = errors.New("authentication required")
+	ErrForbidden       = errors.New("role not sufficient")
+)
+
+// Authenticate returns the user owning the request's token, if any.
+// Tokens are accepted from "Authorization: Bearer <token>" or, for browser WebSocket clients
+// that cannot set headers, the "token" query parameter.
+func (a *Authenticator) Authenticate(r *http.Request) (*User, bool) {
+	return a.lookupToken(requestToken(r))
+}
+
+// requestToken returns the bearer token or "token" query parameter of a request ("" = none)
+func requestToken(r *http.Request) string {
+	if authHeader := r.Header.Get("Authorization"); strings.HasPrefix(authHeader, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(authHeader, "Bearer "))
+	}
+	return r.URL.Query().Get("token")
+}
+
+// lookupToken returns the user owning a token, if any
+func (a *Authenticator) lookupToken(token string) (*User, bool) {
+	if token == "" {
+		return nil, false
+	}
+
+	for i := range a.users {
+		// Constant-time compare so tokens can't be guessed byte by byte
+		if subtle.ConstantTimeCompare([]byte(a.users[i].Token), []byte(token)) == 1 {
+			return &a.users[i], true
+		}
+	}
+	return nil, false
+}
+
+// Authorize returns the user owning a token if they hold at least minRole, or an error wrapping
+// ErrUnauthenticated or ErrForbidden. Every attempt is written to the audit log under the given action name;
+// method and path describe the call, for transports other than HTTP such as the gRPC API.
+func (a *Authenticator) Authorize(token string, minRole Role, action, method, path, remote string) (*User, error) {
+	user, ok := a.lookupToken(token)
+	if !ok {
+		a.audit.Record(AuditEntry{
+			User:     "anonymous",
+			Action:   action,
+			Method:   method,
+			Path:     path,
+			Remote:   remote,
+			Allowed:  false,
+			Decision: "unauthenticated",
+		})
+		return nil, ErrUnauthenticated
+	}
+
+	if user.role < minRole {
+		a.audit.Record(AuditEntry{
+			User:     user.Name,
+			Role:     user.role.String(),
+			Action:   action,
+			Method:   method,
+			Path:     path,
+			Remote:   remote,
+			Allowed:  false,
+			Decision: fmt.Sprintf("requires %s", minRole),
+		})
+		return nil, fmt.Errorf("%w: %s role required", ErrForbidden, minRole)
+	}
+
+	a.audit.Record(AuditEntry{
+		User:     user.Name,
+		Role:     user.role.String(),
+		Action:   action,
+		Method:   method,
+		Path:     path,
+		Remote:   remote,
+		Allowed:  true,
+		Decision: "allowed",
+	})
+	return user, nil
+}
+
+// Require wraps a handler so it only runs for users holding at least minRole.
+// Every attempt, allowed or denied, is written to the audit log under the given action name.
+func (a *Authenticator) Require(minRole Role, action string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, err := a.Authorize(requestToken(r), minRole, action, r.Method, r.URL.Path, remoteHost(r))
+		switch {
+		case errors.Is(err, ErrUnauthenticated):
+			w.Header().Set("WWW-Authenticate", `Bearer realm="NOLO"`)
+			http.Error(w, "authentication required", http.StatusUnauthorized)
+			return
+		case err != nil:
+			http.Error(w, fmt.Sprintf("%s role required", minRole), http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// remoteHost extracts the client address for audit entries
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// AuditEntry is a single line in the API audit log
+type AuditEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	User      string    `json:"user"`
+	Role      string    `json:"role,omitempty"`
+	Action    string    `json:"action"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Remote    string    `json:"remote"`
+	Allowed   bool      `json:"allowed"`
+	Decision  string    `json:"decision"`
+}
+
+// AuditLog appends API access decisions to a JSON-lines file
+type AuditLog struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewAuditLog opens (or creates) the audit log file in append mode
+func NewAuditLog(path string) (*AuditLog, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0640)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open API audit log: %v", err)
+	}
+	return &AuditLog{file: file}, nil
+}
+
+// Record writes an entry to the audit log and mirrors denials to the debug log
+func (al *AuditLog) Record(entry AuditEntry) {
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+
+	if !entry.Allowed {
+		debugMsg("API_AUTH", fmt.Sprintf("🚫 Denied %s %s (%s) for %s@%s: %s",
+			entry.Method, entry.Path, entry.Action, entry.User, entry.Remote, entry.Decision))
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		debugMsg("API_AUTH_ERROR", fmt.Sprintf("Failed to encode audit entry: %v", err))
+		return
+	}
+
+	al.mu.Lock()
+	defer al.mu.Unlock()
+	if _, err := al.file.Write(append(line, '\n')); err != nil {
+		debugMsg("API_AUTH_ERROR", fmt.Sprintf("Failed to write audit entry: %v", err))
+	}
+}
+
+// BetweenWrites runs fn while no entry is being written, and reopens the log file when fn replaced it
+// (retention)
+func (al *AuditLog) BetweenWrites(fn func() bool) {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+	if !fn() {
+		return
+	}
+	file, err := os.OpenFile(al.file.Name(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0640)
+	if err != nil {
+		debugMsg("API_AUTH_ERROR", fmt.Sprintf("Failed to reopen audit log: %v", err))
+		return
+	}
+	al.file.Close()
+	al.file = file
+}
+
+// Close closes the audit log file
+func (al *AuditLog) Close() error {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+	return al.file.Close()
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestAuthenticator loads an authenticator with one user per role and returns it with its audit log path
+func newTestAuthenticator(t *testing.T) (*Authenticator, string) {
+	t.Helper()
+	dir := t.TempDir()
+	usersPath := filepath.Join(dir, "users.json")
+	auditPath := filepath.Join(dir, "audit.jsonl")
+	users := `{"users": [
+		{"name": "guest", "token": "viewer-token", "role": "viewer"},
+		{"name": "harbor", "token": "operator-token", "role": "operator"},
+		{"name": "root", "token": "admin-token", "role": "Admin"}
+	]}`
+	if err := os.WriteFile(usersPath, []byte(users), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	auth, err := LoadAuthenticator(usersPath, auditPath)
+	if err != nil {
+		t.Fatalf("LoadAuthenticator: %v", err)
+	}
+	t.Cleanup(func() { auth.Close() })
+	return auth, auditPath
+}
+
+func readAudit(t *testing.T, path string) []AuditEntry {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("audit line %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestAuthorize(t *testing.T) {
+	auth, auditPath := newTestAuthenticator(t)
+
+	tests := []struct {
+		name     string
+		token    string
+		minRole  Role
+		wantUser string
+		wantErr  error
+		decision string
+	}{
+		{"no token", "", RoleViewer, "", ErrUnauthenticated, "unauthenticated"},
+		{"unknown token", "guessed", RoleViewer, "", ErrUnauthenticated, "unauthenticated"},
+		{"token prefix", "admin", RoleViewer, "", ErrUnauthenticated, "unauthenticated"},
+		{"viewer reads status", "viewer-token", RoleViewer, "guest", nil, "allowed"},
+		{"viewer pins a target", "viewer-token", RoleOperator, "", ErrForbidden, "requires operator"},
+		{"operator pins a target", "operator-token", RoleOperator, "harbor", nil, "allowed"},
+		{"operator edits limits", "operator-token", RoleAdmin, "", ErrForbidden, "requires admin"},
+		{"admin edits limits", "admin-token", RoleAdmin, "root", nil, "allowed"},
+		{"admin reads status", "admin-token", RoleViewer, "root", nil, "allowed"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			user, err := auth.Authorize(test.token, test.minRole, "test", "POST", "/api/test", "192.0.2.1")
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("err = %v, want %v", err, test.wantErr)
+			}
+			if test.wantUser == "" {
+				if user != nil {
+					t.Errorf("user = %s, want none", user.Name)
+				}
+			} else if user == nil || user.Name != test.wantUser {
+				t.Errorf("user = %v, want %s", user, test.wantUser)
+			}
+		})
+	}
+
+	entries := readAudit(t, auditPath)
+	if len(entries) != len(tests) {
+		t.Fatalf("%d audit entries, want one per attempt (%d)", len(entries), len(tests))
+	}
+	for i, entry := range entries {
+		test := tests[i]
+		if entry.Decision != test.decision || entry.Allowed != (test.wantErr == nil) {
+			t.Errorf("%s: audit decision %q allowed=%v, want %q", test.name, entry.Decision, entry.Allowed, test.decision)
+		}
+		if entry.Action != "test" || entry.Method != "POST" || entry.Path != "/api/test" || entry.Remote != "192.0.2.1" {
+			t.Errorf("%s: audit entry %+v does not describe the call", test.name, entry)
+		}
+	}
+}
+
+func TestLoadAuthenticatorRejectsBadUsers(t *testing.T) {
+	tests := map[string]string{
+		"no users":      `{"users": []}`,
+		"missing token": `{"users": [{"name": "a", "role": "viewer"}]}`,
+		"shared token":  `{"users": [{"name": "a", "token": "t", "role": "viewer"}, {"name": "b", "token": "t", "role": "admin"}]}`,
+		"unknown role":  `{"users": [{"name": "a", "token": "t", "role": "captain"}]}`,
+	}
+	for name, users := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			usersPath := filepath.Join(dir, "users.json")
+			if err := os.WriteFile(usersPath, []byte(users), 0600); err != nil {
+				t.Fatal(err)
+			}
+			if auth, err := LoadAuthenticator(usersPath, filepath.Join(dir, "audit.jsonl")); err == nil {
+				auth.Close()
+				t.Errorf("users file accepted")
+			}
+		})
+	}
+}
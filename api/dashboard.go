@@ -0,0 +1,315 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"rivercam/ptz"
+)
+
+// previewBuffer is how many frames a slow MJPEG client may fall behind before frames are dropped for it
+const previewBuffer = 2
+
+// CameraSource reports where the camera points and whether it is moving (satisfied by *ptz.CameraStateManager)
+type CameraSource interface {
+	GetCurrentPosition() ptz.PTZPosition
+	GetState() ptz.CameraState
+	GetTargetPosition() *ptz.PTZPosition
+}
+
+// PreviewHub fans the annotated output frames (JPEG) out to MJPEG viewers. Publishing never blocks: a client
+// that cannot keep up skips frames.
+type PreviewHub struct {
+	mu      sync.Mutex
+	clients map[chan []byte]struct{}
+}
+
+// NewPreviewHub creates an empty preview hub
+func NewPreviewHub() *PreviewHub {
+	return &PreviewHub{clients: make(map[chan []byte]struct{})}
+}
+
+// Active reports whether anyone is watching, so the pipeline can skip encoding frames nobody sees
+func (h *PreviewHub) Active() bool {
+	if h == nil {
+		return false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.clients) > 0
+}
+
+// Publish sends a JPEG frame to every viewer. The frame must not be modified afterwards.
+func (h *PreviewHub) Publish(jpeg []byte) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for client := range h.clients {
+		select {
+		case client <- jpeg:
+		default: // Viewer is behind; it gets the next frame
+		}
+	}
+}
+
+func (h *PreviewHub) subscribe() chan []byte {
+	client := make(chan []byte, previewBuffer)
+	h.mu.Lock()
+	h.clients[client] = struct{}{}
+	h.mu.Unlock()
+	return client
+}
+
+func (h *PreviewHub) unsubscribe(client chan []byte) {
+	h.mu.Lock()
+	delete(h.clients, client)
+	h.mu.Unlock()
+}
+
+// handleDashboard serves the dashboard page. The page itself holds no data; it asks for a token and calls
+// the API with it, so it is served without authentication.
+func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	fmt.Fprint(w, dashboardHTML)
+}
+
+// handleStream serves the annotated output as multipart MJPEG until the client disconnects
+func (s *Server) handleStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	client := s.Preview.subscribe()
+	defer s.Preview.unsubscribe(client)
+	debugMsg("API", fmt.Sprintf("📺 Preview viewer connected from %s", r.RemoteAddr))
+
+	w.Header().Set("Content-Type", "multipart/x-mixed-replace; boundary=frame")
+	w.Header().Set("Cache-Control", "no-cache, no-store")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			debugMsg("API", fmt.Sprintf("📺 Preview viewer %s disconnected", r.RemoteAddr))
+			return
+		case frame := <-client:
+			if _, err := fmt.Fprintf(w, "--frame\r\nContent-Type: image/jpeg\r\nContent-Length: %d\r\n\r\n", len(frame)); err != nil {
+				return
+			}
+			if _, err := w.Write(frame); err != nil {
+				return
+			}
+			if _, err := w.Write([]byte("\r\n")); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *Server) handleCamera(w http.ResponseWriter, r *http.Request) {
+	position := s.Camera.GetCurrentPosition()
+	response := struct {
+		State  string           `json:"state"`
+		Pan    float64          `json:"pan"`
+		Tilt   float64          `json:"tilt"`
+		Zoom   float64          `json:"zoom"`
+		Target *ptz.PTZPosition `json:"target,omitempty"`
+	}{
+		State:  s.Camera.GetState().String(),
+		Pan:    position.Pan,
+		Tilt:   position.Tilt,
+		Zoom:   position.Zoom,
+		Target: s.Camera.GetTargetPosition(),
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// dashboardHTML is the built-in live dashboard: preview stream, camera position, lock state and the tracked
+// objects, with buttons to pin a target, go back to scanning or drive the camera manually
+const dashboardHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>NOLO River Camera</title>
+<style>
+body { margin: 0; font-family: sans-serif; background: #10161c; color: #dde4ea; }
+header { display: flex; gap: 12px; align-items: center; padding: 8px 16px; background: #1b252e; }
+header h1 { font-size: 18px; margin: 0 auto 0 0; }
+main { display: flex; flex-wrap: wrap; gap: 16px; padding: 16px; }
+#preview { flex: 3 1 640px; }
+#preview img { width: 100%; background: #000; min-height: 200px; }
+#side { flex: 1 1 320px; }
+section { background: #1b252e; border-radius: 4px; padding: 8px 12px; margin-bottom: 12px; }
+h2 { font-size: 14px; margin: 4px 0 8px; text-transform: uppercase; color: #8fa3b5; }
+table { width: 100%; border-collapse: collapse; font-size: 13px; }
+td, th { text-align: left; padding: 3px 4px; border-bottom: 1px solid #2a3845; }
+tr.target { background: #23402a; }
+button { background: #2d5f8a; color: #fff; border: 0; border-radius: 3px; padding: 4px 10px; cursor: pointer; }
+button.stop { background: #8a2d2d; }
+#status { font-size: 12px; color: #f0a0a0; }
+</style>
+</head>
+<body>
+<header>
+<h1>NOLO River Camera</h1>
+<input id="token" type="password" placeholder="API token" size="24">
+<button onclick="saveToken()">Connect</button>
+<span id="status"></span>
+</header>
+<main>
+<div id="preview"><img id="stream" alt="live preview"></div>
+<div id="side">
+<section>
+<h2>Camera</h2>
+<div id="camera">-</div>
+</section>
+<section>
+<h2>Tracking</h2>
+<div id="mode">-</div>
+<p>
+<button onclick="scan()">Force scanning</button>
+<button class="stop" onclick="setScanning(false)">Pause scanning</button>
+<button onclick="act(call('DELETE', '/target'))">Release target</button>
+</p>
+</section>
+<section>
+<h2>Manual control</h2>
+<div id="manual">-</div>
+<p>
+<button onclick="move(-1, 0, 0)">◀</button>
+<button onclick="move(0, -1, 0)">▲</button>
+<button onclick="move(0, 1, 0)">▼</button>
+<button onclick="move(1, 0, 0)">▶</button>
+<button onclick="move(0, 0, 1)">Zoom +</button>
+<button onclick="move(0, 0, -1)">Zoom −</button>
+<button onclick="act(call('DELETE', '/manual'))">Resume tracking</button>
+</p>
+</section>
+<section>
+<h2>Objects</h2>
+<table>
+<thead><tr><th>ID</th><th>Class</th><th>State</th><th>Pan/Tilt</th><th>Speed</th><th></th></tr></thead>
+<tbody id="objects"></tbody>
+</table>
+</section>
+</div>
+</main>
+<script>
+let token = localStorage.getItem('nolo-token') || '';
+document.getElementById('token').value = token;
+
+function saveToken() {
+  token = document.getElementById('token').value;
+  localStorage.setItem('nolo-token', token);
+  startStream();
+  refresh();
+}
+
+function startStream() {
+  document.getElementById('stream').src = '/stream.mjpg?token=' + encodeURIComponent(token) + '&t=' + Date.now();
+}
+
+async function call(method, path, body) {
+  const options = {method: method, headers: {'Authorization': 'Bearer ' + token}};
+  if (body !== undefined) {
+    options.headers['Content-Type'] = 'application/json';
+    options.body = JSON.stringify(body);
+  }
+  const response = await fetch(path, options);
+  if (!response.ok) {
+    throw new Error(method + ' ' + path + ': ' + response.status + ' ' + (await response.text()).trim());
+  }
+  return response.status === 204 ? null : response.json();
+}
+
+function act(promise) {
+  promise.then(refresh).catch(err => { document.getElementById('status').textContent = err.message; });
+}
+
+function scan() {
+  act(call('DELETE', '/target').then(() => call('POST', '/scanning', {enabled: true})));
+}
+
+function setScanning(enabled) {
+  act(call('POST', '/scanning', {enabled: enabled}));
+}
+
+function move(pan, tilt, zoom) {
+  act(call('POST', '/manual/move', {pan: pan, tilt: tilt, zoom: zoom}));
+}
+
+function pin(id) {
+  act(call('POST', '/target/' + encodeURIComponent(id)));
+}
+
+function text(tag, value) {
+  const cell = document.createElement(tag);
+  cell.textContent = value;
+  return cell;
+}
+
+async function refresh() {
+  if (!token) {
+    document.getElementById('status').textContent = 'Enter an API token';
+    return;
+  }
+  try {
+    const [target, scanning, objects, camera, manual] = await Promise.all([
+      call('GET', '/target'), call('GET', '/scanning'), call('GET', '/objects'),
+      call('GET', '/camera').catch(() => null), call('GET', '/manual')]);
+
+    let mode = target.mode + (target.target_id ? ' - target ' + target.target_id : '') + (target.pinned ? ' (pinned)' : '');
+    mode += ' | scanning ' + (scanning.enabled ? 'on' : 'paused') + (scanning.profile ? ' (' + scanning.profile + ')' : '');
+    document.getElementById('mode').textContent = mode;
+
+    document.getElementById('camera').textContent = camera
+      ? camera.state + ' - pan ' + camera.pan.toFixed(0) + ' tilt ' + camera.tilt.toFixed(0) + ' zoom ' + camera.zoom.toFixed(0)
+      : 'not available';
+
+    document.getElementById('manual').textContent = manual.active
+      ? 'operator in control - tracking resumes in ' + manual.remaining_s.toFixed(0) + 's' + (manual.target_id ? ' (target ' + manual.target_id + ' kept)' : '')
+      : 'automatic tracking';
+
+    const rows = document.getElementById('objects');
+    rows.replaceChildren();
+    for (const boat of objects) {
+      const row = document.createElement('tr');
+      if (boat.IsTarget) row.className = 'target';
+      row.append(text('td', boat.ID), text('td', boat.Classification),
+        text('td', boat.State + (boat.IsLocked ? ' 🔒' : '')),
+        text('td', boat.CurrentSpatial.Pan.toFixed(0) + '/' + boat.CurrentSpatial.Tilt.toFixed(0)),
+        text('td', boat.Speed ? boat.Speed.knots.toFixed(1) + ' kn' : '-'));
+      const action = document.createElement('td');
+      const button = document.createElement('button');
+      button.textContent = 'Track';
+      button.onclick = () => pin(boat.ID);
+      action.append(button);
+      row.append(action);
+      rows.append(row);
+    }
+    document.getElementById('status').textContent = '';
+  } catch (err) {
+    document.getElementById('status').textContent = err.message;
+  }
+}
+
+if (token) startStream();
+refresh();
+setInterval(refresh, 2000);
+</script>
+</body>
+</html>
+`
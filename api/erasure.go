@@ -0,0 +1,76 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"rivercam/retention"
+)
+
+// ObjectEraser erases everything stored about an object: snapshots, trajectories, event records and database
+// rows (satisfied by *retention.Purger)
+type ObjectEraser interface {
+	PurgeObject(objectID string) (*retention.PurgeReport, error)
+}
+
+// ErasureReport is what a right-to-erasure request removed
+type ErasureReport struct {
+	ObjectID     string   `json:"object_id"`
+	Snapshots    int      `json:"snapshots"`
+	Trajectories int      `json:"trajectories"`
+	Events       int      `json:"events"` // Event records and database rows
+	BytesFreed   int64    `json:"bytes_freed"`
+	Errors       []string `json:"errors,omitempty"`
+}
+
+// handleObject serves GET /objects/{id}/snapshot.jpg to viewers and DELETE /objects/{id} to admins
+func (s *Server) handleObject(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && s.Snapshots != nil:
+		s.auth.Require(RoleViewer, "object_snapshot", s.handleObjectSnapshot)(w, r)
+	case r.Method == http.MethodDelete && s.Erasure != nil:
+		s.auth.Require(RoleAdmin, "erase_object", s.handleEraseObject)(w, r)
+	default:
+		var allowed []string
+		if s.Snapshots != nil {
+			allowed = append(allowed, http.MethodGet)
+		}
+		if s.Erasure != nil {
+			allowed = append(allowed, http.MethodDelete)
+		}
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleEraseObject erases every stored artifact of an object (right-to-erasure request). A partial erasure
+// answers 500 with what was removed and what failed, so the request can be retried.
+func (s *Server) handleEraseObject(w http.ResponseWriter, r *http.Request) {
+	objectID := strings.TrimPrefix(r.URL.Path, "/objects/")
+	if objectID == "" || strings.Contains(objectID, "/") {
+		http.Error(w, "expected /objects/{id}", http.StatusNotFound)
+		return
+	}
+
+	purged, err := s.Erasure.PurgeObject(objectID)
+	if purged == nil {
+		http.Error(w, fmt.Sprintf("cannot erase object: %v", err), http.StatusBadRequest)
+		return
+	}
+	report := ErasureReport{
+		ObjectID:     objectID,
+		Snapshots:    purged.FilesRemoved[retention.Snapshots],
+		Trajectories: purged.FilesRemoved[retention.Trajectories],
+		Events:       purged.FilesRemoved[retention.Events],
+		BytesFreed:   purged.BytesFreed,
+		Errors:       purged.Errors,
+	}
+	status := http.StatusOK
+	if err != nil {
+		status = http.StatusInternalServerError
+	}
+	debugMsg("API", fmt.Sprintf("🗑️ Erased object %s: %d snapshots, %d trajectories, %d events (%d errors)",
+		objectID, report.Snapshots, report.Trajectories, report.Events, len(report.Errors)), objectID)
+	writeJSON(w, status, report)
+}
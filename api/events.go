@@ -0,0 +1,58 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	eventStreamBuffer = 256 // Events queued per client before it starts missing them
+	eventStreamPing   = 30 * time.Second
+)
+
+// handleEvents upgrades to a WebSocket and streams tracking events as JSON text messages until the client
+// disconnects. Browsers pass their token as ?token= since they cannot set headers on WebSocket requests.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgradeWebSocket(w, r)
+	if err != nil {
+		debugMsg("API_EVENTS", fmt.Sprintf("⚠️ Event stream request from %s rejected: %v", remoteHost(r), err))
+		return
+	}
+	defer conn.Close()
+
+	events, unsubscribe := s.Events.Subscribe(eventStreamBuffer)
+	defer unsubscribe()
+
+	debugMsg("API_EVENTS", fmt.Sprintf("📡 Event stream client connected from %s (%d connected)", remoteHost(r), s.Events.Subscribers()))
+	defer debugMsg("API_EVENTS", fmt.Sprintf("📡 Event stream client %s disconnected", remoteHost(r)))
+
+	go conn.readLoop()
+
+	ping := time.NewTicker(eventStreamPing)
+	defer ping.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			payload, err := json.Marshal(event)
+			if err != nil {
+				debugMsg("API_ERROR", fmt.Sprintf("Failed to encode event: %v", err))
+				continue
+			}
+			if conn.WriteText(payload) != nil {
+				return
+			}
+		case <-ping.C:
+			if conn.Ping() != nil {
+				return
+			}
+		case <-conn.Closed():
+			return
+		}
+	}
+}
@@ -0,0 +1,2174 @@
+// gRPC control API for NOLO: the tracking state, target control, configuration updates and a stream of
+// tracking events, for services orchestrating several instances. It mirrors the REST/WebSocket API and uses
+// the same users file: every call carries "authorization: Bearer <token>" metadata and needs the same role
+// as its REST counterpart.
+//
+// Regenerate the Go code with `make proto` after changing this file.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: nolo.proto
+
+package nolopb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetStateRequest) Reset() {
+	*x = GetStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStateRequest) ProtoMessage() {}
+
+func (x *GetStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStateRequest.ProtoReflect.Descriptor instead.
+func (*GetStateRequest) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{0}
+}
+
+type TrackingState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CapturedAt           *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=captured_at,json=capturedAt,proto3" json:"captured_at,omitempty"`
+	Frame                int64                  `protobuf:"varint,2,opt,name=frame,proto3" json:"frame,omitempty"`
+	Mode                 string                 `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`                                             // SCANNING, TRACKING, LOCK or the recovery phase
+	TargetId             string                 `protobuf:"bytes,4,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`                     // Object the camera follows ("" = none)
+	PinnedTargetId       string                 `protobuf:"bytes,5,opt,name=pinned_target_id,json=pinnedTargetId,proto3" json:"pinned_target_id,omitempty"` // Object pinned by an operator ("" = none)
+	ScanningPaused       bool                   `protobuf:"varint,6,opt,name=scanning_paused,json=scanningPaused,proto3" json:"scanning_paused,omitempty"`
+	TourTargetId         string                 `protobuf:"bytes,7,opt,name=tour_target_id,json=tourTargetId,proto3" json:"tour_target_id,omitempty"` // Object whose turn it is in tour mode ("" = no tour)
+	TotalDetectedObjects int64                  `protobuf:"varint,8,opt,name=total_detected_objects,json=totalDetectedObjects,proto3" json:"total_detected_objects,omitempty"`
+	Objects              []*TrackedObject       `protobuf:"bytes,9,rep,name=objects,proto3" json:"objects,omitempty"`
+	LastCommand          *CameraPosition        `protobuf:"bytes,10,opt,name=last_command,json=lastCommand,proto3" json:"last_command,omitempty"` // Last position sent to the camera
+}
+
+func (x *TrackingState) Reset() {
+	*x = TrackingState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrackingState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackingState) ProtoMessage() {}
+
+func (x *TrackingState) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackingState.ProtoReflect.Descriptor instead.
+func (*TrackingState) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TrackingState) GetCapturedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CapturedAt
+	}
+	return nil
+}
+
+func (x *TrackingState) GetFrame() int64 {
+	if x != nil {
+		return x.Frame
+	}
+	return 0
+}
+
+func (x *TrackingState) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *TrackingState) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *TrackingState) GetPinnedTargetId() string {
+	if x != nil {
+		return x.PinnedTargetId
+	}
+	return ""
+}
+
+func (x *TrackingState) GetScanningPaused() bool {
+	if x != nil {
+		return x.ScanningPaused
+	}
+	return false
+}
+
+func (x *TrackingState) GetTourTargetId() string {
+	if x != nil {
+		return x.TourTargetId
+	}
+	return ""
+}
+
+func (x *TrackingState) GetTotalDetectedObjects() int64 {
+	if x != nil {
+		return x.TotalDetectedObjects
+	}
+	return 0
+}
+
+func (x *TrackingState) GetObjects() []*TrackedObject {
+	if x != nil {
+		return x.Objects
+	}
+	return nil
+}
+
+func (x *TrackingState) GetLastCommand() *CameraPosition {
+	if x != nil {
+		return x.LastCommand
+	}
+	return nil
+}
+
+type TrackedObject struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Classification   string                 `protobuf:"bytes,2,opt,name=classification,proto3" json:"classification,omitempty"`
+	Confidence       float64                `protobuf:"fixed64,3,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	State            string                 `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"` // TENTATIVE, CONFIRMED, LOCKED, SUPER_LOCKED or COASTING
+	DetectionCount   int32                  `protobuf:"varint,5,opt,name=detection_count,json=detectionCount,proto3" json:"detection_count,omitempty"`
+	LostFrames       int32                  `protobuf:"varint,6,opt,name=lost_frames,json=lostFrames,proto3" json:"lost_frames,omitempty"`
+	Locked           bool                   `protobuf:"varint,7,opt,name=locked,proto3" json:"locked,omitempty"`
+	IsTarget         bool                   `protobuf:"varint,8,opt,name=is_target,json=isTarget,proto3" json:"is_target,omitempty"`
+	LockStrength     float64                `protobuf:"fixed64,9,opt,name=lock_strength,json=lockStrength,proto3" json:"lock_strength,omitempty"`
+	TrackingPriority float64                `protobuf:"fixed64,10,opt,name=tracking_priority,json=trackingPriority,proto3" json:"tracking_priority,omitempty"`
+	FirstDetected    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=first_detected,json=firstDetected,proto3" json:"first_detected,omitempty"`
+	LastSeen         *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	Center           *Point                 `protobuf:"bytes,13,opt,name=center,proto3" json:"center,omitempty"` // Pixels
+	Box              *Box                   `protobuf:"bytes,14,opt,name=box,proto3" json:"box,omitempty"`       // Pixels
+	PixelArea        float64                `protobuf:"fixed64,15,opt,name=pixel_area,json=pixelArea,proto3" json:"pixel_area,omitempty"`
+	VelocityX        float64                `protobuf:"fixed64,16,opt,name=velocity_x,json=velocityX,proto3" json:"velocity_x,omitempty"` // Pixels per second
+	VelocityY        float64                `protobuf:"fixed64,17,opt,name=velocity_y,json=velocityY,proto3" json:"velocity_y,omitempty"`
+	People           int32                  `protobuf:"varint,18,opt,name=people,proto3" json:"people,omitempty"`
+	Quality          float64                `protobuf:"fixed64,19,opt,name=quality,proto3" json:"quality,omitempty"` // Track quality 0-1 (1 until measured)
+	Quarantined      bool                   `protobuf:"varint,20,opt,name=quarantined,proto3" json:"quarantined,omitempty"`
+	EdgeClipped      string                 `protobuf:"bytes,21,opt,name=edge_clipped,json=edgeClipped,proto3" json:"edge_clipped,omitempty"` // Frame borders the object is cut off by, e.g. "left+top" ("" = none)
+}
+
+func (x *TrackedObject) Reset() {
+	*x = TrackedObject{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrackedObject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackedObject) ProtoMessage() {}
+
+func (x *TrackedObject) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackedObject.ProtoReflect.Descriptor instead.
+func (*TrackedObject) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TrackedObject) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TrackedObject) GetClassification() string {
+	if x != nil {
+		return x.Classification
+	}
+	return ""
+}
+
+func (x *TrackedObject) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *TrackedObject) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *TrackedObject) GetDetectionCount() int32 {
+	if x != nil {
+		return x.DetectionCount
+	}
+	return 0
+}
+
+func (x *TrackedObject) GetLostFrames() int32 {
+	if x != nil {
+		return x.LostFrames
+	}
+	return 0
+}
+
+func (x *TrackedObject) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+func (x *TrackedObject) GetIsTarget() bool {
+	if x != nil {
+		return x.IsTarget
+	}
+	return false
+}
+
+func (x *TrackedObject) GetLockStrength() float64 {
+	if x != nil {
+		return x.LockStrength
+	}
+	return 0
+}
+
+func (x *TrackedObject) GetTrackingPriority() float64 {
+	if x != nil {
+		return x.TrackingPriority
+	}
+	return 0
+}
+
+func (x *TrackedObject) GetFirstDetected() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstDetected
+	}
+	return nil
+}
+
+func (x *TrackedObject) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *TrackedObject) GetCenter() *Point {
+	if x != nil {
+		return x.Center
+	}
+	return nil
+}
+
+func (x *TrackedObject) GetBox() *Box {
+	if x != nil {
+		return x.Box
+	}
+	return nil
+}
+
+func (x *TrackedObject) GetPixelArea() float64 {
+	if x != nil {
+		return x.PixelArea
+	}
+	return 0
+}
+
+func (x *TrackedObject) GetVelocityX() float64 {
+	if x != nil {
+		return x.VelocityX
+	}
+	return 0
+}
+
+func (x *TrackedObject) GetVelocityY() float64 {
+	if x != nil {
+		return x.VelocityY
+	}
+	return 0
+}
+
+func (x *TrackedObject) GetPeople() int32 {
+	if x != nil {
+		return x.People
+	}
+	return 0
+}
+
+func (x *TrackedObject) GetQuality() float64 {
+	if x != nil {
+		return x.Quality
+	}
+	return 0
+}
+
+func (x *TrackedObject) GetQuarantined() bool {
+	if x != nil {
+		return x.Quarantined
+	}
+	return false
+}
+
+func (x *TrackedObject) GetEdgeClipped() string {
+	if x != nil {
+		return x.EdgeClipped
+	}
+	return ""
+}
+
+type Point struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X int32 `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y int32 `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+}
+
+func (x *Point) Reset() {
+	*x = Point{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Point) ProtoMessage() {}
+
+func (x *Point) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Point.ProtoReflect.Descriptor instead.
+func (*Point) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Point) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Point) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+type Box struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MinX int32 `protobuf:"varint,1,opt,name=min_x,json=minX,proto3" json:"min_x,omitempty"`
+	MinY int32 `protobuf:"varint,2,opt,name=min_y,json=minY,proto3" json:"min_y,omitempty"`
+	MaxX int32 `protobuf:"varint,3,opt,name=max_x,json=maxX,proto3" json:"max_x,omitempty"`
+	MaxY int32 `protobuf:"varint,4,opt,name=max_y,json=maxY,proto3" json:"max_y,omitempty"`
+}
+
+func (x *Box) Reset() {
+	*x = Box{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Box) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Box) ProtoMessage() {}
+
+func (x *Box) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Box.ProtoReflect.Descriptor instead.
+func (*Box) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Box) GetMinX() int32 {
+	if x != nil {
+		return x.MinX
+	}
+	return 0
+}
+
+func (x *Box) GetMinY() int32 {
+	if x != nil {
+		return x.MinY
+	}
+	return 0
+}
+
+func (x *Box) GetMaxX() int32 {
+	if x != nil {
+		return x.MaxX
+	}
+	return 0
+}
+
+func (x *Box) GetMaxY() int32 {
+	if x != nil {
+		return x.MaxY
+	}
+	return 0
+}
+
+type CameraPosition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pan  float64 `protobuf:"fixed64,1,opt,name=pan,proto3" json:"pan,omitempty"`
+	Tilt float64 `protobuf:"fixed64,2,opt,name=tilt,proto3" json:"tilt,omitempty"`
+	Zoom float64 `protobuf:"fixed64,3,opt,name=zoom,proto3" json:"zoom,omitempty"`
+}
+
+func (x *CameraPosition) Reset() {
+	*x = CameraPosition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CameraPosition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CameraPosition) ProtoMessage() {}
+
+func (x *CameraPosition) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CameraPosition.ProtoReflect.Descriptor instead.
+func (*CameraPosition) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CameraPosition) GetPan() float64 {
+	if x != nil {
+		return x.Pan
+	}
+	return 0
+}
+
+func (x *CameraPosition) GetTilt() float64 {
+	if x != nil {
+		return x.Tilt
+	}
+	return 0
+}
+
+func (x *CameraPosition) GetZoom() float64 {
+	if x != nil {
+		return x.Zoom
+	}
+	return 0
+}
+
+type PinTargetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ObjectId string `protobuf:"bytes,1,opt,name=object_id,json=objectId,proto3" json:"object_id,omitempty"`
+}
+
+func (x *PinTargetRequest) Reset() {
+	*x = PinTargetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PinTargetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinTargetRequest) ProtoMessage() {}
+
+func (x *PinTargetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinTargetRequest.ProtoReflect.Descriptor instead.
+func (*PinTargetRequest) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PinTargetRequest) GetObjectId() string {
+	if x != nil {
+		return x.ObjectId
+	}
+	return ""
+}
+
+type ReleaseTargetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ReleaseTargetRequest) Reset() {
+	*x = ReleaseTargetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReleaseTargetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseTargetRequest) ProtoMessage() {}
+
+func (x *ReleaseTargetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseTargetRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseTargetRequest) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{7}
+}
+
+type TargetStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mode     string `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	TargetId string `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Pinned   bool   `protobuf:"varint,3,opt,name=pinned,proto3" json:"pinned,omitempty"`
+}
+
+func (x *TargetStatus) Reset() {
+	*x = TargetStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TargetStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetStatus) ProtoMessage() {}
+
+func (x *TargetStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TargetStatus.ProtoReflect.Descriptor instead.
+func (*TargetStatus) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TargetStatus) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *TargetStatus) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *TargetStatus) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+type SetScanningRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+}
+
+func (x *SetScanningRequest) Reset() {
+	*x = SetScanningRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetScanningRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetScanningRequest) ProtoMessage() {}
+
+func (x *SetScanningRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetScanningRequest.ProtoReflect.Descriptor instead.
+func (*SetScanningRequest) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SetScanningRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type SelectScanProfileRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *SelectScanProfileRequest) Reset() {
+	*x = SelectScanProfileRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SelectScanProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectScanProfileRequest) ProtoMessage() {}
+
+func (x *SelectScanProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectScanProfileRequest.ProtoReflect.Descriptor instead.
+func (*SelectScanProfileRequest) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SelectScanProfileRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ScanningStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled bool   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Profile string `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"` // Active scan profile
+}
+
+func (x *ScanningStatus) Reset() {
+	*x = ScanningStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScanningStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanningStatus) ProtoMessage() {}
+
+func (x *ScanningStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanningStatus.ProtoReflect.Descriptor instead.
+func (*ScanningStatus) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ScanningStatus) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *ScanningStatus) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+type GetConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetConfigRequest) Reset() {
+	*x = GetConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigRequest) ProtoMessage() {}
+
+func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{12}
+}
+
+type Config struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TrackLists *TrackLists `protobuf:"bytes,1,opt,name=track_lists,json=trackLists,proto3" json:"track_lists,omitempty"`
+	SmartPtz   *SmartPTZ   `protobuf:"bytes,2,opt,name=smart_ptz,json=smartPtz,proto3" json:"smart_ptz,omitempty"`
+	Scoring    *Scoring    `protobuf:"bytes,3,opt,name=scoring,proto3" json:"scoring,omitempty"`
+}
+
+func (x *Config) Reset() {
+	*x = Config{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Config) ProtoMessage() {}
+
+func (x *Config) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Config.ProtoReflect.Descriptor instead.
+func (*Config) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Config) GetTrackLists() *TrackLists {
+	if x != nil {
+		return x.TrackLists
+	}
+	return nil
+}
+
+func (x *Config) GetSmartPtz() *SmartPTZ {
+	if x != nil {
+		return x.SmartPtz
+	}
+	return nil
+}
+
+func (x *Config) GetScoring() *Scoring {
+	if x != nil {
+		return x.Scoring
+	}
+	return nil
+}
+
+type TrackLists struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	P1    []string `protobuf:"bytes,1,rep,name=p1,proto3" json:"p1,omitempty"`
+	P2    []string `protobuf:"bytes,2,rep,name=p2,proto3" json:"p2,omitempty"`
+	P1All bool     `protobuf:"varint,3,opt,name=p1_all,json=p1All,proto3" json:"p1_all,omitempty"`
+	P2All bool     `protobuf:"varint,4,opt,name=p2_all,json=p2All,proto3" json:"p2_all,omitempty"`
+}
+
+func (x *TrackLists) Reset() {
+	*x = TrackLists{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrackLists) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackLists) ProtoMessage() {}
+
+func (x *TrackLists) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackLists.ProtoReflect.Descriptor instead.
+func (*TrackLists) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TrackLists) GetP1() []string {
+	if x != nil {
+		return x.P1
+	}
+	return nil
+}
+
+func (x *TrackLists) GetP2() []string {
+	if x != nil {
+		return x.P2
+	}
+	return nil
+}
+
+func (x *TrackLists) GetP1All() bool {
+	if x != nil {
+		return x.P1All
+	}
+	return false
+}
+
+func (x *TrackLists) GetP2All() bool {
+	if x != nil {
+		return x.P2All
+	}
+	return false
+}
+
+type SmartPTZ struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled         bool    `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	PredictionTime  float64 `protobuf:"fixed64,2,opt,name=prediction_time,json=predictionTime,proto3" json:"prediction_time,omitempty"`    // Seconds
+	MinVelocity     float64 `protobuf:"fixed64,3,opt,name=min_velocity,json=minVelocity,proto3" json:"min_velocity,omitempty"`             // Pixels/frame
+	BufferFactor    float64 `protobuf:"fixed64,4,opt,name=buffer_factor,json=bufferFactor,proto3" json:"buffer_factor,omitempty"`          // Fraction of the frame kept as margin
+	PipelineLatency float64 `protobuf:"fixed64,5,opt,name=pipeline_latency,json=pipelineLatency,proto3" json:"pipeline_latency,omitempty"` // Seconds
+	CenterTrigger   float64 `protobuf:"fixed64,6,opt,name=center_trigger,json=centerTrigger,proto3" json:"center_trigger,omitempty"`       // Fraction of the frame
+}
+
+func (x *SmartPTZ) Reset() {
+	*x = SmartPTZ{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SmartPTZ) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SmartPTZ) ProtoMessage() {}
+
+func (x *SmartPTZ) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SmartPTZ.ProtoReflect.Descriptor instead.
+func (*SmartPTZ) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SmartPTZ) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *SmartPTZ) GetPredictionTime() float64 {
+	if x != nil {
+		return x.PredictionTime
+	}
+	return 0
+}
+
+func (x *SmartPTZ) GetMinVelocity() float64 {
+	if x != nil {
+		return x.MinVelocity
+	}
+	return 0
+}
+
+func (x *SmartPTZ) GetBufferFactor() float64 {
+	if x != nil {
+		return x.BufferFactor
+	}
+	return 0
+}
+
+func (x *SmartPTZ) GetPipelineLatency() float64 {
+	if x != nil {
+		return x.PipelineLatency
+	}
+	return 0
+}
+
+func (x *SmartPTZ) GetCenterTrigger() float64 {
+	if x != nil {
+		return x.CenterTrigger
+	}
+	return 0
+}
+
+type Scoring struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Strategy  string             `protobuf:"bytes,1,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Weights   map[string]float64 `protobuf:"bytes,2,rep,name=weights,proto3" json:"weights,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	Available []string           `protobuf:"bytes,3,rep,name=available,proto3" json:"available,omitempty"` // Built-in strategies
+}
+
+func (x *Scoring) Reset() {
+	*x = Scoring{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Scoring) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Scoring) ProtoMessage() {}
+
+func (x *Scoring) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Scoring.ProtoReflect.Descriptor instead.
+func (*Scoring) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Scoring) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *Scoring) GetWeights() map[string]float64 {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+func (x *Scoring) GetAvailable() []string {
+	if x != nil {
+		return x.Available
+	}
+	return nil
+}
+
+type UpdateConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TrackLists *TrackLists     `protobuf:"bytes,1,opt,name=track_lists,json=trackLists,proto3" json:"track_lists,omitempty"` // Replaces both lists when set
+	SmartPtz   *SmartPTZUpdate `protobuf:"bytes,2,opt,name=smart_ptz,json=smartPtz,proto3" json:"smart_ptz,omitempty"`
+	Scoring    *ScoringUpdate  `protobuf:"bytes,3,opt,name=scoring,proto3" json:"scoring,omitempty"`
+}
+
+func (x *UpdateConfigRequest) Reset() {
+	*x = UpdateConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateConfigRequest) ProtoMessage() {}
+
+func (x *UpdateConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateConfigRequest.ProtoReflect.Descriptor instead.
+func (*UpdateConfigRequest) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateConfigRequest) GetTrackLists() *TrackLists {
+	if x != nil {
+		return x.TrackLists
+	}
+	return nil
+}
+
+func (x *UpdateConfigRequest) GetSmartPtz() *SmartPTZUpdate {
+	if x != nil {
+		return x.SmartPtz
+	}
+	return nil
+}
+
+func (x *UpdateConfigRequest) GetScoring() *ScoringUpdate {
+	if x != nil {
+		return x.Scoring
+	}
+	return nil
+}
+
+type SmartPTZUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled         *bool    `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	PredictionTime  *float64 `protobuf:"fixed64,2,opt,name=prediction_time,json=predictionTime,proto3,oneof" json:"prediction_time,omitempty"`
+	MinVelocity     *float64 `protobuf:"fixed64,3,opt,name=min_velocity,json=minVelocity,proto3,oneof" json:"min_velocity,omitempty"`
+	BufferFactor    *float64 `protobuf:"fixed64,4,opt,name=buffer_factor,json=bufferFactor,proto3,oneof" json:"buffer_factor,omitempty"`
+	PipelineLatency *float64 `protobuf:"fixed64,5,opt,name=pipeline_latency,json=pipelineLatency,proto3,oneof" json:"pipeline_latency,omitempty"`
+	CenterTrigger   *float64 `protobuf:"fixed64,6,opt,name=center_trigger,json=centerTrigger,proto3,oneof" json:"center_trigger,omitempty"`
+}
+
+func (x *SmartPTZUpdate) Reset() {
+	*x = SmartPTZUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SmartPTZUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SmartPTZUpdate) ProtoMessage() {}
+
+func (x *SmartPTZUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SmartPTZUpdate.ProtoReflect.Descriptor instead.
+func (*SmartPTZUpdate) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SmartPTZUpdate) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *SmartPTZUpdate) GetPredictionTime() float64 {
+	if x != nil && x.PredictionTime != nil {
+		return *x.PredictionTime
+	}
+	return 0
+}
+
+func (x *SmartPTZUpdate) GetMinVelocity() float64 {
+	if x != nil && x.MinVelocity != nil {
+		return *x.MinVelocity
+	}
+	return 0
+}
+
+func (x *SmartPTZUpdate) GetBufferFactor() float64 {
+	if x != nil && x.BufferFactor != nil {
+		return *x.BufferFactor
+	}
+	return 0
+}
+
+func (x *SmartPTZUpdate) GetPipelineLatency() float64 {
+	if x != nil && x.PipelineLatency != nil {
+		return *x.PipelineLatency
+	}
+	return 0
+}
+
+func (x *SmartPTZUpdate) GetCenterTrigger() float64 {
+	if x != nil && x.CenterTrigger != nil {
+		return *x.CenterTrigger
+	}
+	return 0
+}
+
+type ScoringUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Strategy string             `protobuf:"bytes,1,opt,name=strategy,proto3" json:"strategy,omitempty"`                                                                                         // "" keeps the current strategy
+	Weights  map[string]float64 `protobuf:"bytes,2,rep,name=weights,proto3" json:"weights,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"` // Weight overrides by factor
+}
+
+func (x *ScoringUpdate) Reset() {
+	*x = ScoringUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScoringUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoringUpdate) ProtoMessage() {}
+
+func (x *ScoringUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoringUpdate.ProtoReflect.Descriptor instead.
+func (*ScoringUpdate) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ScoringUpdate) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *ScoringUpdate) GetWeights() map[string]float64 {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+type StreamEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Types []string `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"` // Event types to receive, e.g. "lock_acquired" (empty = all)
+}
+
+func (x *StreamEventsRequest) Reset() {
+	*x = StreamEventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsRequest) ProtoMessage() {}
+
+func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *StreamEventsRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time      *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Type      string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	ObjectId  string                 `protobuf:"bytes,3,opt,name=object_id,json=objectId,proto3" json:"object_id,omitempty"`
+	Component string                 `protobuf:"bytes,4,opt,name=component,proto3" json:"component,omitempty"`
+	Message   string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	Data      *structpb.Struct       `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nolo_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_nolo_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_nolo_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Event) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetObjectId() string {
+	if x != nil {
+		return x.ObjectId
+	}
+	return ""
+}
+
+func (x *Event) GetComponent() string {
+	if x != nil {
+		return x.Component
+	}
+	return ""
+}
+
+func (x *Event) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Event) GetData() *structpb.Struct {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_nolo_proto protoreflect.FileDescriptor
+
+var file_nolo_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x6e, 0x6f, 0x6c, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x6e, 0x6f,
+	0x6c, 0x6f, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x11, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xb0, 0x03, 0x0a, 0x0d, 0x54, 0x72, 0x61, 0x63,
+	0x6b, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x61, 0x70,
+	0x74, 0x75, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63, 0x61, 0x70, 0x74,
+	0x75, 0x72, 0x65, 0x64, 0x41, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6d, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x12, 0x28, 0x0a,
+	0x10, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x54,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x63, 0x61, 0x6e, 0x6e,
+	0x69, 0x6e, 0x67, 0x5f, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0e, 0x73, 0x63, 0x61, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64,
+	0x12, 0x24, 0x0a, 0x0e, 0x74, 0x6f, 0x75, 0x72, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x6f, 0x75, 0x72, 0x54, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x16, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f,
+	0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x14, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x44, 0x65, 0x74,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x30, 0x0a, 0x07,
+	0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x6e, 0x6f, 0x6c, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x07, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x3a,
+	0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6e, 0x6f, 0x6c, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x61, 0x6d, 0x65, 0x72, 0x61, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x6c,
+	0x61, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x22, 0xe6, 0x05, 0x0a, 0x0d, 0x54,
+	0x72, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x26, 0x0a, 0x0e,
+	0x63, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e,
+	0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x65,
+	0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0e, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x6f, 0x73, 0x74, 0x5f, 0x66, 0x72, 0x61, 0x6d,
+	0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6c, 0x6f, 0x73, 0x74, 0x46, 0x72,
+	0x61, 0x6d, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x1b, 0x0a, 0x09,
+	0x69, 0x73, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x69, 0x73, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x6f, 0x63,
+	0x6b, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0c, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x2b,
+	0x0a, 0x11, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x72, 0x69, 0x6f, 0x72,
+	0x69, 0x74, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x74, 0x72, 0x61, 0x63, 0x6b,
+	0x69, 0x6e, 0x67, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x41, 0x0a, 0x0e, 0x66,
+	0x69, 0x72, 0x73, 0x74, 0x5f, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0d, 0x66, 0x69, 0x72, 0x73, 0x74, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x37,
+	0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x6c,
+	0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x65, 0x6e, 0x74, 0x65,
+	0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x6c, 0x6f, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x06, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x12,
+	0x1e, 0x0a, 0x03, 0x62, 0x6f, 0x78, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6e,
+	0x6f, 0x6c, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x78, 0x52, 0x03, 0x62, 0x6f, 0x78, 0x12,
+	0x1d, 0x0a, 0x0a, 0x70, 0x69, 0x78, 0x65, 0x6c, 0x5f, 0x61, 0x72, 0x65, 0x61, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x09, 0x70, 0x69, 0x78, 0x65, 0x6c, 0x41, 0x72, 0x65, 0x61, 0x12, 0x1d,
+	0x0a, 0x0a, 0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x78, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x09, 0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x58, 0x12, 0x1d, 0x0a,
+	0x0a, 0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x79, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x09, 0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x59, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x65, 0x6f, 0x70, 0x6c, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x65,
+	0x6f, 0x70, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x18,
+	0x13, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x20,
+	0x0a, 0x0b, 0x71, 0x75, 0x61, 0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x64, 0x18, 0x14, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x71, 0x75, 0x61, 0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x65, 0x64, 0x67, 0x65, 0x5f, 0x63, 0x6c, 0x69, 0x70, 0x70, 0x65, 0x64,
+	0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x64, 0x67, 0x65, 0x43, 0x6c, 0x69, 0x70,
+	0x70, 0x65, 0x64, 0x22, 0x23, 0x0a, 0x05, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x0c, 0x0a, 0x01,
+	0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x79, 0x22, 0x59, 0x0a, 0x03, 0x42, 0x6f, 0x78, 0x12,
+	0x13, 0x0a, 0x05, 0x6d, 0x69, 0x6e, 0x5f, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x6d, 0x69, 0x6e, 0x58, 0x12, 0x13, 0x0a, 0x05, 0x6d, 0x69, 0x6e, 0x5f, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x6d, 0x69, 0x6e, 0x59, 0x12, 0x13, 0x0a, 0x05, 0x6d, 0x61, 0x78,
+	0x5f, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6d, 0x61, 0x78, 0x58, 0x12, 0x13,
+	0x0a, 0x05, 0x6d, 0x61, 0x78, 0x5f, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6d,
+	0x61, 0x78, 0x59, 0x22, 0x4a, 0x0a, 0x0e, 0x43, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x50, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x61, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x03, 0x70, 0x61, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6c, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x74, 0x69, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x7a,
+	0x6f, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x7a, 0x6f, 0x6f, 0x6d, 0x22,
+	0x2f, 0x0a, 0x10, 0x50, 0x69, 0x6e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64,
+	0x22, 0x16, 0x0a, 0x14, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x57, 0x0a, 0x0c, 0x54, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x69, 0x6e,
+	0x6e, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x69, 0x6e, 0x6e, 0x65,
+	0x64, 0x22, 0x2e, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x6e, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x64, 0x22, 0x2e, 0x0a, 0x18, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x22, 0x44, 0x0a, 0x0e, 0x53, 0x63, 0x61, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x22, 0x12, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x9a, 0x01, 0x0a, 0x06,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x34, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x5f,
+	0x6c, 0x69, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6e, 0x6f,
+	0x6c, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x73,
+	0x52, 0x0a, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x09,
+	0x73, 0x6d, 0x61, 0x72, 0x74, 0x5f, 0x70, 0x74, 0x7a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x6e, 0x6f, 0x6c, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6d, 0x61, 0x72, 0x74, 0x50,
+	0x54, 0x5a, 0x52, 0x08, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x50, 0x74, 0x7a, 0x12, 0x2a, 0x0a, 0x07,
+	0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x6e, 0x6f, 0x6c, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x52,
+	0x07, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x22, 0x5a, 0x0a, 0x0a, 0x54, 0x72, 0x61, 0x63,
+	0x6b, 0x4c, 0x69, 0x73, 0x74, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x70, 0x31, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x02, 0x70, 0x31, 0x12, 0x0e, 0x0a, 0x02, 0x70, 0x32, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x02, 0x70, 0x32, 0x12, 0x15, 0x0a, 0x06, 0x70, 0x31, 0x5f, 0x61, 0x6c, 0x6c,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x70, 0x31, 0x41, 0x6c, 0x6c, 0x12, 0x15, 0x0a,
+	0x06, 0x70, 0x32, 0x5f, 0x61, 0x6c, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x70,
+	0x32, 0x41, 0x6c, 0x6c, 0x22, 0xe7, 0x01, 0x0a, 0x08, 0x53, 0x6d, 0x61, 0x72, 0x74, 0x50, 0x54,
+	0x5a, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x70,
+	0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x69, 0x6e, 0x5f, 0x76, 0x65, 0x6c, 0x6f,
+	0x63, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x56,
+	0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x75, 0x66, 0x66, 0x65,
+	0x72, 0x5f, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c,
+	0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x29, 0x0a, 0x10,
+	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65,
+	0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x65, 0x6e, 0x74, 0x65,
+	0x72, 0x5f, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0d, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x22, 0xb8,
+	0x01, 0x0a, 0x07, 0x53, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x37, 0x0a, 0x07, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6e, 0x6f, 0x6c, 0x6f, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x12,
+	0x1c, 0x0a, 0x09, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x09, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x1a, 0x3a, 0x0a,
+	0x0c, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb3, 0x01, 0x0a, 0x13, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x34, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6e, 0x6f, 0x6c, 0x6f, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x73, 0x52, 0x0a, 0x74, 0x72, 0x61,
+	0x63, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x73, 0x12, 0x34, 0x0a, 0x09, 0x73, 0x6d, 0x61, 0x72, 0x74,
+	0x5f, 0x70, 0x74, 0x7a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6e, 0x6f, 0x6c,
+	0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6d, 0x61, 0x72, 0x74, 0x50, 0x54, 0x5a, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x52, 0x08, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x50, 0x74, 0x7a, 0x12, 0x30, 0x0a,
+	0x07, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x6e, 0x6f, 0x6c, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x07, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x22,
+	0xf6, 0x02, 0x0a, 0x0e, 0x53, 0x6d, 0x61, 0x72, 0x74, 0x50, 0x54, 0x5a, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01,
+	0x01, 0x12, 0x2c, 0x0a, 0x0f, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x0e, 0x70, 0x72,
+	0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12,
+	0x26, 0x0a, 0x0c, 0x6d, 0x69, 0x6e, 0x5f, 0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x01, 0x48, 0x02, 0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x56, 0x65, 0x6c, 0x6f,
+	0x63, 0x69, 0x74, 0x79, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d, 0x62, 0x75, 0x66, 0x66, 0x65,
+	0x72, 0x5f, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x48, 0x03,
+	0x52, 0x0c, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x88, 0x01,
+	0x01, 0x12, 0x2e, 0x0a, 0x10, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x6c, 0x61,
+	0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x48, 0x04, 0x52, 0x0f, 0x70,
+	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x88, 0x01,
+	0x01, 0x12, 0x2a, 0x0a, 0x0e, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x74, 0x72, 0x69, 0x67,
+	0x67, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x48, 0x05, 0x52, 0x0d, 0x63, 0x65, 0x6e,
+	0x74, 0x65, 0x72, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a,
+	0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x70, 0x72,
+	0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x42, 0x0f, 0x0a,
+	0x0d, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x42, 0x10,
+	0x0a, 0x0e, 0x5f, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x5f, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72,
+	0x42, 0x13, 0x0a, 0x11, 0x5f, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x6c, 0x61,
+	0x74, 0x65, 0x6e, 0x63, 0x79, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72,
+	0x5f, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x22, 0xa6, 0x01, 0x0a, 0x0d, 0x53, 0x63, 0x6f,
+	0x72, 0x69, 0x6e, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x3d, 0x0a, 0x07, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6e, 0x6f, 0x6c, 0x6f, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x2e,
+	0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x77, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x2b, 0x0a, 0x13, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0xcd,
+	0x01, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f,
+	0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x2b, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x32, 0x98,
+	0x04, 0x0a, 0x04, 0x4e, 0x6f, 0x6c, 0x6f, 0x12, 0x3c, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x6e, 0x6f, 0x6c, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x6e, 0x6f, 0x6c, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x3d, 0x0a, 0x09, 0x50, 0x69, 0x6e, 0x54, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x12, 0x19, 0x2e, 0x6e, 0x6f, 0x6c, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x6e,
+	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e,
+	0x6e, 0x6f, 0x6c, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x45, 0x0a, 0x0d, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x54,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1d, 0x2e, 0x6e, 0x6f, 0x6c, 0x6f, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x6e, 0x6f, 0x6c, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x54,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x43, 0x0a, 0x0b, 0x53,
+	0x65, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x1b, 0x2e, 0x6e, 0x6f, 0x6c,
+	0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x6e, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6e, 0x6f, 0x6c, 0x6f, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x4f, 0x0a, 0x11, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x21, 0x2e, 0x6e, 0x6f, 0x6c, 0x6f, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6e, 0x6f, 0x6c, 0x6f, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x37, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x19,
+	0x2e, 0x6e, 0x6f, 0x6c, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x6e, 0x6f, 0x6c, 0x6f,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3d, 0x0a, 0x0c, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1c, 0x2e, 0x6e, 0x6f, 0x6c,
+	0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x6e, 0x6f, 0x6c, 0x6f, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3e, 0x0a, 0x0c, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x6e, 0x6f, 0x6c, 0x6f,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x6e, 0x6f, 0x6c, 0x6f, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42, 0x1d, 0x5a, 0x1b, 0x72, 0x69, 0x76,
+	0x65, 0x72, 0x63, 0x61, 0x6d, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x61, 0x70,
+	0x69, 0x2f, 0x6e, 0x6f, 0x6c, 0x6f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_nolo_proto_rawDescOnce sync.Once
+	file_nolo_proto_rawDescData = file_nolo_proto_rawDesc
+)
+
+func file_nolo_proto_rawDescGZIP() []byte {
+	file_nolo_proto_rawDescOnce.Do(func() {
+		file_nolo_proto_rawDescData = protoimpl.X.CompressGZIP(file_nolo_proto_rawDescData)
+	})
+	return file_nolo_proto_rawDescData
+}
+
+var file_nolo_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_nolo_proto_goTypes = []any{
+	(*GetStateRequest)(nil),          // 0: nolo.v1.GetStateRequest
+	(*TrackingState)(nil),            // 1: nolo.v1.TrackingState
+	(*TrackedObject)(nil),            // 2: nolo.v1.TrackedObject
+	(*Point)(nil),                    // 3: nolo.v1.Point
+	(*Box)(nil),                      // 4: nolo.v1.Box
+	(*CameraPosition)(nil),           // 5: nolo.v1.CameraPosition
+	(*PinTargetRequest)(nil),         // 6: nolo.v1.PinTargetRequest
+	(*ReleaseTargetRequest)(nil),     // 7: nolo.v1.ReleaseTargetRequest
+	(*TargetStatus)(nil),             // 8: nolo.v1.TargetStatus
+	(*SetScanningRequest)(nil),       // 9: nolo.v1.SetScanningRequest
+	(*SelectScanProfileRequest)(nil), // 10: nolo.v1.SelectScanProfileRequest
+	(*ScanningStatus)(nil),           // 11: nolo.v1.ScanningStatus
+	(*GetConfigRequest)(nil),         // 12: nolo.v1.GetConfigRequest
+	(*Config)(nil),                   // 13: nolo.v1.Config
+	(*TrackLists)(nil),               // 14: nolo.v1.TrackLists
+	(*SmartPTZ)(nil),                 // 15: nolo.v1.SmartPTZ
+	(*Scoring)(nil),                  // 16: nolo.v1.Scoring
+	(*UpdateConfigRequest)(nil),      // 17: nolo.v1.UpdateConfigRequest
+	(*SmartPTZUpdate)(nil),           // 18: nolo.v1.SmartPTZUpdate
+	(*ScoringUpdate)(nil),            // 19: nolo.v1.ScoringUpdate
+	(*StreamEventsRequest)(nil),      // 20: nolo.v1.StreamEventsRequest
+	(*Event)(nil),                    // 21: nolo.v1.Event
+	nil,                              // 22: nolo.v1.Scoring.WeightsEntry
+	nil,                              // 23: nolo.v1.ScoringUpdate.WeightsEntry
+	(*timestamppb.Timestamp)(nil),    // 24: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),          // 25: google.protobuf.Struct
+}
+var file_nolo_proto_depIdxs = []int32{
+	24, // 0: nolo.v1.TrackingState.captured_at:type_name -> google.protobuf.Timestamp
+	2,  // 1: nolo.v1.TrackingState.objects:type_name -> nolo.v1.TrackedObject
+	5,  // 2: nolo.v1.TrackingState.last_command:type_name -> nolo.v1.CameraPosition
+	24, // 3: nolo.v1.TrackedObject.first_detected:type_name -> google.protobuf.Timestamp
+	24, // 4: nolo.v1.TrackedObject.last_seen:type_name -> google.protobuf.Timestamp
+	3,  // 5: nolo.v1.TrackedObject.center:type_name -> nolo.v1.Point
+	4,  // 6: nolo.v1.TrackedObject.box:type_name -> nolo.v1.Box
+	14, // 7: nolo.v1.Config.track_lists:type_name -> nolo.v1.TrackLists
+	15, // 8: nolo.v1.Config.smart_ptz:type_name -> nolo.v1.SmartPTZ
+	16, // 9: nolo.v1.Config.scoring:type_name -> nolo.v1.Scoring
+	22, // 10: nolo.v1.Scoring.weights:type_name -> nolo.v1.Scoring.WeightsEntry
+	14, // 11: nolo.v1.UpdateConfigRequest.track_lists:type_name -> nolo.v1.TrackLists
+	18, // 12: nolo.v1.UpdateConfigRequest.smart_ptz:type_name -> nolo.v1.SmartPTZUpdate
+	19, // 13: nolo.v1.UpdateConfigRequest.scoring:type_name -> nolo.v1.ScoringUpdate
+	23, // 14: nolo.v1.ScoringUpdate.weights:type_name -> nolo.v1.ScoringUpdate.WeightsEntry
+	24, // 15: nolo.v1.Event.time:type_name -> google.protobuf.Timestamp
+	25, // 16: nolo.v1.Event.data:type_name -> google.protobuf.Struct
+	0,  // 17: nolo.v1.Nolo.GetState:input_type -> nolo.v1.GetStateRequest
+	6,  // 18: nolo.v1.Nolo.PinTarget:input_type -> nolo.v1.PinTargetRequest
+	7,  // 19: nolo.v1.Nolo.ReleaseTarget:input_type -> nolo.v1.ReleaseTargetRequest
+	9,  // 20: nolo.v1.Nolo.SetScanning:input_type -> nolo.v1.SetScanningRequest
+	10, // 21: nolo.v1.Nolo.SelectScanProfile:input_type -> nolo.v1.SelectScanProfileRequest
+	12, // 22: nolo.v1.Nolo.GetConfig:input_type -> nolo.v1.GetConfigRequest
+	17, // 23: nolo.v1.Nolo.UpdateConfig:input_type -> nolo.v1.UpdateConfigRequest
+	20, // 24: nolo.v1.Nolo.StreamEvents:input_type -> nolo.v1.StreamEventsRequest
+	1,  // 25: nolo.v1.Nolo.GetState:output_type -> nolo.v1.TrackingState
+	8,  // 26: nolo.v1.Nolo.PinTarget:output_type -> nolo.v1.TargetStatus
+	8,  // 27: nolo.v1.Nolo.ReleaseTarget:output_type -> nolo.v1.TargetStatus
+	11, // 28: nolo.v1.Nolo.SetScanning:output_type -> nolo.v1.ScanningStatus
+	11, // 29: nolo.v1.Nolo.SelectScanProfile:output_type -> nolo.v1.ScanningStatus
+	13, // 30: nolo.v1.Nolo.GetConfig:output_type -> nolo.v1.Config
+	13, // 31: nolo.v1.Nolo.UpdateConfig:output_type -> nolo.v1.Config
+	21, // 32: nolo.v1.Nolo.StreamEvents:output_type -> nolo.v1.Event
+	25, // [25:33] is the sub-list for method output_type
+	17, // [17:25] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_nolo_proto_init() }
+func file_nolo_proto_init() {
+	if File_nolo_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_nolo_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*GetStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*TrackingState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*TrackedObject); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*Point); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*Box); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*CameraPosition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*PinTargetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*ReleaseTargetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*TargetStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*SetScanningRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*SelectScanProfileRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*ScanningStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*GetConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*Config); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*TrackLists); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*SmartPTZ); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*Scoring); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*SmartPTZUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*ScoringUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*StreamEventsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nolo_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*Event); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_nolo_proto_msgTypes[18].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_nolo_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_nolo_proto_goTypes,
+		DependencyIndexes: file_nolo_proto_depIdxs,
+		MessageInfos:      file_nolo_proto_msgTypes,
+	}.Build()
+	File_nolo_proto = out.File
+	file_nolo_proto_rawDesc = nil
+	file_nolo_proto_goTypes = nil
+	file_nolo_proto_depIdxs = nil
+}
@@ -0,0 +1,427 @@
+// gRPC control API for NOLO: the tracking state, target control, configuration updates and a stream of
+// tracking events, for services orchestrating several instances. It mirrors the REST/WebSocket API and uses
+// the same users file: every call carries "authorization: Bearer <token>" metadata and needs the same role
+// as its REST counterpart.
+//
+// Regenerate the Go code with `make proto` after changing this file.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: nolo.proto
+
+package nolopb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	Nolo_GetState_FullMethodName          = "/nolo.v1.Nolo/GetState"
+	Nolo_PinTarget_FullMethodName         = "/nolo.v1.Nolo/PinTarget"
+	Nolo_ReleaseTarget_FullMethodName     = "/nolo.v1.Nolo/ReleaseTarget"
+	Nolo_SetScanning_FullMethodName       = "/nolo.v1.Nolo/SetScanning"
+	Nolo_SelectScanProfile_FullMethodName = "/nolo.v1.Nolo/SelectScanProfile"
+	Nolo_GetConfig_FullMethodName         = "/nolo.v1.Nolo/GetConfig"
+	Nolo_UpdateConfig_FullMethodName      = "/nolo.v1.Nolo/UpdateConfig"
+	Nolo_StreamEvents_FullMethodName      = "/nolo.v1.Nolo/StreamEvents"
+)
+
+// NoloClient is the client API for Nolo service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NoloClient interface {
+	// Tracking state and every tracked object (viewer)
+	GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*TrackingState, error)
+	// Pin a tracked object as the target (operator)
+	PinTarget(ctx context.Context, in *PinTargetRequest, opts ...grpc.CallOption) (*TargetStatus, error)
+	// Release the pinned target (operator)
+	ReleaseTarget(ctx context.Context, in *ReleaseTargetRequest, opts ...grpc.CallOption) (*TargetStatus, error)
+	// Pause or resume scanning (operator)
+	SetScanning(ctx context.Context, in *SetScanningRequest, opts ...grpc.CallOption) (*ScanningStatus, error)
+	// Switch the scan to a profile (operator)
+	SelectScanProfile(ctx context.Context, in *SelectScanProfileRequest, opts ...grpc.CallOption) (*ScanningStatus, error)
+	// Track lists, smart PTZ parameters and target scoring (admin)
+	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*Config, error)
+	// Change any of them; omitted sections and fields keep their value (admin)
+	UpdateConfig(ctx context.Context, in *UpdateConfigRequest, opts ...grpc.CallOption) (*Config, error)
+	// Tracking events as they happen, the same as the /events WebSocket (viewer)
+	StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (Nolo_StreamEventsClient, error)
+}
+
+type noloClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNoloClient(cc grpc.ClientConnInterface) NoloClient {
+	return &noloClient{cc}
+}
+
+func (c *noloClient) GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*TrackingState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrackingState)
+	err := c.cc.Invoke(ctx, Nolo_GetState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noloClient) PinTarget(ctx context.Context, in *PinTargetRequest, opts ...grpc.CallOption) (*TargetStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TargetStatus)
+	err := c.cc.Invoke(ctx, Nolo_PinTarget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noloClient) ReleaseTarget(ctx context.Context, in *ReleaseTargetRequest, opts ...grpc.CallOption) (*TargetStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TargetStatus)
+	err := c.cc.Invoke(ctx, Nolo_ReleaseTarget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noloClient) SetScanning(ctx context.Context, in *SetScanningRequest, opts ...grpc.CallOption) (*ScanningStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScanningStatus)
+	err := c.cc.Invoke(ctx, Nolo_SetScanning_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noloClient) SelectScanProfile(ctx context.Context, in *SelectScanProfileRequest, opts ...grpc.CallOption) (*ScanningStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScanningStatus)
+	err := c.cc.Invoke(ctx, Nolo_SelectScanProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noloClient) GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*Config, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Config)
+	err := c.cc.Invoke(ctx, Nolo_GetConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noloClient) UpdateConfig(ctx context.Context, in *UpdateConfigRequest, opts ...grpc.CallOption) (*Config, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Config)
+	err := c.cc.Invoke(ctx, Nolo_UpdateConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noloClient) StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (Nolo_StreamEventsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Nolo_ServiceDesc.Streams[0], Nolo_StreamEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &noloStreamEventsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Nolo_StreamEventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type noloStreamEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *noloStreamEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NoloServer is the server API for Nolo service.
+// All implementations must embed UnimplementedNoloServer
+// for forward compatibility
+type NoloServer interface {
+	// Tracking state and every tracked object (viewer)
+	GetState(context.Context, *GetStateRequest) (*TrackingState, error)
+	// Pin a tracked object as the target (operator)
+	PinTarget(context.Context, *PinTargetRequest) (*TargetStatus, error)
+	// Release the pinned target (operator)
+	ReleaseTarget(context.Context, *ReleaseTargetRequest) (*TargetStatus, error)
+	// Pause or resume scanning (operator)
+	SetScanning(context.Context, *SetScanningRequest) (*ScanningStatus, error)
+	// Switch the scan to a profile (operator)
+	SelectScanProfile(context.Context, *SelectScanProfileRequest) (*ScanningStatus, error)
+	// Track lists, smart PTZ parameters and target scoring (admin)
+	GetConfig(context.Context, *GetConfigRequest) (*Config, error)
+	// Change any of them; omitted sections and fields keep their value (admin)
+	UpdateConfig(context.Context, *UpdateConfigRequest) (*Config, error)
+	// Tracking events as they happen, the same as the /events WebSocket (viewer)
+	StreamEvents(*StreamEventsRequest, Nolo_StreamEventsServer) error
+	mustEmbedUnimplementedNoloServer()
+}
+
+// UnimplementedNoloServer must be embedded to have forward compatible implementations.
+type UnimplementedNoloServer struct {
+}
+
+func (UnimplementedNoloServer) GetState(context.Context, *GetStateRequest) (*TrackingState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetState not implemented")
+}
+func (UnimplementedNoloServer) PinTarget(context.Context, *PinTargetRequest) (*TargetStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PinTarget not implemented")
+}
+func (UnimplementedNoloServer) ReleaseTarget(context.Context, *ReleaseTargetRequest) (*TargetStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseTarget not implemented")
+}
+func (UnimplementedNoloServer) SetScanning(context.Context, *SetScanningRequest) (*ScanningStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetScanning not implemented")
+}
+func (UnimplementedNoloServer) SelectScanProfile(context.Context, *SelectScanProfileRequest) (*ScanningStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SelectScanProfile not implemented")
+}
+func (UnimplementedNoloServer) GetConfig(context.Context, *GetConfigRequest) (*Config, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
+}
+func (UnimplementedNoloServer) UpdateConfig(context.Context, *UpdateConfigRequest) (*Config, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateConfig not implemented")
+}
+func (UnimplementedNoloServer) StreamEvents(*StreamEventsRequest, Nolo_StreamEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEvents not implemented")
+}
+func (UnimplementedNoloServer) mustEmbedUnimplementedNoloServer() {}
+
+// UnsafeNoloServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NoloServer will
+// result in compilation errors.
+type UnsafeNoloServer interface {
+	mustEmbedUnimplementedNoloServer()
+}
+
+func RegisterNoloServer(s grpc.ServiceRegistrar, srv NoloServer) {
+	s.RegisterService(&Nolo_ServiceDesc, srv)
+}
+
+func _Nolo_GetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoloServer).GetState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Nolo_GetState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoloServer).GetState(ctx, req.(*GetStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Nolo_PinTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoloServer).PinTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Nolo_PinTarget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoloServer).PinTarget(ctx, req.(*PinTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Nolo_ReleaseTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoloServer).ReleaseTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Nolo_ReleaseTarget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoloServer).ReleaseTarget(ctx, req.(*ReleaseTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Nolo_SetScanning_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetScanningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoloServer).SetScanning(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Nolo_SetScanning_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoloServer).SetScanning(ctx, req.(*SetScanningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Nolo_SelectScanProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelectScanProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoloServer).SelectScanProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Nolo_SelectScanProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoloServer).SelectScanProfile(ctx, req.(*SelectScanProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Nolo_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoloServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Nolo_GetConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoloServer).GetConfig(ctx, req.(*GetConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Nolo_UpdateConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoloServer).UpdateConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Nolo_UpdateConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoloServer).UpdateConfig(ctx, req.(*UpdateConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Nolo_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NoloServer).StreamEvents(m, &noloStreamEventsServer{ServerStream: stream})
+}
+
+type Nolo_StreamEventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type noloStreamEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *noloStreamEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Nolo_ServiceDesc is the grpc.ServiceDesc for Nolo service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Nolo_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "nolo.v1.Nolo",
+	HandlerType: (*NoloServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetState",
+			Handler:    _Nolo_GetState_Handler,
+		},
+		{
+			MethodName: "PinTarget",
+			Handler:    _Nolo_PinTarget_Handler,
+		},
+		{
+			MethodName: "ReleaseTarget",
+			Handler:    _Nolo_ReleaseTarget_Handler,
+		},
+		{
+			MethodName: "SetScanning",
+			Handler:    _Nolo_SetScanning_Handler,
+		},
+		{
+			MethodName: "SelectScanProfile",
+			Handler:    _Nolo_SelectScanProfile_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _Nolo_GetConfig_Handler,
+		},
+		{
+			MethodName: "UpdateConfig",
+			Handler:    _Nolo_UpdateConfig_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _Nolo_StreamEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "nolo.proto",
+}
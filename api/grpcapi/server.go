@@ -0,0 +1,438 @@
+// Package grpcapi serves the control API over gRPC (nolopb/nolo.proto) for services orchestrating several
+// NOLO instances. It offers what the REST/WebSocket API does - the tracking state, target control,
+// configuration updates and the stream of tracking events - with the same users, roles and audit log, over
+// TLS only since every call carries a bearer token.
+package grpcapi
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"rivercam/api"
+	"rivercam/api/grpcapi/nolopb"
+	"rivercam/pkg/eventbus"
+	"rivercam/tracking"
+)
+
+// Global debug function for grpcapi package
+var debugMsgFunc func(string, string, ...string)
+
+// SetDebugFunction allows main package to provide debug function
+func SetDebugFunction(fn func(string, string, ...string)) {
+	debugMsgFunc = fn
+}
+
+// debugMsg is a wrapper that handles nil checks
+func debugMsg(component, message string, boatID ...string) {
+	if debugMsgFunc != nil {
+		debugMsgFunc(component, message, boatID...)
+	}
+}
+
+const eventStreamBuffer = 256 // Events queued per client before it starts missing them
+
+// methodAccess is the role each call needs and the action it is audited as, matching the REST routes
+var methodAccess = map[string]struct {
+	role   api.Role
+	action string
+}{
+	nolopb.Nolo_GetState_FullMethodName:          {api.RoleViewer, "state"},
+	nolopb.Nolo_PinTarget_FullMethodName:         {api.RoleOperator, "pin_target"},
+	nolopb.Nolo_ReleaseTarget_FullMethodName:     {api.RoleOperator, "release_target"},
+	nolopb.Nolo_SetScanning_FullMethodName:       {api.RoleOperator, "set_scanning"},
+	nolopb.Nolo_SelectScanProfile_FullMethodName: {api.RoleOperator, "select_scan_profile"},
+	nolopb.Nolo_GetConfig_FullMethodName:         {api.RoleAdmin, "config"},
+	nolopb.Nolo_UpdateConfig_FullMethodName:      {api.RoleAdmin, "set_config"},
+	nolopb.Nolo_StreamEvents_FullMethodName:      {api.RoleViewer, "events"},
+}
+
+// TLSConfig is the server certificate the gRPC API is served with
+type TLSConfig struct {
+	CertFile string // PEM certificate (chain)
+	KeyFile  string // PEM private key
+}
+
+// Server is the gRPC control API for live tracking
+type Server struct {
+	nolopb.UnimplementedNoloServer
+
+	auth    *api.Authenticator
+	control api.TrackingControl
+
+	// OnTrackListsChanged (optional) is called after the P1/P2 lists are changed so detection filtering
+	// outside the tracker can follow
+	OnTrackListsChanged func(tracking.TrackLists)
+
+	// Events (optional) is streamed to StreamEvents clients; without it the call is unavailable
+	Events *eventbus.Bus
+}
+
+// NewServer creates the gRPC control API server
+func NewServer(auth *api.Authenticator, control api.TrackingControl) *Server {
+	return &Server{auth: auth, control: control}
+}
+
+// ListenAndServe loads the certificate, listens on addr and serves the API in the background. Certificate
+// and listen errors are returned; errors while serving are logged.
+func (s *Server) ListenAndServe(addr string, tlsConfig TLSConfig) error {
+	certificate, err := tls.LoadX509KeyPair(tlsConfig.CertFile, tlsConfig.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load gRPC API certificate: %v", err)
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for the gRPC API: %v", err)
+	}
+
+	server := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{certificate}, MinVersion: tls.VersionTLS12})),
+		grpc.UnaryInterceptor(s.authorizeUnary),
+		grpc.StreamInterceptor(s.authorizeStream),
+	)
+	nolopb.RegisterNoloServer(server, s)
+
+	go func() {
+		debugMsg("GRPC_API", fmt.Sprintf("🌐 gRPC control API listening on %s (TLS)", addr))
+		if err := server.Serve(listener); err != nil {
+			debugMsg("API_ERROR", fmt.Sprintf("❌ gRPC control API stopped: %v", err))
+		}
+	}()
+	return nil
+}
+
+// authorizeUnary checks the caller's token and role before a unary call
+func (s *Server) authorizeUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := s.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// authorizeStream checks the caller's token and role before a streaming call
+func (s *Server) authorizeStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.authorize(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
+
+// authorize checks the "authorization: Bearer <token>" metadata against the role the method needs and
+// records the decision in the audit log. Methods missing from methodAccess are refused.
+func (s *Server) authorize(ctx context.Context, fullMethod string) error {
+	access, ok := methodAccess[fullMethod]
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "no access rule for %s", fullMethod)
+	}
+
+	token := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, value := range md.Get("authorization") {
+			if strings.HasPrefix(value, "Bearer ") {
+				token = strings.TrimSpace(strings.TrimPrefix(value, "Bearer "))
+				break
+			}
+		}
+	}
+	remote := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		remote = p.Addr.String()
+		if host, _, err := net.SplitHostPort(remote); err == nil {
+			remote = host
+		}
+	}
+
+	_, err := s.auth.Authorize(token, access.role, access.action, "GRPC", fullMethod, remote)
+	switch {
+	case errors.Is(err, api.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, "authentication required")
+	case err != nil:
+		return status.Errorf(codes.PermissionDenied, "%s role required", access.role)
+	}
+	return nil
+}
+
+// GetState returns the tracking state with every tracked object, ordered by ID
+func (s *Server) GetState(ctx context.Context, req *nolopb.GetStateRequest) (*nolopb.TrackingState, error) {
+	snapshot := s.control.SnapshotState()
+	state := &nolopb.TrackingState{
+		CapturedAt:           timestamp(snapshot.CapturedAt),
+		Frame:                int64(snapshot.FrameCount),
+		Mode:                 snapshot.Mode,
+		TargetId:             snapshot.TargetID,
+		PinnedTargetId:       snapshot.PinnedTargetID,
+		ScanningPaused:       snapshot.ScanningPaused,
+		TourTargetId:         snapshot.TourTargetID,
+		TotalDetectedObjects: snapshot.TotalDetectedObjects,
+		LastCommand:          &nolopb.CameraPosition{Pan: snapshot.LastSentPan, Tilt: snapshot.LastSentTilt, Zoom: snapshot.LastSentZoom},
+	}
+	for i := range snapshot.Boats {
+		state.Objects = append(state.Objects, trackedObject(&snapshot.Boats[i]))
+	}
+	sort.Slice(state.Objects, func(i, j int) bool { return state.Objects[i].Id < state.Objects[j].Id })
+	return state, nil
+}
+
+// trackedObject converts a boat snapshot
+func trackedObject(boat *tracking.BoatStateSnapshot) *nolopb.TrackedObject {
+	object := &nolopb.TrackedObject{
+		Id:               boat.ID,
+		Classification:   boat.Classification,
+		Confidence:       boat.Confidence,
+		State:            boat.State,
+		DetectionCount:   int32(boat.DetectionCount),
+		LostFrames:       int32(boat.LostFrames),
+		Locked:           boat.IsLocked,
+		IsTarget:         boat.IsTarget,
+		LockStrength:     boat.LockStrength,
+		TrackingPriority: boat.TrackingPriority,
+		FirstDetected:    timestamp(boat.FirstDetected),
+		LastSeen:         timestamp(boat.LastSeen),
+		Center:           &nolopb.Point{X: int32(boat.CurrentPixel.X), Y: int32(boat.CurrentPixel.Y)},
+		Box: &nolopb.Box{
+			MinX: int32(boat.BoundingBox.MinX), MinY: int32(boat.BoundingBox.MinY),
+			MaxX: int32(boat.BoundingBox.MaxX), MaxY: int32(boat.BoundingBox.MaxY),
+		},
+		PixelArea:   boat.PixelArea,
+		VelocityX:   boat.PixelVelocity.X,
+		VelocityY:   boat.PixelVelocity.Y,
+		People:      int32(boat.P2Count),
+		Quality:     1,
+		EdgeClipped: boat.EdgeClipped,
+	}
+	if boat.Quality != nil {
+		object.Quality = boat.Quality.Score
+		object.Quarantined = boat.Quality.Quarantined
+	}
+	return object
+}
+
+// timestamp converts a time, leaving unset times (zero) unset
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// PinTarget makes a tracked object the locked target until it is released or no longer tracked
+func (s *Server) PinTarget(ctx context.Context, req *nolopb.PinTargetRequest) (*nolopb.TargetStatus, error) {
+	if req.ObjectId == "" {
+		return nil, status.Error(codes.InvalidArgument, "object_id is required")
+	}
+	if err := s.control.ForceTarget(req.ObjectId); err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return s.targetStatus(), nil
+}
+
+// ReleaseTarget releases the pinned target
+func (s *Server) ReleaseTarget(ctx context.Context, req *nolopb.ReleaseTargetRequest) (*nolopb.TargetStatus, error) {
+	s.control.ReleaseTarget()
+	return s.targetStatus(), nil
+}
+
+func (s *Server) targetStatus() *nolopb.TargetStatus {
+	snapshot := s.control.SnapshotState()
+	return &nolopb.TargetStatus{
+		Mode:     snapshot.Mode,
+		TargetId: snapshot.TargetID,
+		Pinned:   snapshot.PinnedTargetID != "" && snapshot.PinnedTargetID == snapshot.TargetID,
+	}
+}
+
+// SetScanning pauses or resumes scanning
+func (s *Server) SetScanning(ctx context.Context, req *nolopb.SetScanningRequest) (*nolopb.ScanningStatus, error) {
+	s.control.SetScanningEnabled(req.Enabled)
+	return s.scanningStatus(), nil
+}
+
+// SelectScanProfile switches the scan to a profile
+func (s *Server) SelectScanProfile(ctx context.Context, req *nolopb.SelectScanProfileRequest) (*nolopb.ScanningStatus, error) {
+	if err := s.control.SelectScanProfile(req.Name); err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return s.scanningStatus(), nil
+}
+
+func (s *Server) scanningStatus() *nolopb.ScanningStatus {
+	return &nolopb.ScanningStatus{
+		Enabled: s.control.IsScanningEnabled(),
+		Profile: s.control.GetScanProfiles().Active,
+	}
+}
+
+// GetConfig returns the track lists, smart PTZ parameters and target scoring in effect
+func (s *Server) GetConfig(ctx context.Context, req *nolopb.GetConfigRequest) (*nolopb.Config, error) {
+	return s.config(), nil
+}
+
+// UpdateConfig validates every section of the change before applying any, so an invalid request changes
+// nothing
+func (s *Server) UpdateConfig(ctx context.Context, req *nolopb.UpdateConfigRequest) (*nolopb.Config, error) {
+	var lists *tracking.TrackLists
+	if req.TrackLists != nil {
+		lists = &tracking.TrackLists{P1: trimmed(req.TrackLists.P1), P2: trimmed(req.TrackLists.P2), P1All: req.TrackLists.P1All, P2All: req.TrackLists.P2All}
+		if !lists.P1All && len(lists.P1) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "at least one P1 class (or p1_all) is required")
+		}
+	}
+
+	var smartPTZ *api.SmartPTZConfig
+	if update := req.SmartPtz; update != nil {
+		config, err := api.SmartPTZUpdate{
+			Enabled:         update.Enabled,
+			PredictionTime:  update.PredictionTime,
+			MinVelocity:     update.MinVelocity,
+			BufferFactor:    update.BufferFactor,
+			PipelineLatency: update.PipelineLatency,
+			CenterTrigger:   update.CenterTrigger,
+		}.Merge(api.GetSmartPTZ(s.control))
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		smartPTZ = &config
+	}
+
+	strategy := ""
+	if req.Scoring != nil {
+		strategy = req.Scoring.Strategy
+		if strategy == "" {
+			strategy = s.control.GetScoringStrategy().Strategy
+		}
+		if _, err := tracking.NewScoringStrategy(strategy, req.Scoring.Weights); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	if lists != nil {
+		if err := s.control.SetTrackLists(*lists); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if s.OnTrackListsChanged != nil {
+			s.OnTrackListsChanged(*lists)
+		}
+	}
+	if smartPTZ != nil {
+		api.SetSmartPTZ(s.control, *smartPTZ)
+	}
+	if strategy != "" {
+		if err := s.control.SetScoringStrategy(strategy, req.Scoring.Weights); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	return s.config(), nil
+}
+
+func (s *Server) config() *nolopb.Config {
+	lists := s.control.GetTrackLists()
+	smartPTZ := api.GetSmartPTZ(s.control)
+	scoring := s.control.GetScoringStrategy()
+
+	weights := map[string]float64{}
+	if data, err := json.Marshal(scoring.Weights); err == nil {
+		json.Unmarshal(data, &weights) // Factor names as in the REST API and weight overrides
+	}
+	return &nolopb.Config{
+		TrackLists: &nolopb.TrackLists{P1: lists.P1, P2: lists.P2, P1All: lists.P1All, P2All: lists.P2All},
+		SmartPtz: &nolopb.SmartPTZ{
+			Enabled:         smartPTZ.Enabled,
+			PredictionTime:  smartPTZ.PredictionTime,
+			MinVelocity:     smartPTZ.MinVelocity,
+			BufferFactor:    smartPTZ.BufferFactor,
+			PipelineLatency: smartPTZ.PipelineLatency,
+			CenterTrigger:   smartPTZ.CenterTrigger,
+		},
+		Scoring: &nolopb.Scoring{Strategy: scoring.Strategy, Weights: weights, Available: scoring.Available},
+	}
+}
+
+// trimmed returns the class names without surrounding whitespace
+func trimmed(names []string) []string {
+	result := make([]string, len(names))
+	for i, name := range names {
+		result[i] = strings.TrimSpace(name)
+	}
+	return result
+}
+
+// StreamEvents sends tracking events, optionally only some types, until the client cancels
+func (s *Server) StreamEvents(req *nolopb.StreamEventsRequest, stream nolopb.Nolo_StreamEventsServer) error {
+	if s.Events == nil {
+		return status.Error(codes.Unavailable, "event stream not enabled")
+	}
+	wanted := make(map[string]bool, len(req.Types))
+	for _, eventType := range req.Types {
+		wanted[eventType] = true
+	}
+
+	events, unsubscribe := s.Events.Subscribe(eventStreamBuffer)
+	defer unsubscribe()
+
+	remote := "unknown"
+	if p, ok := peer.FromContext(stream.Context()); ok {
+		remote = p.Addr.String()
+	}
+	debugMsg("GRPC_API", fmt.Sprintf("📡 gRPC event stream client connected from %s (%d connected)", remote, s.Events.Subscribers()))
+	defer debugMsg("GRPC_API", fmt.Sprintf("📡 gRPC event stream client %s disconnected", remote))
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if len(wanted) > 0 && !wanted[event.Type] {
+				continue
+			}
+			if err := stream.Send(eventMessage(event)); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// eventMessage converts a tracking event. Data values protobuf can't hold directly are passed through JSON.
+func eventMessage(event eventbus.Event) *nolopb.Event {
+	message := &nolopb.Event{
+		Time:      timestamp(event.Time),
+		Type:      event.Type,
+		ObjectId:  event.ObjectID,
+		Component: event.Component,
+		Message:   event.Message,
+	}
+	if len(event.Data) == 0 {
+		return message
+	}
+	data, err := structpb.NewStruct(event.Data)
+	if err != nil {
+		var generic map[string]interface{}
+		encoded, _ := json.Marshal(event.Data)
+		if json.Unmarshal(encoded, &generic) == nil {
+			data, err = structpb.NewStruct(generic)
+		}
+	}
+	if err != nil {
+		debugMsg("API_ERROR", fmt.Sprintf("Failed to encode %s event data: %v", event.Type, err))
+		return message
+	}
+	message.Data = data
+	return message
+}
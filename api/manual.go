@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// manualMove is a joystick-style move: deflections from -1 to 1 (positive = right, down, zoom in)
+type manualMove struct {
+	Pan  float64 `json:"pan"`
+	Tilt float64 `json:"tilt"`
+	Zoom float64 `json:"zoom"`
+}
+
+// manualGoto is an absolute camera position in PTZ units
+type manualGoto struct {
+	Pan  *float64 `json:"pan"`
+	Tilt *float64 `json:"tilt"`
+	Zoom *float64 `json:"zoom"`
+}
+
+// handleManual serves GET /manual to viewers and POST/DELETE /manual to operators
+func (s *Server) handleManual(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.auth.Require(RoleViewer, "manual", s.handleGetManual)(w, r)
+	case http.MethodPost:
+		s.auth.Require(RoleOperator, "enter_manual", s.handleEnterManual)(w, r)
+	case http.MethodDelete:
+		s.auth.Require(RoleOperator, "exit_manual", s.handleExitManual)(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleGetManual(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.control.GetManualControlStatus())
+}
+
+func (s *Server) handleEnterManual(w http.ResponseWriter, r *http.Request) {
+	s.control.EnterManualControl()
+	writeJSON(w, http.StatusOK, s.control.GetManualControlStatus())
+}
+
+func (s *Server) handleExitManual(w http.ResponseWriter, r *http.Request) {
+	s.control.ExitManualControl()
+	writeJSON(w, http.StatusOK, s.control.GetManualControlStatus())
+}
+
+// handleManualMove moves the camera one step at the given rates, entering manual control
+func (s *Server) handleManualMove(w http.ResponseWriter, r *http.Request) {
+	var move manualMove
+	if err := json.NewDecoder(r.Body).Decode(&move); err != nil {
+		http.Error(w, `expected {"pan": -1..1, "tilt": -1..1, "zoom": -1..1}`, http.StatusBadRequest)
+		return
+	}
+	if err := s.control.ManualMove(move.Pan, move.Tilt, move.Zoom); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.control.GetManualControlStatus())
+}
+
+// handleManualGoto moves the camera to an absolute position, entering manual control
+func (s *Server) handleManualGoto(w http.ResponseWriter, r *http.Request) {
+	var position manualGoto
+	if err := json.NewDecoder(r.Body).Decode(&position); err != nil || position.Pan == nil || position.Tilt == nil || position.Zoom == nil {
+		http.Error(w, `expected {"pan": number, "tilt": number, "zoom": number}`, http.StatusBadRequest)
+		return
+	}
+	if err := s.control.ManualGoto(*position.Pan, *position.Tilt, *position.Zoom); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.control.GetManualControlStatus())
+}
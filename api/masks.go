@@ -0,0 +1,70 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ColorMaskConfig is the detector input masking as exchanged over the API
+type ColorMaskConfig struct {
+	Colors    []string `json:"colors"`    // RGB hex colors masked within Tolerance, e.g. "6d9755"
+	Tolerance int      `json:"tolerance"` // Per-channel tolerance of the hex colors (0-255)
+	HSV       []string `json:"hsv"`       // HSV ranges "h1-h2:s1-s2:v1-v2" (OpenCV scale: H 0-179, S/V 0-255)
+}
+
+// colorMaskUpdate is a partial masking change; omitted fields keep their current value
+type colorMaskUpdate struct {
+	Colors    *[]string `json:"colors"`
+	Tolerance *int      `json:"tolerance"`
+	HSV       *[]string `json:"hsv"`
+}
+
+// MaskControl is the live color masking the API reads and changes
+type MaskControl interface {
+	ColorMasks() ColorMaskConfig
+	SetColorMasks(config ColorMaskConfig) error
+	// MaskPreview renders the latest detector input with the masked pixels highlighted (JPEG)
+	MaskPreview() (jpeg []byte, err error)
+}
+
+func (s *Server) handleGetMasks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.Masks.ColorMasks())
+}
+
+func (s *Server) handlePutMasks(w http.ResponseWriter, r *http.Request) {
+	var update colorMaskUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, fmt.Sprintf("invalid color masks: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	config := s.Masks.ColorMasks()
+	if update.Colors != nil {
+		config.Colors = *update.Colors
+	}
+	if update.Tolerance != nil {
+		config.Tolerance = *update.Tolerance
+	}
+	if update.HSV != nil {
+		config.HSV = *update.HSV
+	}
+	if err := s.Masks.SetColorMasks(config); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.Masks.ColorMasks())
+}
+
+// handleMaskPreview serves the latest detector input with the masked regions highlighted, so masks can be
+// tuned while watching their effect
+func (s *Server) handleMaskPreview(w http.ResponseWriter, r *http.Request) {
+	jpeg, err := s.Masks.MaskPreview()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "image/jpeg")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write(jpeg)
+}
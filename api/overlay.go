@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// OverlayLayerSource is the latest overlay layer published next to the clean output stream
+type OverlayLayerSource interface {
+	// OverlayLayer returns the latest layer as a transparent PNG with the sequence and capture time of the
+	// frame it annotates
+	OverlayLayer() (png []byte, sequence int64, captured time.Time, err error)
+}
+
+// handleOverlayLayer serves the latest overlay layer; X-Frame-Sequence and X-Frame-Timestamp identify the
+// frame of the clean stream it belongs to
+func (s *Server) handleOverlayLayer(w http.ResponseWriter, r *http.Request) {
+	png, sequence, captured, err := s.Overlay.OverlayLayer()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Frame-Sequence", strconv.FormatInt(sequence, 10))
+	w.Header().Set("X-Frame-Timestamp", captured.UTC().Format(time.RFC3339Nano))
+	w.Write(png)
+}
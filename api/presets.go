@@ -0,0 +1,108 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"rivercam/ptz"
+	"rivercam/tracking"
+)
+
+// PresetControl is the named PTZ presets the API lists and edits (satisfied by *tracking.SpatialIntegration)
+type PresetControl interface {
+	GetPresets() tracking.PresetList
+	SavePreset(preset ptz.Preset) error
+	CapturePreset(name string) (ptz.Preset, error)
+	DeletePreset(name string) error
+	GotoPreset(name string) error
+}
+
+// presetPosition is an explicit preset position; an empty PUT body captures the current position instead
+type presetPosition struct {
+	Pan  *float64 `json:"pan"`
+	Tilt *float64 `json:"tilt"`
+	Zoom *float64 `json:"zoom"`
+}
+
+func (s *Server) handleGetPresets(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.Presets.GetPresets())
+}
+
+// handlePreset serves PUT/DELETE /presets/{name} to admins and POST /presets/{name}/goto to operators
+func (s *Server) handlePreset(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/presets/")
+	name, action, _ := strings.Cut(path, "/")
+	if name == "" || (action != "" && action != "goto") {
+		http.Error(w, "expected /presets/{name} or /presets/{name}/goto", http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case action == "goto" && r.Method == http.MethodPost:
+		s.auth.Require(RoleOperator, "goto_preset", func(w http.ResponseWriter, r *http.Request) {
+			s.handleGotoPreset(w, r, name)
+		})(w, r)
+	case action == "" && r.Method == http.MethodPut:
+		s.auth.Require(RoleAdmin, "save_preset", func(w http.ResponseWriter, r *http.Request) {
+			s.handlePutPreset(w, r, name)
+		})(w, r)
+	case action == "" && r.Method == http.MethodDelete:
+		s.auth.Require(RoleAdmin, "delete_preset", func(w http.ResponseWriter, r *http.Request) {
+			s.handleDeletePreset(w, r, name)
+		})(w, r)
+	default:
+		if action == "goto" {
+			w.Header().Set("Allow", "POST")
+		} else {
+			w.Header().Set("Allow", "PUT, DELETE")
+		}
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handlePutPreset saves a preset at the given position, or at the camera's current position when the body
+// is empty
+func (s *Server) handlePutPreset(w http.ResponseWriter, r *http.Request, name string) {
+	var position presetPosition
+	err := json.NewDecoder(r.Body).Decode(&position)
+	if errors.Is(err, io.EOF) {
+		preset, err := s.Presets.CapturePreset(name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, http.StatusOK, preset)
+		return
+	}
+	if err != nil || position.Pan == nil || position.Tilt == nil || position.Zoom == nil {
+		http.Error(w, `expected {"pan": number, "tilt": number, "zoom": number} or an empty body to capture the current position`, http.StatusBadRequest)
+		return
+	}
+
+	preset := ptz.Preset{Name: name, Pan: *position.Pan, Tilt: *position.Tilt, Zoom: *position.Zoom, Source: ptz.PresetFromConfig}
+	if err := s.Presets.SavePreset(preset); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, http.StatusOK, preset)
+}
+
+func (s *Server) handleDeletePreset(w http.ResponseWriter, r *http.Request, name string) {
+	if err := s.Presets.DeletePreset(name); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleGotoPreset takes manual control and moves the camera to a preset
+func (s *Server) handleGotoPreset(w http.ResponseWriter, r *http.Request, name string) {
+	if err := s.Presets.GotoPreset(name); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.control.GetManualControlStatus())
+}
@@ -0,0 +1,624 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"rivercam/pkg/eventbus"
+	"rivercam/pkg/pipeline"
+	"rivercam/tracking"
+)
+
+// TrackingControl is the live tracking state the API reads and changes (satisfied by *tracking.SpatialIntegration)
+type TrackingControl interface {
+	SnapshotState() *tracking.TrackingStateSnapshot
+	ForceTarget(objectID string) error
+	ReleaseTarget()
+	SetScanningEnabled(enabled bool)
+	IsScanningEnabled() bool
+	GetManualControlStatus() tracking.ManualControlStatus
+	EnterManualControl()
+	ExitManualControl()
+	ManualMove(pan, tilt, zoom float64) error
+	ManualGoto(pan, tilt, zoom float64) error
+	GetRankedTargets() []tracking.RankedTarget
+	GetTrackLists() tracking.TrackLists
+	SetTrackLists(lists tracking.TrackLists) error
+	GetSmartPTZConfigAdvanced() (bool, float64, float64, float64, float64, float64)
+	ConfigureSmartPTZAdvanced(predictionTime, minVelocity, bufferFactor, pipelineLatency, centerTrigger float64)
+	EnableSmartPTZTracking()
+	DisableSmartPTZTracking()
+	GetScanProfiles() tracking.ScanProfileList
+	SelectScanProfile(name string) error
+	SaveScanProfile(profile tracking.CustomScanningPattern) error
+	GetScoringStrategy() tracking.ScoringStrategyInfo
+	SetScoringStrategy(name string, overrides map[string]float64) error
+}
+
+// SnapshotSource provides the best frame (JPEG) kept for each tracked object
+type SnapshotSource interface {
+	Snapshot(objectID string) (jpeg []byte, taken time.Time, ok bool)
+}
+
+// LatencySource reports the rolling capture-to-stage latency percentiles
+type LatencySource interface {
+	Snapshot() []pipeline.LatencyPercentiles
+}
+
+// SmartPTZConfig is the smart PTZ configuration as exchanged over the API
+type SmartPTZConfig struct {
+	Enabled         bool    `json:"enabled"`
+	PredictionTime  float64 `json:"prediction_time"`  // Seconds
+	MinVelocity     float64 `json:"min_velocity"`     // Pixels/frame
+	BufferFactor    float64 `json:"buffer_factor"`    // Fraction of the frame kept as margin
+	PipelineLatency float64 `json:"pipeline_latency"` // Seconds
+	CenterTrigger   float64 `json:"center_trigger"`   // Fraction of the frame
+}
+
+// SmartPTZUpdate is a partial smart PTZ change; omitted fields keep their current value
+type SmartPTZUpdate struct {
+	Enabled         *bool    `json:"enabled"`
+	PredictionTime  *float64 `json:"prediction_time"`
+	MinVelocity     *float64 `json:"min_velocity"`
+	BufferFactor    *float64 `json:"buffer_factor"`
+	PipelineLatency *float64 `json:"pipeline_latency"`
+	CenterTrigger   *float64 `json:"center_trigger"`
+}
+
+// Server is the HTTP control API for live tracking
+type Server struct {
+	auth    *Authenticator
+	control TrackingControl
+
+	// OnTrackListsChanged (optional) is called after the P1/P2 lists are changed so detection filtering
+	// outside the tracker can follow
+	OnTrackListsChanged func(tracking.TrackLists)
+
+	// Events (optional) is streamed to WebSocket clients on /events
+	Events *eventbus.Bus
+
+	// Snapshots (optional) serves the best frame of each object on /objects/{id}/snapshot.jpg
+	Snapshots SnapshotSource
+
+	// Erasure (optional) erases everything stored about an object on DELETE /objects/{id}
+	Erasure ObjectEraser
+
+	// Preview (optional) streams the annotated output as MJPEG on /stream.mjpg for the dashboard
+	Preview *PreviewHub
+
+	// Camera (optional) reports the camera position on /camera
+	Camera CameraSource
+
+	// Masks (optional) exposes the detector input color masking on /masks
+	Masks MaskControl
+
+	// Latency (optional) reports the measured pipeline latency on /latency
+	Latency LatencySource
+
+	// Overlay (optional) serves the overlay layer on /overlay.png when annotations are not burned in
+	Overlay OverlayLayerSource
+
+	// Presets (optional) exposes the named PTZ presets on /presets
+	Presets PresetControl
+
+	// Audit (optional) lists the recent PTZ commands on /ptz/audit
+	Audit PTZAuditSource
+
+	// Status (optional) serves the status overlay's content on /status and /status.txt
+	Status StatusSource
+}
+
+// NewServer creates the control API server
+func NewServer(auth *Authenticator, control TrackingControl) *Server {
+	return &Server{auth: auth, control: control}
+}
+
+// Handler returns the API routes:
+//
+//	GET    /            live dashboard page (the page asks for a token; everything it shows needs viewer)
+//	GET    /stream.mjpg MJPEG preview of the annotated output (viewer, only when Preview is set)
+//	GET    /overlay.png latest transparent overlay layer for the clean output stream (viewer, only when Overlay is set)
+//	GET    /camera      camera position and movement state (viewer, only when Camera is set)
+//	GET    /ptz/audit   recent PTZ commands with their source, target, object and whether the camera accepted them;
+//	                    ?source=, ?object_id=, ?since= (RFC 3339) and ?limit= narrow them down (viewer, only when Audit is set)
+//	GET    /status      FPS, tracking mode, target, camera position and uptime as JSON (viewer, only when Status is set)
+//	GET    /status.txt  the same as "Label: value" lines, or one value with ?field=, for OBS text sources; pass the
+//	                    token as ?token= where headers can't be set (viewer, only when Status is set)
+//	GET    /objects     tracked objects (viewer)
+//	GET    /objects/{id}/snapshot.jpg  best frame of an object (viewer, only when Snapshots is set)
+//	DELETE /objects/{id}  erase everything stored about an object: snapshots, trajectories, event records and
+//	                    database rows (admin, only when Erasure is set)
+//	GET    /target      current target and mode (viewer)
+//	POST   /target/{id} pin a target (operator)
+//	DELETE /target      release the pinned target (operator)
+//	GET    /targets     ranked primary and secondary targets (viewer; empty unless multi-target mode is on)
+//	GET    /scanning    scan state; POST {"enabled":bool} to pause/resume (operator)
+//	GET    /manual      manual control state; POST to take control, DELETE to resume tracking (operator)
+//	POST   /manual/move {"pan","tilt","zoom"} rates from -1 to 1, one joystick step (operator)
+//	POST   /manual/goto {"pan","tilt","zoom"} absolute position (operator)
+//	GET    /presets     named PTZ presets and the home-on-idle policy (viewer, only when Presets is set)
+//	PUT    /presets/{name}        save a preset at {"pan","tilt","zoom"}, or the current position with no body (admin)
+//	DELETE /presets/{name}        delete a preset (admin)
+//	POST   /presets/{name}/goto   take manual control and move to a preset (operator)
+//	GET    /scan-profiles         scan profiles and the active one (viewer)
+//	POST   /scan-profiles/{name}  switch the scan to a profile (operator)
+//	PUT    /scan-profiles/{name}  create or replace a profile's waypoints (admin)
+//	GET    /tracklists  P1/P2 classes; PUT to replace them (admin)
+//	GET    /smart-ptz   smart PTZ parameters; PUT to change them (admin)
+//	GET    /scoring     target scoring strategy, its weights and the built-ins (viewer);
+//	                    PUT {"strategy","weights":{factor:weight}} to switch (operator)
+//	GET    /latency     capture→detect/track/command/output latency and overlay sync drift percentiles (viewer, only when Latency is set)
+//	GET    /masks       color masks (viewer); PUT to change them (admin, only when Masks is set)
+//	GET    /masks/preview.jpg  latest detector input with masked pixels highlighted (viewer, only when Masks is set)
+//	GET    /events      WebSocket stream of tracking events (viewer, only when Events is set)
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.methods(map[string]http.HandlerFunc{
+		http.MethodGet: s.handleDashboard,
+	}))
+	if s.Preview != nil {
+		mux.HandleFunc("/stream.mjpg", s.auth.Require(RoleViewer, "preview_stream", s.methods(map[string]http.HandlerFunc{
+			http.MethodGet: s.handleStream,
+		})))
+	}
+	if s.Camera != nil {
+		mux.HandleFunc("/camera", s.auth.Require(RoleViewer, "camera", s.methods(map[string]http.HandlerFunc{
+			http.MethodGet: s.handleCamera,
+		})))
+	}
+	if s.Audit != nil {
+		mux.HandleFunc("/ptz/audit", s.auth.Require(RoleViewer, "ptz_audit", s.methods(map[string]http.HandlerFunc{
+			http.MethodGet: s.handlePTZAudit,
+		})))
+	}
+	if s.Status != nil {
+		mux.HandleFunc("/status", s.auth.Require(RoleViewer, "status", s.methods(map[string]http.HandlerFunc{
+			http.MethodGet: s.handleStatus,
+		})))
+		mux.HandleFunc("/status.txt", s.auth.Require(RoleViewer, "status_text", s.methods(map[string]http.HandlerFunc{
+			http.MethodGet: s.handleStatusText,
+		})))
+	}
+	mux.HandleFunc("/objects", s.auth.Require(RoleViewer, "objects", s.methods(map[string]http.HandlerFunc{
+		http.MethodGet: s.handleObjects,
+	})))
+	if s.Snapshots != nil || s.Erasure != nil {
+		mux.HandleFunc("/objects/", s.handleObject)
+	}
+	mux.HandleFunc("/target", s.handleTarget)
+	mux.HandleFunc("/target/", s.auth.Require(RoleOperator, "pin_target", s.methods(map[string]http.HandlerFunc{
+		http.MethodPost: s.handlePinTarget,
+	})))
+	mux.HandleFunc("/targets", s.auth.Require(RoleViewer, "targets", s.methods(map[string]http.HandlerFunc{
+		http.MethodGet: s.handleTargets,
+	})))
+	mux.HandleFunc("/scanning", s.handleScanning)
+	mux.HandleFunc("/manual", s.handleManual)
+	mux.HandleFunc("/manual/move", s.auth.Require(RoleOperator, "manual_move", s.methods(map[string]http.HandlerFunc{
+		http.MethodPost: s.handleManualMove,
+	})))
+	mux.HandleFunc("/manual/goto", s.auth.Require(RoleOperator, "manual_goto", s.methods(map[string]http.HandlerFunc{
+		http.MethodPost: s.handleManualGoto,
+	})))
+	if s.Presets != nil {
+		mux.HandleFunc("/presets", s.auth.Require(RoleViewer, "presets", s.methods(map[string]http.HandlerFunc{
+			http.MethodGet: s.handleGetPresets,
+		})))
+		mux.HandleFunc("/presets/", s.handlePreset)
+	}
+	mux.HandleFunc("/scan-profiles", s.auth.Require(RoleViewer, "scan_profiles", s.methods(map[string]http.HandlerFunc{
+		http.MethodGet: s.handleGetScanProfiles,
+	})))
+	mux.HandleFunc("/scan-profiles/", s.handleScanProfile)
+	mux.HandleFunc("/tracklists", s.auth.Require(RoleAdmin, "tracklists", s.methods(map[string]http.HandlerFunc{
+		http.MethodGet: s.handleGetTrackLists,
+		http.MethodPut: s.handlePutTrackLists,
+	})))
+	mux.HandleFunc("/smart-ptz", s.auth.Require(RoleAdmin, "smart_ptz", s.methods(map[string]http.HandlerFunc{
+		http.MethodGet: s.handleGetSmartPTZ,
+		http.MethodPut: s.handlePutSmartPTZ,
+	})))
+	mux.HandleFunc("/scoring", s.handleScoring)
+	if s.Overlay != nil {
+		mux.HandleFunc("/overlay.png", s.auth.Require(RoleViewer, "overlay_layer", s.methods(map[string]http.HandlerFunc{
+			http.MethodGet: s.handleOverlayLayer,
+		})))
+	}
+	if s.Latency != nil {
+		mux.HandleFunc("/latency", s.auth.Require(RoleViewer, "latency", s.methods(map[string]http.HandlerFunc{
+			http.MethodGet: s.handleLatency,
+		})))
+	}
+	if s.Masks != nil {
+		mux.HandleFunc("/masks", s.handleMasks)
+		mux.HandleFunc("/masks/preview.jpg", s.auth.Require(RoleViewer, "mask_preview", s.methods(map[string]http.HandlerFunc{
+			http.MethodGet: s.handleMaskPreview,
+		})))
+	}
+	if s.Events != nil {
+		mux.HandleFunc("/events", s.auth.Require(RoleViewer, "events", s.handleEvents))
+	}
+	return mux
+}
+
+// ListenAndServe starts the API in the background
+func (s *Server) ListenAndServe(addr string) {
+	go func() {
+		debugMsg("API", fmt.Sprintf("🌐 Control API listening on %s", addr))
+		if err := http.ListenAndServe(addr, s.Handler()); err != nil {
+			debugMsg("API_ERROR", fmt.Sprintf("❌ Control API stopped: %v", err))
+		}
+	}()
+}
+
+// methods dispatches on the request method and rejects the others
+func (s *Server) methods(handlers map[string]http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handler, ok := handlers[r.Method]
+		if !ok {
+			allowed := make([]string, 0, len(handlers))
+			for method := range handlers {
+				allowed = append(allowed, method)
+			}
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// handleTarget serves GET /target to viewers and DELETE /target to operators
+func (s *Server) handleTarget(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.auth.Require(RoleViewer, "target", s.handleGetTarget)(w, r)
+	case http.MethodDelete:
+		s.auth.Require(RoleOperator, "release_target", s.handleReleaseTarget)(w, r)
+	default:
+		w.Header().Set("Allow", "GET, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleScanning serves GET /scanning to viewers and POST /scanning to operators
+func (s *Server) handleScanning(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.auth.Require(RoleViewer, "scanning", s.handleGetScanning)(w, r)
+	case http.MethodPost:
+		s.auth.Require(RoleOperator, "set_scanning", s.handleSetScanning)(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleScoring serves GET /scoring to viewers and PUT /scoring to operators
+func (s *Server) handleScoring(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.auth.Require(RoleViewer, "scoring", s.handleGetScoring)(w, r)
+	case http.MethodPut:
+		s.auth.Require(RoleOperator, "set_scoring", s.handlePutScoring)(w, r)
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleScanProfile serves POST /scan-profiles/{name} to operators and PUT /scan-profiles/{name} to admins
+func (s *Server) handleScanProfile(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		s.auth.Require(RoleOperator, "select_scan_profile", s.handleSelectScanProfile)(w, r)
+	case http.MethodPut:
+		s.auth.Require(RoleAdmin, "save_scan_profile", s.handlePutScanProfile)(w, r)
+	default:
+		w.Header().Set("Allow", "POST, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleMasks serves GET /masks to viewers and PUT /masks to admins
+func (s *Server) handleMasks(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.auth.Require(RoleViewer, "masks", s.handleGetMasks)(w, r)
+	case http.MethodPut:
+		s.auth.Require(RoleAdmin, "set_masks", s.handlePutMasks)(w, r)
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleObjects(w http.ResponseWriter, r *http.Request) {
+	snapshot := s.control.SnapshotState()
+	boats := snapshot.Boats
+	if boats == nil {
+		boats = []tracking.BoatStateSnapshot{}
+	}
+	writeJSON(w, http.StatusOK, boats)
+}
+
+// handleObjectSnapshot serves the best frame of a tracked (or recently ended) object
+func (s *Server) handleObjectSnapshot(w http.ResponseWriter, r *http.Request) {
+	objectID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/objects/"), "/snapshot.jpg")
+	if objectID == "" || strings.Contains(objectID, "/") || !strings.HasSuffix(r.URL.Path, "/snapshot.jpg") {
+		http.Error(w, "expected /objects/{id}/snapshot.jpg", http.StatusNotFound)
+		return
+	}
+
+	jpeg, taken, ok := s.Snapshots.Snapshot(objectID)
+	if !ok {
+		http.Error(w, fmt.Sprintf("no snapshot of object %s", objectID), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "image/jpeg")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Last-Modified", taken.UTC().Format(http.TimeFormat))
+	w.Write(jpeg)
+}
+
+// handleTargets lists the ranked targets: the primary the camera follows first, then the secondaries an
+// operator or a second camera can be cued to
+func (s *Server) handleTargets(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.control.GetRankedTargets())
+}
+
+func (s *Server) handleGetTarget(w http.ResponseWriter, r *http.Request) {
+	snapshot := s.control.SnapshotState()
+
+	response := struct {
+		Mode     string                      `json:"mode"`
+		TargetID string                      `json:"target_id,omitempty"`
+		Pinned   bool                        `json:"pinned"`
+		Target   *tracking.BoatStateSnapshot `json:"target,omitempty"`
+	}{
+		Mode:     snapshot.Mode,
+		TargetID: snapshot.TargetID,
+		Pinned:   snapshot.PinnedTargetID != "" && snapshot.PinnedTargetID == snapshot.TargetID,
+	}
+	for i := range snapshot.Boats {
+		if snapshot.Boats[i].IsTarget {
+			response.Target = &snapshot.Boats[i]
+			break
+		}
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) handlePinTarget(w http.ResponseWriter, r *http.Request) {
+	objectID := strings.TrimPrefix(r.URL.Path, "/target/")
+	if objectID == "" || strings.Contains(objectID, "/") {
+		http.Error(w, "expected /target/{id}", http.StatusNotFound)
+		return
+	}
+
+	if err := s.control.ForceTarget(objectID); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"target_id": objectID})
+}
+
+func (s *Server) handleReleaseTarget(w http.ResponseWriter, r *http.Request) {
+	s.control.ReleaseTarget()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleGetScanning(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"enabled": s.control.IsScanningEnabled(),
+		"profile": s.control.GetScanProfiles().Active,
+	})
+}
+
+func (s *Server) handleSetScanning(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Enabled *bool `json:"enabled"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Enabled == nil {
+		http.Error(w, `expected {"enabled": true|false}`, http.StatusBadRequest)
+		return
+	}
+
+	s.control.SetScanningEnabled(*request.Enabled)
+	writeJSON(w, http.StatusOK, map[string]bool{"enabled": *request.Enabled})
+}
+
+func (s *Server) handleGetScanProfiles(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.control.GetScanProfiles())
+}
+
+// scanProfileName extracts {name} from /scan-profiles/{name}
+func scanProfileName(w http.ResponseWriter, r *http.Request) (string, bool) {
+	name := strings.TrimPrefix(r.URL.Path, "/scan-profiles/")
+	if name == "" || strings.Contains(name, "/") {
+		http.Error(w, "expected /scan-profiles/{name}", http.StatusNotFound)
+		return "", false
+	}
+	return name, true
+}
+
+func (s *Server) handleSelectScanProfile(w http.ResponseWriter, r *http.Request) {
+	name, ok := scanProfileName(w, r)
+	if !ok {
+		return
+	}
+	if err := s.control.SelectScanProfile(name); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"active": name})
+}
+
+func (s *Server) handlePutScanProfile(w http.ResponseWriter, r *http.Request) {
+	name, ok := scanProfileName(w, r)
+	if !ok {
+		return
+	}
+	var profile tracking.CustomScanningPattern
+	if err := json.NewDecoder(r.Body).Decode(&profile); err != nil {
+		http.Error(w, fmt.Sprintf("invalid scan profile: %v", err), http.StatusBadRequest)
+		return
+	}
+	profile.Name = name
+
+	if err := s.control.SaveScanProfile(profile); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, saved := range s.control.GetScanProfiles().Profiles {
+		if saved.Name == name {
+			writeJSON(w, http.StatusOK, saved)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleGetTrackLists(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.control.GetTrackLists())
+}
+
+func (s *Server) handlePutTrackLists(w http.ResponseWriter, r *http.Request) {
+	var lists tracking.TrackLists
+	if err := json.NewDecoder(r.Body).Decode(&lists); err != nil {
+		http.Error(w, fmt.Sprintf("invalid track lists: %v", err), http.StatusBadRequest)
+		return
+	}
+	for i := range lists.P1 {
+		lists.P1[i] = strings.TrimSpace(lists.P1[i])
+	}
+	for i := range lists.P2 {
+		lists.P2[i] = strings.TrimSpace(lists.P2[i])
+	}
+
+	if err := s.control.SetTrackLists(lists); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if s.OnTrackListsChanged != nil {
+		s.OnTrackListsChanged(lists)
+	}
+	writeJSON(w, http.StatusOK, s.control.GetTrackLists())
+}
+
+func (s *Server) handleGetScoring(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.control.GetScoringStrategy())
+}
+
+func (s *Server) handlePutScoring(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Strategy string             `json:"strategy"`
+		Weights  map[string]float64 `json:"weights"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, fmt.Sprintf("invalid scoring strategy: %v", err), http.StatusBadRequest)
+		return
+	}
+	if body.Strategy == "" {
+		body.Strategy = s.control.GetScoringStrategy().Strategy
+	}
+	if err := s.control.SetScoringStrategy(body.Strategy, body.Weights); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.control.GetScoringStrategy())
+}
+
+func (s *Server) handleGetSmartPTZ(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.smartPTZConfig())
+}
+
+func (s *Server) handlePutSmartPTZ(w http.ResponseWriter, r *http.Request) {
+	var update SmartPTZUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, fmt.Sprintf("invalid smart PTZ parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	config, err := update.Merge(s.smartPTZConfig())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	SetSmartPTZ(s.control, config)
+	writeJSON(w, http.StatusOK, s.smartPTZConfig())
+}
+
+func (s *Server) handleLatency(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.Latency.Snapshot())
+}
+
+func (s *Server) smartPTZConfig() SmartPTZConfig {
+	return GetSmartPTZ(s.control)
+}
+
+// GetSmartPTZ returns the smart PTZ configuration in effect
+func GetSmartPTZ(control TrackingControl) SmartPTZConfig {
+	enabled, predictionTime, minVelocity, bufferFactor, pipelineLatency, centerTrigger := control.GetSmartPTZConfigAdvanced()
+	return SmartPTZConfig{
+		Enabled:         enabled,
+		PredictionTime:  predictionTime,
+		MinVelocity:     minVelocity,
+		BufferFactor:    bufferFactor,
+		PipelineLatency: pipelineLatency,
+		CenterTrigger:   centerTrigger,
+	}
+}
+
+// Merge validates the change and returns config with it applied; nothing is changed on the tracker yet
+func (u SmartPTZUpdate) Merge(config SmartPTZConfig) (SmartPTZConfig, error) {
+	for _, field := range []struct {
+		value  *float64
+		target *float64
+		name   string
+		min    float64
+		max    float64
+	}{
+		{u.PredictionTime, &config.PredictionTime, "prediction_time", 0, 10},
+		{u.MinVelocity, &config.MinVelocity, "min_velocity", 0, 1000},
+		{u.BufferFactor, &config.BufferFactor, "buffer_factor", 0, 0.5},
+		{u.PipelineLatency, &config.PipelineLatency, "pipeline_latency", 0, 5},
+		{u.CenterTrigger, &config.CenterTrigger, "center_trigger", 0, 1},
+	} {
+		if field.value == nil {
+			continue
+		}
+		if *field.value < field.min || *field.value > field.max {
+			return config, fmt.Errorf("%s must be between %g and %g", field.name, field.min, field.max)
+		}
+		*field.target = *field.value
+	}
+	if u.Enabled != nil {
+		config.Enabled = *u.Enabled
+	}
+	return config, nil
+}
+
+// SetSmartPTZ applies a smart PTZ configuration (validated by SmartPTZUpdate.Merge)
+func SetSmartPTZ(control TrackingControl, config SmartPTZConfig) {
+	control.ConfigureSmartPTZAdvanced(config.PredictionTime, config.MinVelocity, config.BufferFactor, config.PipelineLatency, config.CenterTrigger)
+	if enabled, _, _, _, _, _ := control.GetSmartPTZConfigAdvanced(); enabled != config.Enabled {
+		if config.Enabled {
+			control.EnableSmartPTZTracking()
+		} else {
+			control.DisableSmartPTZTracking()
+		}
+	}
+}
+
+// writeJSON writes a JSON response body
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		debugMsg("API_ERROR", fmt.Sprintf("Failed to encode response: %v", err))
+	}
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// StatusSource reports what the status overlay shows, for scoreboards and stream overlays that draw it
+// themselves instead of having it burned into the video
+type StatusSource interface {
+	Status() Status
+}
+
+// Status is the status overlay's content
+type Status struct {
+	Time          time.Time `json:"time"`
+	UptimeSeconds float64   `json:"uptime_s"`
+	FPS           float64   `json:"fps"`       // Output frames per second
+	Mode          string    `json:"mode"`      // Tracking mode as shown on the overlay, e.g. "SCANNING" or "LOCK"
+	TargetID      string    `json:"target_id"` // ObjectID the camera is following ("" = none)
+	Objects       int       `json:"objects"`   // Objects tracked right now
+	Tracked       int64     `json:"tracked"`   // Objects tracked since startup
+	Pan           float64   `json:"pan"`
+	Tilt          float64   `json:"tilt"`
+	Zoom          float64   `json:"zoom"`
+	CameraState   string    `json:"camera_state,omitempty"`
+}
+
+// statusFields are the values /status.txt serves one at a time with ?field=, in the order of the full text
+var statusFields = []struct {
+	name  string
+	label string
+	value func(Status) string
+}{
+	{"time", "Time", func(s Status) string { return s.Time.Format("15:04:05") }},
+	{"uptime", "Uptime", func(s Status) string { return formatUptime(s.UptimeSeconds) }},
+	{"fps", "FPS", func(s Status) string { return fmt.Sprintf("%.1f", s.FPS) }},
+	{"mode", "Mode", func(s Status) string { return s.Mode }},
+	{"target", "Target", func(s Status) string {
+		if s.TargetID == "" {
+			return "none"
+		}
+		return s.TargetID
+	}},
+	{"objects", "Objects", func(s Status) string { return fmt.Sprint(s.Objects) }},
+	{"tracked", "Tracked", func(s Status) string { return fmt.Sprint(s.Tracked) }},
+	{"ptz", "PTZ", func(s Status) string { return fmt.Sprintf("P%d T%d Z%d", int(s.Pan), int(s.Tilt), int(s.Zoom)) }},
+	{"camera", "Camera", func(s Status) string { return s.CameraState }},
+}
+
+// handleStatus serves the status overlay's content as JSON
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, s.Status.Status())
+}
+
+// handleStatusText serves the status overlay's content as plain text for OBS text sources: one "Label: value"
+// line each, or only the value of ?field= (time, uptime, fps, mode, target, objects, tracked, ptz, camera)
+func (s *Server) handleStatusText(w http.ResponseWriter, r *http.Request) {
+	status := s.Status.Status()
+	field := strings.ToLower(r.URL.Query().Get("field"))
+
+	var text strings.Builder
+	for _, f := range statusFields {
+		if field == "" {
+			if value := f.value(status); value != "" {
+				fmt.Fprintf(&text, "%s: %s\n", f.label, value)
+			}
+		} else if f.name == field {
+			text.WriteString(f.value(status))
+		}
+	}
+	if field != "" && text.Len() == 0 && !knownStatusField(field) {
+		names := make([]string, 0, len(statusFields))
+		for _, f := range statusFields {
+			names = append(names, f.name)
+		}
+		sort.Strings(names)
+		http.Error(w, fmt.Sprintf("unknown status field %q (known: %s)", field, strings.Join(names, ", ")), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write([]byte(text.String()))
+}
+
+// knownStatusField reports whether /status.txt serves a field of that name
+func knownStatusField(name string) bool {
+	for _, f := range statusFields {
+		if f.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// formatUptime formats seconds as e.g. "3d 4h 12m" or "12m 5s"
+func formatUptime(seconds float64) string {
+	uptime := time.Duration(seconds) * time.Second
+	days := int(uptime / (24 * time.Hour))
+	hours := int(uptime/time.Hour) % 24
+	minutes := int(uptime/time.Minute) % 60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh %dm", days, hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm %ds", minutes, int(uptime/time.Second)%60)
+	}
+}
@@ -0,0 +1,129 @@
+package calibration
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+)
+
+// DefaultLensZoomGap is how far (camera zoom units) from its calibrated zoom an intrinsics entry is still used
+const DefaultLensZoomGap = 10.0
+
+// undistortIterations bounds the fixed-point inversion of the distortion model; it converges in a handful of
+// steps for the modest distortion of a PTZ lens
+const undistortIterations = 10
+
+// Intrinsics is a camera model measured at one zoom level (OpenCV conventions: focal lengths and principal
+// point in pixels, Brown-Conrady radial k1..k3 and tangential p1, p2 coefficients)
+type Intrinsics struct {
+	Zoom float64 `json:"zoom"`
+	Fx   float64 `json:"fx"`
+	Fy   float64 `json:"fy"`
+	Cx   float64 `json:"cx"`
+	Cy   float64 `json:"cy"`
+	K1   float64 `json:"k1"`
+	K2   float64 `json:"k2"`
+	K3   float64 `json:"k3"`
+	P1   float64 `json:"p1,omitempty"`
+	P2   float64 `json:"p2,omitempty"`
+}
+
+// LensModel holds intrinsics for one or more zoom levels. Distortion matters most at wide zoom; zoom levels
+// further than MaxZoomGap from every entry are left uncorrected.
+type LensModel struct {
+	FrameWidth  int          `json:"frame_width"` // Frame size the intrinsics were measured at
+	FrameHeight int          `json:"frame_height"`
+	MaxZoomGap  float64      `json:"max_zoom_gap,omitempty"`
+	Entries     []Intrinsics `json:"intrinsics"`
+	Source      string       `json:"-"`
+}
+
+// LoadLensModel reads a camera intrinsics file
+func LoadLensModel(path string) (*LensModel, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lens file: %v", err)
+	}
+
+	var model LensModel
+	if err := json.Unmarshal(data, &model); err != nil {
+		return nil, fmt.Errorf("failed to parse lens file %s: %v", path, err)
+	}
+	model.Source = path
+	if model.FrameWidth <= 0 || model.FrameHeight <= 0 {
+		return nil, fmt.Errorf("lens file %s: frame_width and frame_height are required", path)
+	}
+	if len(model.Entries) == 0 {
+		return nil, fmt.Errorf("lens file %s has no intrinsics entries", path)
+	}
+	if model.MaxZoomGap <= 0 {
+		model.MaxZoomGap = DefaultLensZoomGap
+	}
+	for _, entry := range model.Entries {
+		if entry.Fx <= 0 || entry.Fy <= 0 {
+			return nil, fmt.Errorf("lens file %s: zoom %.0f needs positive fx and fy (fx=%.1f, fy=%.1f)", path, entry.Zoom, entry.Fx, entry.Fy)
+		}
+	}
+	sort.Slice(model.Entries, func(i, j int) bool { return model.Entries[i].Zoom < model.Entries[j].Zoom })
+	for i := 1; i < len(model.Entries); i++ {
+		if model.Entries[i].Zoom == model.Entries[i-1].Zoom {
+			return nil, fmt.Errorf("lens file %s: zoom %.0f is listed twice", path, model.Entries[i].Zoom)
+		}
+	}
+	return &model, nil
+}
+
+// At returns the intrinsics closest to a zoom level, or nil when none is within MaxZoomGap
+func (m *LensModel) At(zoom float64) *Intrinsics {
+	if m == nil {
+		return nil
+	}
+	var best *Intrinsics
+	for i := range m.Entries {
+		gap := math.Abs(m.Entries[i].Zoom - zoom)
+		if gap <= m.MaxZoomGap && (best == nil || gap < math.Abs(best.Zoom-zoom)) {
+			best = &m.Entries[i]
+		}
+	}
+	return best
+}
+
+// Undistort maps a pixel of a frameWidth x frameHeight image to where it would be through an ideal
+// (distortion-free) lens with the same focal length. Zoom levels without intrinsics return the pixel unchanged.
+func (m *LensModel) Undistort(x, y, zoom float64, frameWidth, frameHeight int) (float64, float64) {
+	intrinsics := m.At(zoom)
+	if intrinsics == nil || frameWidth <= 0 || frameHeight <= 0 {
+		return x, y
+	}
+
+	// Intrinsics scale with the stream resolution
+	scaleX := float64(frameWidth) / float64(m.FrameWidth)
+	scaleY := float64(frameHeight) / float64(m.FrameHeight)
+	fx, fy := intrinsics.Fx*scaleX, intrinsics.Fy*scaleY
+	cx, cy := intrinsics.Cx*scaleX, intrinsics.Cy*scaleY
+
+	// Normalized distorted coordinates; invert the distortion by fixed-point iteration
+	distortedX := (x - cx) / fx
+	distortedY := (y - cy) / fy
+	undistortedX, undistortedY := distortedX, distortedY
+	for i := 0; i < undistortIterations; i++ {
+		r2 := undistortedX*undistortedX + undistortedY*undistortedY
+		radial := 1 + intrinsics.K1*r2 + intrinsics.K2*r2*r2 + intrinsics.K3*r2*r2*r2
+		if radial <= 0 {
+			return x, y // Far outside the model's valid field
+		}
+		deltaX := 2*intrinsics.P1*undistortedX*undistortedY + intrinsics.P2*(r2+2*undistortedX*undistortedX)
+		deltaY := intrinsics.P1*(r2+2*undistortedY*undistortedY) + 2*intrinsics.P2*undistortedX*undistortedY
+		undistortedX = (distortedX - deltaX) / radial
+		undistortedY = (distortedY - deltaY) / radial
+	}
+	return undistortedX*fx + cx, undistortedY*fy + cy
+}
+
+// String summarizes the model for startup logs
+func (m *LensModel) String() string {
+	return fmt.Sprintf("%s: %d zoom levels %.0f-%.0f (±%.0f), measured at %dx%d", m.Source, len(m.Entries),
+		m.Entries[0].Zoom, m.Entries[len(m.Entries)-1].Zoom, m.MaxZoomGap, m.FrameWidth, m.FrameHeight)
+}
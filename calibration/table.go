@@ -0,0 +1,187 @@
+// Package calibration loads the zoom calibration table that converts pixel offsets into PTZ units.
+//
+// The table is produced by the hand_calibrator tool (manual-calibration-results.json): for each tested
+// zoom level it records how many pixels the image moves per pan unit and per tilt unit. Values between
+// calibrated zoom levels are linearly interpolated.
+//
+// The table assumes pixels map linearly onto pan/tilt. At wide zoom a lens model (camera intrinsics with
+// radial/tangential distortion, see LensModel) undistorts pixels first so targets near the frame edges
+// convert correctly.
+package calibration
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Entry is the calibration of one zoom level
+type Entry struct {
+	Zoom              float64
+	PanPixelsPerUnit  float64
+	TiltPixelsPerUnit float64
+}
+
+// Table is a calibration table sorted by zoom level
+type Table struct {
+	Entries     []Entry
+	FrameWidth  int    // Frame size the table was measured at (0 = unknown)
+	FrameHeight int    // Frame size the table was measured at (0 = unknown)
+	Source      string // File the table was loaded from ("built-in" for DefaultTable)
+}
+
+// handCalibratorResults is the subset of the hand_calibrator output used here
+type handCalibratorResults struct {
+	FrameDimensions struct {
+		Width  int `json:"width"`
+		Height int `json:"height"`
+	} `json:"frame_dimensions"`
+	CalibrationTable map[string]struct {
+		ZoomLevel         float64
+		PanPixelsPerUnit  float64
+		TiltPixelsPerUnit float64
+	} `json:"calibration_table"`
+}
+
+// DefaultTable returns the built-in calibration measured on the original bridge camera at 2688x1520
+func DefaultTable() *Table {
+	return &Table{
+		Entries: []Entry{
+			{Zoom: 10, PanPixelsPerUnit: 4.87, TiltPixelsPerUnit: 5.00},
+			{Zoom: 20, PanPixelsPerUnit: 7.91, TiltPixelsPerUnit: 7.76},
+			{Zoom: 30, PanPixelsPerUnit: 10.38, TiltPixelsPerUnit: 10.86},
+			{Zoom: 40, PanPixelsPerUnit: 12.74, TiltPixelsPerUnit: 12.67},
+			{Zoom: 50, PanPixelsPerUnit: 15.27, TiltPixelsPerUnit: 15.83},
+			{Zoom: 60, PanPixelsPerUnit: 18.41, TiltPixelsPerUnit: 18.54},
+			{Zoom: 70, PanPixelsPerUnit: 19.91, TiltPixelsPerUnit: 22.69},
+			{Zoom: 80, PanPixelsPerUnit: 21.68, TiltPixelsPerUnit: 23.75},
+			{Zoom: 90, PanPixelsPerUnit: 26.10, TiltPixelsPerUnit: 26.67},
+			{Zoom: 100, PanPixelsPerUnit: 27.71, TiltPixelsPerUnit: 30.40},
+			{Zoom: 110, PanPixelsPerUnit: 30.20, TiltPixelsPerUnit: 31.67},
+			{Zoom: 120, PanPixelsPerUnit: 36.32, TiltPixelsPerUnit: 33.78},
+		},
+		FrameWidth:  2688,
+		FrameHeight: 1520,
+		Source:      "built-in",
+	}
+}
+
+// LoadCalibrationTable reads a hand_calibrator results file. Every entry must have positive pan and
+// tilt sensitivities; hand_calibrator stores them as magnitudes, negative values are accepted and
+// made positive.
+func LoadCalibrationTable(path string) (*Table, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read calibration file: %v", err)
+	}
+
+	var results handCalibratorResults
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse calibration file %s: %v", path, err)
+	}
+	if len(results.CalibrationTable) == 0 {
+		return nil, fmt.Errorf("calibration file %s has no calibration_table entries", path)
+	}
+
+	table := &Table{
+		FrameWidth:  results.FrameDimensions.Width,
+		FrameHeight: results.FrameDimensions.Height,
+		Source:      path,
+	}
+	for key, value := range results.CalibrationTable {
+		zoom := value.ZoomLevel
+		if zoom == 0 {
+			// Older results only carry the zoom level in the map key
+			if zoom, err = strconv.ParseFloat(key, 64); err != nil {
+				return nil, fmt.Errorf("calibration file %s: invalid zoom level %q", path, key)
+			}
+		}
+		entry := Entry{Zoom: zoom, PanPixelsPerUnit: abs(value.PanPixelsPerUnit), TiltPixelsPerUnit: abs(value.TiltPixelsPerUnit)}
+		if entry.PanPixelsPerUnit == 0 || entry.TiltPixelsPerUnit == 0 {
+			return nil, fmt.Errorf("calibration file %s: zoom %.0f has no pan/tilt sensitivity (pan=%.3f, tilt=%.3f)",
+				path, zoom, value.PanPixelsPerUnit, value.TiltPixelsPerUnit)
+		}
+		table.Entries = append(table.Entries, entry)
+	}
+	sort.Slice(table.Entries, func(i, j int) bool { return table.Entries[i].Zoom < table.Entries[j].Zoom })
+
+	for i := 1; i < len(table.Entries); i++ {
+		if table.Entries[i].Zoom == table.Entries[i-1].Zoom {
+			return nil, fmt.Errorf("calibration file %s: zoom %.0f is listed twice", path, table.Entries[i].Zoom)
+		}
+	}
+	return table, nil
+}
+
+// CheckCoverage returns an error naming every part of [minZoom, maxZoom] the table does not cover: below
+// the first entry, above the last, and gaps between neighbouring entries wider than maxGap.
+func (t *Table) CheckCoverage(minZoom, maxZoom, maxGap float64) error {
+	if len(t.Entries) == 0 {
+		return fmt.Errorf("calibration table %s is empty", t.Source)
+	}
+
+	var uncovered []string
+	first, last := t.Entries[0].Zoom, t.Entries[len(t.Entries)-1].Zoom
+	if first > minZoom {
+		uncovered = append(uncovered, fmt.Sprintf("%.0f-%.0f", minZoom, first))
+	}
+	for i := 1; i < len(t.Entries); i++ {
+		lower, upper := t.Entries[i-1].Zoom, t.Entries[i].Zoom
+		if upper <= minZoom || lower >= maxZoom {
+			continue
+		}
+		if maxGap > 0 && upper-lower > maxGap {
+			uncovered = append(uncovered, fmt.Sprintf("%.0f-%.0f", lower, upper))
+		}
+	}
+	if last < maxZoom {
+		uncovered = append(uncovered, fmt.Sprintf("%.0f-%.0f", last, maxZoom))
+	}
+
+	if len(uncovered) > 0 {
+		return fmt.Errorf("calibration table %s (zoom %.0f-%.0f, %d levels) does not cover zoom %s of the camera range %.0f-%.0f - run hand_calibrator for those levels",
+			t.Source, first, last, len(t.Entries), strings.Join(uncovered, ", "), minZoom, maxZoom)
+	}
+	return nil
+}
+
+// InterpolateAt returns the pan and tilt pixels per PTZ unit at a zoom level. Zoom outside the table is
+// clamped to the first or last entry.
+func (t *Table) InterpolateAt(zoom float64) (panPixelsPerUnit, tiltPixelsPerUnit float64) {
+	if len(t.Entries) == 0 {
+		return 0, 0
+	}
+	first, last := t.Entries[0], t.Entries[len(t.Entries)-1]
+	if zoom <= first.Zoom {
+		return first.PanPixelsPerUnit, first.TiltPixelsPerUnit
+	}
+	if zoom >= last.Zoom {
+		return last.PanPixelsPerUnit, last.TiltPixelsPerUnit
+	}
+
+	// First entry above the zoom level; the one before it is below
+	i := sort.Search(len(t.Entries), func(i int) bool { return t.Entries[i].Zoom >= zoom })
+	lower, upper := t.Entries[i-1], t.Entries[i]
+	ratio := (zoom - lower.Zoom) / (upper.Zoom - lower.Zoom)
+	return lower.PanPixelsPerUnit + ratio*(upper.PanPixelsPerUnit-lower.PanPixelsPerUnit),
+		lower.TiltPixelsPerUnit + ratio*(upper.TiltPixelsPerUnit-lower.TiltPixelsPerUnit)
+}
+
+// String summarizes the table for startup logs
+func (t *Table) String() string {
+	if len(t.Entries) == 0 {
+		return fmt.Sprintf("%s: empty", t.Source)
+	}
+	return fmt.Sprintf("%s: %d zoom levels %.0f-%.0f, measured at %dx%d", t.Source, len(t.Entries),
+		t.Entries[0].Zoom, t.Entries[len(t.Entries)-1].Zoom, t.FrameWidth, t.FrameHeight)
+}
+
+func abs(value float64) float64 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
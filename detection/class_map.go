@@ -0,0 +1,128 @@
+package detection
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxClassMapIndex bounds the class indexes a class map may name (models have at most a few thousand classes)
+const maxClassMapIndex = 9999
+
+// ClassMap maps the class indexes a custom model outputs to the canonical names tracking uses ("boat",
+// "person", ...), so the P1/P2 lists and per-class settings work with any model without code changes
+type ClassMap map[int]string
+
+// LoadClassMap reads a class map file (-class-map): YAML or JSON with a "names" entry giving the canonical name
+// of each model class index, either as a mapping or as a list in index order, so an Ultralytics data.yaml
+// works as is. Several indexes may share a name, e.g. a model's motorboat and sailboat classes both as "boat":
+//
+//	names:
+//	  0: boat
+//	  1: boat
+//	  2: person
+func LoadClassMap(path string) (ClassMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read class map: %v", err)
+	}
+	var file struct {
+		Names yaml.Node `yaml:"names"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("could not parse class map %s: %v", path, err)
+	}
+
+	classMap := ClassMap{}
+	add := func(index int, name, where string) error {
+		name = strings.TrimSpace(name)
+		if index < 0 || index > maxClassMapIndex {
+			return fmt.Errorf("class map %s: index %d at %s is out of range (0-%d)", path, index, where, maxClassMapIndex)
+		}
+		if name == "" {
+			return fmt.Errorf("class map %s: class %d at %s has no name", path, index, where)
+		}
+		if previous, exists := classMap[index]; exists {
+			return fmt.Errorf("class map %s: class %d is mapped twice (%q and %q)", path, index, previous, name)
+		}
+		classMap[index] = name
+		return nil
+	}
+
+	names := file.Names
+	switch names.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(names.Content); i += 2 {
+			key, value := names.Content[i], names.Content[i+1]
+			where := fmt.Sprintf("line %d", key.Line)
+			index, err := strconv.Atoi(key.Value)
+			if err != nil {
+				return nil, fmt.Errorf("class map %s: %q at %s is not a class index", path, key.Value, where)
+			}
+			if value.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("class map %s: class %d at %s must be a name", path, index, where)
+			}
+			if err := add(index, value.Value, where); err != nil {
+				return nil, err
+			}
+		}
+	case yaml.SequenceNode:
+		for index, value := range names.Content {
+			where := fmt.Sprintf("line %d", value.Line)
+			if value.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("class map %s: class %d at %s must be a name", path, index, where)
+			}
+			if err := add(index, value.Value, where); err != nil {
+				return nil, err
+			}
+		}
+	case 0:
+		return nil, fmt.Errorf("class map %s has no names entry", path)
+	default:
+		return nil, fmt.Errorf("class map %s: names must map class indexes to names, or list names in index order", path)
+	}
+	if len(classMap) == 0 {
+		return nil, fmt.Errorf("class map %s maps no classes", path)
+	}
+	return classMap, nil
+}
+
+// Apply returns the class names by index with the map applied: mapped indexes get their canonical name, the
+// others keep theirs from names when keepUnmapped is set. Unmapped indexes otherwise, and the gaps left by mapped
+// indexes beyond names, are named "class<N>", which no track list matches.
+func (m ClassMap) Apply(names []string, keepUnmapped bool) []string {
+	size := len(names)
+	for index := range m {
+		size = max(size, index+1)
+	}
+	result := make([]string, size)
+	for index := range result {
+		switch name, mapped := m[index]; {
+		case mapped:
+			result[index] = name
+		case keepUnmapped && index < len(names):
+			result[index] = names[index]
+		default:
+			result[index] = fmt.Sprintf("class%d", index)
+		}
+	}
+	return result
+}
+
+// String summarizes the map for the log, e.g. "0→boat 1→boat 2→person"
+func (m ClassMap) String() string {
+	indexes := make([]int, 0, len(m))
+	for index := range m {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	entries := make([]string, len(indexes))
+	for i, index := range indexes {
+		entries[i] = fmt.Sprintf("%d→%s", index, m[index])
+	}
+	return strings.Join(entries, " ")
+}
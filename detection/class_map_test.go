@@ -0,0 +1,93 @@
+package detection
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeClassMap(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "names.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadClassMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     ClassMap
+	}{
+		{"mapping", "names:\n  0: boat\n  1: boat\n  2: person\n", ClassMap{0: "boat", 1: "boat", 2: "person"}},
+		{"sparse mapping", "names:\n  3: boat\n  7: ' person '\n", ClassMap{3: "boat", 7: "person"}},
+		{"list", "names: [boat, person, kayak]\n", ClassMap{0: "boat", 1: "person", 2: "kayak"}},
+		{"ultralytics data.yaml", "path: ../datasets/river\ntrain: images/train\nnc: 2\nnames:\n  - boat\n  - person\n", ClassMap{0: "boat", 1: "person"}},
+		{"json", `{"names": {"0": "boat", "4": "person"}}`, ClassMap{0: "boat", 4: "person"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := LoadClassMap(writeClassMap(t, test.contents))
+			if err != nil {
+				t.Fatalf("LoadClassMap: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLoadClassMapErrors(t *testing.T) {
+	tests := map[string]string{
+		"no names":         "nc: 2\n",
+		"empty names":      "names: {}\n",
+		"not an index":     "names:\n  boat: 0\n",
+		"negative index":   "names:\n  -1: boat\n",
+		"index too large":  "names:\n  10000: boat\n",
+		"empty name":       "names:\n  0: ''\n",
+		"nested name":      "names:\n  0: [boat]\n",
+		"scalar names":     "names: boat\n",
+		"mapped twice":     "names:\n  0: boat\n  0: person\n",
+		"unparseable yaml": "names: [boat\n",
+	}
+	for name, contents := range tests {
+		t.Run(name, func(t *testing.T) {
+			if classMap, err := LoadClassMap(writeClassMap(t, contents)); err == nil {
+				t.Errorf("accepted as %v", classMap)
+			}
+		})
+	}
+
+	if _, err := LoadClassMap(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Errorf("missing file accepted")
+	}
+}
+
+func TestClassMapApply(t *testing.T) {
+	classMap := ClassMap{0: "boat", 2: "person", 5: "boat"}
+	names := []string{"motorboat", "sailboat", "swimmer", "buoy"}
+
+	tests := []struct {
+		name         string
+		keepUnmapped bool
+		want         []string
+	}{
+		{"keep unmapped names", true, []string{"boat", "sailboat", "person", "buoy", "class4", "boat"}},
+		{"unmapped as class<N>", false, []string{"boat", "class1", "person", "class3", "class4", "boat"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := classMap.Apply(names, test.keepUnmapped); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Apply = %v, want %v", got, test.want)
+			}
+		})
+	}
+
+	if got := classMap.String(); got != "0→boat 2→person 5→boat" {
+		t.Errorf("String = %q", got)
+	}
+}
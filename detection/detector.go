@@ -0,0 +1,61 @@
+package detection
+
+import (
+	"fmt"
+	"image"
+	"io/ioutil"
+	"strings"
+
+	"gocv.io/x/gocv"
+)
+
+// Model formats (-model-format)
+const (
+	FormatDarknet = "darknet" // YOLOv3-tiny weights/cfg through OpenCV DNN
+	FormatONNX    = "onnx"    // YOLOv5/v8/v9 or RT-DETR exports through ONNX Runtime
+)
+
+// Detection is a single detected object in frame coordinates
+type Detection struct {
+	Rect       image.Rectangle
+	ClassID    int
+	ClassName  string // Empty when the class ID is not in the names file
+	Confidence float64
+}
+
+// Detector is a pluggable object detection model. Detect returns every candidate above the model's minimal
+// confidence; class and confidence filtering for tracking is left to the caller.
+type Detector interface {
+	Detect(frame gocv.Mat) ([]Detection, error)
+	Close() error
+	Name() string
+}
+
+// ParseModelFormat validates a -model-format value
+func ParseModelFormat(format string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case FormatDarknet:
+		return FormatDarknet, nil
+	case FormatONNX:
+		return FormatONNX, nil
+	default:
+		return "", fmt.Errorf("unknown model format %q (valid formats are: %s, %s)", format, FormatDarknet, FormatONNX)
+	}
+}
+
+// LoadClassNames reads a names file with one class per line
+func LoadClassNames(path string) ([]string, error) {
+	namesBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read class names: %v", err)
+	}
+	return strings.Split(strings.TrimRight(string(namesBytes), "\n"), "\n"), nil
+}
+
+// classNameFor returns the name of a class ID, or "" when it is out of range
+func classNameFor(classNames []string, classID int) string {
+	if classID < 0 || classID >= len(classNames) {
+		return ""
+	}
+	return strings.TrimSpace(classNames[classID])
+}
@@ -0,0 +1,93 @@
+package detection
+
+import (
+	"fmt"
+	"image"
+	"time"
+
+	"gocv.io/x/gocv"
+)
+
+// ensembleCropPadding is how much context (fraction of the box size) is added around a detection before
+// it is handed to the secondary model
+const ensembleCropPadding = 0.5
+
+// EnsembleVerifier runs a second, higher-accuracy model on single detections to confirm or reject the
+// primary model's output. It only ever sees small crops, so its latency is paid per check rather than per frame.
+type EnsembleVerifier struct {
+	manager       *ProviderManager
+	minConfidence float64
+}
+
+// NewEnsembleVerifier loads the secondary model (GPU when available, CPU otherwise)
+func NewEnsembleVerifier(weightsPath, configPath, namesPath string, minConfidence float64) (*EnsembleVerifier, error) {
+	manager := NewProviderManager()
+	if err := manager.Initialize(weightsPath, configPath, namesPath); err != nil {
+		return nil, fmt.Errorf("failed to load ensemble model: %v", err)
+	}
+
+	info := manager.GetProviderInfo()
+	debugMsg("ENSEMBLE", fmt.Sprintf("🛡️ Ensemble model %s loaded on %s (%s) in %v", weightsPath, info.Type, info.Backend, info.InitTime))
+
+	return &EnsembleVerifier{
+		manager:       manager,
+		minConfidence: minConfidence,
+	}, nil
+}
+
+// Verify runs the secondary model on a square crop around rect and reports whether it also sees className
+// there. Returns the best matching confidence (0 when nothing matched).
+func (ev *EnsembleVerifier) Verify(frame gocv.Mat, rect image.Rectangle, className string) (bool, float64, error) {
+	frameBounds := image.Rect(0, 0, frame.Cols(), frame.Rows())
+	rect = rect.Intersect(frameBounds)
+	if rect.Empty() {
+		return false, 0, fmt.Errorf("detection is outside the frame")
+	}
+
+	// Square crop with context around the box; a square input keeps the provider's letterbox mapping exact
+	side := rect.Dx()
+	if rect.Dy() > side {
+		side = rect.Dy()
+	}
+	side += int(float64(side) * 2 * ensembleCropPadding)
+	center := image.Pt(rect.Min.X+rect.Dx()/2, rect.Min.Y+rect.Dy()/2)
+	cropRect := image.Rect(center.X-side/2, center.Y-side/2, center.X-side/2+side, center.Y-side/2+side)
+	visible := cropRect.Intersect(frameBounds)
+
+	square := gocv.NewMatWithSize(side, side, frame.Type())
+	defer square.Close()
+	source := frame.Region(visible)
+	target := square.Region(visible.Sub(cropRect.Min))
+	source.CopyTo(&target)
+	source.Close()
+	target.Close()
+
+	start := time.Now()
+	result, err := ev.manager.GetProvider().Detect(square)
+	if err != nil {
+		return false, 0, fmt.Errorf("ensemble inference failed: %v", err)
+	}
+
+	// The primary box, in crop coordinates - the secondary detection must be centered inside it
+	expected := rect.Sub(cropRect.Min)
+	best := 0.0
+	for i, detected := range result.Rects {
+		if result.ClassNames[i] != className {
+			continue
+		}
+		detectedCenter := image.Pt(detected.Min.X+detected.Dx()/2, detected.Min.Y+detected.Dy()/2)
+		if detectedCenter.In(expected) && result.Confidences[i] > best {
+			best = result.Confidences[i]
+		}
+	}
+
+	confirmed := best >= ev.minConfidence
+	debugMsg("ENSEMBLE", fmt.Sprintf("🛡️ Secondary check %s at %v: best conf %.2f (need %.2f) → confirmed=%v in %v",
+		className, rect, best, ev.minConfidence, confirmed, time.Since(start).Round(time.Millisecond)))
+	return confirmed, best, nil
+}
+
+// Close releases the secondary model
+func (ev *EnsembleVerifier) Close() error {
+	return ev.manager.Close()
+}
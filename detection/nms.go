@@ -0,0 +1,160 @@
+package detection
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sort"
+	"strings"
+)
+
+// NMS modes (-nms)
+const (
+	NMSOff      = "off"
+	NMSSuppress = "suppress" // Keep the most confident box of each group of overlapping boxes
+	NMSMerge    = "merge"    // Replace each group by its confidence-weighted mean box (steadier boxes across tile seams)
+)
+
+// NMS defaults
+const (
+	DefaultNMSIoU           = 0.45 // Overlap above which two boxes are taken for the same object
+	DefaultNMSMinConfidence = 0.05 // Below every tracking threshold (adaptive P2 goes down to 0.075); keeps raw model output small
+)
+
+// NMSConfig configures the non-maximum suppression stage that removes duplicate boxes of one object, from
+// the model itself or from overlapping tiles, before detections reach tracking
+type NMSConfig struct {
+	Mode          string     // NMSSuppress, NMSMerge or NMSOff ("" = NMSSuppress)
+	IoUThreshold  float64    // Overlap above which two boxes are duplicates (0 = DefaultNMSIoU)
+	MinConfidence float64    // Candidates below this are dropped first
+	ClassGroups   [][]string // Classes deduplicated against each other (e.g. boat and ship for one hull); every other class only against itself
+}
+
+// Validate fills defaults and checks the configuration
+func (c *NMSConfig) Validate() error {
+	switch c.Mode {
+	case "":
+		c.Mode = NMSSuppress
+	case NMSSuppress, NMSMerge, NMSOff:
+	default:
+		return fmt.Errorf("unknown NMS mode %q (valid modes are: %s, %s, %s)", c.Mode, NMSOff, NMSSuppress, NMSMerge)
+	}
+	if c.IoUThreshold == 0 {
+		c.IoUThreshold = DefaultNMSIoU
+	}
+	if c.IoUThreshold <= 0 || c.IoUThreshold >= 1 {
+		return fmt.Errorf("NMS IoU threshold must be between 0 and 1, got %v", c.IoUThreshold)
+	}
+	seen := make(map[string]bool)
+	for _, group := range c.ClassGroups {
+		for _, class := range group {
+			if seen[class] {
+				return fmt.Errorf("class %q is in more than one NMS class group", class)
+			}
+			seen[class] = true
+		}
+	}
+	return nil
+}
+
+// ParseClassGroups parses "boat,ship;car,truck" into NMS class groups
+func ParseClassGroups(value string) ([][]string, error) {
+	var groups [][]string
+	for _, part := range strings.Split(value, ";") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		var group []string
+		for _, class := range strings.Split(part, ",") {
+			if class = strings.TrimSpace(class); class != "" {
+				group = append(group, class)
+			}
+		}
+		if len(group) < 2 {
+			return nil, fmt.Errorf("class group %q needs at least two classes", part)
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// Suppress removes duplicate detections: boxes of the same class (or class group) overlapping a more confident
+// one by more than the IoU threshold are dropped, or with NMSMerge folded into it. The result is ordered by
+// confidence, most confident first.
+func Suppress(detections []Detection, config NMSConfig) []Detection {
+	if err := config.Validate(); err != nil || config.Mode == NMSOff {
+		return detections
+	}
+
+	groupOf := make(map[string]int)
+	for i, group := range config.ClassGroups {
+		for _, class := range group {
+			groupOf[class] = i
+		}
+	}
+	candidates := make([]Detection, 0, len(detections))
+	for _, detection := range detections {
+		if detection.Confidence >= config.MinConfidence && !detection.Rect.Empty() {
+			candidates = append(candidates, detection)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Confidence > candidates[j].Confidence })
+
+	// Boxes are only compared within their class or class group
+	keys := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		if group, ok := groupOf[candidate.ClassName]; ok {
+			keys[i] = fmt.Sprintf("group %d", group)
+		} else if candidate.ClassName == "" {
+			keys[i] = fmt.Sprintf("class %d", candidate.ClassID)
+		} else {
+			keys[i] = candidate.ClassName
+		}
+	}
+
+	suppressed := make([]bool, len(candidates))
+	var kept []Detection
+	for i, best := range candidates {
+		if suppressed[i] {
+			continue
+		}
+		var left, top, right, bottom, weights float64
+		addBox := func(rect image.Rectangle, weight float64) {
+			left += float64(rect.Min.X) * weight
+			top += float64(rect.Min.Y) * weight
+			right += float64(rect.Max.X) * weight
+			bottom += float64(rect.Max.Y) * weight
+			weights += weight
+		}
+		addBox(best.Rect, best.Confidence)
+
+		for j := i + 1; j < len(candidates); j++ {
+			if suppressed[j] || keys[j] != keys[i] || boxIoU(best.Rect, candidates[j].Rect) <= config.IoUThreshold {
+				continue
+			}
+			suppressed[j] = true
+			addBox(candidates[j].Rect, candidates[j].Confidence)
+		}
+
+		if config.Mode == NMSMerge && weights > 0 {
+			best.Rect = image.Rect(int(math.Round(left/weights)), int(math.Round(top/weights)),
+				int(math.Round(right/weights)), int(math.Round(bottom/weights)))
+		}
+		kept = append(kept, best)
+	}
+	return kept
+}
+
+// boxIoU is the intersection over union of two boxes
+func boxIoU(a, b image.Rectangle) float64 {
+	intersection := a.Intersect(b)
+	if intersection.Empty() {
+		return 0
+	}
+	overlap := float64(intersection.Dx() * intersection.Dy())
+	union := float64(a.Dx()*a.Dy()+b.Dx()*b.Dy()) - overlap
+	if union <= 0 {
+		return 0
+	}
+	return overlap / union
+}
@@ -0,0 +1,167 @@
+package detection
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"gocv.io/x/gocv"
+)
+
+// ONNX detector defaults
+const (
+	DefaultONNXInputSize     = 640
+	DefaultONNXMinConfidence = 0.1  // Same floor the raw YOLO overlay uses
+	DefaultONNXNMSThreshold  = 0.45 // IoU above which the weaker of two same-class boxes is suppressed
+)
+
+// ONNXConfig configures an ONNX Runtime detector
+type ONNXConfig struct {
+	ModelPath     string
+	NamesPath     string
+	ClassNames    []string // Class names by index, e.g. after a class map (overrides NamesPath)
+	InputSize     int      // Square model input in pixels (640 for most YOLOv8/v9 and RT-DETR exports)
+	MinConfidence float64  // Candidates below this are dropped before NMS
+	NMSThreshold  float64
+	UseCUDA       bool // Run on the CUDA execution provider (falls back to the CPU if it is unavailable)
+	Threads       int  // Intra-op threads on the CPU (0 = ONNX Runtime default)
+}
+
+// validate fills defaults and checks the configuration
+func (c *ONNXConfig) validate() error {
+	if c.ModelPath == "" {
+		return fmt.Errorf("ONNX model path is required")
+	}
+	if c.InputSize == 0 {
+		c.InputSize = DefaultONNXInputSize
+	}
+	if c.InputSize < 32 || c.InputSize%32 != 0 {
+		return fmt.Errorf("ONNX input size must be a positive multiple of 32, got %d", c.InputSize)
+	}
+	if c.MinConfidence <= 0 {
+		c.MinConfidence = DefaultONNXMinConfidence
+	}
+	if c.NMSThreshold <= 0 {
+		c.NMSThreshold = DefaultONNXNMSThreshold
+	}
+	return nil
+}
+
+// letterboxTransform maps model input coordinates back to the frame
+type letterboxTransform struct {
+	scale      float64
+	padX, padY float64
+	frame      image.Rectangle
+}
+
+// toFrame converts a center box in model input pixels to a frame rectangle
+func (t letterboxTransform) toFrame(centerX, centerY, width, height float64) image.Rectangle {
+	left := (centerX - width/2 - t.padX) / t.scale
+	top := (centerY - height/2 - t.padY) / t.scale
+	right := (centerX + width/2 - t.padX) / t.scale
+	bottom := (centerY + height/2 - t.padY) / t.scale
+	return image.Rect(int(left), int(top), int(right), int(bottom)).Intersect(t.frame)
+}
+
+// letterboxFrame scales the frame into a size x size canvas, centered with black bars, preserving its aspect ratio
+func letterboxFrame(frame gocv.Mat, size int) (gocv.Mat, letterboxTransform) {
+	scale := math.Min(float64(size)/float64(frame.Cols()), float64(size)/float64(frame.Rows()))
+	width := int(math.Round(float64(frame.Cols()) * scale))
+	height := int(math.Round(float64(frame.Rows()) * scale))
+	padX := (size - width) / 2
+	padY := (size - height) / 2
+
+	canvas := gocv.NewMatWithSize(size, size, gocv.MatTypeCV8UC3)
+	canvas.SetTo(gocv.NewScalar(0, 0, 0, 0))
+
+	resized := gocv.NewMat()
+	defer resized.Close()
+	gocv.Resize(frame, &resized, image.Pt(width, height), 0, 0, gocv.InterpolationLinear)
+
+	content := canvas.Region(image.Rect(padX, padY, padX+width, padY+height))
+	defer content.Close()
+	resized.CopyTo(&content)
+
+	return canvas, letterboxTransform{
+		scale: scale,
+		padX:  float64(padX),
+		padY:  float64(padY),
+		frame: image.Rect(0, 0, frame.Cols(), frame.Rows()),
+	}
+}
+
+// decodeONNXOutput converts a detection tensor into frame detections (before NMS). Three layouts are understood:
+//   - [1, 4+classes, anchors]: YOLOv8/v9, center boxes in input pixels followed by one score per class
+//   - [1, boxes, 5+classes]: YOLOv5, center box, objectness, then class scores
+//   - [1, boxes, 4+classes]: RT-DETR, normalized center boxes followed by class scores
+func decodeONNXOutput(output []float32, shape []int64, inputSize int, transform letterboxTransform, classNames []string, minConfidence float64) ([]Detection, error) {
+	if len(shape) == 3 && shape[0] == 1 {
+		shape = shape[1:]
+	}
+	if len(shape) != 2 || shape[0] <= 0 || shape[1] <= 0 || int64(len(output)) != shape[0]*shape[1] {
+		return nil, fmt.Errorf("unsupported ONNX output shape %v", shape)
+	}
+
+	// Channels-first when there are far more anchors than values per box
+	channelsFirst := shape[0] < shape[1]
+	boxes, values := int(shape[0]), int(shape[1])
+	if channelsFirst {
+		boxes, values = values, boxes
+	}
+	if values < 5 {
+		return nil, fmt.Errorf("ONNX output has %d values per box, need at least 5", values)
+	}
+	at := func(box, value int) float64 {
+		if channelsFirst {
+			return float64(output[value*boxes+box])
+		}
+		return float64(output[box*values+value])
+	}
+
+	// YOLOv5 has an objectness score after the box; recognizable when the names file matches the class count
+	classOffset, hasObjectness := 4, false
+	if !channelsFirst && len(classNames) > 0 && values == 5+len(classNames) {
+		classOffset, hasObjectness = 5, true
+	}
+
+	// RT-DETR boxes are normalized to 0-1
+	coordinateScale := 1.0
+	if !channelsFirst && !hasObjectness {
+		maxCoordinate := 0.0
+		for box := 0; box < boxes; box++ {
+			maxCoordinate = math.Max(maxCoordinate, math.Max(at(box, 0), at(box, 1)))
+		}
+		if maxCoordinate <= 1.5 {
+			coordinateScale = float64(inputSize)
+		}
+	}
+
+	var detections []Detection
+	for box := 0; box < boxes; box++ {
+		classID, score := -1, 0.0
+		for value := classOffset; value < values; value++ {
+			if s := at(box, value); s > score {
+				classID, score = value-classOffset, s
+			}
+		}
+		if hasObjectness {
+			score *= at(box, 4)
+		}
+		if classID < 0 || score < minConfidence {
+			continue
+		}
+
+		rect := transform.toFrame(at(box, 0)*coordinateScale, at(box, 1)*coordinateScale,
+			at(box, 2)*coordinateScale, at(box, 3)*coordinateScale)
+		if rect.Empty() {
+			continue
+		}
+		detections = append(detections, Detection{
+			Rect:       rect,
+			ClassID:    classID,
+			ClassName:  classNameFor(classNames, classID),
+			Confidence: score,
+		})
+	}
+	return detections, nil
+}
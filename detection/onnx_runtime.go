@@ -0,0 +1,288 @@
+//go:build onnxruntime
+
+package detection
+
+/*
+#cgo LDFLAGS: -lonnxruntime
+#include <stdint.h>
+#include <stdlib.h>
+#include <string.h>
+#include <onnxruntime_c_api.h>
+
+static const OrtApi *ortApi(void) {
+	return OrtGetApiBase()->GetApi(ORT_API_VERSION);
+}
+
+// ortError turns a status into a malloc'ed message (NULL on success) and releases it
+static char *ortError(OrtStatus *status) {
+	if (status == NULL) {
+		return NULL;
+	}
+	char *message = strdup(ortApi()->GetErrorMessage(status));
+	ortApi()->ReleaseStatus(status);
+	return message;
+}
+
+typedef struct {
+	OrtEnv *env;
+	OrtSession *session;
+	OrtMemoryInfo *memory;
+	char *inputName;
+	char *outputName;
+	int cuda;
+} ortSession;
+
+static void ortReleaseSession(ortSession *s) {
+	const OrtApi *api = ortApi();
+	if (s->memory) api->ReleaseMemoryInfo(s->memory);
+	if (s->session) api->ReleaseSession(s->session);
+	if (s->env) api->ReleaseEnv(s->env);
+	free(s->inputName);
+	free(s->outputName);
+	memset(s, 0, sizeof(*s));
+}
+
+static char *ortCreateSession(const char *modelPath, int threads, int useCUDA, ortSession *s) {
+	const OrtApi *api = ortApi();
+	OrtSessionOptions *options = NULL;
+	OrtAllocator *allocator = NULL;
+	OrtCUDAProviderOptions cuda;
+	char *name = NULL;
+	char *err = NULL;
+
+	memset(s, 0, sizeof(*s));
+	if ((err = ortError(api->CreateEnv(ORT_LOGGING_LEVEL_WARNING, "nolo", &s->env)))) goto done;
+	if ((err = ortError(api->CreateSessionOptions(&options)))) goto done;
+	if ((err = ortError(api->SetSessionGraphOptimizationLevel(options, ORT_ENABLE_ALL)))) goto done;
+	if (threads > 0 && (err = ortError(api->SetIntraOpNumThreads(options, threads)))) goto done;
+
+	// A missing CUDA provider is not fatal: the session runs on the CPU instead
+	if (useCUDA) {
+		memset(&cuda, 0, sizeof(cuda));
+		cuda.gpu_mem_limit = SIZE_MAX;
+		cuda.do_copy_in_default_stream = 1;
+		char *cudaErr = ortError(api->SessionOptionsAppendExecutionProvider_CUDA(options, &cuda));
+		s->cuda = cudaErr == NULL;
+		free(cudaErr);
+	}
+
+	if ((err = ortError(api->CreateSession(s->env, modelPath, options, &s->session)))) goto done;
+	if ((err = ortError(api->CreateCpuMemoryInfo(OrtArenaAllocator, OrtMemTypeDefault, &s->memory)))) goto done;
+	if ((err = ortError(api->GetAllocatorWithDefaultOptions(&allocator)))) goto done;
+
+	if ((err = ortError(api->SessionGetInputName(s->session, 0, allocator, &name)))) goto done;
+	s->inputName = strdup(name);
+	allocator->Free(allocator, name);
+	if ((err = ortError(api->SessionGetOutputName(s->session, 0, allocator, &name)))) goto done;
+	s->outputName = strdup(name);
+	allocator->Free(allocator, name);
+
+done:
+	if (options) api->ReleaseSessionOptions(options);
+	if (err) ortReleaseSession(s);
+	return err;
+}
+
+// ortRun runs the model on a float input tensor and copies the first output into a malloc'ed buffer
+static char *ortRun(ortSession *s, float *input, int64_t *shape, size_t rank,
+		float **output, size_t *outputLen, int64_t *outputShape, size_t *outputRank) {
+	const OrtApi *api = ortApi();
+	OrtValue *inputTensor = NULL;
+	OrtValue *outputTensor = NULL;
+	OrtTensorTypeAndShapeInfo *info = NULL;
+	const char *inputNames[1];
+	const char *outputNames[1];
+	float *data = NULL;
+	size_t count = 1;
+	char *err = NULL;
+
+	for (size_t i = 0; i < rank; i++) {
+		count *= (size_t)shape[i];
+	}
+	inputNames[0] = s->inputName;
+	outputNames[0] = s->outputName;
+
+	if ((err = ortError(api->CreateTensorWithDataAsOrtValue(s->memory, input, count * sizeof(float), shape, rank,
+			ONNX_TENSOR_ELEMENT_DATA_TYPE_FLOAT, &inputTensor)))) goto done;
+	if ((err = ortError(api->Run(s->session, NULL, inputNames, (const OrtValue *const *)&inputTensor, 1,
+			outputNames, 1, &outputTensor)))) goto done;
+	if ((err = ortError(api->GetTensorTypeAndShape(outputTensor, &info)))) goto done;
+	if ((err = ortError(api->GetDimensionsCount(info, outputRank)))) goto done;
+	if (*outputRank > 8) {
+		err = strdup("output tensor has more than 8 dimensions");
+		goto done;
+	}
+	if ((err = ortError(api->GetDimensions(info, outputShape, *outputRank)))) goto done;
+	if ((err = ortError(api->GetTensorShapeElementCount(info, outputLen)))) goto done;
+	if ((err = ortError(api->GetTensorMutableData(outputTensor, (void **)&data)))) goto done;
+
+	*output = malloc(*outputLen * sizeof(float));
+	memcpy(*output, data, *outputLen * sizeof(float));
+
+done:
+	if (info) api->ReleaseTensorTypeAndShapeInfo(info);
+	if (outputTensor) api->ReleaseValue(outputTensor);
+	if (inputTensor) api->ReleaseValue(inputTensor);
+	return err;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"image"
+	"sync"
+	"unsafe"
+
+	"gocv.io/x/gocv"
+)
+
+// ortModel is an ONNX Runtime session with one float input and one float output
+type ortModel struct {
+	session C.ortSession
+}
+
+// loadORTModel creates a session for the model, on the CUDA execution provider when asked and available
+func loadORTModel(path string, threads int, useCUDA bool) (*ortModel, error) {
+	m := &ortModel{}
+	modelPath := C.CString(path)
+	defer C.free(unsafe.Pointer(modelPath))
+	cuda := C.int(0)
+	if useCUDA {
+		cuda = 1
+	}
+	if message := C.ortCreateSession(modelPath, C.int(threads), cuda, &m.session); message != nil {
+		defer C.free(unsafe.Pointer(message))
+		return nil, fmt.Errorf("failed to load ONNX model %s: %s", path, C.GoString(message))
+	}
+	return m, nil
+}
+
+// run feeds the input tensor to the model and returns a copy of its output and the output shape
+func (m *ortModel) run(input []float32, shape []int64) ([]float32, []int64, error) {
+	if m.session.session == nil {
+		return nil, nil, fmt.Errorf("ONNX session is closed")
+	}
+	inputShape := make([]C.int64_t, len(shape))
+	for i, dim := range shape {
+		inputShape[i] = C.int64_t(dim)
+	}
+	var outputShape [8]C.int64_t
+	var outputRank, outputLen C.size_t
+	var output *C.float
+	if message := C.ortRun(&m.session, (*C.float)(unsafe.Pointer(&input[0])), &inputShape[0], C.size_t(len(inputShape)),
+		&output, &outputLen, &outputShape[0], &outputRank); message != nil {
+		defer C.free(unsafe.Pointer(message))
+		return nil, nil, fmt.Errorf("ONNX inference failed: %s", C.GoString(message))
+	}
+	defer C.free(unsafe.Pointer(output))
+
+	values := make([]float32, int(outputLen))
+	copy(values, unsafe.Slice((*float32)(unsafe.Pointer(output)), int(outputLen)))
+	dims := make([]int64, int(outputRank))
+	for i := range dims {
+		dims[i] = int64(outputShape[i])
+	}
+	return values, dims, nil
+}
+
+// usesCUDA reports whether the session runs on the CUDA execution provider
+func (m *ortModel) usesCUDA() bool {
+	return m.session.cuda != 0
+}
+
+// close releases the session
+func (m *ortModel) close() {
+	C.ortReleaseSession(&m.session)
+}
+
+// ONNXDetector runs ONNX exports of newer detectors (YOLOv5/v8/v9, RT-DETR) through ONNX Runtime
+type ONNXDetector struct {
+	mu         sync.Mutex
+	model      *ortModel
+	config     ONNXConfig
+	classNames []string
+}
+
+// NewONNXDetector loads an ONNX model into an ONNX Runtime session
+func NewONNXDetector(config ONNXConfig) (*ONNXDetector, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	classNames := config.ClassNames
+	if classNames == nil {
+		var err error
+		if classNames, err = LoadClassNames(config.NamesPath); err != nil {
+			return nil, err
+		}
+	}
+
+	model, err := loadORTModel(config.ModelPath, config.Threads, config.UseCUDA)
+	if err != nil {
+		return nil, err
+	}
+	od := &ONNXDetector{model: model, config: config, classNames: classNames}
+
+	if config.UseCUDA && !model.usesCUDA() {
+		debugMsg("ONNX", "⚠️ CUDA execution provider unavailable - ONNX Runtime runs on the CPU")
+	}
+	debugMsg("ONNX", fmt.Sprintf("🧠 ONNX model %s loaded (%dx%d input, %d classes, %s)",
+		config.ModelPath, config.InputSize, config.InputSize, len(classNames), od.provider()))
+	return od, nil
+}
+
+// UsesCUDA reports whether the session runs on the CUDA execution provider
+func (od *ONNXDetector) UsesCUDA() bool {
+	return od.model.usesCUDA()
+}
+
+// provider names the execution provider in use
+func (od *ONNXDetector) provider() string {
+	if od.UsesCUDA() {
+		return "CUDA"
+	}
+	return "CPU"
+}
+
+// Detect runs the model on a frame and returns detections in frame coordinates after per-class NMS
+func (od *ONNXDetector) Detect(frame gocv.Mat) ([]Detection, error) {
+	od.mu.Lock()
+	defer od.mu.Unlock()
+
+	letterboxed, transform := letterboxFrame(frame, od.config.InputSize)
+	defer letterboxed.Close()
+
+	// NCHW float32, RGB, scaled to 0-1
+	blob := gocv.BlobFromImage(letterboxed, 1.0/255.0, image.Pt(od.config.InputSize, od.config.InputSize),
+		gocv.NewScalar(0, 0, 0, 0), true, false)
+	defer blob.Close()
+	input, err := blob.DataPtrFloat32()
+	if err != nil {
+		return nil, fmt.Errorf("could not read input blob: %v", err)
+	}
+
+	size := int64(od.config.InputSize)
+	values, shape, err := od.model.run(input, []int64{1, 3, size, size})
+	if err != nil {
+		return nil, err
+	}
+
+	detections, err := decodeONNXOutput(values, shape, od.config.InputSize, transform, od.classNames, od.config.MinConfidence)
+	if err != nil {
+		return nil, err
+	}
+	return Suppress(detections, NMSConfig{IoUThreshold: od.config.NMSThreshold, MinConfidence: od.config.MinConfidence}), nil
+}
+
+// Name describes the detector
+func (od *ONNXDetector) Name() string {
+	return fmt.Sprintf("ONNX Runtime (%s)", od.provider())
+}
+
+// Close releases the ONNX Runtime session
+func (od *ONNXDetector) Close() error {
+	od.mu.Lock()
+	defer od.mu.Unlock()
+	od.model.close()
+	return nil
+}
@@ -0,0 +1,61 @@
+//go:build !onnxruntime
+
+package detection
+
+import (
+	"fmt"
+
+	"gocv.io/x/gocv"
+)
+
+// ONNXDetector is unavailable in builds without the onnxruntime tag
+type ONNXDetector struct{}
+
+// NewONNXDetector reports that ONNX Runtime support was not compiled in
+func NewONNXDetector(config ONNXConfig) (*ONNXDetector, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("this build has no ONNX Runtime support (install onnxruntime and rebuild with -tags onnxruntime)")
+}
+
+// Detect always fails without ONNX Runtime
+func (od *ONNXDetector) Detect(frame gocv.Mat) ([]Detection, error) {
+	return nil, fmt.Errorf("ONNX Runtime support not compiled in")
+}
+
+// UsesCUDA is always false without ONNX Runtime
+func (od *ONNXDetector) UsesCUDA() bool {
+	return false
+}
+
+// Name describes the detector
+func (od *ONNXDetector) Name() string {
+	return "ONNX Runtime (unavailable)"
+}
+
+// Close does nothing
+func (od *ONNXDetector) Close() error {
+	return nil
+}
+
+// ortModel stands in for the ONNX Runtime session in builds without the onnxruntime tag
+type ortModel struct{}
+
+// loadORTModel reports that ONNX Runtime support was not compiled in
+func loadORTModel(path string, threads int, useCUDA bool) (*ortModel, error) {
+	return nil, fmt.Errorf("this build has no ONNX Runtime support (install onnxruntime and rebuild with -tags onnxruntime)")
+}
+
+// run always fails without ONNX Runtime
+func (m *ortModel) run(input []float32, shape []int64) ([]float32, []int64, error) {
+	return nil, nil, fmt.Errorf("ONNX Runtime support not compiled in")
+}
+
+// usesCUDA is always false without ONNX Runtime
+func (m *ortModel) usesCUDA() bool {
+	return false
+}
+
+// close does nothing
+func (m *ortModel) close() {}
@@ -64,11 +64,11 @@ func NewProviderManager() *ProviderManager {
 
 // Initialize performs auto-detection and initializes the best available provider
 func (pm *ProviderManager) Initialize(weightsPath, configPath, namesPath string) error {
-	fmt.Println("[PROVIDER] Auto-detecting best inference provider...")
+	debugMsg("PROVIDER", "Auto-detecting best inference provider...")
 
 	// Try GPU first
 	if hasGPUCapability() {
-		fmt.Println("[PROVIDER] GPU capability detected, attempting GPU initialization...")
+		debugMsg("PROVIDER", "GPU capability detected, attempting GPU initialization...")
 		gpuProvider := &GPUProvider{}
 
 		startTime := time.Now()
@@ -82,18 +82,18 @@ func (pm *ProviderManager) Initialize(weightsPath, configPath, namesPath string)
 				debugMsg("PROVIDER", fmt.Sprintf("GPU provider successfully initialized (%v)", pm.providerInfo.InitTime))
 				return nil
 			} else {
-				fmt.Println("[PROVIDER] GPU test inference failed, falling back to CPU")
+				debugMsg("PROVIDER", "GPU test inference failed, falling back to CPU")
 				gpuProvider.Close()
 			}
 		} else {
 			debugMsg("PROVIDER", fmt.Sprintf("GPU initialization failed: %v, falling back to CPU", err))
 		}
 	} else {
-		fmt.Println("[PROVIDER] No GPU capability detected")
+		debugMsg("PROVIDER", "No GPU capability detected")
 	}
 
 	// Fall back to CPU
-	fmt.Println("[PROVIDER] Initializing CPU provider...")
+	debugMsg("PROVIDER", "Initializing CPU provider...")
 	cpuProvider := &CPUProvider{}
 
 	startTime := time.Now()
@@ -132,20 +132,20 @@ func (pm *ProviderManager) Close() error {
 func hasGPUCapability() bool {
 	// Check 1: NVIDIA GPU present
 	if !hasNVIDIAGPU() {
-		fmt.Println("[GPU_DETECT] No NVIDIA GPU detected")
+		debugMsg("GPU_DETECT", "No NVIDIA GPU detected")
 		return false
 	}
-	fmt.Println("[GPU_DETECT] NVIDIA GPU found")
+	debugMsg("GPU_DETECT", "NVIDIA GPU found")
 
 	// Check 2: NVIDIA drivers loaded
 	if !hasNVIDIADriver() {
-		fmt.Println("[GPU_DETECT] NVIDIA drivers not loaded")
+		debugMsg("GPU_DETECT", "NVIDIA drivers not loaded")
 		return false
 	}
-	fmt.Println("[GPU_DETECT] NVIDIA drivers loaded")
+	debugMsg("GPU_DETECT", "NVIDIA drivers loaded")
 
 	// Check 3: We'll test CUDA during actual GPU provider initialization
-	fmt.Println("[GPU_DETECT] Hardware checks passed, will test CUDA during initialization")
+	debugMsg("GPU_DETECT", "Hardware checks passed, will test CUDA during initialization")
 
 	return true
 }
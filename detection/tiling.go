@@ -0,0 +1,184 @@
+package detection
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"gocv.io/x/gocv"
+)
+
+// Tiled detection defaults
+const (
+	DefaultTileOverlap       = 0.15 // Share of a tile's width it overlaps its neighbor by (when the tiles are not square already)
+	DefaultTileMinConfidence = 0.1  // Same floor the raw YOLO overlay uses; weaker candidates are dropped when the tiles are merged
+	MaxTiles                 = 4
+	tileEdgeMargin           = 2 // Pixels from a tile edge within which a box counts as cut off
+)
+
+// TilingConfig is the part of the frame detection runs on and how it is split for the model. The ROI is
+// given in pixels of a reference frame size (the display stream) and scaled to each frame detected on, so
+// it also fits a lower-resolution detection substream.
+type TilingConfig struct {
+	ROI           image.Rectangle // Detection region in reference frame pixels (empty = whole frame)
+	ReferenceSize image.Point     // Frame size the ROI is given in
+	Tiles         int             // Overlapping crops across the ROI, each letterboxed into the model on its own (0 or 1 = one crop)
+	Overlap       float64         // Share of a tile's width shared with its neighbor (0 = DefaultTileOverlap)
+	NMS           NMSConfig       // How boxes from overlapping tiles are merged (minimum confidence 0 = DefaultTileMinConfidence)
+}
+
+// validate fills defaults and checks the configuration
+func (c *TilingConfig) validate() error {
+	if c.Tiles < 0 || c.Tiles > MaxTiles {
+		return fmt.Errorf("detection tiles must be between 1 and %d, got %d", MaxTiles, c.Tiles)
+	}
+	if c.Tiles == 0 {
+		c.Tiles = 1
+	}
+	if c.Overlap == 0 {
+		c.Overlap = DefaultTileOverlap
+	}
+	if c.Overlap < 0 || c.Overlap >= 0.5 {
+		return fmt.Errorf("tile overlap must be between 0 and 0.5, got %v", c.Overlap)
+	}
+	if c.NMS.Mode == NMSOff {
+		c.NMS.Mode = NMSSuppress // Overlapping tiles see the boats in the overlap twice
+	}
+	if c.NMS.MinConfidence <= 0 {
+		c.NMS.MinConfidence = DefaultTileMinConfidence
+	}
+	if err := c.NMS.Validate(); err != nil {
+		return err
+	}
+	if c.ReferenceSize.X <= 0 || c.ReferenceSize.Y <= 0 {
+		return fmt.Errorf("reference frame size is required")
+	}
+	if c.ROI.Empty() {
+		c.ROI = image.Rect(0, 0, c.ReferenceSize.X, c.ReferenceSize.Y)
+	}
+	if !c.ROI.In(image.Rect(0, 0, c.ReferenceSize.X, c.ReferenceSize.Y)) {
+		return fmt.Errorf("detection ROI %v is outside the %dx%d frame", c.ROI, c.ReferenceSize.X, c.ReferenceSize.Y)
+	}
+	// Letterboxing pads a crop above and below only, so crops must not be taller than wide
+	if c.ROI.Dx() < c.ROI.Dy() {
+		return fmt.Errorf("detection ROI must be at least as wide as it is tall, got %dx%d", c.ROI.Dx(), c.ROI.Dy())
+	}
+	return nil
+}
+
+// TiledDetector runs a detector on a region of the frame, optionally split into overlapping tiles, instead of
+// letterboxing the whole wide frame into the square model input: a 2688x1520 frame leaves ~44% of an 832x832
+// input as black bars, while two or three square tiles give distant boats up to twice the input pixels
+type TiledDetector struct {
+	detector Detector
+	config   TilingConfig
+}
+
+// NewTiledDetector wraps a detector; the wrapper owns it and closes it
+func NewTiledDetector(detector Detector, config TilingConfig) (*TiledDetector, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return &TiledDetector{detector: detector, config: config}, nil
+}
+
+// Tiles returns the crops a frame of the given size is split into
+func (td *TiledDetector) Tiles(width, height int) []image.Rectangle {
+	roi := scaleRect(td.config.ROI, td.config.ReferenceSize, image.Pt(width, height)).Intersect(image.Rect(0, 0, width, height))
+	if roi.Empty() {
+		return nil
+	}
+	return splitTiles(roi, td.config.Tiles, td.config.Overlap)
+}
+
+// Detect runs the detector on every tile and merges the results in frame coordinates
+func (td *TiledDetector) Detect(frame gocv.Mat) ([]Detection, error) {
+	tiles := td.Tiles(frame.Cols(), frame.Rows())
+	if len(tiles) == 1 && tiles[0] == image.Rect(0, 0, frame.Cols(), frame.Rows()) {
+		return td.detector.Detect(frame)
+	}
+
+	var merged []Detection
+	for i, tile := range tiles {
+		crop := frame.Region(tile)
+		detections, err := td.detector.Detect(crop)
+		crop.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		var leftNeighbor, rightNeighbor image.Rectangle
+		if i > 0 {
+			leftNeighbor = tiles[i-1]
+		}
+		if i < len(tiles)-1 {
+			rightNeighbor = tiles[i+1]
+		}
+		for _, detection := range detections {
+			detection.Rect = detection.Rect.Add(tile.Min).Intersect(tile)
+			if detection.Rect.Empty() || cutAtSeam(detection.Rect, tile, leftNeighbor, rightNeighbor) {
+				continue
+			}
+			merged = append(merged, detection)
+		}
+	}
+	if len(tiles) == 1 {
+		return merged, nil // Nothing overlaps, so the detector's own output is kept as is
+	}
+	return Suppress(merged, td.config.NMS), nil
+}
+
+// Name describes the detector and the frame layout
+func (td *TiledDetector) Name() string {
+	if td.config.Tiles > 1 {
+		return fmt.Sprintf("%s, %d tiles", td.detector.Name(), td.config.Tiles)
+	}
+	return fmt.Sprintf("%s, ROI %dx%d", td.detector.Name(), td.config.ROI.Dx(), td.config.ROI.Dy())
+}
+
+// Close closes the wrapped detector
+func (td *TiledDetector) Close() error {
+	return td.detector.Close()
+}
+
+// splitTiles spreads n crops of the ROI's height evenly across its width. Tiles are square where that still
+// covers the ROI with the configured overlap, and wider otherwise.
+func splitTiles(roi image.Rectangle, n int, overlap float64) []image.Rectangle {
+	if n <= 1 {
+		return []image.Rectangle{roi}
+	}
+	width := int(math.Ceil(float64(roi.Dx()) / (float64(n) - float64(n-1)*overlap)))
+	width = min(roi.Dx(), max(width, roi.Dy()))
+
+	tiles := make([]image.Rectangle, n)
+	step := float64(roi.Dx()-width) / float64(n-1)
+	for i := range tiles {
+		left := roi.Min.X + int(math.Round(step*float64(i)))
+		tiles[i] = image.Rect(left, roi.Min.Y, left+width, roi.Max.Y)
+	}
+	return tiles
+}
+
+// cutAtSeam reports whether a box is cut off by a tile edge that a neighboring tile extends past far enough
+// to see the whole box, so the neighbor's complete box is kept instead of this fragment
+func cutAtSeam(box, tile, leftNeighbor, rightNeighbor image.Rectangle) bool {
+	if !rightNeighbor.Empty() && box.Max.X >= tile.Max.X-tileEdgeMargin && box.Min.X > rightNeighbor.Min.X {
+		return true
+	}
+	if !leftNeighbor.Empty() && box.Min.X <= tile.Min.X+tileEdgeMargin && box.Max.X < leftNeighbor.Max.X {
+		return true
+	}
+	return false
+}
+
+// scaleRect maps a rectangle from one frame size to another
+func scaleRect(rect image.Rectangle, from, to image.Point) image.Rectangle {
+	if from == to {
+		return rect
+	}
+	scaleX := float64(to.X) / float64(from.X)
+	scaleY := float64(to.Y) / float64(from.Y)
+	return image.Rect(
+		int(math.Round(float64(rect.Min.X)*scaleX)), int(math.Round(float64(rect.Min.Y)*scaleY)),
+		int(math.Round(float64(rect.Max.X)*scaleX)), int(math.Round(float64(rect.Max.Y)*scaleY)))
+}
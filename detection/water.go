@@ -0,0 +1,218 @@
+package detection
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sync"
+
+	"gocv.io/x/gocv"
+)
+
+// Water segmentation defaults
+const (
+	DefaultWaterInputSize = 512
+	DefaultWaterClass     = 1 // Class index of water in multi-class outputs (0 = background in binary models)
+)
+
+// WaterSegmenterConfig configures the water segmentation model
+type WaterSegmenterConfig struct {
+	ModelPath  string
+	InputSize  int  // Square model input in pixels
+	WaterClass int  // Water's channel in [1, classes, H, W] outputs; ignored for single-channel outputs
+	UseCUDA    bool // Run on the CUDA execution provider (falls back to the CPU if it is unavailable)
+	Threads    int  // Intra-op threads on the CPU (0 = ONNX Runtime default)
+}
+
+// validate fills defaults and checks the configuration
+func (c *WaterSegmenterConfig) validate() error {
+	if c.ModelPath == "" {
+		return fmt.Errorf("water segmentation model path is required")
+	}
+	if c.InputSize == 0 {
+		c.InputSize = DefaultWaterInputSize
+	}
+	if c.InputSize < 32 || c.InputSize%32 != 0 {
+		return fmt.Errorf("water model input size must be a positive multiple of 32, got %d", c.InputSize)
+	}
+	if c.WaterClass < 0 {
+		return fmt.Errorf("water class must not be negative")
+	}
+	return nil
+}
+
+// WaterMask says which parts of a frame are water
+type WaterMask struct {
+	width, height int
+	water         []bool // width x height, row-major, in model output resolution
+	inputSize     int
+	transform     letterboxTransform
+}
+
+// Coverage is the share of the frame classified as water
+func (m *WaterMask) Coverage() float64 {
+	total, water := 0, 0
+	m.sample(m.transform.frame, func(isWater bool) {
+		total++
+		if isWater {
+			water++
+		}
+	})
+	if total == 0 {
+		return 0
+	}
+	return float64(water) / float64(total)
+}
+
+// WaterlineShare is the share of water in the bottom quarter of a frame rectangle: a boat sits in water,
+// while a car or person on the shore stands on land
+func (m *WaterMask) WaterlineShare(rect image.Rectangle) float64 {
+	rect = rect.Intersect(m.transform.frame)
+	if rect.Empty() {
+		return 0
+	}
+	rect.Min.Y = rect.Max.Y - max(1, rect.Dy()/4)
+
+	total, water := 0, 0
+	m.sample(rect, func(isWater bool) {
+		total++
+		if isWater {
+			water++
+		}
+	})
+	if total == 0 {
+		return 0
+	}
+	return float64(water) / float64(total)
+}
+
+// sample visits the mask cells covering a frame rectangle (at most 32x32 of them)
+func (m *WaterMask) sample(rect image.Rectangle, visit func(isWater bool)) {
+	// Frame → model input → mask resolution
+	cellScale := float64(m.width) / float64(m.inputSize)
+	toCell := func(x, y int) (int, int) {
+		cx := int((float64(x)*m.transform.scale + m.transform.padX) * cellScale)
+		cy := int((float64(y)*m.transform.scale + m.transform.padY) * cellScale)
+		return min(max(cx, 0), m.width-1), min(max(cy, 0), m.height-1)
+	}
+	minX, minY := toCell(rect.Min.X, rect.Min.Y)
+	maxX, maxY := toCell(rect.Max.X-1, rect.Max.Y-1)
+	stepX := max(1, (maxX-minX+1)/32)
+	stepY := max(1, (maxY-minY+1)/32)
+	for y := minY; y <= maxY; y += stepY {
+		for x := minX; x <= maxX; x += stepX {
+			visit(m.water[y*m.width+x])
+		}
+	}
+}
+
+// decodeWaterMask turns a segmentation output into a water mask. Two layouts are understood:
+//   - [1, classes, H, W]: per-class scores, water where the water class scores highest
+//   - [1, 1, H, W] or [1, H, W]: water probability (or logit when values leave 0-1), water above one half
+func decodeWaterMask(output []float32, shape []int64, waterClass, inputSize int, transform letterboxTransform) (*WaterMask, error) {
+	for len(shape) > 3 && shape[0] == 1 {
+		shape = shape[1:]
+	}
+	if len(shape) == 2 {
+		shape = append([]int64{1}, shape...)
+	}
+	if len(shape) != 3 || shape[0] <= 0 || shape[1] <= 0 || shape[2] <= 0 || int64(len(output)) != shape[0]*shape[1]*shape[2] {
+		return nil, fmt.Errorf("unsupported water segmentation output shape %v", shape)
+	}
+	classes, height, width := int(shape[0]), int(shape[1]), int(shape[2])
+	if height != width {
+		return nil, fmt.Errorf("water segmentation output %dx%d is not square like the model input", width, height)
+	}
+	if classes > 1 && waterClass >= classes {
+		return nil, fmt.Errorf("water class %d is outside the model's %d classes", waterClass, classes)
+	}
+
+	mask := &WaterMask{width: width, height: height, water: make([]bool, width*height), inputSize: inputSize, transform: transform}
+	plane := width * height
+	if classes == 1 {
+		logits := false
+		for _, v := range output {
+			if v < 0 || v > 1 {
+				logits = true
+				break
+			}
+		}
+		threshold := float32(0.5)
+		if logits {
+			threshold = 0 // sigmoid(0) = 0.5
+		}
+		for i := 0; i < plane; i++ {
+			mask.water[i] = output[i] > threshold
+		}
+		return mask, nil
+	}
+
+	for i := 0; i < plane; i++ {
+		best, bestScore := 0, float32(math.Inf(-1))
+		for c := 0; c < classes; c++ {
+			if score := output[c*plane+i]; score > bestScore {
+				best, bestScore = c, score
+			}
+		}
+		mask.water[i] = best == waterClass
+	}
+	return mask, nil
+}
+
+// WaterSegmenter classifies the water in a frame with an ONNX segmentation model (input: NCHW float32, RGB
+// scaled to 0-1, letterboxed square)
+type WaterSegmenter struct {
+	mu     sync.Mutex
+	model  *ortModel
+	config WaterSegmenterConfig
+}
+
+// NewWaterSegmenter loads the water segmentation model
+func NewWaterSegmenter(config WaterSegmenterConfig) (*WaterSegmenter, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	model, err := loadORTModel(config.ModelPath, config.Threads, config.UseCUDA)
+	if err != nil {
+		return nil, err
+	}
+	provider := "CPU"
+	if model.usesCUDA() {
+		provider = "CUDA"
+	}
+	debugMsg("WATER", fmt.Sprintf("🌊 Water segmentation model %s loaded (%dx%d input, %s)",
+		config.ModelPath, config.InputSize, config.InputSize, provider))
+	return &WaterSegmenter{model: model, config: config}, nil
+}
+
+// Segment classifies the frame's water
+func (ws *WaterSegmenter) Segment(frame gocv.Mat) (*WaterMask, error) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	letterboxed, transform := letterboxFrame(frame, ws.config.InputSize)
+	defer letterboxed.Close()
+
+	blob := gocv.BlobFromImage(letterboxed, 1.0/255.0, image.Pt(ws.config.InputSize, ws.config.InputSize),
+		gocv.NewScalar(0, 0, 0, 0), true, false)
+	defer blob.Close()
+	input, err := blob.DataPtrFloat32()
+	if err != nil {
+		return nil, fmt.Errorf("could not read input blob: %v", err)
+	}
+
+	size := int64(ws.config.InputSize)
+	values, shape, err := ws.model.run(input, []int64{1, 3, size, size})
+	if err != nil {
+		return nil, err
+	}
+	return decodeWaterMask(values, shape, ws.config.WaterClass, ws.config.InputSize, transform)
+}
+
+// Close releases the model
+func (ws *WaterSegmenter) Close() error {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.model.close()
+	return nil
+}
@@ -2,4 +2,17 @@ module rivercam
 
 go 1.21
 
-require gocv.io/x/gocv v0.35.0
+require (
+	github.com/mattn/go-sqlite3 v1.14.22
+	gocv.io/x/gocv v0.35.0
+	google.golang.org/grpc v1.65.0
+	google.golang.org/protobuf v1.34.2
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	golang.org/x/net v0.25.0 // indirect
+	golang.org/x/sys v0.20.0 // indirect
+	golang.org/x/text v0.15.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240528184218-531527333157 // indirect
+)
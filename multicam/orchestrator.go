@@ -0,0 +1,387 @@
+package multicam
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"rivercam/tracking"
+)
+
+// Orchestrator defaults
+const (
+	DefaultHandoffLookahead = 3 * time.Second
+	defaultHandoffZoom      = 30.0
+	maxWorldSpeed           = 30.0             // m/s; faster apparent motion is a projection glitch, not a boat
+	velocitySmoothing       = 0.3              // EMA weight of the newest velocity sample
+	handoffMemory           = 5 * time.Minute  // How long an object is remembered as already handed off
+	secondaryCueInterval    = 2 * time.Second  // How often a camera following a secondary target is re-aimed
+	handoffHold             = 30 * time.Second // A camera cued for a handoff is not re-used for secondary targets this long
+)
+
+// Tracker is a tracking camera's view of its locked target (satisfied by *tracking.SpatialIntegration)
+type Tracker interface {
+	GetLockedTargetDirection() (tracking.LockedTargetDirection, bool)
+}
+
+// SecondaryTracker is a tracking camera that also ranks secondary targets (satisfied by
+// *tracking.SpatialIntegration in multi-target mode). A free cue-only camera is kept on its top secondary.
+type SecondaryTracker interface {
+	GetSecondaryTargetDirections() []tracking.LockedTargetDirection
+}
+
+// CueFunc aims a camera at an absolute PTZ position and reports whether the command was accepted
+type CueFunc func(pan, tilt, zoom float64) bool
+
+// HandoffEvent is emitted when a camera is cued to pick up another camera's target
+type HandoffEvent struct {
+	Time      time.Time
+	ObjectID  string
+	ClassName string
+	From      string
+	To        string
+	Predicted WorldPoint
+	Pan       float64
+	Tilt      float64
+	Zoom      float64
+}
+
+// WorldTarget is a camera's locked target on the shared ground plane
+type WorldTarget struct {
+	Camera    string     `json:"camera"`
+	ObjectID  string     `json:"object_id"`
+	ClassName string     `json:"class_name"`
+	Position  WorldPoint `json:"position"`
+	Velocity  WorldPoint `json:"velocity"` // m/s
+	Updated   time.Time  `json:"updated"`
+}
+
+// predict extrapolates the target position
+func (t *WorldTarget) predict(after time.Duration) WorldPoint {
+	seconds := after.Seconds()
+	return WorldPoint{X: t.Position.X + t.Velocity.X*seconds, Y: t.Position.Y + t.Velocity.Y*seconds}
+}
+
+// secondaryCue is a cue-only camera's current secondary target
+type secondaryCue struct {
+	objectID string
+	cued     time.Time
+}
+
+type orchestratedCamera struct {
+	config  CameraConfig
+	tracker Tracker // nil for cue-only cameras
+	cue     CueFunc // nil for cameras that cannot be cued
+}
+
+// Orchestrator runs handoff between the registered cameras
+type Orchestrator struct {
+	registry  *Registry
+	lookahead time.Duration
+	onHandoff func(HandoffEvent)
+
+	mu        sync.Mutex
+	cameras   map[string]*orchestratedCamera
+	targets   map[string]*WorldTarget // Locked target per tracking camera
+	handedOff map[string]time.Time    // objectID -> time of its handoff
+	cuedAt    map[string]time.Time    // Cue-only camera -> time of its last handoff cue
+	secondary map[string]secondaryCue // Cue-only camera -> secondary target it follows
+
+	stopChan chan struct{}
+	stopOnce sync.Once
+}
+
+// NewOrchestrator creates an orchestrator for a registry. onHandoff (optional) is called for every cue.
+func NewOrchestrator(registry *Registry, lookahead time.Duration, onHandoff func(HandoffEvent)) *Orchestrator {
+	if lookahead <= 0 {
+		lookahead = DefaultHandoffLookahead
+	}
+	return &Orchestrator{
+		registry:  registry,
+		lookahead: lookahead,
+		onHandoff: onHandoff,
+		cameras:   make(map[string]*orchestratedCamera),
+		targets:   make(map[string]*WorldTarget),
+		handedOff: make(map[string]time.Time),
+		cuedAt:    make(map[string]time.Time),
+		secondary: make(map[string]secondaryCue),
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// Attach connects a registry camera to its tracker (nil for cue-only cameras) and the function that aims it
+func (o *Orchestrator) Attach(name string, tracker Tracker, cue CueFunc) error {
+	config, ok := o.registry.Get(name)
+	if !ok {
+		return fmt.Errorf("camera %s is not in the registry", name)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.cameras[name] = &orchestratedCamera{config: config, tracker: tracker, cue: cue}
+
+	role := "cue-only"
+	if tracker != nil {
+		role = "tracking"
+	}
+	debugMsg("MULTICAM", fmt.Sprintf("📷 Camera %s attached (%s, pan %.0f-%.0f)", name, role, config.MinPan, config.MaxPan))
+	return nil
+}
+
+// Start polls every tracking camera concurrently at interval until Stop
+func (o *Orchestrator) Start(interval time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for name, camera := range o.cameras {
+		if camera.tracker == nil {
+			continue
+		}
+		go func(name string) {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					o.observe(name)
+					o.observeSecondary(name)
+				case <-o.stopChan:
+					return
+				}
+			}
+		}(name)
+	}
+}
+
+// Stop ends polling
+func (o *Orchestrator) Stop() {
+	o.stopOnce.Do(func() { close(o.stopChan) })
+}
+
+// Targets returns every tracking camera's locked target on the shared ground plane
+func (o *Orchestrator) Targets() []WorldTarget {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	targets := make([]WorldTarget, 0, len(o.targets))
+	for _, target := range o.targets {
+		targets = append(targets, *target)
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].Camera < targets[j].Camera })
+	return targets
+}
+
+// observe updates one camera's world target and hands it off when its predicted position leaves the
+// camera's reach - either while still locked or, if the lock was just lost, from its last known motion
+func (o *Orchestrator) observe(name string) {
+	o.mu.Lock()
+	camera := o.cameras[name]
+	o.mu.Unlock()
+	if camera == nil || camera.tracker == nil {
+		return
+	}
+
+	direction, locked := camera.tracker.GetLockedTargetDirection()
+	now := time.Now()
+
+	o.mu.Lock()
+	previous := o.targets[name]
+	var target *WorldTarget
+	var predicted WorldPoint
+
+	if locked {
+		position, ok := camera.config.ToWorld(direction.Pan, direction.Tilt)
+		if !ok {
+			o.mu.Unlock()
+			return // Looking at the horizon: no usable ground position
+		}
+
+		target = &WorldTarget{Camera: name, ObjectID: direction.ObjectID, ClassName: direction.ClassName, Position: position, Updated: now}
+		if previous != nil && previous.ObjectID == direction.ObjectID {
+			if dt := now.Sub(previous.Updated).Seconds(); dt > 0 {
+				vx, vy := (position.X-previous.Position.X)/dt, (position.Y-previous.Position.Y)/dt
+				if math.Hypot(vx, vy) <= maxWorldSpeed {
+					target.Velocity = WorldPoint{
+						X: previous.Velocity.X + velocitySmoothing*(vx-previous.Velocity.X),
+						Y: previous.Velocity.Y + velocitySmoothing*(vy-previous.Velocity.Y),
+					}
+				} else {
+					target.Velocity = previous.Velocity
+				}
+			}
+		}
+		o.targets[name] = target
+		predicted = target.predict(o.lookahead)
+	} else {
+		if previous == nil {
+			o.mu.Unlock()
+			return
+		}
+		delete(o.targets, name)
+		target = previous
+		predicted = previous.predict(now.Sub(previous.Updated) + o.lookahead)
+	}
+
+	for objectID, when := range o.handedOff {
+		if now.Sub(when) > handoffMemory {
+			delete(o.handedOff, objectID)
+		}
+	}
+
+	var event *HandoffEvent
+	if _, done := o.handedOff[target.ObjectID]; !done && !camera.config.CanReach(predicted) {
+		event = o.handoff(camera, target, predicted, now)
+	}
+	o.mu.Unlock()
+
+	if event != nil && o.onHandoff != nil {
+		o.onHandoff(*event)
+	}
+}
+
+// handoff cues the best camera that can reach the predicted position: listed neighbors first, then the
+// nearest other camera. Must be called with o.mu held.
+func (o *Orchestrator) handoff(from *orchestratedCamera, target *WorldTarget, predicted WorldPoint, now time.Time) *HandoffEvent {
+	candidates := o.handoffCandidates(from.config, predicted)
+	for _, candidate := range candidates {
+		if !candidate.config.CanReach(predicted) {
+			continue
+		}
+
+		pan, tilt := candidate.config.ToPTZ(predicted)
+		zoom := candidate.config.HandoffZoom
+		if zoom <= 0 {
+			zoom = defaultHandoffZoom
+		}
+		if !candidate.cue(pan, tilt, zoom) {
+			debugMsg("MULTICAM", fmt.Sprintf("⚠️ Camera %s did not accept the handoff cue", candidate.config.Name), target.ObjectID)
+			continue
+		}
+
+		o.handedOff[target.ObjectID] = now
+		o.cuedAt[candidate.config.Name] = now
+		delete(o.secondary, candidate.config.Name)
+		debugMsg("MULTICAM", fmt.Sprintf("🤝 Handoff %s → %s: predicted (%.0f, %.0f)m in %v, cued to Pan=%.0f Tilt=%.0f Zoom=%.0f",
+			from.config.Name, candidate.config.Name, predicted.X, predicted.Y, o.lookahead, pan, tilt, zoom), target.ObjectID)
+		return &HandoffEvent{
+			Time:      now,
+			ObjectID:  target.ObjectID,
+			ClassName: target.ClassName,
+			From:      from.config.Name,
+			To:        candidate.config.Name,
+			Predicted: predicted,
+			Pan:       pan,
+			Tilt:      tilt,
+			Zoom:      zoom,
+		}
+	}
+
+	debugMsg("MULTICAM", fmt.Sprintf("📤 Target leaving %s at (%.0f, %.0f)m - no camera can reach it", from.config.Name, predicted.X, predicted.Y), target.ObjectID)
+	o.handedOff[target.ObjectID] = now // Don't re-evaluate every poll
+	return nil
+}
+
+// observeSecondary keeps a free cue-only camera on a tracking camera's top-ranked secondary target, so a
+// second target is covered while the tracking camera follows the primary
+func (o *Orchestrator) observeSecondary(name string) {
+	o.mu.Lock()
+	camera := o.cameras[name]
+	o.mu.Unlock()
+	if camera == nil {
+		return
+	}
+	tracker, ok := camera.tracker.(SecondaryTracker)
+	if !ok {
+		return
+	}
+	directions := tracker.GetSecondaryTargetDirections()
+	if len(directions) == 0 {
+		return
+	}
+
+	// Top-ranked secondary with a usable ground position
+	var direction tracking.LockedTargetDirection
+	var point WorldPoint
+	found := false
+	for _, candidate := range directions {
+		if position, ok := camera.config.ToWorld(candidate.Pan, candidate.Tilt); ok {
+			direction, point, found = candidate, position, true
+			break
+		}
+	}
+	if !found {
+		return
+	}
+
+	now := time.Now()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	// Keep following with the camera already on this target
+	for cameraName, cue := range o.secondary {
+		if cue.objectID != direction.ObjectID {
+			continue
+		}
+		if now.Sub(cue.cued) < secondaryCueInterval {
+			return
+		}
+		if following := o.cameras[cameraName]; following != nil && following.config.CanReach(point) {
+			pan, tilt := following.config.ToPTZ(point)
+			if following.cue(pan, tilt, o.secondaryZoom(following.config)) {
+				o.secondary[cameraName] = secondaryCue{objectID: direction.ObjectID, cued: now}
+			}
+			return
+		}
+		delete(o.secondary, cameraName)
+	}
+
+	for _, candidate := range o.handoffCandidates(camera.config, point) {
+		if candidate.tracker != nil || now.Sub(o.cuedAt[candidate.config.Name]) < handoffHold || !candidate.config.CanReach(point) {
+			continue
+		}
+		pan, tilt := candidate.config.ToPTZ(point)
+		zoom := o.secondaryZoom(candidate.config)
+		if !candidate.cue(pan, tilt, zoom) {
+			continue
+		}
+		o.secondary[candidate.config.Name] = secondaryCue{objectID: direction.ObjectID, cued: now}
+		debugMsg("MULTICAM", fmt.Sprintf("🎯 Secondary target %s (%s) of %s cued to camera %s: (%.0f, %.0f)m, Pan=%.0f Tilt=%.0f Zoom=%.0f",
+			direction.ObjectID, direction.ClassName, camera.config.Name, candidate.config.Name, point.X, point.Y, pan, tilt, zoom), direction.ObjectID)
+		return
+	}
+}
+
+// secondaryZoom is the zoom a camera is cued to for a secondary target
+func (o *Orchestrator) secondaryZoom(config CameraConfig) float64 {
+	if config.HandoffZoom > 0 {
+		return config.HandoffZoom
+	}
+	return defaultHandoffZoom
+}
+
+// handoffCandidates lists cameras that can be cued, neighbors in registry order followed by the others by
+// distance to the predicted point. Must be called with o.mu held.
+func (o *Orchestrator) handoffCandidates(from CameraConfig, predicted WorldPoint) []*orchestratedCamera {
+	var candidates []*orchestratedCamera
+	listed := map[string]bool{from.Name: true}
+	for _, name := range from.Neighbors {
+		if camera := o.cameras[name]; camera != nil && camera.cue != nil && !listed[name] {
+			candidates = append(candidates, camera)
+			listed[name] = true
+		}
+	}
+
+	var others []*orchestratedCamera
+	for name, camera := range o.cameras {
+		if !listed[name] && camera.cue != nil {
+			others = append(others, camera)
+		}
+	}
+	sort.Slice(others, func(i, j int) bool {
+		return predicted.Distance(WorldPoint{X: others[i].config.X, Y: others[i].config.Y}) <
+			predicted.Distance(WorldPoint{X: others[j].config.X, Y: others[j].config.Y})
+	})
+	return append(candidates, others...)
+}
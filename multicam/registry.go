@@ -0,0 +1,119 @@
+// Package multicam coordinates several PTZ cameras covering adjacent stretches of the river.
+//
+// Every camera is described in a registry file with its position on a shared local ground plane and the
+// pan range it can reach. Pan/tilt directions are projected onto the water (camera height above the water
+// plus tilt gives range, pan gives bearing), so a boat seen by one camera has a world position any other
+// camera can aim at. The Orchestrator watches the locked target of each tracking camera and, when the target
+// is about to leave that camera's reach, cues an adjacent camera to the predicted position.
+package multicam
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Global debug function for multicam package
+var debugMsgFunc func(string, string, ...string)
+
+// SetDebugFunction allows main package to provide debug function
+func SetDebugFunction(fn func(string, string, ...string)) {
+	debugMsgFunc = fn
+}
+
+// debugMsg is a wrapper that handles nil checks
+func debugMsg(component, message string, boatID ...string) {
+	if debugMsgFunc != nil {
+		debugMsgFunc(component, message, boatID...)
+	}
+}
+
+// CameraConfig describes one camera in the registry
+type CameraConfig struct {
+	Name      string   `json:"name"`
+	PTZURL    string   `json:"ptz_url"`             // isapi:// or onvif:// URL (cue-only cameras are driven through it)
+	InputURL  string   `json:"input_url,omitempty"` // RTSP stream the camera is tracked on (empty = cue-only)
+	Neighbors []string `json:"neighbors,omitempty"` // Cameras to try first on handoff, in order (empty = all others)
+
+	// Placement on the shared ground plane (meters east/north of an arbitrary site origin)
+	X       float64 `json:"x"`
+	Y       float64 `json:"y"`
+	HeightM float64 `json:"height_m"` // Lens height above the water
+
+	// Orientation: compass bearing (degrees) the camera faces at pan 0, and the tilt reading at the horizon.
+	// Pan and tilt are in camera units (tenths of a degree); tilt increases looking down.
+	PanZeroBearing float64 `json:"pan_zero_bearing"`
+	TiltHorizon    float64 `json:"tilt_horizon"`
+
+	// Reachable pan range in camera units; MinPan > MaxPan wraps through 0
+	MinPan float64 `json:"min_pan"`
+	MaxPan float64 `json:"max_pan"`
+
+	HandoffZoom float64 `json:"handoff_zoom,omitempty"` // Zoom used when cued (0 = 30)
+	MaxRangeM   float64 `json:"max_range_m,omitempty"`  // Farthest useful distance (0 = unlimited)
+}
+
+// Registry is the set of cameras at a site
+type Registry struct {
+	Cameras []CameraConfig `json:"cameras"`
+}
+
+// LoadRegistry reads and validates a camera registry file
+func LoadRegistry(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read camera registry: %v", err)
+	}
+
+	var registry Registry
+	if err := json.Unmarshal(data, &registry); err != nil {
+		return nil, fmt.Errorf("failed to parse camera registry %s: %v", path, err)
+	}
+	if err := registry.Validate(); err != nil {
+		return nil, fmt.Errorf("camera registry %s: %v", path, err)
+	}
+	return &registry, nil
+}
+
+// Validate checks that every camera has a unique name, a height and a pan range, and that neighbors exist
+func (r *Registry) Validate() error {
+	if len(r.Cameras) == 0 {
+		return fmt.Errorf("no cameras")
+	}
+
+	names := make(map[string]bool)
+	for _, camera := range r.Cameras {
+		if camera.Name == "" {
+			return fmt.Errorf("camera without a name")
+		}
+		if names[camera.Name] {
+			return fmt.Errorf("camera %s is listed twice", camera.Name)
+		}
+		names[camera.Name] = true
+
+		if camera.HeightM <= 0 {
+			return fmt.Errorf("camera %s: height_m must be positive (needed to project onto the water)", camera.Name)
+		}
+		if camera.MinPan < 0 || camera.MinPan >= 3600 || camera.MaxPan < 0 || camera.MaxPan >= 3600 || camera.MinPan == camera.MaxPan {
+			return fmt.Errorf("camera %s: min_pan/max_pan must be distinct values in 0-3599", camera.Name)
+		}
+	}
+	for _, camera := range r.Cameras {
+		for _, neighbor := range camera.Neighbors {
+			if !names[neighbor] {
+				return fmt.Errorf("camera %s: unknown neighbor %s", camera.Name, neighbor)
+			}
+		}
+	}
+	return nil
+}
+
+// Get returns a camera by name
+func (r *Registry) Get(name string) (CameraConfig, bool) {
+	for _, camera := range r.Cameras {
+		if camera.Name == name {
+			return camera, true
+		}
+	}
+	return CameraConfig{}, false
+}
@@ -0,0 +1,71 @@
+package multicam
+
+import (
+	"math"
+)
+
+// minDepressionDegrees is the shallowest look-down angle that still projects onto the water; closer to the
+// horizon a tenth of a degree of tilt error moves the projected point by hundreds of meters
+const minDepressionDegrees = 0.5
+
+// WorldPoint is a position on the shared ground (water) plane in meters east (X) and north (Y) of the site origin
+type WorldPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// Distance returns the distance between two points in meters
+func (p WorldPoint) Distance(other WorldPoint) float64 {
+	return math.Hypot(p.X-other.X, p.Y-other.Y)
+}
+
+// ToWorld projects a pan/tilt direction of this camera onto the water. ok is false when the direction is at
+// or above the horizon.
+func (c CameraConfig) ToWorld(pan, tilt float64) (point WorldPoint, ok bool) {
+	depression := (tilt - c.TiltHorizon) / 10
+	if depression < minDepressionDegrees {
+		return WorldPoint{}, false
+	}
+
+	distance := c.HeightM / math.Tan(depression*math.Pi/180)
+	bearing := (c.PanZeroBearing + pan/10) * math.Pi / 180
+	return WorldPoint{
+		X: c.X + distance*math.Sin(bearing),
+		Y: c.Y + distance*math.Cos(bearing),
+	}, true
+}
+
+// ToPTZ returns the pan/tilt at which this camera looks at a point on the water
+func (c CameraConfig) ToPTZ(point WorldPoint) (pan, tilt float64) {
+	dx, dy := point.X-c.X, point.Y-c.Y
+	distance := math.Hypot(dx, dy)
+
+	bearing := math.Atan2(dx, dy) * 180 / math.Pi
+	pan = math.Mod((bearing-c.PanZeroBearing)*10, 3600)
+	if pan < 0 {
+		pan += 3600
+	}
+
+	depression := 90.0
+	if distance > 0 {
+		depression = math.Atan(c.HeightM/distance) * 180 / math.Pi
+	}
+	return pan, c.TiltHorizon + depression*10
+}
+
+// InPanRange reports whether a pan position is inside the camera's reachable pan range
+func (c CameraConfig) InPanRange(pan float64) bool {
+	if c.MinPan <= c.MaxPan {
+		return pan >= c.MinPan && pan <= c.MaxPan
+	}
+	return pan >= c.MinPan || pan <= c.MaxPan // Range wraps through 0
+}
+
+// CanReach reports whether the camera can aim at a point: inside its pan range and, if set, its max range
+func (c CameraConfig) CanReach(point WorldPoint) bool {
+	if c.MaxRangeM > 0 && point.Distance(WorldPoint{X: c.X, Y: c.Y}) > c.MaxRangeM {
+		return false
+	}
+	pan, _ := c.ToPTZ(point)
+	return c.InPanRange(pan)
+}
@@ -0,0 +1,49 @@
+package overlay
+
+import (
+	"fmt"
+
+	"gocv.io/x/gocv"
+)
+
+// ExtractLayer separates the annotations from an annotated copy of a frame: the result is a BGRA image that
+// holds the annotated pixels wherever they differ from the clean frame and is fully transparent elsewhere, so
+// a downstream viewer can lay it over the clean video or leave it off. The caller closes the returned Mat.
+func ExtractLayer(clean, annotated gocv.Mat) (gocv.Mat, error) {
+	if clean.Empty() || annotated.Empty() || clean.Rows() != annotated.Rows() || clean.Cols() != annotated.Cols() ||
+		clean.Type() != gocv.MatTypeCV8UC3 || annotated.Type() != gocv.MatTypeCV8UC3 {
+		return gocv.NewMat(), fmt.Errorf("overlay layer needs two 8-bit BGR frames of the same size")
+	}
+
+	// A pixel belongs to the overlay if any channel changed
+	diff := gocv.NewMat()
+	defer diff.Close()
+	gocv.AbsDiff(clean, annotated, &diff)
+	diffChannels := gocv.Split(diff)
+	defer func() {
+		for _, channel := range diffChannels {
+			channel.Close()
+		}
+	}()
+	alpha := gocv.NewMat()
+	defer alpha.Close()
+	gocv.BitwiseOr(diffChannels[0], diffChannels[1], &alpha)
+	gocv.BitwiseOr(alpha, diffChannels[2], &alpha)
+	gocv.Threshold(alpha, &alpha, 0, 255, gocv.ThresholdBinary)
+
+	// Transparent pixels carry no video, which also keeps the PNG small
+	annotations := gocv.NewMatWithSize(annotated.Rows(), annotated.Cols(), gocv.MatTypeCV8UC3)
+	defer annotations.Close()
+	annotations.SetTo(gocv.NewScalar(0, 0, 0, 0))
+	annotated.CopyToWithMask(&annotations, alpha)
+
+	channels := gocv.Split(annotations)
+	defer func() {
+		for _, channel := range channels {
+			channel.Close()
+		}
+	}()
+	layer := gocv.NewMat()
+	gocv.Merge(append(channels, alpha), &layer)
+	return layer, nil
+}
@@ -0,0 +1,80 @@
+package overlay
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"rivercam/ptz"
+
+	"gocv.io/x/gocv"
+)
+
+// PTZ limit mini-map dimensions (pixels)
+const (
+	limitMapWidth  = 240
+	limitMapHeight = 80
+	limitMapMargin = 20
+	limitZoomBarH  = 10
+)
+
+// DrawPTZLimitMap renders a mini-map of the full hardware pan/tilt range in the lower-right corner with the
+// soft limits as a box, the current camera position as a dot and a zoom bar below. Lines in status are printed
+// above the map (used by the live limit editor for key hints and captured values).
+func (r *Renderer) DrawPTZLimitMap(img *gocv.Mat, limits ptz.PTZLimits, position ptz.PTZPosition, status []string) {
+	panRange := limits.HardMaxPan - limits.HardMinPan
+	tiltRange := limits.HardMaxTilt - limits.HardMinTilt
+	zoomRange := limits.HardMaxZoom - limits.HardMinZoom
+	if panRange <= 0 || tiltRange <= 0 || zoomRange <= 0 {
+		return
+	}
+
+	left := img.Cols() - limitMapWidth - limitMapMargin
+	top := img.Rows() - limitMapHeight - limitZoomBarH - 2*limitMapMargin
+	if left < 0 || top < 0 {
+		return
+	}
+
+	mapRect := image.Rect(left, top, left+limitMapWidth, top+limitMapHeight)
+	panToX := func(pan float64) int {
+		return mapRect.Min.X + int((pan-limits.HardMinPan)/panRange*float64(limitMapWidth))
+	}
+	tiltToY := func(tilt float64) int {
+		return mapRect.Min.Y + int((tilt-limits.HardMinTilt)/tiltRange*float64(limitMapHeight))
+	}
+
+	limitGreen := color.RGBA{0, 255, 0, 255}
+	limitYellow := color.RGBA{255, 255, 0, 255}
+
+	// Hardware range background
+	gocv.Rectangle(img, mapRect, color.RGBA{0, 0, 0, 200}, -1)
+	gocv.Rectangle(img, mapRect, color.RGBA{128, 128, 128, 255}, 1)
+
+	// Soft limits box
+	softRect := image.Rect(panToX(limits.SoftMinPan), tiltToY(limits.SoftMinTilt), panToX(limits.SoftMaxPan), tiltToY(limits.SoftMaxTilt))
+	gocv.Rectangle(img, softRect, limitGreen, 1)
+
+	// Current camera position
+	positionPoint := image.Pt(panToX(position.Pan), tiltToY(position.Tilt))
+	gocv.Circle(img, positionPoint, 3, limitYellow, -1)
+
+	// Zoom bar: hardware range with soft limits and current zoom marker
+	zoomTop := mapRect.Max.Y + 6
+	zoomRect := image.Rect(mapRect.Min.X, zoomTop, mapRect.Max.X, zoomTop+limitZoomBarH)
+	zoomToX := func(zoom float64) int {
+		return zoomRect.Min.X + int((zoom-limits.HardMinZoom)/zoomRange*float64(limitMapWidth))
+	}
+	gocv.Rectangle(img, zoomRect, color.RGBA{0, 0, 0, 200}, -1)
+	gocv.Rectangle(img, image.Rect(zoomToX(limits.SoftMinZoom), zoomRect.Min.Y, zoomToX(limits.SoftMaxZoom), zoomRect.Max.Y), limitGreen, 1)
+	zoomX := zoomToX(position.Zoom)
+	gocv.Line(img, image.Pt(zoomX, zoomRect.Min.Y), image.Pt(zoomX, zoomRect.Max.Y), limitYellow, 2)
+
+	// Labels
+	gocv.PutText(img, fmt.Sprintf("P:%.0f T:%.0f Z:%.0f", position.Pan, position.Tilt, position.Zoom),
+		image.Pt(mapRect.Min.X, zoomRect.Max.Y+14), gocv.FontHersheySimplex, 0.4, limitYellow, 1)
+
+	for i, line := range status {
+		y := mapRect.Min.Y - 6 - (len(status)-1-i)*14
+		gocv.PutText(img, line, image.Pt(mapRect.Min.X, y), gocv.FontHersheySimplex, 0.4, limitGreen, 1)
+	}
+}
@@ -0,0 +1,232 @@
+package overlay
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sort"
+
+	"gocv.io/x/gocv"
+)
+
+// PIP window corners
+const (
+	PIPBottomRight = "bottom-right"
+	PIPBottomLeft  = "bottom-left"
+	PIPTopRight    = "top-right"
+	PIPTopLeft     = "top-left"
+)
+
+// PIP stabilizer tuning
+const (
+	pipStabilizerPull   = 0.1  // Share of the gap to the smoothed target box closed per frame, so the content-locked crop can't drift off
+	pipFlowMaxCorners   = 60   // Corners tracked between consecutive crops
+	pipFlowMinPoints    = 6    // Fewer tracked corners than this and the frame is not aligned
+	pipFlowMaxShareStep = 0.25 // Flow larger than this share of the crop is treated as a failure (target swap, camera jump)
+)
+
+// PIPConfig is the size, placement and crop behavior of the picture-in-picture zoom window
+type PIPConfig struct {
+	Width     int // Window size in output pixels
+	Height    int
+	Position  string  // Corner of the output frame (PIPBottomRight, PIPBottomLeft, PIPTopRight or PIPTopLeft)
+	Margin    int     // Distance from the frame edges in pixels
+	Zoom      float64 // Digital zoom into the padded target box (1.0 = the whole box)
+	Smoothing float64 // Crop-window low-pass strength from 0 (follow the raw centroid) to 0.95 (very slow)
+	Stabilize bool    // Lock the crop to the image content between frames with sparse optical flow
+}
+
+// DefaultPIPConfig returns the PIP window used when nothing is configured
+func DefaultPIPConfig() PIPConfig {
+	return PIPConfig{Width: 1200, Height: 800, Position: PIPBottomRight, Margin: 20, Zoom: 1.4, Smoothing: 0.7}
+}
+
+// pipStabilizer is the crop window state carried between frames
+type pipStabilizer struct {
+	centered         bool
+	centerX, centerY float64 // Crop center in frame pixels
+	lastCameraZoom   float64 // Camera zoom of the previous frame, to rescale the crop when the camera zooms
+	reference        gocv.Mat
+	referenceRect    image.Rectangle
+	hasReference     bool
+}
+
+// ConfigurePIP sets the PIP window and how its crop follows the target
+func (r *Renderer) ConfigurePIP(config PIPConfig) error {
+	if config.Width < 100 || config.Height < 100 {
+		return fmt.Errorf("PIP window must be at least 100x100, got %dx%d", config.Width, config.Height)
+	}
+	switch config.Position {
+	case PIPBottomRight, PIPBottomLeft, PIPTopRight, PIPTopLeft:
+	default:
+		return fmt.Errorf("unknown PIP position %q (use bottom-right, bottom-left, top-right or top-left)", config.Position)
+	}
+	if config.Margin < 0 {
+		return fmt.Errorf("PIP margin must not be negative, got %d", config.Margin)
+	}
+	if config.Zoom < 1 || config.Zoom > 8 {
+		return fmt.Errorf("PIP zoom must be between 1.0 and 8.0, got %v", config.Zoom)
+	}
+	if config.Smoothing < 0 || config.Smoothing > 0.95 {
+		return fmt.Errorf("PIP smoothing must be between 0 and 0.95, got %v", config.Smoothing)
+	}
+
+	r.pip = config
+	r.resetPIPStabilizer()
+	if debugMsg != nil {
+		stabilized := "off"
+		if config.Stabilize {
+			stabilized = "optical flow"
+		}
+		debugMsg("PIP_CONFIG", fmt.Sprintf("📺 PIP %dx%d %s, %.1fx digital zoom, smoothing %.2f, stabilization %s",
+			config.Width, config.Height, config.Position, config.Zoom, config.Smoothing, stabilized))
+	}
+	return nil
+}
+
+// pipWindow places the PIP window in the output frame, shrinking it to fit small frames
+func (r *Renderer) pipWindow(frameWidth, frameHeight int) image.Rectangle {
+	const titleSpace = 30 // The title is drawn above the window
+	width := min(r.pip.Width, frameWidth-2*r.pip.Margin)
+	height := min(r.pip.Height, frameHeight-2*r.pip.Margin-titleSpace)
+
+	x := frameWidth - width - r.pip.Margin
+	if r.pip.Position == PIPBottomLeft || r.pip.Position == PIPTopLeft {
+		x = r.pip.Margin
+	}
+	y := frameHeight - height - r.pip.Margin
+	if r.pip.Position == PIPTopRight || r.pip.Position == PIPTopLeft {
+		y = r.pip.Margin + titleSpace
+	}
+	return image.Rect(x, y, x+width, y+height)
+}
+
+// rescalePIPForZoom keeps the smoothed crop on the target while the camera zooms: an optical zoom magnifies
+// the picture about its center, so the filtered box is scaled by the same ratio instead of lagging behind
+func (r *Renderer) rescalePIPForZoom(cameraZoom float64, frameWidth, frameHeight int) {
+	last := r.pipStab.lastCameraZoom
+	r.pipStab.lastCameraZoom = cameraZoom
+	if last <= 0 || cameraZoom <= 0 || cameraZoom == last || r.lastKnownCoords == nil {
+		return
+	}
+
+	ratio := cameraZoom / last
+	midX, midY := float64(frameWidth)/2, float64(frameHeight)/2
+	coords := r.lastKnownCoords
+	coords.SmoothedX = midX + (coords.SmoothedX-midX)*ratio
+	coords.SmoothedY = midY + (coords.SmoothedY-midY)*ratio
+	coords.SmoothedW *= ratio
+	coords.SmoothedH *= ratio
+	if r.pipStab.centered {
+		r.pipStab.centerX = midX + (r.pipStab.centerX-midX)*ratio
+		r.pipStab.centerY = midY + (r.pipStab.centerY-midY)*ratio
+	}
+	r.dropPIPReference() // The previous crop is at another scale
+}
+
+// pipCropCenter returns where the crop is centered this frame. Without stabilization that is the smoothed
+// target box; with it the crop moves with the image content measured by optical flow and is pulled gently
+// toward the smoothed box, which removes the centroid jitter the detector adds.
+func (r *Renderer) pipCropCenter(frame gocv.Mat, targetX, targetY, boxWidth, boxHeight float64) (float64, float64) {
+	if !r.pip.Stabilize {
+		return targetX, targetY
+	}
+	if !r.pipStab.centered {
+		r.pipStab.centerX, r.pipStab.centerY = targetX, targetY
+		r.pipStab.centered = true
+		return targetX, targetY
+	}
+
+	if dx, dy, ok := r.measurePIPFlow(frame); ok {
+		r.pipStab.centerX += dx
+		r.pipStab.centerY += dy
+	}
+	r.pipStab.centerX += pipStabilizerPull * (targetX - r.pipStab.centerX)
+	r.pipStab.centerY += pipStabilizerPull * (targetY - r.pipStab.centerY)
+
+	// Far off the box means the flow locked onto something else: start over from the box
+	if math.Abs(r.pipStab.centerX-targetX) > boxWidth/2 || math.Abs(r.pipStab.centerY-targetY) > boxHeight/2 {
+		r.pipStab.centerX, r.pipStab.centerY = targetX, targetY
+	}
+	return r.pipStab.centerX, r.pipStab.centerY
+}
+
+// measurePIPFlow returns the median motion of the corners of the previous crop into this frame
+func (r *Renderer) measurePIPFlow(frame gocv.Mat) (float64, float64, bool) {
+	if !r.pipStab.hasReference || !r.pipStab.referenceRect.In(image.Rect(0, 0, frame.Cols(), frame.Rows())) {
+		return 0, 0, false
+	}
+
+	region := frame.Region(r.pipStab.referenceRect)
+	defer region.Close()
+	current := gocv.NewMat()
+	defer current.Close()
+	gocv.CvtColor(region, &current, gocv.ColorBGRToGray)
+
+	corners := gocv.NewMat()
+	defer corners.Close()
+	gocv.GoodFeaturesToTrack(r.pipStab.reference, &corners, pipFlowMaxCorners, 0.01, 5)
+	if corners.Rows() < pipFlowMinPoints {
+		return 0, 0, false
+	}
+
+	moved := gocv.NewMat()
+	defer moved.Close()
+	status := gocv.NewMat()
+	defer status.Close()
+	flowErr := gocv.NewMat()
+	defer flowErr.Close()
+	gocv.CalcOpticalFlowPyrLK(r.pipStab.reference, current, corners, moved, &status, &flowErr)
+
+	var dxs, dys []float64
+	for i := 0; i < corners.Rows() && i < moved.Rows() && i < status.Rows(); i++ {
+		if status.GetUCharAt(i, 0) != 1 {
+			continue
+		}
+		from, to := corners.GetVecfAt(i, 0), moved.GetVecfAt(i, 0)
+		dxs = append(dxs, float64(to[0]-from[0]))
+		dys = append(dys, float64(to[1]-from[1]))
+	}
+	if len(dxs) < pipFlowMinPoints {
+		return 0, 0, false
+	}
+	sort.Float64s(dxs)
+	sort.Float64s(dys)
+	dx, dy := dxs[len(dxs)/2], dys[len(dys)/2]
+	if math.Abs(dx) > pipFlowMaxShareStep*float64(r.pipStab.referenceRect.Dx()) ||
+		math.Abs(dy) > pipFlowMaxShareStep*float64(r.pipStab.referenceRect.Dy()) {
+		return 0, 0, false
+	}
+	return dx, dy, true
+}
+
+// keepPIPReference remembers this frame's crop for the next flow measurement
+func (r *Renderer) keepPIPReference(frame gocv.Mat, crop image.Rectangle) {
+	if !r.pip.Stabilize {
+		return
+	}
+	r.dropPIPReference()
+	if crop.Dx() < 20 || crop.Dy() < 20 {
+		return
+	}
+	region := frame.Region(crop)
+	defer region.Close()
+	r.pipStab.reference = gocv.NewMat()
+	gocv.CvtColor(region, &r.pipStab.reference, gocv.ColorBGRToGray)
+	r.pipStab.referenceRect = crop
+	r.pipStab.hasReference = true
+}
+
+// dropPIPReference releases the previous crop
+func (r *Renderer) dropPIPReference() {
+	if r.pipStab.hasReference {
+		r.pipStab.reference.Close()
+		r.pipStab.hasReference = false
+	}
+}
+
+// resetPIPStabilizer forgets the crop state when the PIP closes
+func (r *Renderer) resetPIPStabilizer() {
+	r.dropPIPReference()
+	r.pipStab.centered = false
+}
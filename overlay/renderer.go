@@ -31,13 +31,13 @@ var globalDebugLogger interface {
 // SetDebugFunction allows main package to provide the debug logger
 func SetDebugFunction(fn func(component, message string, boatID ...string)) {
 	debugMsgFunc = fn
-	fmt.Printf("[OVERLAY_INIT] ✅ Debug function connected from main.go\n")
+	debugMsg("OVERLAY_INIT", "✅ Debug function connected from main.go")
 }
 
 // SetDebugVerboseFunction allows main package to provide the verbose debug logger
 func SetDebugVerboseFunction(fn func(component, message string, boatID ...string)) {
 	debugMsgVerboseFunc = fn
-	fmt.Printf("[OVERLAY_INIT] ✅ Verbose debug function connected from main.go\n")
+	debugMsg("OVERLAY_INIT", "✅ Verbose debug function connected from main.go")
 }
 
 // SetDebugLogger allows main package to provide the debug logger for tracking history
@@ -95,6 +95,9 @@ type Renderer struct {
 	// PIP coordinate buffering
 	lastKnownCoords *PIPCoordinates
 	lastUpdateTime  time.Time
+	// PIP window and crop stabilization
+	pip     PIPConfig
+	pipStab pipStabilizer
 	// Decision terminal state
 	lastDecisionUpdate time.Time
 	decisionHistory    []DecisionLogEntry
@@ -437,6 +440,7 @@ func NewRenderer() *Renderer {
 		boatSpeedData:          make(map[string]*SimpleSpeedTracker), // Initialize speed tracking map
 		objectMeasurements:     make(map[string]*ObjectMeasurements), // Initialize comprehensive measurement tracking
 		lastCleanupTime:        time.Now(),                           // Initialize cleanup timer
+		pip:                    DefaultPIPConfig(),
 	}
 }
 
@@ -918,6 +922,11 @@ func (r *Renderer) DrawPIPZoom(img *gocv.Mat, originalFrame gocv.Mat, trackedObj
 	// Get the LOCKED target directly from spatial integration (no more guessing!)
 	// NO FALLBACK: PIP is SUPER LOCK ONLY via spatial integration
 
+	// Keep the smoothed crop on the target while the camera zooms
+	if spatialIntegration != nil && spatialIntegration.GetPTZController() != nil {
+		r.rescalePIPForZoom(spatialIntegration.GetPTZController().GetCurrentPosition().Zoom, originalFrame.Cols(), originalFrame.Rows())
+	}
+
 	// Update time for PIP linger logic
 	now = time.Now()
 
@@ -947,7 +956,7 @@ func (r *Renderer) DrawPIPZoom(img *gocv.Mat, originalFrame gocv.Mat, trackedObj
 		}
 
 		// Update coordinates with fresh data using exponential smoothing
-		alpha := 0.3 // Smoothing factor: 0.3 = balanced responsiveness vs stability
+		alpha := 1 - r.pip.Smoothing // Crop-window low-pass (-pip-smoothing 0.7 = balanced responsiveness vs stability)
 
 		if r.lastKnownCoords != nil {
 			// Existing coordinates - apply exponential smoothing
@@ -990,6 +999,7 @@ func (r *Renderer) DrawPIPZoom(img *gocv.Mat, originalFrame gocv.Mat, trackedObj
 		if cameraMoving && !inMinDisplayPeriod {
 			r.pipVisible = false
 			r.lastKnownCoords = nil // MEMORY LEAK FIX: Clear coordinates when PIP disabled
+			r.resetPIPStabilizer()
 			if shouldDebugPIP {
 				if debugMsg != nil {
 					debugMsg("PIP_STATE", "📺🚫 PIP disabled - camera moving, past minimum display time")
@@ -999,6 +1009,7 @@ func (r *Renderer) DrawPIPZoom(img *gocv.Mat, originalFrame gocv.Mat, trackedObj
 			// Past both minimum display and linger time - disable PIP
 			r.pipVisible = false
 			r.lastKnownCoords = nil // MEMORY LEAK FIX: Clear coordinates when PIP disabled
+			r.resetPIPStabilizer()
 			if shouldDebugPIP {
 				if debugMsg != nil {
 					debugMsg("PIP_STATE", "📺⏰ PIP disabled - past minimum display (5s) and linger time (5s)")
@@ -1063,6 +1074,7 @@ func (r *Renderer) DrawPIPZoom(img *gocv.Mat, originalFrame gocv.Mat, trackedObj
 		}
 		r.pipVisible = false
 		r.lastKnownCoords = nil // MEMORY LEAK FIX: Clear coordinates when PIP disabled
+		r.resetPIPStabilizer()
 		return
 	}
 
@@ -1092,19 +1104,10 @@ func (r *Renderer) DrawPIPZoom(img *gocv.Mat, originalFrame gocv.Mat, trackedObj
 		fadeAlpha = 1
 	}
 
-	// Calculate PIP dimensions and position (larger size)
-	outputFrameWidth := img.Cols()
-	outputFrameHeight := img.Rows()
-	pipWidth := 1200 // PIP window width (4x original: 300 -> 1200)
-	pipHeight := 800 // PIP window height (4x original: 200 -> 800)
-	pipMargin := 20  // Distance from edges
-
-	// Position in lower right corner
-	pipX := outputFrameWidth - pipWidth - pipMargin
-	pipY := outputFrameHeight - pipHeight - pipMargin
-
-	// Create PIP window background with fade
-	pipRect := image.Rect(pipX, pipY, pipX+pipWidth, pipY+pipHeight)
+	// PIP dimensions and position (-pip-size, -pip-position)
+	pipRect := r.pipWindow(img.Cols(), img.Rows())
+	pipX, pipY := pipRect.Min.X, pipRect.Min.Y
+	pipWidth, pipHeight := pipRect.Dx(), pipRect.Dy()
 
 	// Apply staleness fade based on data freshness
 	var staleFadeAlpha float64 = 1.0
@@ -1154,9 +1157,10 @@ func (r *Renderer) DrawPIPZoom(img *gocv.Mat, originalFrame gocv.Mat, trackedObj
 
 	// If we have coordinates to use, show the zoom content
 	if coordsToUse != nil {
-		// Extract smoothed coordinates for use throughout PIP rendering
-		smoothedCenterX := int(coordsToUse.SmoothedX)
-		smoothedCenterY := int(coordsToUse.SmoothedY)
+		// Extract smoothed coordinates for use throughout PIP rendering (content-aligned when stabilized)
+		cropCenterX, cropCenterY := r.pipCropCenter(originalFrame, coordsToUse.SmoothedX, coordsToUse.SmoothedY, coordsToUse.SmoothedW, coordsToUse.SmoothedH)
+		smoothedCenterX := int(cropCenterX)
+		smoothedCenterY := int(cropCenterY)
 		smoothedWidth := int(coordsToUse.SmoothedW)
 		smoothedHeight := int(coordsToUse.SmoothedH)
 
@@ -1181,8 +1185,8 @@ func (r *Renderer) DrawPIPZoom(img *gocv.Mat, originalFrame gocv.Mat, trackedObj
 			if !roi.Empty() {
 				defer roi.Close()
 
-				// Apply 1.4x digital zoom by cropping the center of the ROI (reduced from 2.0x)
-				zoomFactor := 1.4
+				// Apply the digital zoom (-pip-zoom) by cropping the center of the ROI
+				zoomFactor := r.pip.Zoom
 				roiWidth := roi.Cols()
 				roiHeight := roi.Rows()
 
@@ -1213,9 +1217,11 @@ func (r *Renderer) DrawPIPZoom(img *gocv.Mat, originalFrame gocv.Mat, trackedObj
 				if cropWidth > 10 && cropHeight > 10 {
 					cropRect := image.Rect(cropX, cropY, cropX+cropWidth, cropY+cropHeight)
 					zoomedROI = roi.Region(cropRect)
+					r.keepPIPReference(originalFrame, cropRect.Add(objectRect.Min))
 				} else {
 					// Fallback to original ROI if crop is too small
 					zoomedROI = roi.Clone()
+					r.keepPIPReference(originalFrame, objectRect)
 				}
 				defer zoomedROI.Close()
 
@@ -1284,7 +1290,7 @@ func (r *Renderer) DrawPIPZoom(img *gocv.Mat, originalFrame gocv.Mat, trackedObj
 		)
 		// Calculate total zoom: (PIP size / original object size) * digital zoom factor
 		baseZoom := float64(pipWidth) / float64(objectRect.Dx())
-		digitalZoom := 1.4 // Our digital zoom factor (reduced from 2.0)
+		digitalZoom := r.pip.Zoom // Our digital zoom factor (-pip-zoom)
 		totalZoom := baseZoom * digitalZoom
 
 		zoomText := fmt.Sprintf("%.1fX DIGITAL ZOOM", totalZoom)
@@ -2015,7 +2021,7 @@ func (r *Renderer) loadCalibrationData() error {
 	r.calibrationData = &calibData
 	r.calibrationLoaded = true
 
-	fmt.Printf("✅ Loaded pixel-to-inches calibration data with %d zoom levels\n", len(calibData.CalibrationData))
+	debugMsg("OVERLAY_CALIBRATION", fmt.Sprintf("✅ Loaded pixel-to-inches calibration data with %d zoom levels", len(calibData.CalibrationData)))
 	return nil
 }
 
@@ -2024,7 +2030,7 @@ func (r *Renderer) getPixelsPerInchForZoom(currentZoom float64) float64 {
 	// Try to load calibration data if not already loaded
 	if err := r.loadCalibrationData(); err != nil {
 		// Fallback to old hardcoded value if calibration can't be loaded
-		fmt.Printf("⚠️  Using fallback calibration (Zoom 10): %v\n", err)
+		debugMsg("OVERLAY_CALIBRATION", fmt.Sprintf("⚠️  Using fallback calibration (Zoom 10): %v", err))
 		return 0.954 // Our measured Zoom 10 value from calibration data
 	}
 
@@ -0,0 +1,46 @@
+package overlay
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"rivercam/tracking"
+
+	"gocv.io/x/gocv"
+)
+
+// Secondary target colors
+var (
+	secondaryTargetColor      = color.RGBA{0, 200, 255, 255}   // Light blue - reachable secondary
+	secondaryUnreachableColor = color.RGBA{120, 120, 120, 255} // Grey - outside the camera's pan range
+)
+
+// DrawSecondaryTargets outlines the secondary targets of multi-target mode with their rank, so an operator
+// can see what a second camera could be cued to. The primary (rank 1) is left to the regular lock-on overlay.
+func (r *Renderer) DrawSecondaryTargets(img *gocv.Mat, targets []tracking.RankedTarget) {
+	for _, target := range targets {
+		if target.Rank == 1 || target.Width <= 0 || target.Height <= 0 {
+			continue
+		}
+
+		boxColor := secondaryTargetColor
+		if !target.Reachable {
+			boxColor = secondaryUnreachableColor
+		}
+		rect := image.Rect(target.CenterX-target.Width/2, target.CenterY-target.Height/2,
+			target.CenterX+target.Width/2, target.CenterY+target.Height/2)
+		r.drawCornerBrackets(*img, rect, boxColor, 2, max(6, min(rect.Dx(), rect.Dy())/4), 1.0)
+
+		label := fmt.Sprintf("#%d %s %s", target.Rank, target.ClassName, target.ObjectID)
+		if !target.Reachable {
+			label += " (out of range)"
+		}
+		labelY := rect.Min.Y - 6
+		if labelY < 14 {
+			labelY = rect.Max.Y + 16
+		}
+		gocv.PutText(img, label, image.Pt(rect.Min.X, labelY), gocv.FontHersheySimplex, 0.45, color.RGBA{0, 0, 0, 255}, 3)
+		gocv.PutText(img, label, image.Pt(rect.Min.X, labelY), gocv.FontHersheySimplex, 0.45, boxColor, 1)
+	}
+}
@@ -0,0 +1,129 @@
+package overlay
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"time"
+
+	"rivercam/tracking"
+
+	"gocv.io/x/gocv"
+)
+
+// Timeline strip dimensions (pixels)
+const (
+	timelineStripWidth  = 300
+	timelineStripHeight = 34
+	timelineStripMargin = 12
+)
+
+// Timeline event colors
+var (
+	timelineDetectionColor = color.RGBA{0, 200, 0, 255}     // Green - detected this frame
+	timelineLostColor      = color.RGBA{200, 0, 0, 255}     // Red - not detected
+	timelineLockColor      = color.RGBA{255, 255, 0, 255}   // Yellow - LOCK acquired
+	timelineSuperLockColor = color.RGBA{255, 0, 255, 255}   // Magenta - SUPER LOCK reached
+	timelineUnlockColor    = color.RGBA{160, 160, 160, 255} // Grey - lock released
+	timelineRecoveryColor  = color.RGBA{255, 140, 0, 255}   // Orange - recovery phase change
+)
+
+// DrawObjectTimeline renders a compact strip of the last tracking.TimelineWindow of events next to a target box.
+// Detection/lost state fills the lower band; lock transitions and recovery phases are drawn as full-height markers.
+func (r *Renderer) DrawObjectTimeline(img *gocv.Mat, target *tracking.TrackedObject, events []tracking.TimelineEvent) {
+	if target == nil || len(events) == 0 {
+		return
+	}
+
+	// Place strip to the right of the target box, or to the left if it would run off the frame
+	boxRight := target.CenterX + target.Width/2
+	boxLeft := target.CenterX - target.Width/2
+	top := target.CenterY - target.Height/2
+
+	left := boxRight + timelineStripMargin
+	if left+timelineStripWidth > img.Cols() {
+		left = boxLeft - timelineStripMargin - timelineStripWidth
+	}
+	if left < 0 {
+		left = 0
+	}
+	if top < 20 {
+		top = 20
+	}
+	if top+timelineStripHeight > img.Rows() {
+		top = img.Rows() - timelineStripHeight
+	}
+
+	strip := image.Rect(left, top, left+timelineStripWidth, top+timelineStripHeight)
+	gocv.Rectangle(img, strip, color.RGBA{0, 0, 0, 200}, -1)
+	gocv.Rectangle(img, strip, color.RGBA{0, 255, 0, 255}, 1)
+
+	now := time.Now()
+	windowStart := now.Add(-tracking.TimelineWindow)
+	xForTime := func(t time.Time) int {
+		offset := t.Sub(windowStart).Seconds() / tracking.TimelineWindow.Seconds()
+		if offset < 0 {
+			offset = 0
+		}
+		if offset > 1 {
+			offset = 1
+		}
+		return strip.Min.X + 1 + int(offset*float64(timelineStripWidth-2))
+	}
+
+	// Lower band: detection vs lost state, one column per pixel
+	bandTop := strip.Min.Y + timelineStripHeight/2
+	bandBottom := strip.Max.Y - 2
+	for i, event := range events {
+		var bandColor color.RGBA
+		switch event.Type {
+		case tracking.TimelineDetection:
+			bandColor = timelineDetectionColor
+		case tracking.TimelineLost:
+			bandColor = timelineLostColor
+		default:
+			continue
+		}
+
+		// Lost state persists until the next detection event, so fill forward to it
+		endX := xForTime(now)
+		if event.Type == tracking.TimelineLost {
+			for _, next := range events[i+1:] {
+				if next.Type == tracking.TimelineDetection {
+					endX = xForTime(next.Time)
+					break
+				}
+			}
+		} else {
+			endX = xForTime(event.Time) + 1
+		}
+
+		gocv.Rectangle(img, image.Rect(xForTime(event.Time), bandTop, endX, bandBottom), bandColor, -1)
+	}
+
+	// Full-height markers for state transitions
+	for _, event := range events {
+		var markerColor color.RGBA
+		label := ""
+		switch event.Type {
+		case tracking.TimelineLock:
+			markerColor, label = timelineLockColor, "L"
+		case tracking.TimelineSuperLock:
+			markerColor, label = timelineSuperLockColor, "S"
+		case tracking.TimelineUnlock:
+			markerColor, label = timelineUnlockColor, "U"
+		case tracking.TimelineRecovery:
+			markerColor, label = timelineRecoveryColor, "R"
+		default:
+			continue
+		}
+
+		x := xForTime(event.Time)
+		gocv.Line(img, image.Pt(x, strip.Min.Y+2), image.Pt(x, bandBottom), markerColor, 2)
+		gocv.PutText(img, label, image.Pt(x+2, strip.Min.Y+12), gocv.FontHersheySimplex, 0.35, markerColor, 1)
+	}
+
+	// Caption with window length and object ID
+	caption := fmt.Sprintf("%s  -%.0fs", target.ObjectID, tracking.TimelineWindow.Seconds())
+	gocv.PutText(img, caption, image.Pt(strip.Min.X, strip.Min.Y-4), gocv.FontHersheySimplex, 0.35, color.RGBA{0, 255, 0, 255}, 1)
+}
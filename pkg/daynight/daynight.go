@@ -0,0 +1,262 @@
+// Package daynight decides when NOLO should run in night mode (IR/thermal input, night-tuned model, relaxed
+// P1 confidence).
+//
+// Three sources can drive the decision:
+//
+//   - luminance: the mean luminance of the day stream. Two thresholds give hysteresis, so a scene hovering
+//     around one value at dusk does not flap between modes.
+//   - schedule: a fixed local time window such as 19:30-06:30.
+//   - sun: the sun's elevation at the site, night once it drops below a configured angle (civil dusk by
+//     default). Needs no camera and follows the seasons.
+//
+// Whatever the source, a change has to persist for the hold time before the mode switches.
+package daynight
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Decision sources (-night-mode)
+const (
+	SourceLuminance = "luminance"
+	SourceSchedule  = "schedule"
+	SourceSun       = "sun"
+)
+
+// Defaults
+const (
+	DefaultNightLuma = 40.0 // Mean luminance (0-255) below which the scene counts as dark
+	DefaultDayLuma   = 70.0 // Mean luminance above which it counts as light again
+	DefaultSunAngle  = -6.0 // Civil dusk/dawn
+	DefaultHold      = 2 * time.Minute
+)
+
+// ParseSource validates a -night-mode value ("" disables night mode)
+func ParseSource(source string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(source)) {
+	case "", "off":
+		return "", nil
+	case SourceLuminance:
+		return SourceLuminance, nil
+	case SourceSchedule:
+		return SourceSchedule, nil
+	case SourceSun:
+		return SourceSun, nil
+	default:
+		return "", fmt.Errorf("unknown night mode %q (valid modes are: %s, %s, %s)", source, SourceLuminance, SourceSchedule, SourceSun)
+	}
+}
+
+// Schedule is a daily night window in local time. Start after End wraps past midnight.
+type Schedule struct {
+	Start time.Duration // Offset from local midnight
+	End   time.Duration
+}
+
+// ParseSchedule parses a "HH:MM-HH:MM" night window
+func ParseSchedule(value string) (Schedule, error) {
+	startText, endText, ok := strings.Cut(strings.TrimSpace(value), "-")
+	if !ok {
+		return Schedule{}, fmt.Errorf("invalid night schedule %q (expected HH:MM-HH:MM, e.g. 19:30-06:30)", value)
+	}
+	start, err := parseClock(startText)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("invalid night schedule %q: %v", value, err)
+	}
+	end, err := parseClock(endText)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("invalid night schedule %q: %v", value, err)
+	}
+	if start == end {
+		return Schedule{}, fmt.Errorf("invalid night schedule %q: start and end are the same", value)
+	}
+	return Schedule{Start: start, End: end}, nil
+}
+
+func parseClock(value string) (time.Duration, error) {
+	clock, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a HH:MM time", value)
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
+
+// Contains reports whether t falls inside the night window
+func (s Schedule) Contains(t time.Time) bool {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := t.Sub(midnight)
+	if s.Start < s.End {
+		return offset >= s.Start && offset < s.End
+	}
+	return offset >= s.Start || offset < s.End
+}
+
+func (s Schedule) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", int(s.Start.Hours()), int(s.Start.Minutes())%60, int(s.End.Hours()), int(s.End.Minutes())%60)
+}
+
+// SunElevation returns the sun's elevation above the horizon in degrees at the given position (NOAA general
+// solar position approximation, accurate to a fraction of a degree - plenty for picking dusk)
+func SunElevation(t time.Time, latitude, longitude float64) float64 {
+	elevation, _ := SunPosition(t, latitude, longitude)
+	return elevation
+}
+
+// SunPosition returns the sun's elevation above the horizon and its azimuth (compass bearing, clockwise from
+// north) in degrees at the given position
+func SunPosition(t time.Time, latitude, longitude float64) (elevation, azimuth float64) {
+	t = t.UTC()
+	hours := float64(t.Hour()) + float64(t.Minute())/60 + float64(t.Second())/3600
+	gamma := 2 * math.Pi / 365 * (float64(t.YearDay()-1) + (hours-12)/24)
+
+	// Equation of time (minutes) and solar declination (radians)
+	equationOfTime := 229.18 * (0.000075 + 0.001868*math.Cos(gamma) - 0.032077*math.Sin(gamma) -
+		0.014615*math.Cos(2*gamma) - 0.040849*math.Sin(2*gamma))
+	declination := 0.006918 - 0.399912*math.Cos(gamma) + 0.070257*math.Sin(gamma) - 0.006758*math.Cos(2*gamma) +
+		0.000907*math.Sin(2*gamma) - 0.002697*math.Cos(3*gamma) + 0.00148*math.Sin(3*gamma)
+
+	trueSolarMinutes := hours*60 + equationOfTime + 4*longitude
+	hourAngle := (trueSolarMinutes/4 - 180) * math.Pi / 180
+	lat := latitude * math.Pi / 180
+
+	cosZenith := math.Sin(lat)*math.Sin(declination) + math.Cos(lat)*math.Cos(declination)*math.Cos(hourAngle)
+	cosZenith = math.Max(-1, math.Min(1, cosZenith))
+	zenith := math.Acos(cosZenith)
+	elevation = 90 - zenith*180/math.Pi
+
+	// Azimuth from north; the sun is east of the meridian before solar noon (negative hour angle)
+	denominator := math.Cos(lat) * math.Sin(zenith)
+	if math.Abs(denominator) < 1e-9 { // Sun straight overhead or at a pole
+		return elevation, 180
+	}
+	cosAzimuth := math.Max(-1, math.Min(1, (math.Sin(declination)-math.Sin(lat)*cosZenith)/denominator))
+	azimuth = math.Acos(cosAzimuth) * 180 / math.Pi
+	if math.Sin(hourAngle) > 0 {
+		azimuth = 360 - azimuth
+	}
+	return elevation, azimuth
+}
+
+// Config configures a Detector
+type Config struct {
+	Source    string
+	NightLuma float64 // Luminance below which it gets dark (luminance source)
+	DayLuma   float64 // Luminance above which it gets light again; must exceed NightLuma
+	Schedule  Schedule
+	Latitude  float64 // Site position (sun source)
+	Longitude float64
+	SunAngle  float64       // Sun elevation (degrees) below which it is night (sun source)
+	Hold      time.Duration // How long a change must persist before the mode switches
+}
+
+// Detector tracks day/night with hysteresis and a hold time. It is not safe for concurrent use.
+type Detector struct {
+	config       Config
+	night        bool
+	started      bool
+	pendingSince time.Time // When the opposite condition was first seen (zero when none is pending)
+}
+
+// New validates the configuration and creates a detector, starting in day mode
+func New(config Config) (*Detector, error) {
+	switch config.Source {
+	case SourceLuminance:
+		if config.NightLuma <= 0 {
+			config.NightLuma = DefaultNightLuma
+		}
+		if config.DayLuma <= 0 {
+			config.DayLuma = DefaultDayLuma
+		}
+		if config.DayLuma <= config.NightLuma || config.DayLuma > 255 {
+			return nil, fmt.Errorf("day luminance %.0f must be above night luminance %.0f and at most 255", config.DayLuma, config.NightLuma)
+		}
+	case SourceSchedule:
+		if config.Schedule.Start == config.Schedule.End {
+			return nil, fmt.Errorf("the schedule night mode needs a night window")
+		}
+	case SourceSun:
+		if config.Latitude < -90 || config.Latitude > 90 || config.Longitude < -180 || config.Longitude > 180 {
+			return nil, fmt.Errorf("site position %.4f,%.4f is out of range", config.Latitude, config.Longitude)
+		}
+		if config.Latitude == 0 && config.Longitude == 0 {
+			return nil, fmt.Errorf("the sun night mode needs the site position")
+		}
+		if config.SunAngle == 0 {
+			config.SunAngle = DefaultSunAngle
+		}
+	default:
+		return nil, fmt.Errorf("unknown night mode source %q", config.Source)
+	}
+	if config.Hold < 0 {
+		config.Hold = 0
+	}
+	return &Detector{config: config}, nil
+}
+
+// Config returns the effective configuration (with defaults applied)
+func (d *Detector) Config() Config {
+	return d.config
+}
+
+// Night reports the current mode
+func (d *Detector) Night() bool {
+	return d.night
+}
+
+// Observe feeds one observation and returns the (possibly new) mode and whether it changed. luma is the mean
+// luminance of the day stream and is only used by the luminance source. The first observation sets the mode
+// immediately, so a start after dark goes straight to night mode.
+func (d *Detector) Observe(now time.Time, luma float64) (night bool, changed bool) {
+	wantNight := d.wantNight(now, luma)
+	if !d.started {
+		d.started = true
+		d.night = wantNight
+		return d.night, wantNight
+	}
+
+	if wantNight == d.night {
+		d.pendingSince = time.Time{}
+		return d.night, false
+	}
+	if d.pendingSince.IsZero() {
+		d.pendingSince = now
+	}
+	if now.Sub(d.pendingSince) < d.config.Hold {
+		return d.night, false
+	}
+	d.night = wantNight
+	d.pendingSince = time.Time{}
+	return d.night, true
+}
+
+// wantNight evaluates the source; luminance keeps the current mode between the two thresholds
+func (d *Detector) wantNight(now time.Time, luma float64) bool {
+	switch d.config.Source {
+	case SourceLuminance:
+		if d.night {
+			return luma < d.config.DayLuma
+		}
+		return luma < d.config.NightLuma
+	case SourceSchedule:
+		return d.config.Schedule.Contains(now)
+	case SourceSun:
+		return SunElevation(now, d.config.Latitude, d.config.Longitude) < d.config.SunAngle
+	}
+	return false
+}
+
+// Describe summarizes the configuration for the startup log
+func (d *Detector) Describe() string {
+	switch d.config.Source {
+	case SourceLuminance:
+		return fmt.Sprintf("luminance below %.0f → night, above %.0f → day (hold %v)", d.config.NightLuma, d.config.DayLuma, d.config.Hold)
+	case SourceSchedule:
+		return fmt.Sprintf("night %s local time (hold %v)", d.config.Schedule, d.config.Hold)
+	case SourceSun:
+		return fmt.Sprintf("sun below %.1f° at %.4f,%.4f (hold %v)", d.config.SunAngle, d.config.Latitude, d.config.Longitude, d.config.Hold)
+	}
+	return d.config.Source
+}
@@ -0,0 +1,302 @@
+// Package debugjournal keeps a per-session manifest of completed debug artifacts so a crash mid-write never
+// leaves downstream tooling guessing which files are whole.
+//
+// Each debug session appends JSON lines to <session>.manifest: a "start" record, one "artifact" record per
+// file that was completely written, and an "end" record once every queued write has finished. Images are
+// written under a ".partial" name and renamed into place, so a file without a manifest entry is never
+// half-written data under its final name. On startup Recover finalizes sessions that have no "end" record
+// and moves partial or unjournaled files into a quarantine directory.
+package debugjournal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	manifestExt   = ".manifest"
+	partialMarker = ".partial"
+	quarantineDir = "quarantine"
+)
+
+// Entry is one manifest record
+type Entry struct {
+	Event     string    `json:"event"` // "start", "artifact" or "end"
+	Session   string    `json:"session"`
+	Time      time.Time `json:"time"`
+	File      string    `json:"file,omitempty"`
+	Bytes     int64     `json:"bytes,omitempty"`
+	Artifacts int       `json:"artifacts,omitempty"` // Artifact count on "end"
+	Recovered bool      `json:"recovered,omitempty"` // "end" written by the startup scan after a crash
+}
+
+// Journal is the manifest writer for one debug session
+type Journal struct {
+	session   string
+	file      *os.File
+	mu        sync.Mutex
+	artifacts int
+	pending   int  // Queued writes that have not reported back yet
+	closing   bool // Close was called; "end" is written once pending reaches zero
+}
+
+// Open starts (or, for a re-opened session, continues) the manifest for a session in dir
+func Open(dir, session string) (*Journal, error) {
+	path := filepath.Join(dir, session+manifestExt)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open debug manifest: %v", err)
+	}
+
+	j := &Journal{session: session, file: file}
+	if err := j.write(Entry{Event: "start"}); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return j, nil
+}
+
+// Expect registers a write that will report back through Done; the "end" record waits for it
+func (j *Journal) Expect() {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	j.pending++
+	j.mu.Unlock()
+}
+
+// Done reports an expected write. Successful writes are journaled as artifacts.
+func (j *Journal) Done(path string, ok bool) {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.pending--
+	if ok {
+		j.recordLocked(path)
+	}
+	j.finishLocked()
+}
+
+// Record journals an artifact that is already complete on disk (e.g. a closed log file)
+func (j *Journal) Record(path string) {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.recordLocked(path)
+}
+
+// Close ends the session; the "end" record is written as soon as all expected writes have reported back
+func (j *Journal) Close() {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.closing = true
+	j.finishLocked()
+}
+
+// recordLocked appends an artifact record. Must be called with j.mu held.
+func (j *Journal) recordLocked(path string) {
+	if j.file == nil {
+		return
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+	if err := j.write(Entry{Event: "artifact", File: filepath.Base(path), Bytes: info.Size()}); err == nil {
+		j.artifacts++
+	}
+}
+
+// finishLocked writes the "end" record and closes the manifest once closing and idle. Must be called with j.mu held.
+func (j *Journal) finishLocked() {
+	if !j.closing || j.pending > 0 || j.file == nil {
+		return
+	}
+	j.write(Entry{Event: "end", Artifacts: j.artifacts})
+	j.file.Sync()
+	j.file.Close()
+	j.file = nil
+}
+
+// write appends one JSON line to the manifest
+func (j *Journal) write(entry Entry) error {
+	entry.Session = j.session
+	entry.Time = time.Now()
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest entry: %v", err)
+	}
+	if _, err := j.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write debug manifest: %v", err)
+	}
+	return nil
+}
+
+// PartialPath returns the temporary name a file is written under before Commit. The extension is kept so
+// encoders that pick the format from the file name still work.
+func PartialPath(path string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + partialMarker + ext
+}
+
+// Commit atomically moves a completed partial file to its final name
+func Commit(partialPath, path string) error {
+	if err := os.Rename(partialPath, path); err != nil {
+		return fmt.Errorf("failed to commit %s: %v", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// RecoveredSession describes an interrupted session finalized by Recover
+type RecoveredSession struct {
+	Session     string
+	Artifacts   int // Journaled artifacts that were verified intact
+	Quarantined int // Files of this session moved to quarantine
+}
+
+// RecoveryReport summarizes a startup scan
+type RecoveryReport struct {
+	Sessions       []RecoveredSession
+	PartialFiles   int    // Half-written files moved to quarantine
+	QuarantinePath string // Empty when nothing was quarantined
+}
+
+// Recover scans dir for sessions without an "end" record, verifies their journaled artifacts, quarantines
+// partial, damaged and unjournaled files and writes a recovered "end" record so the session is final.
+func Recover(dir string) (RecoveryReport, error) {
+	var report RecoveryReport
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return report, fmt.Errorf("failed to scan debug directory: %v", err)
+	}
+
+	quarantine := func(name string) error {
+		if report.QuarantinePath == "" {
+			report.QuarantinePath = filepath.Join(dir, quarantineDir, time.Now().Format("20060102-150405"))
+			if err := os.MkdirAll(report.QuarantinePath, 0755); err != nil {
+				return fmt.Errorf("failed to create quarantine directory: %v", err)
+			}
+		}
+		return os.Rename(filepath.Join(dir, name), filepath.Join(report.QuarantinePath, name))
+	}
+
+	// Half-written files never reached their final name
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if strings.Contains(name, partialMarker+".") {
+			if err := quarantine(name); err != nil {
+				return report, err
+			}
+			report.PartialFiles++
+			continue
+		}
+		files = append(files, name)
+	}
+
+	for _, name := range files {
+		if !strings.HasSuffix(name, manifestExt) {
+			continue
+		}
+		session := strings.TrimSuffix(name, manifestExt)
+		recovered, interrupted, err := recoverSession(dir, session, files, quarantine)
+		if err != nil {
+			return report, err
+		}
+		if interrupted {
+			report.Sessions = append(report.Sessions, recovered)
+		}
+	}
+	return report, nil
+}
+
+// recoverSession finalizes one session manifest. Returns interrupted=false when the session ended cleanly.
+func recoverSession(dir, session string, files []string, quarantine func(string) error) (RecoveredSession, bool, error) {
+	result := RecoveredSession{Session: session}
+	manifestPath := filepath.Join(dir, session+manifestExt)
+
+	file, err := os.Open(manifestPath)
+	if err != nil {
+		return result, false, fmt.Errorf("failed to read manifest %s: %v", session, err)
+	}
+	journaled := make(map[string]int64)
+	ended := false
+	truncated := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			truncated = true // Torn line from the crash
+			continue
+		}
+		truncated = false
+		switch entry.Event {
+		case "start":
+			ended = false
+		case "artifact":
+			journaled[entry.File] = entry.Bytes
+		case "end":
+			ended = true
+		}
+	}
+	file.Close()
+
+	if ended {
+		return result, false, nil
+	}
+
+	// Verify journaled artifacts and quarantine anything of this session the journal cannot vouch for
+	for _, name := range files {
+		if name == session+manifestExt || name == session+".txt" || !belongsToSession(name, session) {
+			continue // The session log is kept; the caller appends an interruption footer
+		}
+		size, listed := journaled[name]
+		if info, err := os.Stat(filepath.Join(dir, name)); err == nil && listed && info.Size() == size {
+			result.Artifacts++
+			continue
+		}
+		if err := quarantine(name); err != nil {
+			return result, true, err
+		}
+		result.Quarantined++
+	}
+
+	journal := &Journal{session: session, artifacts: result.Artifacts}
+	journal.file, err = os.OpenFile(manifestPath, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return result, true, fmt.Errorf("failed to finalize manifest %s: %v", session, err)
+	}
+	if truncated {
+		journal.file.Write([]byte("\n")) // Terminate the torn line so the end record parses
+	}
+	journal.write(Entry{Event: "end", Artifacts: result.Artifacts, Recovered: true})
+	journal.file.Close()
+
+	return result, true, nil
+}
+
+// belongsToSession matches the debug file naming schemes (<id>.txt, <id>_overlay_0001.jpg, yolo_input_<id>_001.jpg)
+func belongsToSession(name, session string) bool {
+	return name == session+".txt" || strings.HasPrefix(name, session+"_") || strings.Contains(name, "_"+session+"_")
+}
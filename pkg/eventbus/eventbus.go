@@ -0,0 +1,207 @@
+// Package eventbus turns NOLO's debug messages into structured tracking events and fans them out to
+// subscribers such as dashboard WebSocket clients and the MQTT publisher.
+//
+// Events are not emitted from dedicated call sites: the existing debugMsg/logDebugMessage calls are recognized
+// by their component tag and message, so the console log and the event stream can never disagree. They cover:
+//
+//   - tracking milestones: new track, lock, SUPER LOCK, recovery and its outcome, loss, people on board,
+//     occupancy summary, speed, PTZ command, alerting rule, target swap, track merge, glare on/off
+//   - stream health: input lost, frozen, reconnected, stepped down to or up from a fallback stream
+//   - pipeline goroutines crashing and restarting
+package eventbus
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Event types
+const (
+	ObjectCreated   = "object_created"
+	LockAcquired    = "lock_acquired"
+	SuperLock       = "super_lock"
+	RecoveryStarted = "recovery_started"
+	RecoveryResumed = "recovery_resumed"
+	RecoveryFailed  = "recovery_failed"
+	ObjectLost      = "object_lost"
+	PeopleOnBoard   = "people_on_board"
+	Occupancy       = "occupancy"
+	Speed           = "speed"
+	PTZCommand      = "ptz_command"
+	PersonOverboard = "person_overboard"
+	RuleTriggered   = "rule_triggered"
+	TargetSwapped   = "target_swapped"
+	TrackMerged     = "track_merged"
+	GlareStarted    = "glare_started"
+	GlareEnded      = "glare_ended"
+
+	StreamLost        = "stream_lost"
+	StreamFrozen      = "stream_frozen"
+	StreamReconnected = "stream_reconnected"
+	StreamDowngraded  = "stream_downgraded"
+	StreamUpgraded    = "stream_upgraded"
+
+	SubsystemCrashed   = "subsystem_crashed"
+	SubsystemRestarted = "subsystem_restarted"
+)
+
+// Event is a structured tracking event
+type Event struct {
+	Time      time.Time              `json:"time"`
+	Type      string                 `json:"type"`
+	ObjectID  string                 `json:"object_id,omitempty"`
+	Component string                 `json:"component"`
+	Message   string                 `json:"message"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+}
+
+// Classify maps a debug message onto an event type. ok is false for messages that are not events.
+func Classify(component, message string) (eventType string, ok bool) {
+	switch component {
+	case "TRACK_STATE":
+		switch {
+		case strings.Contains(message, "New track"):
+			return ObjectCreated, true
+		case strings.Contains(message, "→ LOCKED "):
+			return LockAcquired, true
+		case strings.Contains(message, "→ SUPER_LOCKED "):
+			return SuperLock, true
+		case strings.Contains(message, "→ LOST "):
+			return ObjectLost, true
+		}
+	case "RECOVERY_PREP":
+		return RecoveryStarted, true
+	case "RECOVERY_RESUME":
+		return RecoveryResumed, true
+	case "RECOVERY_END":
+		return RecoveryFailed, true
+	case "PEOPLE_ON_BOARD":
+		return PeopleOnBoard, true
+	case "OCCUPANCY":
+		return Occupancy, true
+	case "SPEED":
+		return Speed, true
+	case "PTZ":
+		if strings.HasPrefix(message, "Executing command:") {
+			return PTZCommand, true
+		}
+	case "OVERBOARD":
+		return PersonOverboard, true
+	case "RULE_ALERT":
+		return RuleTriggered, true
+	case "TARGET_SWAP":
+		return TargetSwapped, true
+	case "TRACK_MERGE":
+		return TrackMerged, true
+	case "GLARE":
+		switch {
+		case strings.Contains(message, "Glare suppression on:"):
+			return GlareStarted, true
+		case strings.Contains(message, "Glare suppression off:"):
+			return GlareEnded, true
+		}
+	case "STREAM_HEALTH":
+		switch {
+		case strings.Contains(message, "Stream lost"):
+			return StreamLost, true
+		case strings.Contains(message, "Stream frozen"):
+			return StreamFrozen, true
+		case strings.Contains(message, "Stream reconnected"):
+			return StreamReconnected, true
+		}
+	case "STREAM_QUALITY":
+		switch {
+		case strings.Contains(message, "Stream downgraded:"):
+			return StreamDowngraded, true
+		case strings.Contains(message, "Stream upgraded:"):
+			return StreamUpgraded, true
+		}
+	case "WATCHDOG":
+		switch {
+		case strings.Contains(message, " crashed: "):
+			return SubsystemCrashed, true
+		case strings.Contains(message, " restarted "):
+			return SubsystemRestarted, true
+		}
+	}
+	return "", false
+}
+
+// Bus delivers events to every subscriber. Publishing never blocks: a subscriber whose buffer is full
+// misses the event.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[int]chan Event
+	nextID      int
+	dropped     atomic.Int64
+}
+
+// New creates an event bus
+func New() *Bus {
+	return &Bus{subscribers: make(map[int]chan Event)}
+}
+
+// Subscribe returns a channel receiving events from now on and a function that ends the subscription
+// (and closes the channel)
+func (b *Bus) Subscribe(buffer int) (<-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	ch := make(chan Event, buffer)
+	b.subscribers[id] = ch
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subscribers, id)
+			close(ch)
+		})
+	}
+}
+
+// Publish sends an event to all subscribers
+func (b *Bus) Publish(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			b.dropped.Add(1)
+		}
+	}
+}
+
+// PublishMessage publishes a debug message if it classifies as an event
+func (b *Bus) PublishMessage(component, message, objectID string, data map[string]interface{}) {
+	if b == nil {
+		return
+	}
+	eventType, ok := Classify(component, message)
+	if !ok {
+		return
+	}
+	b.Publish(Event{Type: eventType, ObjectID: objectID, Component: component, Message: message, Data: data})
+}
+
+// Subscribers returns the number of active subscriptions
+func (b *Bus) Subscribers() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers)
+}
+
+// Dropped returns how many deliveries were skipped because a subscriber was not keeping up
+func (b *Bus) Dropped() int64 {
+	return b.dropped.Load()
+}
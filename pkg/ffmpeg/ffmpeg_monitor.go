@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"fmt"
 	"io"
-	"log"
 	"os/exec"
 	"regexp"
 	"strconv"
@@ -13,6 +12,23 @@ import (
 	"time"
 )
 
+// Global debug function for ffmpeg package
+var debugMsgFunc func(string, string, ...string)
+
+// SetDebugFunction allows main package to provide debug function
+func SetDebugFunction(fn func(string, string, ...string)) {
+	debugMsgFunc = fn
+}
+
+// debugMsg is a wrapper that handles nil checks
+func debugMsg(component, message string, boatID ...string) {
+	if debugMsgFunc != nil {
+		debugMsgFunc(component, message, boatID...)
+	} else {
+		fmt.Printf("[%s] %s\n", component, message)
+	}
+}
+
 // OutputBuffer stores recent output lines for crash dump analysis
 type OutputBuffer struct {
 	lines    []string
@@ -131,7 +147,7 @@ func (fhm *FFmpegHealthMonitor) Start(cmd *exec.Cmd, onUnhealthy func(reason str
 	fhm.timestampErrors = 0
 	fhm.forceUnhealthy = false
 
-	fmt.Printf("[FFMPEG_MONITOR] Setting up health monitoring (process will start after pipe setup)\n")
+	debugMsg("FFMPEG_MONITOR", "Setting up health monitoring (process will start after pipe setup)")
 
 	// Create pipes for stderr and stdout monitoring
 	var err error
@@ -145,7 +161,7 @@ func (fhm *FFmpegHealthMonitor) Start(cmd *exec.Cmd, onUnhealthy func(reason str
 		return fmt.Errorf("failed to create stdout pipe: %v", err)
 	}
 
-	fmt.Printf("[FFMPEG_MONITOR] Health monitor pipes created successfully\n")
+	debugMsg("FFMPEG_MONITOR", "Health monitor pipes created successfully")
 
 	return nil
 }
@@ -156,7 +172,7 @@ func (fhm *FFmpegHealthMonitor) StartMonitoring() {
 	defer fhm.mutex.Unlock()
 
 	if fhm.cmd != nil && fhm.cmd.Process != nil {
-		fmt.Printf("[FFMPEG_MONITOR] Starting health monitoring for PID: %d\n", fhm.cmd.Process.Pid)
+		debugMsg("FFMPEG_MONITOR", fmt.Sprintf("Starting health monitoring for PID: %d", fhm.cmd.Process.Pid))
 	}
 
 	// Start monitoring goroutines
@@ -193,7 +209,7 @@ func (fhm *FFmpegHealthMonitor) Stop() {
 	fhm.mutex.Lock()
 	defer fhm.mutex.Unlock()
 
-	fmt.Printf("[FFMPEG_MONITOR] Stopping health monitoring\n")
+	debugMsg("FFMPEG_MONITOR", "Stopping health monitoring")
 	fhm.monitoringActive = false
 	fhm.isRunning = false
 }
@@ -235,7 +251,7 @@ func (fhm *FFmpegHealthMonitor) DumpCrashInfo() {
 func (fhm *FFmpegHealthMonitor) monitorOutput(pipe io.ReadCloser, source string, buffer *OutputBuffer) {
 	defer pipe.Close()
 
-	fmt.Printf("[FFMPEG_MONITOR] 👁️ Starting output monitor for %s\n", source)
+	debugMsg("FFMPEG_MONITOR", fmt.Sprintf("👁️ Starting output monitor for %s", source))
 
 	// Regex patterns for parsing FFmpeg output
 	frameRegex := regexp.MustCompile(`frame=\s*(\d+)`)
@@ -266,16 +282,16 @@ func (fhm *FFmpegHealthMonitor) monitorOutput(pipe io.ReadCloser, source string,
 		fhm.processOutputLine(line, source, frameRegex, timestampErrorRegex)
 
 		// 🎯 REAL-TIME CONSOLE OUTPUT: Show ALL lines with proper formatting
-		fmt.Printf("[FFMPEG_%s] %s\n", source, line)
+		debugMsg("FFMPEG_"+source, line)
 	}
 
 	if err := scanner.Err(); err != nil {
-		fmt.Printf("[FFMPEG_MONITOR] ❌ Scanner error for %s: %v\n", source, err)
+		debugMsg("FFMPEG_MONITOR", fmt.Sprintf("❌ Scanner error for %s: %v", source, err))
 		// Store error in buffer too
 		buffer.Add(fmt.Sprintf("SCANNER_ERROR: %v", err))
 	}
 
-	fmt.Printf("[FFMPEG_MONITOR] 👁️ Output monitor for %s finished (%d lines)\n", source, lineCount)
+	debugMsg("FFMPEG_MONITOR", fmt.Sprintf("👁️ Output monitor for %s finished (%d lines)", source, lineCount))
 }
 
 // processOutputLine analyzes FFmpeg output for health indicators
@@ -293,12 +309,11 @@ func (fhm *FFmpegHealthMonitor) processOutputLine(line, source string, frameRege
 		fhm.timestampErrors++
 		fhm.lastErrorTime = now
 
-		fmt.Printf("[FFMPEG_MONITOR] 🚨 CRITICAL TIMESTAMP ERROR #%d: %s\n", fhm.timestampErrors, line)
-		log.Printf("[%s CRITICAL] Timestamp error #%d: %s", source, fhm.timestampErrors, line)
+		debugMsg("FFMPEG_MONITOR", fmt.Sprintf("🚨 CRITICAL TIMESTAMP ERROR #%d: %s", fhm.timestampErrors, line))
 
 		// Trigger unhealthy state if we see 3+ timestamp errors within 30 seconds
 		if fhm.timestampErrors >= 3 {
-			fmt.Printf("[FFMPEG_MONITOR] 🚨 TIMESTAMP ERROR THRESHOLD REACHED (%d errors), marking unhealthy!\n", fhm.timestampErrors)
+			debugMsg("FFMPEG_MONITOR", fmt.Sprintf("🚨 TIMESTAMP ERROR THRESHOLD REACHED (%d errors), marking unhealthy!", fhm.timestampErrors))
 			fhm.forceUnhealthy = true
 			fhm.lastOutput = time.Now().Add(-fhm.healthTimeout - time.Second)
 			fhm.timestampErrors = 0
@@ -323,7 +338,7 @@ func (fhm *FFmpegHealthMonitor) processOutputLine(line, source string, frameRege
 
 // healthCheckLoop continuously monitors FFmpeg health status
 func (fhm *FFmpegHealthMonitor) healthCheckLoop() {
-	fmt.Printf("[FFMPEG_MONITOR] ⚙️ Starting health check loop (interval: 5s)\n")
+	debugMsg("FFMPEG_MONITOR", "⚙️ Starting health check loop (interval: 5s)")
 
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
@@ -332,13 +347,13 @@ func (fhm *FFmpegHealthMonitor) healthCheckLoop() {
 		select {
 		case <-ticker.C:
 			if !fhm.monitoringActive {
-				fmt.Printf("[FFMPEG_MONITOR] ⚙️ Health check loop stopping\n")
+				debugMsg("FFMPEG_MONITOR", "⚙️ Health check loop stopping")
 				return
 			}
 
 			if !fhm.isHealthy() {
 				reason := fhm.getUnhealthyReason()
-				fmt.Printf("[FFMPEG_MONITOR] 🚨 FFmpeg became unhealthy: %s\n", reason)
+				debugMsg("FFMPEG_MONITOR", fmt.Sprintf("🚨 FFmpeg became unhealthy: %s", reason))
 
 				// Dump crash info before triggering unhealthy callback
 				fhm.DumpCrashInfo()
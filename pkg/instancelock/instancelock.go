@@ -0,0 +1,253 @@
+// Package instancelock keeps two NOLO instances from controlling the same PTZ head at once.
+//
+// Two layers guard a camera:
+//
+//   - a file lock (flock) per camera in a local directory catches a double start on the same host
+//     and is released by the kernel even if the process crashes.
+//   - a lease stored on the camera itself catches instances on different hosts. The lease names its
+//     owner and an expiry; the holder renews it well before it expires, so a crashed owner's lease
+//     simply runs out and the camera becomes free again.
+package instancelock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Global debug function for instancelock package
+var debugMsgFunc func(string, string, ...string)
+
+// SetDebugFunction allows main package to provide debug function
+func SetDebugFunction(fn func(string, string, ...string)) {
+	debugMsgFunc = fn
+}
+
+// debugMsg is a wrapper that handles nil checks
+func debugMsg(component, message string, boatID ...string) {
+	if debugMsgFunc != nil {
+		debugMsgFunc(component, message, boatID...)
+	}
+}
+
+// FileLock is an exclusive per-camera lock file held for the lifetime of the process
+type FileLock struct {
+	file *os.File
+	path string
+}
+
+// AcquireFile takes the lock file for a camera. It fails immediately when another process holds it and
+// reports that process's owner string.
+func AcquireFile(dir, camera, owner string) (*FileLock, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %v", err)
+	}
+	path := filepath.Join(dir, "nolo-"+sanitize(camera)+".lock")
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %v", err)
+	}
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		holder, _ := os.ReadFile(path)
+		file.Close()
+		return nil, fmt.Errorf("camera %s is already controlled by another NOLO instance (%s, lock %s)",
+			camera, strings.TrimSpace(string(holder)), path)
+	}
+
+	file.Truncate(0)
+	file.WriteAt([]byte(owner+"\n"), 0)
+	return &FileLock{file: file, path: path}, nil
+}
+
+// Release unlocks the lock file. The file itself is left in place: removing it could let a waiting
+// process lock the old file while a third creates a new one.
+func (l *FileLock) Release() {
+	if l == nil || l.file == nil {
+		return
+	}
+	syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN)
+	l.file.Close()
+	l.file = nil
+}
+
+// LeaseStore is a camera-side field the lease is written to
+type LeaseStore interface {
+	ReadLeaseField() (string, error)
+	WriteLeaseField(value string) error
+}
+
+// ErrLeaseHeld is returned by Acquire when another instance holds an unexpired lease
+var ErrLeaseHeld = errors.New("camera lease is held by another NOLO instance")
+
+// leasePrefix marks a lease value so unrelated text in the field is treated as "no lease"
+const leasePrefix = "NOLO-LEASE"
+
+// Lease is a camera-side lease held by this instance
+type Lease struct {
+	store    LeaseStore
+	owner    string
+	ttl      time.Duration
+	mu       sync.Mutex
+	held     bool
+	stopChan chan struct{}
+	stopOnce sync.Once
+}
+
+// NewLease creates a lease on a camera field for owner; it is not taken until Acquire
+func NewLease(store LeaseStore, owner string, ttl time.Duration) *Lease {
+	return &Lease{
+		store:    store,
+		owner:    owner,
+		ttl:      ttl,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Acquire takes the lease unless another owner holds an unexpired one. force takes it regardless
+// (for recovering from an instance that cannot be reached to shut it down).
+func (l *Lease) Acquire(force bool) error {
+	holder, expires, err := l.read()
+	if err != nil {
+		return err
+	}
+	if holder != "" && holder != l.owner && time.Now().Before(expires) {
+		if !force {
+			return fmt.Errorf("%w (%s, until %s)", ErrLeaseHeld, holder, expires.Format("15:04:05"))
+		}
+		debugMsg("INSTANCE_LOCK", fmt.Sprintf("⚠️ Forcing camera lease away from %s (valid until %s)", holder, expires.Format("15:04:05")))
+	}
+
+	if err := l.write(); err != nil {
+		return err
+	}
+
+	// Read back: if another instance wrote at the same moment, only one of us sees its own name
+	holder, _, err = l.read()
+	if err != nil {
+		return err
+	}
+	if holder != l.owner {
+		return fmt.Errorf("camera lease was taken by %s while acquiring it", holder)
+	}
+
+	l.mu.Lock()
+	l.held = true
+	l.mu.Unlock()
+	debugMsg("INSTANCE_LOCK", fmt.Sprintf("🔒 Camera lease acquired by %s (ttl %v)", l.owner, l.ttl))
+	return nil
+}
+
+// Keep renews the lease at a third of its ttl until Release. onLost is called once, on the renewal
+// goroutine, if another owner has taken the lease over.
+func (l *Lease) Keep(onLost func(holder string)) {
+	go func() {
+		ticker := time.NewTicker(l.ttl / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				holder, _, err := l.read()
+				if err != nil {
+					debugMsg("INSTANCE_LOCK", fmt.Sprintf("⚠️ Failed to check camera lease: %v", err))
+					continue
+				}
+				if holder != "" && holder != l.owner {
+					l.mu.Lock()
+					l.held = false
+					l.mu.Unlock()
+					debugMsg("INSTANCE_LOCK", fmt.Sprintf("🚨 Camera lease taken over by %s - another NOLO instance controls this camera", holder))
+					if onLost != nil {
+						onLost(holder)
+					}
+					return
+				}
+				if err := l.write(); err != nil {
+					debugMsg("INSTANCE_LOCK", fmt.Sprintf("⚠️ Failed to renew camera lease: %v", err))
+				}
+			case <-l.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Release stops renewal and clears the lease if this instance still holds it
+func (l *Lease) Release() {
+	if l == nil {
+		return
+	}
+	l.stopOnce.Do(func() { close(l.stopChan) })
+
+	l.mu.Lock()
+	held := l.held
+	l.held = false
+	l.mu.Unlock()
+	if !held {
+		return
+	}
+	if holder, _, err := l.read(); err == nil && holder == l.owner {
+		if err := l.store.WriteLeaseField(""); err != nil {
+			debugMsg("INSTANCE_LOCK", fmt.Sprintf("⚠️ Failed to clear camera lease: %v", err))
+			return
+		}
+		debugMsg("INSTANCE_LOCK", "🔓 Camera lease released")
+	}
+}
+
+// read parses the current lease; an empty holder means the field holds no lease
+func (l *Lease) read() (string, time.Time, error) {
+	value, err := l.store.ReadLeaseField()
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to read camera lease: %v", err)
+	}
+	fields := strings.Fields(value)
+	if len(fields) != 3 || fields[0] != leasePrefix {
+		return "", time.Time{}, nil
+	}
+	expiresUnix, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return "", time.Time{}, nil
+	}
+	return fields[1], time.Unix(expiresUnix, 0), nil
+}
+
+// write stores the lease with a fresh expiry
+func (l *Lease) write() error {
+	value := fmt.Sprintf("%s %s %d", leasePrefix, l.owner, time.Now().Add(l.ttl).Unix())
+	if err := l.store.WriteLeaseField(value); err != nil {
+		return fmt.Errorf("failed to write camera lease: %v", err)
+	}
+	return nil
+}
+
+// Owner returns the owner string for this process: host:pid
+func Owner() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	if len(host) > 16 {
+		host = host[:16] // Camera text fields are short
+	}
+	return fmt.Sprintf("%s:%d", sanitize(host), os.Getpid())
+}
+
+// sanitize keeps a string safe for file names and the space-separated lease format
+func sanitize(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, value)
+}
@@ -0,0 +1,247 @@
+// Package joystick drives NOLO's manual camera control from a gamepad or joystick through the Linux
+// joystick API (/dev/input/js*).
+//
+// Two axes pan and tilt the camera and a third zooms. While any axis is deflected past the dead zone the
+// reader sends a move at a fixed rate, which takes the camera out of automatic tracking; a button hands it
+// back immediately instead of waiting for the inactivity timeout.
+package joystick
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults
+const (
+	DefaultDevice       = "/dev/input/js0"
+	DefaultDeadZone     = 0.15
+	DefaultMoveInterval = 200 * time.Millisecond
+	reopenDelay         = 5 * time.Second
+)
+
+// Linux joystick event types (linux/joystick.h)
+const (
+	eventButton = 0x01 // Initial-state events (0x80 set) of buttons are not presses and are ignored
+	eventAxis   = 0x02
+)
+
+// Global debug function for joystick package
+var debugMsgFunc func(string, string, ...string)
+
+// SetDebugFunction allows main package to provide debug function
+func SetDebugFunction(fn func(string, string, ...string)) {
+	debugMsgFunc = fn
+}
+
+// debugMsg is a wrapper that handles nil checks
+func debugMsg(component, message string, boatID ...string) {
+	if debugMsgFunc != nil {
+		debugMsgFunc(component, message, boatID...)
+	}
+}
+
+// Controller is what the joystick drives (satisfied by *tracking.SpatialIntegration)
+type Controller interface {
+	ManualMove(pan, tilt, zoom float64) error
+	ExitManualControl()
+}
+
+// Axis is a joystick axis number, optionally inverted
+type Axis struct {
+	Number   int
+	Inverted bool
+}
+
+// Mapping assigns joystick axes and buttons to camera controls
+type Mapping struct {
+	Pan          Axis
+	Tilt         Axis
+	Zoom         Axis
+	ResumeButton int // Hands the camera back to automatic tracking
+}
+
+// DefaultMapping suits most gamepads: left stick pans and tilts, right stick vertical zooms (up = in),
+// the first button resumes tracking
+var DefaultMapping = Mapping{Pan: Axis{Number: 0}, Tilt: Axis{Number: 1}, Zoom: Axis{Number: 3, Inverted: true}}
+
+// ParseMapping parses "pan=0,tilt=1,zoom=-3,resume=0" (a minus sign inverts an axis, so "-0" is an inverted
+// axis 0); omitted controls keep their DefaultMapping value
+func ParseMapping(value string) (Mapping, error) {
+	mapping := DefaultMapping
+	if strings.TrimSpace(value) == "" {
+		return mapping, nil
+	}
+	for _, part := range strings.Split(value, ",") {
+		name, number, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return mapping, fmt.Errorf("invalid joystick mapping %q (expected control=number)", part)
+		}
+		number = strings.TrimSpace(number)
+		inverted := strings.HasPrefix(number, "-")
+		n, err := strconv.Atoi(strings.TrimPrefix(number, "-"))
+		if err != nil || n < 0 {
+			return mapping, fmt.Errorf("invalid joystick mapping %q (expected a non-negative number)", part)
+		}
+		axis := Axis{Number: n, Inverted: inverted}
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "pan":
+			mapping.Pan = axis
+		case "tilt":
+			mapping.Tilt = axis
+		case "zoom":
+			mapping.Zoom = axis
+		case "resume":
+			if inverted {
+				return mapping, fmt.Errorf("joystick resume button cannot be inverted")
+			}
+			mapping.ResumeButton = n
+		default:
+			return mapping, fmt.Errorf("unknown joystick control %q (expected pan, tilt, zoom or resume)", name)
+		}
+	}
+	return mapping, nil
+}
+
+// Reader turns joystick events into manual camera moves
+type Reader struct {
+	device     string
+	mapping    Mapping
+	deadZone   float64
+	interval   time.Duration
+	controller Controller
+
+	mu   sync.Mutex
+	axes map[int]float64 // Axis number -> deflection (-1 to 1)
+
+	stopChan chan struct{}
+	stopOnce sync.Once
+}
+
+// NewReader creates a reader for a joystick device
+func NewReader(device string, mapping Mapping, deadZone float64, controller Controller) *Reader {
+	if device == "" {
+		device = DefaultDevice
+	}
+	if deadZone <= 0 || deadZone >= 1 {
+		deadZone = DefaultDeadZone
+	}
+	return &Reader{
+		device:     device,
+		mapping:    mapping,
+		deadZone:   deadZone,
+		interval:   DefaultMoveInterval,
+		controller: controller,
+		axes:       make(map[int]float64),
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// Start reads the device and sends moves until Stop. A missing or unplugged device is retried.
+func (r *Reader) Start() {
+	go r.readLoop()
+	go r.moveLoop()
+}
+
+// Stop ends reading and moving
+func (r *Reader) Stop() {
+	r.stopOnce.Do(func() { close(r.stopChan) })
+}
+
+// readLoop opens the device and reads its events, reopening it after errors
+func (r *Reader) readLoop() {
+	for {
+		file, err := os.Open(r.device)
+		if err != nil {
+			debugMsg("JOYSTICK", fmt.Sprintf("⚠️ Cannot open %s: %v - retrying in %v", r.device, err, reopenDelay))
+		} else {
+			debugMsg("JOYSTICK", fmt.Sprintf("🕹️ Joystick %s connected", r.device))
+			closed := make(chan struct{})
+			go func() {
+				select {
+				case <-r.stopChan:
+					file.Close() // Unblocks readEvents
+				case <-closed:
+				}
+			}()
+			err = r.readEvents(file)
+			close(closed)
+			file.Close()
+			r.mu.Lock()
+			r.axes = make(map[int]float64) // A disconnected stick must not keep the camera moving
+			r.mu.Unlock()
+			debugMsg("JOYSTICK", fmt.Sprintf("⚠️ Joystick %s disconnected: %v", r.device, err))
+		}
+
+		select {
+		case <-r.stopChan:
+			return
+		case <-time.After(reopenDelay):
+		}
+	}
+}
+
+// readEvents decodes js_event records (u32 time, s16 value, u8 type, u8 number) until the device fails
+func (r *Reader) readEvents(device io.Reader) error {
+	var event [8]byte
+	for {
+		if _, err := io.ReadFull(device, event[:]); err != nil {
+			return err
+		}
+		value := int16(binary.LittleEndian.Uint16(event[4:6]))
+		kind, number := event[6], int(event[7])
+
+		switch {
+		case kind&eventAxis != 0:
+			r.mu.Lock()
+			r.axes[number] = float64(value) / 32767
+			r.mu.Unlock()
+		case kind == eventButton && value == 1 && number == r.mapping.ResumeButton:
+			debugMsg("JOYSTICK", "🕹️ Resume button pressed")
+			r.controller.ExitManualControl()
+		}
+	}
+}
+
+// axis returns a mapped axis' deflection with the dead zone removed and rescaled to -1..1. Must be called
+// with r.mu held.
+func (r *Reader) axis(mapped Axis) float64 {
+	value := r.axes[mapped.Number]
+	if mapped.Inverted {
+		value = -value
+	}
+	if math.Abs(value) < r.deadZone {
+		return 0
+	}
+	scaled := (math.Abs(value) - r.deadZone) / (1 - r.deadZone)
+	return math.Copysign(math.Min(scaled, 1), value)
+}
+
+// moveLoop sends a move every interval while the stick is deflected
+func (r *Reader) moveLoop() {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stopChan:
+			return
+		case <-ticker.C:
+		}
+
+		r.mu.Lock()
+		pan, tilt, zoom := r.axis(r.mapping.Pan), r.axis(r.mapping.Tilt), r.axis(r.mapping.Zoom)
+		r.mu.Unlock()
+		if pan == 0 && tilt == 0 && zoom == 0 {
+			continue
+		}
+		if err := r.controller.ManualMove(pan, tilt, zoom); err != nil {
+			debugMsg("JOYSTICK", fmt.Sprintf("⚠️ Move not sent: %v", err))
+		}
+	}
+}
@@ -0,0 +1,277 @@
+// Package logging provides leveled, structured log records (component, object ID, frame, level) written to
+// pluggable sinks: human-readable console lines, plain text files and JSON lines for log aggregation.
+//
+// NOLO's debugMsg(component, message, objectID) calls map onto records directly. Their level is inferred
+// from the component tag and message (…_ERROR / ❌ → error, …_WARN / ⚠️ → warn, …_DEBUG → debug, everything
+// else info) so existing call sites gain levels without being rewritten; verbose messages are trace.
+package logging
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is a log severity; lower values are more severe
+type Level int
+
+const (
+	LevelError Level = iota
+	LevelWarn
+	LevelInfo
+	LevelDebug
+	LevelTrace
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelError:
+		return "error"
+	case LevelWarn:
+		return "warn"
+	case LevelInfo:
+		return "info"
+	case LevelDebug:
+		return "debug"
+	case LevelTrace:
+		return "trace"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseLevel converts a level name into a Level
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "error":
+		return LevelError, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "info":
+		return LevelInfo, nil
+	case "debug":
+		return LevelDebug, nil
+	case "trace":
+		return LevelTrace, nil
+	default:
+		return LevelInfo, fmt.Errorf("unknown log level %q (valid levels are: error, warn, info, debug, trace)", name)
+	}
+}
+
+// InferLevel derives a level from a component tag and message
+func InferLevel(component, message string) Level {
+	component = strings.ToUpper(component)
+	message = strings.TrimSpace(message)
+	switch {
+	case strings.Contains(component, "ERROR") || strings.Contains(component, "FAIL") ||
+		strings.HasPrefix(message, "❌") || strings.HasPrefix(message, "🚨"):
+		return LevelError
+	case strings.Contains(component, "WARN") || strings.HasPrefix(message, "⚠"):
+		return LevelWarn
+	case strings.Contains(component, "DEBUG") || strings.Contains(component, "VERBOSE"):
+		return LevelDebug
+	default:
+		return LevelInfo
+	}
+}
+
+// Record is a single structured log entry
+type Record struct {
+	Time      time.Time              `json:"time"`
+	Level     Level                  `json:"-"`
+	Component string                 `json:"component"`
+	ObjectID  string                 `json:"object_id,omitempty"`
+	Frame     int64                  `json:"frame,omitempty"`
+	Message   string                 `json:"message"`
+	Fields    map[string]interface{} `json:"fields,omitempty"`
+}
+
+// MarshalJSON writes the level by name
+func (r Record) MarshalJSON() ([]byte, error) {
+	type plain Record
+	return json.Marshal(struct {
+		Level string `json:"level"`
+		plain
+	}{Level: r.Level.String(), plain: plain(r)})
+}
+
+// Sink receives records that passed the logger's level filter
+type Sink interface {
+	Write(record Record) error
+	Close() error
+}
+
+// TextSink writes "[15:04:05.000][COMPONENT] message" lines, the format NOLO has always printed
+type TextSink struct {
+	mu     sync.Mutex
+	writer io.Writer
+	closer io.Closer
+}
+
+// NewConsoleSink writes text lines to stdout
+func NewConsoleSink() *TextSink {
+	return &TextSink{writer: os.Stdout}
+}
+
+// NewTextFileSink appends text lines to a file; lines carry the date, level and object ID as well
+func NewTextFileSink(path string) (*TextSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %v", err)
+	}
+	return &TextSink{writer: file, closer: file}, nil
+}
+
+func (s *TextSink) Write(record Record) error {
+	var line string
+	if s.closer == nil {
+		line = fmt.Sprintf("[%s][%s] %s\n", record.Time.Format("15:04:05.000"), record.Component, record.Message)
+	} else {
+		objectID := ""
+		if record.ObjectID != "" {
+			objectID = " " + record.ObjectID
+		}
+		line = fmt.Sprintf("%s %-5s [%s]%s %s\n", record.Time.Format("2006-01-02 15:04:05.000"),
+			strings.ToUpper(record.Level.String()), record.Component, objectID, record.Message)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := io.WriteString(s.writer, line)
+	return err
+}
+
+func (s *TextSink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// JSONSink writes one JSON object per record
+type JSONSink struct {
+	mu     sync.Mutex
+	writer *bufio.Writer
+	closer io.Closer
+}
+
+// NewJSONConsoleSink writes JSON lines to stdout
+func NewJSONConsoleSink() *JSONSink {
+	return &JSONSink{writer: bufio.NewWriter(os.Stdout)}
+}
+
+// NewJSONFileSink appends JSON lines to a file
+func NewJSONFileSink(path string) (*JSONSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open JSON log file: %v", err)
+	}
+	return &JSONSink{writer: bufio.NewWriter(file), closer: file}, nil
+}
+
+func (s *JSONSink) Write(record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.writer.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return s.writer.Flush()
+}
+
+func (s *JSONSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writer.Flush(); err != nil {
+		return err
+	}
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// Logger filters records by level and fans them out to its sinks
+type Logger struct {
+	mu    sync.RWMutex
+	level Level
+	sinks []Sink
+}
+
+// New creates a logger at the given level with the given sinks
+func New(level Level, sinks ...Sink) *Logger {
+	return &Logger{level: level, sinks: sinks}
+}
+
+// SetLevel changes the most verbose level that is written
+func (l *Logger) SetLevel(level Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.level = level
+}
+
+// Enabled reports whether records at a level are written
+func (l *Logger) Enabled(level Level) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return level <= l.level
+}
+
+// AddSink adds a sink
+func (l *Logger) AddSink(sink Sink) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sinks = append(l.sinks, sink)
+}
+
+// SetSinks replaces all sinks (the previous ones are not closed)
+func (l *Logger) SetSinks(sinks ...Sink) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sinks = sinks
+}
+
+// Log writes a record to every sink if its level is enabled. Sink errors go to stderr so a broken log file
+// never takes the process down.
+func (l *Logger) Log(record Record) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if record.Level > l.level {
+		return
+	}
+	if record.Time.IsZero() {
+		record.Time = time.Now()
+	}
+	for _, sink := range l.sinks {
+		if err := sink.Write(record); err != nil {
+			fmt.Fprintf(os.Stderr, "[LOGGING] sink write failed: %v\n", err)
+		}
+	}
+}
+
+// Logf writes a formatted message at a level
+func (l *Logger) Logf(level Level, component, format string, args ...interface{}) {
+	l.Log(Record{Level: level, Component: component, Message: fmt.Sprintf(format, args...)})
+}
+
+// Close closes every sink
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var firstErr error
+	for _, sink := range l.sinks {
+		if err := sink.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
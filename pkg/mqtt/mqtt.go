@@ -0,0 +1,488 @@
+// Package mqtt is a minimal MQTT 3.1.1 publisher: enough to push NOLO's events and retained status topics to
+// a broker for home-automation and VMS integration. Messages are sent at QoS 0; retained messages are
+// remembered and sent again after every reconnect so the broker always holds the latest status.
+package mqtt
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Global debug function for mqtt package
+var debugMsgFunc func(string, string, ...string)
+
+// SetDebugFunction allows main package to provide debug function
+func SetDebugFunction(fn func(string, string, ...string)) {
+	debugMsgFunc = fn
+}
+
+// debugMsg is a wrapper that handles nil checks
+func debugMsg(component, message string, boatID ...string) {
+	if debugMsgFunc != nil {
+		debugMsgFunc(component, message, boatID...)
+	}
+}
+
+// Client defaults
+const (
+	DefaultKeepAlive  = 30 * time.Second
+	DefaultQueueSize  = 256
+	connectTimeout    = 10 * time.Second
+	writeTimeout      = 10 * time.Second
+	maxReconnectDelay = time.Minute
+	closeTimeout      = 5 * time.Second
+)
+
+// MQTT 3.1.1 control packet types (upper nibble of the fixed header)
+const (
+	packetConnect    = 0x10
+	packetConnAck    = 0x20
+	packetPublish    = 0x30
+	packetPingReq    = 0xC0
+	packetDisconnect = 0xE0
+)
+
+// connAckErrors describes CONNACK return codes
+var connAckErrors = map[byte]string{
+	1: "unacceptable protocol version",
+	2: "client identifier rejected",
+	3: "server unavailable",
+	4: "bad user name or password",
+	5: "not authorized",
+}
+
+// Config configures a Client
+type Config struct {
+	Broker    string // tcp://host:1883, mqtt://, or ssl://, tls://, mqtts:// for TLS (default port 8883); user:password@ is allowed
+	ClientID  string
+	KeepAlive time.Duration
+	QueueSize int // Messages buffered while the broker is slow or unreachable; further messages are dropped
+
+	// Last will: published by the broker when the connection drops without a DISCONNECT
+	WillTopic   string
+	WillPayload []byte
+	WillRetain  bool
+}
+
+// message is a queued PUBLISH
+type message struct {
+	topic   string
+	payload []byte
+	retain  bool
+}
+
+// Client publishes messages to a broker, reconnecting in the background with exponential backoff
+type Client struct {
+	config   Config
+	address  string
+	useTLS   bool
+	username string
+	password string
+	hasAuth  bool
+
+	queue     chan message
+	mu        sync.Mutex
+	retained  map[string]message
+	connected atomic.Bool
+	dropped   atomic.Int64
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+// NewClient validates the configuration and starts connecting to the broker in the background
+func NewClient(config Config) (*Client, error) {
+	broker := config.Broker
+	if !strings.Contains(broker, "://") {
+		broker = "tcp://" + broker
+	}
+	u, err := url.Parse(broker)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MQTT broker %q: %v", redactBroker(config.Broker), errors.Unwrap(err)) // err repeats the password
+	}
+
+	c := &Client{
+		config:   config,
+		retained: make(map[string]message),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+
+	port := "1883"
+	switch u.Scheme {
+	case "tcp", "mqtt":
+	case "ssl", "tls", "mqtts":
+		c.useTLS = true
+		port = "8883"
+	default:
+		return nil, fmt.Errorf("unsupported MQTT broker scheme %q (use tcp:// or ssl://)", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("MQTT broker %q has no host", redactBroker(config.Broker))
+	}
+	if u.Port() != "" {
+		port = u.Port()
+	}
+	c.address = net.JoinHostPort(u.Hostname(), port)
+	if u.User != nil {
+		c.username = u.User.Username()
+		c.password, _ = u.User.Password()
+		c.hasAuth = true
+	}
+
+	if config.ClientID == "" {
+		return nil, fmt.Errorf("MQTT client ID is required")
+	}
+	if config.WillTopic != "" {
+		if err := ValidateTopic(config.WillTopic); err != nil {
+			return nil, err
+		}
+	}
+	if c.config.KeepAlive <= 0 {
+		c.config.KeepAlive = DefaultKeepAlive
+	}
+	if c.config.QueueSize <= 0 {
+		c.config.QueueSize = DefaultQueueSize
+	}
+	c.queue = make(chan message, c.config.QueueSize)
+
+	go c.run()
+	return c, nil
+}
+
+// ValidateTopic checks that a topic can be published to
+func ValidateTopic(topic string) error {
+	if topic == "" {
+		return fmt.Errorf("MQTT topic is empty")
+	}
+	if strings.ContainsAny(topic, "+#\x00") {
+		return fmt.Errorf("MQTT topic %q must not contain wildcards", topic)
+	}
+	if len(topic) > 65535 {
+		return fmt.Errorf("MQTT topic is too long")
+	}
+	return nil
+}
+
+// Publish queues a message without blocking. Retained messages are also remembered and sent again after a
+// reconnect. A full queue drops the message.
+func (c *Client) Publish(topic string, payload []byte, retain bool) error {
+	if err := ValidateTopic(topic); err != nil {
+		return err
+	}
+	select {
+	case <-c.done:
+		return fmt.Errorf("MQTT client is closed")
+	default:
+	}
+
+	msg := message{topic: topic, payload: payload, retain: retain}
+	if retain {
+		c.mu.Lock()
+		c.retained[topic] = msg
+		c.mu.Unlock()
+	}
+
+	select {
+	case c.queue <- msg:
+		return nil
+	default:
+		c.dropped.Add(1)
+		return fmt.Errorf("MQTT queue full - message to %s dropped", topic)
+	}
+}
+
+// Connected reports whether the client currently has a broker connection
+func (c *Client) Connected() bool {
+	return c.connected.Load()
+}
+
+// Dropped returns how many messages were dropped because the queue was full
+func (c *Client) Dropped() int64 {
+	return c.dropped.Load()
+}
+
+// Close sends the queued messages, disconnects cleanly (so the broker does not publish the last will) and
+// stops reconnecting
+func (c *Client) Close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		select {
+		case <-c.stopped:
+		case <-time.After(closeTimeout):
+			debugMsg("MQTT", "⚠️ Timed out flushing MQTT messages on shutdown")
+		}
+	})
+}
+
+// run connects and serves the connection until Close, reconnecting with exponential backoff
+func (c *Client) run() {
+	defer close(c.stopped)
+
+	delay := time.Second
+	for {
+		conn, err := c.connect()
+		if err != nil {
+			debugMsg("MQTT", fmt.Sprintf("❌ MQTT connection to %s failed: %v - retrying in %v", c.address, err, delay))
+			select {
+			case <-c.done:
+				return
+			case <-time.After(delay):
+			}
+			delay *= 2
+			if delay > maxReconnectDelay {
+				delay = maxReconnectDelay
+			}
+			continue
+		}
+
+		delay = time.Second
+		c.connected.Store(true)
+		debugMsg("MQTT", fmt.Sprintf("📡 Connected to MQTT broker %s", c.address))
+		err = c.serve(conn)
+		c.connected.Store(false)
+		conn.Close()
+		if err == nil {
+			return
+		}
+		debugMsg("MQTT", fmt.Sprintf("⚠️ MQTT connection to %s lost: %v", c.address, err))
+	}
+}
+
+// redactBroker returns a broker address for messages, with any password masked
+func redactBroker(broker string) string {
+	prefix, rest := "", broker
+	if scheme, address, found := strings.Cut(broker, "://"); found {
+		prefix, rest = scheme+"://", address
+	}
+	at := strings.LastIndex(rest, "@")
+	if at < 0 {
+		return broker
+	}
+	if user, _, found := strings.Cut(rest[:at], ":"); found {
+		return prefix + user + ":xxxxx" + rest[at:]
+	}
+	return broker
+}
+
+// connect opens the network connection and completes the CONNECT/CONNACK handshake
+func (c *Client) connect() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: connectTimeout}
+	var conn net.Conn
+	var err error
+	if c.useTLS {
+		host, _, _ := net.SplitHostPort(c.address)
+		conn, err = tls.DialWithDialer(dialer, "tcp", c.address, &tls.Config{ServerName: host})
+	} else {
+		conn, err = dialer.Dial("tcp", c.address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	conn.SetDeadline(time.Now().Add(connectTimeout))
+	if _, err := conn.Write(c.connectPacket()); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	packetType, body, err := readPacket(bufio.NewReader(conn))
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("no CONNACK: %v", err)
+	}
+	if packetType != packetConnAck || len(body) != 2 {
+		conn.Close()
+		return nil, fmt.Errorf("expected CONNACK, got packet type 0x%02X", packetType)
+	}
+	if code := body[1]; code != 0 {
+		conn.Close()
+		if reason, known := connAckErrors[code]; known {
+			return nil, fmt.Errorf("broker refused connection: %s", reason)
+		}
+		return nil, fmt.Errorf("broker refused connection (code %d)", code)
+	}
+
+	conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+// serve writes queued messages and keepalive pings until the connection fails (error) or the client is
+// closed (nil, after flushing the queue and disconnecting)
+func (c *Client) serve(conn net.Conn) error {
+	// The broker's messages (PINGRESP) are only read to notice a dead connection
+	lost := make(chan error, 1)
+	go func() {
+		reader := bufio.NewReader(conn)
+		for {
+			if _, _, err := readPacket(reader); err != nil {
+				lost <- err
+				return
+			}
+		}
+	}()
+
+	write := func(packet []byte) error {
+		conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		_, err := conn.Write(packet)
+		return err
+	}
+
+	// Restore the retained status topics, which may have changed while disconnected
+	c.mu.Lock()
+	retained := make([]message, 0, len(c.retained))
+	for _, msg := range c.retained {
+		retained = append(retained, msg)
+	}
+	c.mu.Unlock()
+	for _, msg := range retained {
+		if err := write(publishPacket(msg)); err != nil {
+			return err
+		}
+	}
+
+	keepAlive := time.NewTicker(c.config.KeepAlive / 2)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case msg := <-c.queue:
+			if err := write(publishPacket(msg)); err != nil {
+				return err
+			}
+		case <-keepAlive.C:
+			if err := write([]byte{packetPingReq, 0}); err != nil {
+				return err
+			}
+		case err := <-lost:
+			return err
+		case <-c.done:
+		flush:
+			for {
+				select {
+				case msg := <-c.queue:
+					if err := write(publishPacket(msg)); err != nil {
+						return nil
+					}
+				default:
+					break flush
+				}
+			}
+			write([]byte{packetDisconnect, 0})
+			debugMsg("MQTT", fmt.Sprintf("📴 Disconnected from MQTT broker %s", c.address))
+			return nil
+		}
+	}
+}
+
+// connectPacket builds the CONNECT packet
+func (c *Client) connectPacket() []byte {
+	flags := byte(0x02) // Clean session
+	if c.config.WillTopic != "" {
+		flags |= 0x04
+		if c.config.WillRetain {
+			flags |= 0x20
+		}
+	}
+	if c.hasAuth {
+		flags |= 0x80
+		if c.password != "" {
+			flags |= 0x40
+		}
+	}
+
+	var body []byte
+	body = appendString(body, "MQTT")
+	body = append(body, 4, flags) // Protocol level 4 = MQTT 3.1.1
+	body = binary.BigEndian.AppendUint16(body, uint16(c.config.KeepAlive/time.Second))
+	body = appendString(body, c.config.ClientID)
+	if c.config.WillTopic != "" {
+		body = appendString(body, c.config.WillTopic)
+		body = appendBytes(body, c.config.WillPayload)
+	}
+	if c.hasAuth {
+		body = appendString(body, c.username)
+		if c.password != "" {
+			body = appendString(body, c.password)
+		}
+	}
+	return packet(packetConnect, body)
+}
+
+// publishPacket builds a QoS 0 PUBLISH packet
+func publishPacket(msg message) []byte {
+	header := byte(packetPublish)
+	if msg.retain {
+		header |= 0x01
+	}
+	body := appendString(nil, msg.topic)
+	body = append(body, msg.payload...)
+	return packet(header, body)
+}
+
+// packet prefixes a body with the fixed header and its variable-length remaining length
+func packet(header byte, body []byte) []byte {
+	out := []byte{header}
+	length := len(body)
+	for {
+		digit := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			digit |= 0x80
+		}
+		out = append(out, digit)
+		if length == 0 {
+			break
+		}
+	}
+	return append(out, body...)
+}
+
+func appendString(out []byte, s string) []byte {
+	return appendBytes(out, []byte(s))
+}
+
+func appendBytes(out, b []byte) []byte {
+	out = binary.BigEndian.AppendUint16(out, uint16(len(b)))
+	return append(out, b...)
+}
+
+// readPacket reads one control packet and returns its type and body
+func readPacket(reader *bufio.Reader) (byte, []byte, error) {
+	header, err := reader.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		if i == 4 {
+			return 0, nil, fmt.Errorf("malformed remaining length")
+		}
+		digit, err := reader.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length += int(digit&0x7F) * multiplier
+		multiplier *= 128
+		if digit&0x80 == 0 {
+			break
+		}
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(reader, body); err != nil {
+		return 0, nil, err
+	}
+	return header & 0xF0, body, nil
+}
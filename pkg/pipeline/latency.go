@@ -0,0 +1,116 @@
+package pipeline
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Latency stages, each measured from the moment the frame was captured
+const (
+	StageDetect  = "detect"  // Detections for the frame are available
+	StageTrack   = "track"   // Tracking has been updated with them
+	StageCommand = "command" // A PTZ command decided on the frame was sent
+	StageOutput  = "output"  // The annotated frame was handed to the encoder
+
+	// StageSync is not measured from capture: it is how much older the image the overlay's detections came
+	// from is than the frame the overlay is drawn on (sync drift)
+	StageSync = "sync"
+)
+
+// latencyStages is the order stages are reported in
+var latencyStages = []string{StageDetect, StageTrack, StageCommand, StageOutput, StageSync}
+
+// DefaultLatencyWindow is how many recent frames the percentiles of each stage cover
+const DefaultLatencyWindow = 300
+
+// LatencyPercentiles summarizes the recent latencies of one stage
+type LatencyPercentiles struct {
+	Stage   string  `json:"stage"`
+	Samples int     `json:"samples"`
+	P50     float64 `json:"p50_ms"`
+	P90     float64 `json:"p90_ms"`
+	P99     float64 `json:"p99_ms"`
+	Max     float64 `json:"max_ms"`
+}
+
+func (p LatencyPercentiles) String() string {
+	return fmt.Sprintf("capture→%-7s p50 %6.0fms  p90 %6.0fms  p99 %6.0fms  max %6.0fms (%d frames)",
+		p.Stage, p.P50, p.P90, p.P99, p.Max, p.Samples)
+}
+
+// LatencyTracker keeps a rolling window of per-stage latencies
+type LatencyTracker struct {
+	mu      sync.Mutex
+	window  int
+	samples map[string][]time.Duration // Ring buffer per stage
+	next    map[string]int
+}
+
+// NewLatencyTracker creates a tracker keeping the last window samples of every stage
+func NewLatencyTracker(window int) *LatencyTracker {
+	if window <= 0 {
+		window = DefaultLatencyWindow
+	}
+	return &LatencyTracker{
+		window:  window,
+		samples: make(map[string][]time.Duration),
+		next:    make(map[string]int),
+	}
+}
+
+// Observe records how long after capture a frame reached a stage
+func (t *LatencyTracker) Observe(stage string, latency time.Duration) {
+	if t == nil || latency < 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	samples := t.samples[stage]
+	if len(samples) < t.window {
+		t.samples[stage] = append(samples, latency)
+		return
+	}
+	samples[t.next[stage]] = latency
+	t.next[stage] = (t.next[stage] + 1) % t.window
+}
+
+// Percentiles returns the recent latency percentiles of a stage (false before its first sample)
+func (t *LatencyTracker) Percentiles(stage string) (LatencyPercentiles, bool) {
+	if t == nil {
+		return LatencyPercentiles{}, false
+	}
+	t.mu.Lock()
+	sorted := append([]time.Duration(nil), t.samples[stage]...)
+	t.mu.Unlock()
+	if len(sorted) == 0 {
+		return LatencyPercentiles{}, false
+	}
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	at := func(percent int) float64 {
+		index := (len(sorted)*percent+99)/100 - 1
+		return float64(sorted[max(index, 0)]) / float64(time.Millisecond)
+	}
+	return LatencyPercentiles{
+		Stage:   stage,
+		Samples: len(sorted),
+		P50:     at(50),
+		P90:     at(90),
+		P99:     at(99),
+		Max:     at(100),
+	}, true
+}
+
+// Snapshot returns the percentiles of every stage measured so far, in pipeline order
+func (t *LatencyTracker) Snapshot() []LatencyPercentiles {
+	snapshot := []LatencyPercentiles{}
+	for _, stage := range latencyStages {
+		if p, ok := t.Percentiles(stage); ok {
+			snapshot = append(snapshot, p)
+		}
+	}
+	return snapshot
+}
@@ -0,0 +1,231 @@
+// Package pipeline provides bounded queues with explicit drop policies and backpressure metrics
+// for the hand-off points between NOLO's processing stages.
+//
+// Stages run capture → detect/track/overlay → output → reorder buffer → FFmpeg. Drop policy per boundary:
+//
+//   - capture queue: DropOldest, 2 frames deep. The camera read loop never blocks; when processing falls
+//     behind, the stalest queued frame is discarded so detection and tracking always act on the freshest
+//     frame. A deep queue with DropNewest (-capture-drop=newest) instead keeps the queued frames and
+//     drains completely past its flush threshold to jump back to live time.
+//   - detect, track and overlay run in lockstep on one goroutine, so there is no queue (and nothing to
+//     grow) between them; their cost shows up as capture queue drops.
+//   - output queue: DropNewest. A stalled FFmpeg never blocks processing; encoded frames are dropped.
+//   - reorder buffer: DropOldest. Out-of-order frames waiting for a missing sequence number are
+//     evicted oldest-first so the stream keeps moving.
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DropPolicy decides what happens when an item is pushed onto a full queue
+type DropPolicy int
+
+const (
+	DropNewest DropPolicy = iota // Reject the item being pushed (producer never blocks)
+	DropOldest                   // Evict the oldest queued item to make room
+	Block                        // Wait for space (true backpressure onto the producer)
+)
+
+// ParseDropPolicy parses a policy name as printed by String, with or without the "drop-" prefix
+func ParseDropPolicy(name string) (DropPolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "drop-newest", "newest":
+		return DropNewest, nil
+	case "drop-oldest", "oldest":
+		return DropOldest, nil
+	case "block":
+		return Block, nil
+	default:
+		return DropNewest, fmt.Errorf("unknown drop policy %q (valid policies are: oldest, newest, block)", name)
+	}
+}
+
+func (p DropPolicy) String() string {
+	switch p {
+	case DropNewest:
+		return "drop-newest"
+	case DropOldest:
+		return "drop-oldest"
+	case Block:
+		return "block"
+	default:
+		return "unknown"
+	}
+}
+
+// Queue is a bounded FIFO between two pipeline stages
+type Queue[T any] struct {
+	name   string
+	ch     chan T
+	policy DropPolicy
+	onDrop func(T) // Releases resources held by a dropped item (e.g. closes a gocv.Mat)
+
+	enqueued  atomic.Int64
+	dropped   atomic.Int64
+	flushed   atomic.Int64
+	highWater atomic.Int64
+	blocked   atomic.Int64 // Total nanoseconds producers spent blocked (Block policy)
+
+	closeOnce sync.Once
+}
+
+// QueueStats is a snapshot of a queue's backpressure metrics
+type QueueStats struct {
+	Name        string
+	Policy      string
+	Len         int
+	Cap         int
+	Enqueued    int64
+	Dropped     int64
+	Flushed     int64
+	HighWater   int64
+	BlockedTime time.Duration
+}
+
+// NewQueue creates a bounded queue. onDrop may be nil.
+func NewQueue[T any](name string, capacity int, policy DropPolicy, onDrop func(T)) *Queue[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &Queue[T]{
+		name:   name,
+		ch:     make(chan T, capacity),
+		policy: policy,
+		onDrop: onDrop,
+	}
+}
+
+// Push enqueues an item according to the queue's drop policy.
+// Returns false if the pushed item itself was dropped.
+func (q *Queue[T]) Push(item T) bool {
+	select {
+	case q.ch <- item:
+		q.accepted()
+		return true
+	default:
+	}
+
+	switch q.policy {
+	case DropOldest:
+		for {
+			select {
+			case oldest := <-q.ch:
+				q.drop(oldest)
+			default:
+			}
+			select {
+			case q.ch <- item:
+				q.accepted()
+				return true
+			default:
+				// Another producer took the freed slot - evict again
+			}
+		}
+	case Block:
+		blockStart := time.Now()
+		q.ch <- item
+		q.blocked.Add(int64(time.Since(blockStart)))
+		q.accepted()
+		return true
+	default:
+		q.drop(item)
+		return false
+	}
+}
+
+// Policy returns the queue's drop policy
+func (q *Queue[T]) Policy() DropPolicy {
+	return q.policy
+}
+
+// C returns the channel consumers receive from
+func (q *Queue[T]) C() <-chan T {
+	return q.ch
+}
+
+// Flush drops every queued item (used to jump back to live time) and returns how many were dropped
+func (q *Queue[T]) Flush() int {
+	flushed := 0
+	for {
+		select {
+		case item := <-q.ch:
+			if q.onDrop != nil {
+				q.onDrop(item)
+			}
+			flushed++
+		default:
+			q.flushed.Add(int64(flushed))
+			return flushed
+		}
+	}
+}
+
+// Len returns the number of queued items
+func (q *Queue[T]) Len() int {
+	return len(q.ch)
+}
+
+// Cap returns the queue capacity
+func (q *Queue[T]) Cap() int {
+	return cap(q.ch)
+}
+
+// Level returns queue fill as a fraction (0-1)
+func (q *Queue[T]) Level() float64 {
+	return float64(len(q.ch)) / float64(cap(q.ch))
+}
+
+// Close closes the queue channel; consumers ranging over C() will finish
+func (q *Queue[T]) Close() {
+	q.closeOnce.Do(func() { close(q.ch) })
+}
+
+// Stats returns a snapshot of the queue's metrics
+func (q *Queue[T]) Stats() QueueStats {
+	return QueueStats{
+		Name:        q.name,
+		Policy:      q.policy.String(),
+		Len:         len(q.ch),
+		Cap:         cap(q.ch),
+		Enqueued:    q.enqueued.Load(),
+		Dropped:     q.dropped.Load(),
+		Flushed:     q.flushed.Load(),
+		HighWater:   q.highWater.Load(),
+		BlockedTime: time.Duration(q.blocked.Load()),
+	}
+}
+
+// String formats stats for PERF log lines
+func (s QueueStats) String() string {
+	level := 0.0
+	if s.Cap > 0 {
+		level = float64(s.Len) / float64(s.Cap) * 100
+	}
+	return fmt.Sprintf("%s %d/%d (%.0f%%, peak %d) in:%d drop:%d flush:%d [%s]",
+		s.Name, s.Len, s.Cap, level, s.HighWater, s.Enqueued, s.Dropped, s.Flushed, s.Policy)
+}
+
+// accepted updates enqueue counters and the high-water mark
+func (q *Queue[T]) accepted() {
+	q.enqueued.Add(1)
+	depth := int64(len(q.ch))
+	for {
+		peak := q.highWater.Load()
+		if depth <= peak || q.highWater.CompareAndSwap(peak, depth) {
+			return
+		}
+	}
+}
+
+// drop counts and releases a dropped item
+func (q *Queue[T]) drop(item T) {
+	q.dropped.Add(1)
+	if q.onDrop != nil {
+		q.onDrop(item)
+	}
+}
@@ -0,0 +1,408 @@
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"rivercam/pkg/eventbus"
+)
+
+// Engine timing
+const (
+	reloadInterval = 2 * time.Second  // How often the rules file is checked for changes
+	actionTimeout  = 10 * time.Second // Webhook request timeout
+)
+
+// Object is the state of a tracked object that rules are evaluated against
+type Object struct {
+	ID         string  `json:"id"`
+	Class      string  `json:"class"`
+	Locked     bool    `json:"locked"`
+	People     int     `json:"people"`
+	Pan        float64 `json:"pan"`
+	Tilt       float64 `json:"tilt"`
+	SpeedKnots float64 `json:"speed_knots,omitempty"` // 0 when the speed is not known
+}
+
+// Alert is a rule that fired, as sent to webhooks, MQTT and email
+type Alert struct {
+	Rule    string          `json:"rule"`
+	Time    time.Time       `json:"time"`
+	Message string          `json:"message"`
+	Object  *Object         `json:"object,omitempty"`
+	Event   *eventbus.Event `json:"event,omitempty"`
+}
+
+// Hooks carry out the actions that need the rest of NOLO
+type Hooks struct {
+	Publish func(topic string, payload []byte) error        // mqtt actions; nil when no broker is configured
+	Record  func(alert Alert, duration time.Duration) error // record actions
+}
+
+// alertKey identifies the alert state of one rule for one object ("" for events without an object)
+type alertKey struct {
+	rule     string
+	objectID string
+}
+
+// trackedObject is the latest state of an object and when its current lock started
+type trackedObject struct {
+	Object
+	lockedSince time.Time
+}
+
+// Engine evaluates the rules of a rules file against tracking events and object updates
+type Engine struct {
+	path   string
+	hooks  Hooks
+	client *http.Client
+
+	mu        sync.Mutex
+	config    *Config
+	modTime   time.Time
+	objects   map[string]*trackedObject
+	active    map[alertKey]bool // Object rules that currently hold (fire again only after they stopped holding)
+	lastFired map[alertKey]time.Time
+	allowed   map[string]bool // Rules the behavior schedule lets fire (nil = every enabled rule)
+}
+
+// NewEngine loads the rules file; the engine re-reads it on changes once Watch runs
+func NewEngine(path string, hooks Hooks) (*Engine, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read rules: %v", err)
+	}
+	config, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Engine{
+		path:      path,
+		hooks:     hooks,
+		client:    &http.Client{Timeout: actionTimeout},
+		config:    config,
+		modTime:   info.ModTime(),
+		objects:   make(map[string]*trackedObject),
+		active:    make(map[alertKey]bool),
+		lastFired: make(map[alertKey]time.Time),
+	}
+	debugMsg("RULES", fmt.Sprintf("📜 Loaded %d alerting rule(s) from %s", len(config.Rules), path))
+	return e, nil
+}
+
+// Watch reloads the rules whenever the file changes, until stop is closed. A file that does not load keeps
+// the previous rules in effect.
+func (e *Engine) Watch(stop <-chan struct{}) {
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			info, err := os.Stat(e.path)
+			if err != nil {
+				continue // Editors replace files by rename; the next tick sees the new one
+			}
+			e.mu.Lock()
+			changed := !info.ModTime().Equal(e.modTime)
+			e.modTime = info.ModTime()
+			e.mu.Unlock()
+			if changed {
+				e.reload()
+			}
+		}
+	}
+}
+
+// reload swaps in the rules file and forgets the alert state of rules that are gone
+func (e *Engine) reload() {
+	config, err := Load(e.path)
+	if err != nil {
+		debugMsg("RULES", fmt.Sprintf("❌ Rules not reloaded, keeping the previous rules: %v", err))
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.config = config
+	names := make(map[string]bool)
+	for _, rule := range config.Rules {
+		names[rule.Name] = true
+	}
+	for key := range e.active {
+		if !names[key.rule] {
+			delete(e.active, key)
+		}
+	}
+	for key := range e.lastFired {
+		if !names[key.rule] {
+			delete(e.lastFired, key)
+		}
+	}
+	debugMsg("RULES", fmt.Sprintf("🔁 Reloaded %d alerting rule(s) from %s", len(config.Rules), e.path))
+}
+
+// SetActiveRules limits alerting to the named rules, for time windows that need other alerts than the rest of
+// the day; nil makes every enabled rule active again. Names not in the rules file are ignored.
+func (e *Engine) SetActiveRules(names []string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if names == nil {
+		e.allowed = nil
+		return
+	}
+	e.allowed = make(map[string]bool, len(names))
+	for _, name := range names {
+		e.allowed[name] = true
+	}
+	for key := range e.active {
+		if !e.allowed[key.rule] {
+			delete(e.active, key)
+		}
+	}
+}
+
+// inactive reports whether a rule may not fire now. Must be called with e.mu held.
+func (e *Engine) inactive(rule *Rule) bool {
+	return rule.Disabled || (e.allowed != nil && !e.allowed[rule.Name])
+}
+
+// HandleEvent fires the event rules matching a tracking event
+func (e *Engine) HandleEvent(event eventbus.Event) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := event.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	for i := range e.config.Rules {
+		rule := &e.config.Rules[i]
+		if e.inactive(rule) || rule.Event != event.Type {
+			continue
+		}
+		object := e.objects[event.ObjectID]
+		if rule.hasObjectConditions() && (object == nil || !rule.matches(object, now)) {
+			continue
+		}
+		e.fire(rule, event.ObjectID, object, &event, now)
+	}
+}
+
+// Update replaces the tracked objects with their latest state and fires the object rules that started to hold
+func (e *Engine) Update(objects []Object, now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	current := make(map[string]*trackedObject, len(objects))
+	for _, object := range objects {
+		tracked := &trackedObject{Object: object}
+		if previous, ok := e.objects[object.ID]; ok && previous.Locked {
+			tracked.lockedSince = previous.lockedSince
+		}
+		if object.Locked && tracked.lockedSince.IsZero() {
+			tracked.lockedSince = now
+		}
+		current[object.ID] = tracked
+	}
+	e.objects = current
+
+	for key := range e.active {
+		if _, ok := current[key.objectID]; !ok {
+			delete(e.active, key)
+		}
+	}
+	cooldowns := make(map[string]float64, len(e.config.Rules))
+	for _, rule := range e.config.Rules {
+		cooldowns[rule.Name] = rule.CooldownSeconds
+	}
+	for key, fired := range e.lastFired {
+		// Cooldowns outlast the object, so a briefly lost and re-created track doesn't alert again
+		if _, ok := current[key.objectID]; !ok && now.Sub(fired).Seconds() >= cooldowns[key.rule] {
+			delete(e.lastFired, key)
+		}
+	}
+
+	for i := range e.config.Rules {
+		rule := &e.config.Rules[i]
+		if e.inactive(rule) || rule.Event != "" {
+			continue
+		}
+		for id, object := range current {
+			key := alertKey{rule: rule.Name, objectID: id}
+			if !rule.matches(object, now) {
+				delete(e.active, key)
+				continue
+			}
+			if !e.active[key] {
+				e.active[key] = true
+				e.fire(rule, id, object, nil, now)
+			}
+		}
+	}
+}
+
+// matches checks the rule's object conditions
+func (r *Rule) matches(object *trackedObject, now time.Time) bool {
+	if len(r.Classes) > 0 {
+		found := false
+		for _, class := range r.Classes {
+			if strings.EqualFold(class, object.Class) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if r.MinPeople > 0 && object.People < r.MinPeople {
+		return false
+	}
+	if r.LockedForSeconds > 0 && (!object.Locked || now.Sub(object.lockedSince).Seconds() <= r.LockedForSeconds) {
+		return false
+	}
+	if r.Zone != nil && !r.Zone.Contains(object.Pan, object.Tilt) {
+		return false
+	}
+	if r.MinSpeedKnots > 0 && object.SpeedKnots <= r.MinSpeedKnots {
+		return false
+	}
+	return true
+}
+
+// fire raises an alert unless the rule is cooling down for the object. Must be called with e.mu held.
+func (e *Engine) fire(rule *Rule, objectID string, object *trackedObject, event *eventbus.Event, now time.Time) {
+	key := alertKey{rule: rule.Name, objectID: objectID}
+	if last, ok := e.lastFired[key]; ok && now.Sub(last).Seconds() < rule.CooldownSeconds {
+		return
+	}
+	e.lastFired[key] = now
+
+	alert := Alert{Rule: rule.Name, Time: now, Event: event}
+	if object != nil {
+		snapshot := object.Object
+		alert.Object = &snapshot
+	}
+	alert.Message = describe(rule, object, event, now)
+	debugMsg("RULE_ALERT", fmt.Sprintf("🔔 %s", alert.Message), objectID)
+
+	smtpConfig := e.config.SMTP
+	for _, action := range rule.Actions {
+		go e.run(action, alert, smtpConfig)
+	}
+}
+
+// describe summarizes why a rule fired
+func describe(rule *Rule, object *trackedObject, event *eventbus.Event, now time.Time) string {
+	message := fmt.Sprintf("Rule %q", rule.Name)
+	if event != nil {
+		message += fmt.Sprintf(" on %s", event.Type)
+	}
+	if object == nil {
+		if event != nil && event.Message != "" {
+			message += ": " + event.Message
+		}
+		return message
+	}
+
+	details := []string{fmt.Sprintf("%d people", object.People)}
+	if object.Locked {
+		details = append(details, fmt.Sprintf("locked %.0fs", now.Sub(object.lockedSince).Seconds()))
+	}
+	if object.SpeedKnots > 0 {
+		details = append(details, fmt.Sprintf("%.1f kn", object.SpeedKnots))
+	}
+	message += fmt.Sprintf(": %s %s (%s) at pan=%.0f tilt=%.0f", object.Class, object.ID, strings.Join(details, ", "), object.Pan, object.Tilt)
+	if rule.Zone != nil && rule.Zone.Name != "" {
+		message += " in " + rule.Zone.Name
+	}
+	return message
+}
+
+// run carries out one action of a fired rule
+func (e *Engine) run(action Action, alert Alert, smtpConfig *SMTPConfig) {
+	payload, err := json.Marshal(alert)
+	if err != nil {
+		debugMsg("RULES", fmt.Sprintf("❌ Could not encode alert %q: %v", alert.Rule, err))
+		return
+	}
+
+	switch action.Type {
+	case ActionWebhook:
+		err = e.postWebhook(action.URL, payload)
+	case ActionMQTT:
+		if e.hooks.Publish == nil {
+			err = fmt.Errorf("no MQTT broker configured (-mqtt-broker)")
+		} else {
+			err = e.hooks.Publish(action.Topic, payload)
+		}
+	case ActionEmail:
+		err = sendEmail(smtpConfig, action, alert, payload)
+	case ActionRecord:
+		seconds := action.DurationSeconds
+		if seconds == 0 {
+			seconds = DefaultRecordSeconds
+		}
+		if e.hooks.Record == nil {
+			err = fmt.Errorf("recording is not available")
+		} else {
+			err = e.hooks.Record(alert, time.Duration(seconds*float64(time.Second)))
+		}
+	}
+
+	if err != nil {
+		debugMsg("RULES", fmt.Sprintf("❌ %s action of rule %q failed: %v", action.Type, alert.Rule, err))
+		return
+	}
+	debugMsg("RULES", fmt.Sprintf("📨 %s action of rule %q done", action.Type, alert.Rule))
+}
+
+func (e *Engine) postWebhook(url string, payload []byte) error {
+	resp, err := e.client.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// sendEmail mails the alert as plain text with the alert JSON at the end
+func sendEmail(config *SMTPConfig, action Action, alert Alert, payload []byte) error {
+	subject := action.Subject
+	if subject == "" {
+		subject = "NOLO alert: " + alert.Rule
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "From: %s\r\n", config.From)
+	fmt.Fprintf(&body, "To: %s\r\n", strings.Join(action.To, ", "))
+	fmt.Fprintf(&body, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&body, "Date: %s\r\n", alert.Time.Format(time.RFC1123Z))
+	fmt.Fprintf(&body, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&body, "%s\r\n\r\n%s\r\n", alert.Message, payload)
+
+	var auth smtp.Auth
+	if config.Username != "" {
+		host, _, err := net.SplitHostPort(config.Server)
+		if err != nil {
+			host = config.Server
+		}
+		auth = smtp.PlainAuth("", config.Username, config.Password, host)
+	}
+	return smtp.SendMail(config.Server, auth, config.From, action.To, body.Bytes())
+}
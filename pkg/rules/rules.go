@@ -0,0 +1,186 @@
+// Package rules is NOLO's alerting rules engine. Operators describe conditions on tracking events and on the
+// tracked objects ("a boat with 3 or more people locked for over 30 seconds", "any object in the exclusion
+// zone", "faster than 8 knots") and the actions to take when one becomes true (webhook, MQTT message, email,
+// incident recording). Rules live in a JSON file that is re-read whenever it changes, so they can be edited
+// without restarting the camera.
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Global debug function for rules package
+var debugMsgFunc func(string, string, ...string)
+
+// SetDebugFunction allows main package to provide debug function
+func SetDebugFunction(fn func(string, string, ...string)) {
+	debugMsgFunc = fn
+}
+
+// debugMsg is a wrapper that handles nil checks
+func debugMsg(component, message string, boatID ...string) {
+	if debugMsgFunc != nil {
+		debugMsgFunc(component, message, boatID...)
+	}
+}
+
+// Action types
+const (
+	ActionWebhook = "webhook" // POST the alert as JSON to URL
+	ActionMQTT    = "mqtt"    // Publish the alert as JSON on Topic (default: [prefix]/alerts)
+	ActionEmail   = "email"   // Mail the alert to To through the file's SMTP server
+	ActionRecord  = "record"  // Record the annotated output for DurationSeconds
+)
+
+// DefaultRecordSeconds is how long a record action records when the rule doesn't say
+const DefaultRecordSeconds = 30
+
+// Config is the rules file
+type Config struct {
+	SMTP  *SMTPConfig `json:"smtp,omitempty"` // Mail server for email actions
+	Rules []Rule      `json:"rules"`
+}
+
+// SMTPConfig is the mail server email actions are sent through
+type SMTPConfig struct {
+	Server   string `json:"server"` // host:port, STARTTLS is used when the server offers it
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	From     string `json:"from"`
+}
+
+// Rule is a condition and the actions taken when it becomes true. A rule with an Event fires on every
+// matching tracking event (the object conditions, if any, are checked against the event's object); a rule
+// without one fires once per object when the object starts meeting all its conditions, and again only after
+// it stopped meeting them.
+type Rule struct {
+	Name     string `json:"name"`
+	Disabled bool   `json:"disabled,omitempty"`
+	Event    string `json:"event,omitempty"` // Tracking event type (lock_acquired, stream_lost, ...)
+
+	// Object conditions: every one that is set must hold
+	Classes          []string `json:"classes,omitempty"`            // Object class is one of these
+	MinPeople        int      `json:"min_people,omitempty"`         // At least this many people on board
+	LockedForSeconds float64  `json:"locked_for_seconds,omitempty"` // Locked continuously for longer than this
+	Zone             *Zone    `json:"zone,omitempty"`               // Object is inside this area
+	MinSpeedKnots    float64  `json:"min_speed_knots,omitempty"`    // Faster than this (needs speed estimation)
+
+	CooldownSeconds float64  `json:"cooldown_seconds,omitempty"` // Minimum time between alerts for the same object
+	Actions         []Action `json:"actions"`
+}
+
+// Zone is an area in spatial pan/tilt coordinates
+type Zone struct {
+	Name    string  `json:"name,omitempty"`
+	MinPan  float64 `json:"min_pan"`
+	MaxPan  float64 `json:"max_pan"`
+	MinTilt float64 `json:"min_tilt"`
+	MaxTilt float64 `json:"max_tilt"`
+}
+
+// Contains reports whether a pan/tilt position lies inside the zone
+func (z *Zone) Contains(pan, tilt float64) bool {
+	return pan >= z.MinPan && pan <= z.MaxPan && tilt >= z.MinTilt && tilt <= z.MaxTilt
+}
+
+// Action is something done when a rule fires
+type Action struct {
+	Type            string   `json:"type"`
+	URL             string   `json:"url,omitempty"`              // webhook
+	Topic           string   `json:"topic,omitempty"`            // mqtt
+	To              []string `json:"to,omitempty"`               // email
+	Subject         string   `json:"subject,omitempty"`          // email (default: "NOLO alert: [rule]")
+	DurationSeconds float64  `json:"duration_seconds,omitempty"` // record
+}
+
+// hasObjectConditions reports whether the rule looks at the tracked object at all
+func (r *Rule) hasObjectConditions() bool {
+	return len(r.Classes) > 0 || r.MinPeople > 0 || r.LockedForSeconds > 0 || r.Zone != nil || r.MinSpeedKnots > 0
+}
+
+// Load reads and validates a rules file
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read rules: %v", err)
+	}
+
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("could not parse rules %s: %v", path, err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return &config, nil
+}
+
+// Validate checks that every rule can be evaluated and every action carried out
+func (c *Config) Validate() error {
+	if c.SMTP != nil && (c.SMTP.Server == "" || c.SMTP.From == "") {
+		return fmt.Errorf("smtp needs a server and a from address")
+	}
+
+	seen := make(map[string]bool)
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d has no name", i+1)
+		}
+		if seen[rule.Name] {
+			return fmt.Errorf("duplicate rule %q", rule.Name)
+		}
+		seen[rule.Name] = true
+
+		if rule.Event == "" && !rule.hasObjectConditions() {
+			return fmt.Errorf("rule %q has neither an event nor an object condition", rule.Name)
+		}
+		if rule.MinPeople < 0 || rule.LockedForSeconds < 0 || rule.MinSpeedKnots < 0 || rule.CooldownSeconds < 0 {
+			return fmt.Errorf("rule %q: limits must not be negative", rule.Name)
+		}
+		if rule.Zone != nil && (rule.Zone.MinPan > rule.Zone.MaxPan || rule.Zone.MinTilt > rule.Zone.MaxTilt) {
+			return fmt.Errorf("rule %q: zone minimums must not exceed its maximums", rule.Name)
+		}
+		if len(rule.Actions) == 0 {
+			return fmt.Errorf("rule %q has no actions", rule.Name)
+		}
+		for _, action := range rule.Actions {
+			if err := c.validateAction(action); err != nil {
+				return fmt.Errorf("rule %q: %v", rule.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Config) validateAction(action Action) error {
+	switch action.Type {
+	case ActionWebhook:
+		parsed, err := url.Parse(action.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("webhook action needs an http(s) url, got %q", action.URL)
+		}
+	case ActionMQTT:
+		if strings.ContainsAny(action.Topic, "+#") {
+			return fmt.Errorf("mqtt topic %q must not contain wildcards", action.Topic)
+		}
+	case ActionEmail:
+		if len(action.To) == 0 {
+			return fmt.Errorf("email action needs recipients")
+		}
+		if c.SMTP == nil {
+			return fmt.Errorf("email action needs an smtp section in the rules file")
+		}
+	case ActionRecord:
+		if action.DurationSeconds < 0 {
+			return fmt.Errorf("record duration must not be negative")
+		}
+	default:
+		return fmt.Errorf("unknown action type %q (expected webhook, mqtt, email or record)", action.Type)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+// Package schedule changes NOLO's behavior by time of day and day of week. A schedule file lists entries, each
+// a local time window on some days of the week with the behavior to use during it: whether detections are
+// tracked or the camera only scans, the scan profile, the tracking zoom range, the alerting rules that may fire
+// and whether clips are recorded. The first entry containing the current time applies; outside every entry the
+// startup configuration does.
+package schedule
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"rivercam/pkg/daynight"
+)
+
+// Config is the schedule file
+type Config struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Entry is a time window and the behavior used during it. Unset behavior fields keep the startup configuration.
+type Entry struct {
+	Name   string   `json:"name"`
+	Days   []string `json:"days,omitempty"`   // mon ... sun, weekdays or weekends (default: every day)
+	Window string   `json:"window,omitempty"` // Local HH:MM-HH:MM; may wrap past midnight, the part after midnight belongs to the day it started (default: all day)
+
+	Tracking    *bool    `json:"tracking,omitempty"`     // false = scan only: detections start no tracks
+	ScanProfile string   `json:"scan_profile,omitempty"` // Scan profile from -scan-profiles
+	MinZoom     float64  `json:"min_zoom,omitempty"`     // Zoom policy: widest zoom used while tracking
+	MaxZoom     float64  `json:"max_zoom,omitempty"`     // Zoom policy: tightest zoom used while tracking
+	Rules       []string `json:"rules,omitempty"`        // Alerting rules that may fire (unset = all, [] = none)
+	Recording   *bool    `json:"recording,omitempty"`    // Locked-target clips and rule record actions
+
+	window *daynight.Schedule
+	days   [7]bool // By time.Weekday; no day set = every day
+}
+
+// dayNames maps the accepted day names onto weekdays
+var dayNames = map[string][]time.Weekday{
+	"mon":      {time.Monday},
+	"tue":      {time.Tuesday},
+	"wed":      {time.Wednesday},
+	"thu":      {time.Thursday},
+	"fri":      {time.Friday},
+	"sat":      {time.Saturday},
+	"sun":      {time.Sunday},
+	"weekdays": {time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	"weekends": {time.Saturday, time.Sunday},
+}
+
+// LoadConfig reads and validates a schedule file
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schedule file: %v", err)
+	}
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse schedule file %s: %v", path, err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return &config, nil
+}
+
+// Validate fills defaults and checks every entry
+func (c *Config) Validate() error {
+	if len(c.Entries) == 0 {
+		return fmt.Errorf("no schedule entries")
+	}
+	for i := range c.Entries {
+		entry := &c.Entries[i]
+		if entry.Name == "" {
+			entry.Name = fmt.Sprintf("entry %d", i+1)
+		}
+		entry.days = [7]bool{}
+		for _, day := range entry.Days {
+			weekdays, ok := dayNames[strings.ToLower(strings.TrimSpace(day))]
+			if !ok {
+				return fmt.Errorf("%s: unknown day %q (use mon ... sun, weekdays or weekends)", entry.Name, day)
+			}
+			for _, weekday := range weekdays {
+				entry.days[weekday] = true
+			}
+		}
+		entry.window = nil
+		if entry.Window != "" {
+			window, err := daynight.ParseSchedule(entry.Window)
+			if err != nil {
+				return fmt.Errorf("%s: %v", entry.Name, err)
+			}
+			entry.window = &window
+		}
+		if entry.MinZoom < 0 || entry.MaxZoom < 0 || (entry.MinZoom != 0 && entry.MaxZoom != 0 && entry.MinZoom > entry.MaxZoom) {
+			return fmt.Errorf("%s: min_zoom and max_zoom must be positive and in order, got %.0f-%.0f", entry.Name, entry.MinZoom, entry.MaxZoom)
+		}
+	}
+	return nil
+}
+
+// Active returns the first entry containing t, or nil when none does
+func (c *Config) Active(t time.Time) *Entry {
+	for i := range c.Entries {
+		if c.Entries[i].Contains(t) {
+			return &c.Entries[i]
+		}
+	}
+	return nil
+}
+
+// Contains reports whether t falls inside the entry's days and window
+func (e *Entry) Contains(t time.Time) bool {
+	if e.window == nil {
+		return e.onDay(t.Weekday())
+	}
+	if !e.window.Contains(t) {
+		return false
+	}
+	day := t.Weekday()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if e.window.Start > e.window.End && t.Sub(midnight) < e.window.End {
+		day = (day + 6) % 7 // After midnight in a window that started the day before
+	}
+	return e.onDay(day)
+}
+
+// onDay reports whether the entry runs on a weekday
+func (e *Entry) onDay(day time.Weekday) bool {
+	for _, set := range e.days {
+		if set {
+			return e.days[day]
+		}
+	}
+	return true
+}
+
+// Describe summarizes the behavior the entry selects, for the log
+func (e *Entry) Describe() string {
+	var parts []string
+	if e.Tracking != nil && !*e.Tracking {
+		parts = append(parts, "scan only")
+	} else if e.Tracking != nil {
+		parts = append(parts, "tracking")
+	}
+	if e.ScanProfile != "" {
+		parts = append(parts, fmt.Sprintf("scan profile '%s'", e.ScanProfile))
+	}
+	if e.MinZoom > 0 || e.MaxZoom > 0 {
+		parts = append(parts, fmt.Sprintf("zoom %s-%s", zoomBound(e.MinZoom), zoomBound(e.MaxZoom)))
+	}
+	if e.Rules != nil {
+		if len(e.Rules) == 0 {
+			parts = append(parts, "no alerts")
+		} else {
+			parts = append(parts, fmt.Sprintf("rules %s", strings.Join(e.Rules, ", ")))
+		}
+	}
+	if e.Recording != nil {
+		if *e.Recording {
+			parts = append(parts, "recording on")
+		} else {
+			parts = append(parts, "recording off")
+		}
+	}
+	if len(parts) == 0 {
+		return "startup configuration"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func zoomBound(zoom float64) string {
+	if zoom == 0 {
+		return "any"
+	}
+	return fmt.Sprintf("%.0f", zoom)
+}
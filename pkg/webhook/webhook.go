@@ -0,0 +1,344 @@
+// Package webhook POSTs tracking events to HTTP endpoints for integrations that don't speak MQTT (chat
+// bots, ticketing, serverless functions). Each configured hook picks the event types it wants and may shape
+// the JSON body with a Go text/template; deliveries are retried with exponential backoff and can be signed
+// with HMAC-SHA256 so the receiver can verify they came from NOLO.
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// Global debug function for webhook package
+var debugMsgFunc func(string, string, ...string)
+
+// SetDebugFunction allows main package to provide debug function
+func SetDebugFunction(fn func(string, string, ...string)) {
+	debugMsgFunc = fn
+}
+
+// debugMsg is a wrapper that handles nil checks
+func debugMsg(component, message string, boatID ...string) {
+	if debugMsgFunc != nil {
+		debugMsgFunc(component, message, boatID...)
+	}
+}
+
+// Delivery defaults
+const (
+	DefaultMaxAttempts = 5
+	DefaultQueueSize   = 64
+	requestTimeout     = 10 * time.Second
+	firstRetryDelay    = time.Second
+	maxRetryDelay      = time.Minute
+)
+
+// Signature headers: SignatureHeader is "sha256=" followed by the hex HMAC-SHA256 of TimestampHeader + "." +
+// body, keyed with the hook's secret. Receivers should reject old timestamps to stop replays.
+const (
+	SignatureHeader = "X-NOLO-Signature"
+	TimestampHeader = "X-NOLO-Timestamp"
+	EventHeader     = "X-NOLO-Event"
+)
+
+// DefaultEvents are the event types a hook receives when it doesn't list any: lock, loss and people on board
+var DefaultEvents = []string{"lock_acquired", "object_lost", "people_on_board"}
+
+// Config is the webhooks file
+type Config struct {
+	SnapshotBaseURL string `json:"snapshot_base_url,omitempty"` // Control API address receivers can reach, for snapshot links
+	Hooks           []Hook `json:"hooks"`
+}
+
+// Hook is one endpoint and the events POSTed to it
+type Hook struct {
+	Name        string            `json:"name"`
+	URL         string            `json:"url"`
+	Events      []string          `json:"events,omitempty"`       // Event types sent (default: DefaultEvents)
+	Template    string            `json:"template,omitempty"`     // text/template rendering the JSON body from a Payload (default: the Payload as JSON)
+	Secret      string            `json:"secret,omitempty"`       // HMAC-SHA256 signing key (empty = unsigned)
+	Headers     map[string]string `json:"headers,omitempty"`      // Extra request headers, e.g. an API token
+	MaxAttempts int               `json:"max_attempts,omitempty"` // Tries per event before it is dropped (default: DefaultMaxAttempts)
+}
+
+// Payload is what a webhook template is rendered from, and the default body
+type Payload struct {
+	Event          string    `json:"event"`
+	Time           time.Time `json:"time"`
+	ObjectID       string    `json:"object_id,omitempty"`
+	Classification string    `json:"classification,omitempty"`
+	Confidence     float64   `json:"confidence,omitempty"`
+	People         int       `json:"people"`
+	SpeedKnots     float64   `json:"speed_knots,omitempty"` // 0 without speed estimation
+	SnapshotURL    string    `json:"snapshot_url,omitempty"`
+	Pan            float64   `json:"pan"`
+	Tilt           float64   `json:"tilt"`
+	Message        string    `json:"message"`
+}
+
+// templateFuncs are available in hook templates; json encodes a value so strings are quoted and escaped
+var templateFuncs = template.FuncMap{
+	"json": func(value interface{}) (string, error) {
+		data, err := json.Marshal(value)
+		return string(data), err
+	},
+}
+
+// LoadConfig reads and validates a webhooks file
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhooks file: %v", err)
+	}
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse webhooks file %s: %v", path, err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return &config, nil
+}
+
+// Validate fills defaults and checks every hook
+func (c *Config) Validate() error {
+	if c.SnapshotBaseURL != "" {
+		if _, err := url.ParseRequestURI(c.SnapshotBaseURL); err != nil {
+			return fmt.Errorf("invalid snapshot_base_url: %v", err)
+		}
+		c.SnapshotBaseURL = strings.TrimRight(c.SnapshotBaseURL, "/")
+	}
+	if len(c.Hooks) == 0 {
+		return fmt.Errorf("no hooks configured")
+	}
+	for i := range c.Hooks {
+		hook := &c.Hooks[i]
+		if hook.Name == "" {
+			hook.Name = fmt.Sprintf("hook %d", i+1)
+		}
+		parsed, err := url.ParseRequestURI(hook.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return fmt.Errorf("%s: url must be an http:// or https:// URL, got %q", hook.Name, hook.URL)
+		}
+		if len(hook.Events) == 0 {
+			hook.Events = DefaultEvents
+		}
+		if hook.MaxAttempts == 0 {
+			hook.MaxAttempts = DefaultMaxAttempts
+		}
+		if hook.MaxAttempts < 1 {
+			return fmt.Errorf("%s: max_attempts must be at least 1, got %d", hook.Name, hook.MaxAttempts)
+		}
+		if hook.Template != "" {
+			if _, err := template.New(hook.Name).Funcs(templateFuncs).Parse(hook.Template); err != nil {
+				return fmt.Errorf("%s: invalid template: %v", hook.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// SnapshotURL is the control API address of an object's best frame, or "" without a snapshot base URL
+func (c *Config) SnapshotURL(objectID string) string {
+	if c.SnapshotBaseURL == "" || objectID == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/objects/%s/snapshot.jpg", c.SnapshotBaseURL, url.PathEscape(objectID))
+}
+
+// delivery is a rendered body waiting to be POSTed
+type delivery struct {
+	event string
+	body  []byte
+}
+
+// endpoint is a hook with its delivery queue
+type endpoint struct {
+	hook     Hook
+	events   map[string]bool
+	template *template.Template
+	queue    chan delivery
+}
+
+// Dispatcher renders events for every hook that wants them and delivers them in the background, one
+// worker per hook so a slow or failing endpoint doesn't hold up the others
+type Dispatcher struct {
+	endpoints []*endpoint
+	client    *http.Client
+	stop      chan struct{}
+	workers   sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// NewDispatcher prepares the hooks of a validated config
+func NewDispatcher(config *Config) *Dispatcher {
+	d := &Dispatcher{
+		client: &http.Client{Timeout: requestTimeout},
+		stop:   make(chan struct{}),
+	}
+	for _, hook := range config.Hooks {
+		e := &endpoint{hook: hook, events: make(map[string]bool), queue: make(chan delivery, DefaultQueueSize)}
+		for _, event := range hook.Events {
+			e.events[event] = true
+		}
+		if hook.Template != "" {
+			e.template = template.Must(template.New(hook.Name).Funcs(templateFuncs).Parse(hook.Template))
+		}
+		d.endpoints = append(d.endpoints, e)
+	}
+	return d
+}
+
+// Start starts the delivery workers
+func (d *Dispatcher) Start() {
+	for _, e := range d.endpoints {
+		d.workers.Add(1)
+		go d.deliver(e)
+	}
+}
+
+// Wants reports whether any hook receives this event type
+func (d *Dispatcher) Wants(event string) bool {
+	for _, e := range d.endpoints {
+		if e.events[event] {
+			return true
+		}
+	}
+	return false
+}
+
+// Send queues an event for every hook that receives its type. It never blocks: when a hook's queue is full
+// (the endpoint has been down for a while) the event is dropped for that hook.
+func (d *Dispatcher) Send(payload Payload) {
+	for _, e := range d.endpoints {
+		if !e.events[payload.Event] {
+			continue
+		}
+		body, err := e.render(payload)
+		if err != nil {
+			debugMsg("WEBHOOK", fmt.Sprintf("❌ %s: %v", e.hook.Name, err), payload.ObjectID)
+			continue
+		}
+		select {
+		case e.queue <- delivery{event: payload.Event, body: body}:
+		default:
+			debugMsg("WEBHOOK", fmt.Sprintf("⚠️ %s: queue full, dropping %s event", e.hook.Name, payload.Event), payload.ObjectID)
+		}
+	}
+}
+
+// render builds the request body, which must be valid JSON
+func (e *endpoint) render(payload Payload) ([]byte, error) {
+	if e.template == nil {
+		return json.Marshal(payload)
+	}
+	var body bytes.Buffer
+	if err := e.template.Execute(&body, payload); err != nil {
+		return nil, fmt.Errorf("template failed: %v", err)
+	}
+	if !json.Valid(body.Bytes()) {
+		return nil, fmt.Errorf("template did not produce valid JSON (quote strings with {{json .Field}})")
+	}
+	return body.Bytes(), nil
+}
+
+// deliver POSTs a hook's queued events in order, retrying each with exponential backoff
+func (d *Dispatcher) deliver(e *endpoint) {
+	defer d.workers.Done()
+	for {
+		select {
+		case item := <-e.queue:
+			d.deliverWithRetry(e, item)
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+// deliverWithRetry tries a delivery up to the hook's attempt limit. Client errors other than timeouts and
+// rate limiting are not retried: the same body would be rejected again.
+func (d *Dispatcher) deliverWithRetry(e *endpoint, item delivery) {
+	delay := firstRetryDelay
+	for attempt := 1; ; attempt++ {
+		status, err := d.post(e, item)
+		if err == nil {
+			debugMsg("WEBHOOK", fmt.Sprintf("📨 %s: %s delivered (%d)", e.hook.Name, item.event, status))
+			return
+		}
+		retryable := status == 0 || status >= 500 || status == http.StatusRequestTimeout || status == http.StatusTooManyRequests
+		if !retryable || attempt >= e.hook.MaxAttempts {
+			debugMsg("WEBHOOK", fmt.Sprintf("❌ %s: %s not delivered after %d attempt(s): %v", e.hook.Name, item.event, attempt, err))
+			return
+		}
+		debugMsg("WEBHOOK", fmt.Sprintf("⚠️ %s: %s attempt %d failed (%v), retrying in %v", e.hook.Name, item.event, attempt, err, delay))
+		select {
+		case <-time.After(delay):
+		case <-d.stop:
+			return
+		}
+		delay = min(delay*2, maxRetryDelay)
+	}
+}
+
+// post sends one request and returns the HTTP status (0 when no response was received)
+func (d *Dispatcher) post(e *endpoint, item delivery) (int, error) {
+	request, err := http.NewRequest(http.MethodPost, e.hook.URL, bytes.NewReader(item.body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "NOLO-webhook")
+	request.Header.Set(EventHeader, item.event)
+	for name, value := range e.hook.Headers {
+		request.Header.Set(name, value)
+	}
+	if e.hook.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		request.Header.Set(TimestampHeader, timestamp)
+		request.Header.Set(SignatureHeader, "sha256="+Sign(e.hook.Secret, timestamp, item.body))
+	}
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024)) // Lets the connection be reused
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("endpoint returned %s", response.Status)
+	}
+	return response.StatusCode, nil
+}
+
+// Sign is the hex HMAC-SHA256 of timestamp + "." + body, as sent in SignatureHeader
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Close stops the workers; events still queued or waiting for a retry are dropped
+func (d *Dispatcher) Close() {
+	if d == nil {
+		return
+	}
+	d.closeOnce.Do(func() {
+		close(d.stop)
+		d.workers.Wait()
+	})
+}
@@ -0,0 +1,181 @@
+package ptz
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Command sources recorded in the audit log
+const (
+	SourceTracking    = "tracking"    // Following a locked target
+	SourceScan        = "scan"        // Scan pattern, or the return to the home preset when idle
+	SourceRecovery    = "recovery"    // Searching for a lost target
+	SourceAPI         = "api"         // Control API (manual moves, presets)
+	SourceManual      = "manual"      // Joystick
+	SourceStartup     = "startup"     // Initial position
+	SourceCalibration = "calibration" // Calibration probe moves
+	SourceHandoff     = "handoff"     // Cue from another camera of the multi-camera handoff
+)
+
+// DefaultAuditHistory is how many recent commands the audit log keeps in memory for queries
+const DefaultAuditHistory = 1000
+
+// AuditEntry is one PTZ command and whether the camera took it
+type AuditEntry struct {
+	Time     time.Time `json:"time"`   // When the command was issued
+	Source   string    `json:"source"` // One of the Source constants
+	Command  string    `json:"command"`
+	Reason   string    `json:"reason,omitempty"`
+	Pan      *float64  `json:"pan,omitempty"` // Target position after the limits, for absolute moves
+	Tilt     *float64  `json:"tilt,omitempty"`
+	Zoom     *float64  `json:"zoom,omitempty"`
+	Clamped  bool      `json:"clamped,omitempty"` // The requested position was outside the limits
+	ObjectID string    `json:"object_id,omitempty"`
+	Accepted bool      `json:"accepted"`        // The camera accepted the command
+	Error    string    `json:"error,omitempty"` // Why it was not accepted
+}
+
+// AuditFilter selects audit entries; zero fields match everything
+type AuditFilter struct {
+	Source   string
+	ObjectID string
+	Since    time.Time
+	Limit    int // Most recent entries returned (0 = all kept)
+}
+
+// AuditLog records every PTZ command sent through the camera state manager as JSON lines appended to a file,
+// and keeps the most recent ones for queries. An entry is written once the camera has answered the command,
+// so entries are in completion order; Time is when the command was issued.
+type AuditLog struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+	recent  []AuditEntry // Ring of the last history entries
+	next    int
+	full    bool
+}
+
+// OpenAuditLog appends to the audit log file (empty path = memory only) and keeps the last history entries in
+// memory
+func OpenAuditLog(path string, history int) (*AuditLog, error) {
+	if history <= 0 {
+		history = DefaultAuditHistory
+	}
+	audit := &AuditLog{recent: make([]AuditEntry, history)}
+	if path == "" {
+		return audit, nil
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open PTZ audit log: %v", err)
+	}
+	audit.file, audit.encoder = file, json.NewEncoder(file)
+	return audit, nil
+}
+
+// Record appends an entry
+func (a *AuditLog) Record(entry AuditEntry) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.recent[a.next] = entry
+	a.next = (a.next + 1) % len(a.recent)
+	if a.next == 0 {
+		a.full = true
+	}
+	if a.file == nil {
+		return
+	}
+	if err := a.encoder.Encode(entry); err != nil {
+		debugMsg("PTZ_AUDIT", fmt.Sprintf("❌ Failed to write PTZ audit log: %v", err))
+	}
+}
+
+// BetweenWrites runs fn while no entry is being written, and reopens the log file when fn replaced it
+// (retention)
+func (a *AuditLog) BetweenWrites(fn func() bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !fn() || a.file == nil {
+		return
+	}
+	file, err := os.OpenFile(a.file.Name(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		debugMsg("PTZ_AUDIT", fmt.Sprintf("❌ Failed to reopen PTZ audit log: %v", err))
+		return
+	}
+	a.file.Close()
+	a.file, a.encoder = file, json.NewEncoder(file)
+}
+
+// Entries returns the kept entries matching the filter, oldest first
+func (a *AuditLog) Entries(filter AuditFilter) []AuditEntry {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	ordered := a.recent[:a.next]
+	if a.full {
+		ordered = append(append([]AuditEntry(nil), a.recent[a.next:]...), a.recent[:a.next]...)
+	}
+	entries := []AuditEntry{}
+	for _, entry := range ordered {
+		if (filter.Source != "" && entry.Source != filter.Source) ||
+			(filter.ObjectID != "" && entry.ObjectID != filter.ObjectID) ||
+			entry.Time.Before(filter.Since) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if filter.Limit > 0 && len(entries) > filter.Limit {
+		entries = entries[len(entries)-filter.Limit:]
+	}
+	return entries
+}
+
+// Close closes the log file; entries recorded afterwards are only kept in memory
+func (a *AuditLog) Close() error {
+	if a == nil {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.file == nil {
+		return nil
+	}
+	err := a.file.Close()
+	a.file = nil
+	return err
+}
+
+// audited returns cmd with its result reported to the audit log once the controller has run it
+func (a *AuditLog) audited(cmd PTZCommand, entry AuditEntry) PTZCommand {
+	if a == nil {
+		return cmd
+	}
+	cmd.done = func(err error) {
+		entry.Accepted = err == nil
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		a.Record(entry)
+	}
+	return cmd
+}
+
+// auditEntry starts the audit entry of a command issued at t
+func auditEntry(t time.Time, cmd PTZCommand) AuditEntry {
+	return AuditEntry{Time: t, Source: cmd.Source, Command: cmd.Command, Reason: cmd.Reason, ObjectID: cmd.ObjectID}
+}
+
+// finish reports the camera's answer to a command
+func (cmd PTZCommand) finish(err error) {
+	if cmd.done != nil {
+		cmd.done(err)
+	}
+}
@@ -58,6 +58,11 @@ type PTZLimits struct {
 	HardMaxTilt float64
 	HardMinZoom float64
 	HardMaxZoom float64
+
+	// Continuous pan/tilt speed limits the camera reports (0 = the default -100 to 100 range); zoom moves
+	// are absolute, so the zoom speed range does not apply
+	MaxPanSpeed  float64
+	MaxTiltSpeed float64
 }
 
 // CameraStateManager manages camera state and position tracking
@@ -85,6 +90,14 @@ type CameraStateManager struct {
 	// Settling delay - time to wait after arrival before transitioning to IDLE
 	settlingDelay time.Duration // Time to wait after position arrival before declaring IDLE
 	arrivalTime   time.Time     // When camera first arrived at target position
+
+	// Closed-loop correction - commands are pre-compensated and settle errors fed back (nil = open loop)
+	corrector     *PositionCorrector
+	sentPosition  *PTZPosition // Position actually sent to the camera (target minus correction)
+	startPosition PTZPosition  // Where the camera was when the command was sent
+	lastPolled    *PTZPosition // Position seen on the previous monitor tick
+
+	audit *AuditLog // Every command sent to the controller and the camera's answer (nil = not audited)
 }
 
 // NewCameraStateManager creates a new camera state manager
@@ -97,25 +110,7 @@ func NewCameraStateManager(controller Controller) *CameraStateManager {
 		maxCommandTime:  15 * time.Second,
 		rateLimitDelay:  100 * time.Millisecond, // 100ms rate limiting (faster response during idle periods)
 		settlingDelay:   100 * time.Millisecond, // 100ms settling delay after arrival
-
-		// Set default limits - software limits should match hardware limits unless user overrides
-		limits: PTZLimits{
-			// Software limits - default to full hardware range (user can restrict with flags)
-			SoftMinPan:  0,    // Same as hardware minimum - full range by default
-			SoftMaxPan:  3590, // Same as hardware maximum - full range by default
-			SoftMinTilt: 0,    // Same as hardware minimum - full range by default
-			SoftMaxTilt: 900,  // Same as hardware maximum - full range by default
-			SoftMinZoom: 10,   // Same as hardware minimum - full range by default
-			SoftMaxZoom: 120,  // Same as hardware maximum - full range by default
-
-			// Hardware limits - physical camera constraints (fallback)
-			HardMinPan:  0,    // Camera hardware minimum
-			HardMaxPan:  3590, // Camera hardware maximum
-			HardMinTilt: 0,    // Camera hardware minimum
-			HardMaxTilt: 900,  // Camera hardware maximum
-			HardMinZoom: 10,   // Camera hardware minimum
-			HardMaxZoom: 120,  // Camera hardware maximum
-		},
+		limits:          DefaultLimits(),        // Replaced by the camera's own range when it reports one
 	}
 
 	debugMsg("CAMERA_STATE", fmt.Sprintf("Initialized with software limits: Pan(%.0f-%.0f) Tilt(%.0f-%.0f) Zoom(%.0f-%.0f)",
@@ -132,6 +127,9 @@ func (csm *CameraStateManager) SetLimits(limits PTZLimits) {
 	defer csm.mutex.Unlock()
 
 	csm.limits = limits
+	if limiter, ok := csm.controller.(SpeedLimiter); ok {
+		limiter.SetSpeedLimits(limits.MaxPanSpeed, limits.MaxTiltSpeed)
+	}
 	debugMsg("CAMERA_STATE", fmt.Sprintf("Updated limits: Pan(%.0f-%.0f) Tilt(%.0f-%.0f) Zoom(%.0f-%.0f)",
 		limits.SoftMinPan, limits.SoftMaxPan,
 		limits.SoftMinTilt, limits.SoftMaxTilt,
@@ -170,6 +168,28 @@ func (csm *CameraStateManager) validateAndClampPosition(pan, tilt, zoom float64)
 	return pan, tilt, zoom, clamped
 }
 
+// SetCorrector enables closed-loop correction of absolute moves (nil returns to open loop)
+func (csm *CameraStateManager) SetCorrector(corrector *PositionCorrector) {
+	csm.mutex.Lock()
+	defer csm.mutex.Unlock()
+	csm.corrector = corrector
+}
+
+// GetCorrector returns the closed-loop corrector, nil when running open loop
+func (csm *CameraStateManager) GetCorrector() *PositionCorrector {
+	csm.mutex.RLock()
+	defer csm.mutex.RUnlock()
+	return csm.corrector
+}
+
+// SetAuditLog records every command sent to the camera in an audit log (nil stops auditing). Commands
+// refused by the rate limiter never reach the camera and are not recorded.
+func (csm *CameraStateManager) SetAuditLog(audit *AuditLog) {
+	csm.mutex.Lock()
+	defer csm.mutex.Unlock()
+	csm.audit = audit
+}
+
 // SetTolerances is deprecated - we use exact position matching now
 func (csm *CameraStateManager) SetTolerances(pan, tilt, zoom float64) {
 	// No-op - we don't use tolerances anymore
@@ -206,6 +226,18 @@ func (csm *CameraStateManager) IsMoving() bool {
 	return csm.GetState() == MOVING
 }
 
+// GetCurrentPosition returns the last position polled from the camera
+func (csm *CameraStateManager) GetCurrentPosition() PTZPosition {
+	return csm.controller.GetCurrentPosition()
+}
+
+// LastCommandTime returns when the last command was sent to the camera (zero before the first)
+func (csm *CameraStateManager) LastCommandTime() time.Time {
+	csm.mutex.RLock()
+	defer csm.mutex.RUnlock()
+	return csm.lastCommandTime
+}
+
 // GetTargetPosition returns the current target position (if any)
 func (csm *CameraStateManager) GetTargetPosition() *PTZPosition {
 	csm.mutex.RLock()
@@ -254,20 +286,44 @@ func (csm *CameraStateManager) SendCommand(cmd PTZCommand) bool {
 			Zoom: roundedZoom,
 		}
 
+		// Closed loop: aim off by the learned settle error so the camera lands on the target
+		sentPan, sentTilt, sentZoom := roundedPan, roundedTilt, roundedZoom
+		reason := cmd.Reason + " (validated & integer-rounded)"
+		if csm.corrector != nil {
+			correctedPan, correctedTilt, correctedZoom := csm.corrector.Correct(roundedPan, roundedTilt, roundedZoom)
+			correctedPan, correctedTilt, correctedZoom, _ = csm.validateAndClampPosition(correctedPan, correctedTilt, correctedZoom)
+			sentPan, sentTilt, sentZoom = math.Round(correctedPan), math.Round(correctedTilt), math.Round(correctedZoom)
+			if sentPan != roundedPan || sentTilt != roundedTilt || sentZoom != roundedZoom {
+				reason = cmd.Reason + fmt.Sprintf(" (closed-loop corrected %+.0f/%+.0f/%+.0f)",
+					sentPan-roundedPan, sentTilt-roundedTilt, sentZoom-roundedZoom)
+			}
+		}
+		csm.sentPosition = &PTZPosition{Pan: sentPan, Tilt: sentTilt, Zoom: sentZoom}
+		csm.startPosition = csm.controller.GetCurrentPosition()
+		csm.lastPolled = nil
+
 		// Create new command with validated and rounded values to send to camera
 		validatedCmd := PTZCommand{
 			Command:      cmd.Command,
-			Reason:       cmd.Reason + " (validated & integer-rounded)",
+			Reason:       reason,
 			Duration:     cmd.Duration,
-			AbsolutePan:  &roundedPan,
-			AbsoluteTilt: &roundedTilt,
-			AbsoluteZoom: &roundedZoom,
+			AbsolutePan:  &sentPan,
+			AbsoluteTilt: &sentTilt,
+			AbsoluteZoom: &sentZoom,
+			Source:       cmd.Source,
+			ObjectID:     cmd.ObjectID,
 		}
+		entry := auditEntry(now, cmd)
+		entry.Pan, entry.Tilt, entry.Zoom = &roundedPan, &roundedTilt, &roundedZoom
+		entry.Clamped = wasClamped
+		validatedCmd = csm.audit.audited(validatedCmd, entry)
 
 		// Send the validated command to PTZ controller
 		success := csm.controller.SendCommand(validatedCmd)
 		if !success {
 			debugMsg("CAMERA_STATE", fmt.Sprintf("Failed to send command %s to PTZ controller", cmd.Command))
+			entry.Error = "PTZ command queue full"
+			csm.audit.Record(entry)
 			return false
 		}
 
@@ -280,9 +336,12 @@ func (csm *CameraStateManager) SendCommand(cmd PTZCommand) bool {
 			csm.targetPosition.Pan, csm.targetPosition.Tilt, csm.targetPosition.Zoom))
 	} else {
 		// For non-absolute commands (relative movements), send directly
-		success := csm.controller.SendCommand(cmd)
+		entry := auditEntry(now, cmd)
+		success := csm.controller.SendCommand(csm.audit.audited(cmd, entry))
 		if !success {
 			debugMsg("CAMERA_STATE", fmt.Sprintf("Failed to send command %s to PTZ controller", cmd.Command))
+			entry.Error = "PTZ command queue full"
+			csm.audit.Record(entry)
 			return false
 		}
 
@@ -393,6 +452,19 @@ func (csm *CameraStateManager) checkArrival() {
 	// Get current position from camera
 	current := csm.controller.GetCurrentPosition()
 
+	// Closed loop: a camera that moved and then held still for a tick has settled, even if it is off target
+	previous := csm.lastPolled
+	csm.lastPolled = &current
+	if csm.corrector != nil && csm.sentPosition != nil && !csm.isAtTarget(current, *csm.targetPosition) &&
+		previous != nil && csm.isAtTarget(current, *previous) && !csm.isAtTarget(current, csm.startPosition) &&
+		now.Sub(csm.commandStartTime) >= correctionSettleTime {
+		debugMsg("CAMERA_STATE", fmt.Sprintf("📍 Camera settled off target at Pan=%.0f Tilt=%.0f Zoom=%.0f (target Pan=%.0f Tilt=%.0f Zoom=%.0f)",
+			current.Pan, current.Tilt, current.Zoom, csm.targetPosition.Pan, csm.targetPosition.Tilt, csm.targetPosition.Zoom))
+		csm.corrector.Observe(*csm.sentPosition, current)
+		csm.declareArrival()
+		return
+	}
+
 	// Check if we're at the target position
 	if csm.isAtTarget(current, *csm.targetPosition) {
 		// Camera is at target position
@@ -409,6 +481,9 @@ func (csm *CameraStateManager) checkArrival() {
 				if debugMsg != nil {
 					debugMsg("CAMERA_STATE", fmt.Sprintf("✅ Camera settled after %.0fms - transitioning to IDLE", settlingElapsed.Seconds()*1000))
 				}
+				if csm.corrector != nil && csm.sentPosition != nil {
+					csm.corrector.Observe(*csm.sentPosition, current)
+				}
 				csm.declareArrival()
 			} else {
 				// Still settling - show progress occasionally
@@ -446,6 +521,8 @@ func (csm *CameraStateManager) declareArrival() {
 
 	// Clear state
 	csm.targetPosition = nil
+	csm.sentPosition = nil
+	csm.lastPolled = nil
 	csm.commandStartTime = time.Time{}
 	csm.arrivalTime = time.Time{} // Clear arrival time for next movement cycle
 
@@ -0,0 +1,73 @@
+package ptz
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CameraCapabilities is what a camera reported supporting during the startup capability probe
+type CameraCapabilities struct {
+	Model          string          `json:"model"`
+	Firmware       string          `json:"firmware"`
+	AbsolutePTZ    bool            `json:"absolute_ptz"`    // Absolute pan/tilt/zoom positioning (required for tracking)
+	RegionExposure bool            `json:"region_exposure"` // BLC region metering (backlight metering)
+	StreamProfiles bool            `json:"stream_profiles"` // Input stream encoding readable (adaptive streaming)
+	MainStream     StreamProfile   `json:"main_stream"`
+	LinkKbps       float64         `json:"link_kbps"`                 // Measured snapshot download rate (0 = not measured)
+	HardwareLimits *HardwareLimits `json:"hardware_limits,omitempty"` // Absolute position ranges and move speeds (nil = not reported)
+}
+
+// CapabilityProbe defines cameras that can report their supported features
+type CapabilityProbe interface {
+	ProbeCapabilities(channelID string) (CameraCapabilities, error)
+}
+
+// ProbeCapabilities queries the camera's device information, PTZ, imaging and the input's streaming channel
+// (channelID, e.g. "101") and measures link throughput by downloading a snapshot of that channel. Only an
+// unreachable camera is an error; a feature whose resource fails is reported as unsupported.
+func (c *HikvisionController) ProbeCapabilities(channelID string) (CameraCapabilities, error) {
+	var caps CameraCapabilities
+
+	body, err := c.isapiRequest("GET", "/ISAPI/System/deviceInfo", "")
+	if err != nil {
+		return caps, fmt.Errorf("failed to read device info: %v", err)
+	}
+	if match := xmlTagValue("model").FindStringSubmatch(string(body)); match != nil {
+		caps.Model = match[1]
+	}
+	if match := xmlTagValue("firmwareVersion").FindStringSubmatch(string(body)); match != nil {
+		caps.Firmware = match[1]
+	}
+
+	if body, err := c.ptzCapabilities(); err == nil {
+		caps.AbsolutePTZ = strings.Contains(string(body), "AbsolutePanTiltPositionSpace") || strings.Contains(string(body), "AbsoluteHigh")
+		if limits, err := parseHardwareLimits(body); err == nil {
+			caps.HardwareLimits = &limits
+		}
+	} else {
+		// Older firmware lacks the capabilities document - a readable status means absolute positioning works
+		_, err := c.isapiRequest("GET", "/ISAPI/PTZCtrl/channels/1/status", "")
+		caps.AbsolutePTZ = err == nil
+	}
+
+	if _, err := c.isapiRequest("GET", "/ISAPI/Image/channels/1/BLC", ""); err == nil {
+		caps.RegionExposure = true
+	}
+
+	if profile, err := c.GetStreamProfile(channelID); err == nil {
+		caps.StreamProfiles = true
+		caps.MainStream = profile
+	}
+
+	start := time.Now()
+	if snapshot, err := c.isapiRequest("GET", streamingChannelURI(channelID)+"/picture", ""); err == nil && len(snapshot) > 0 {
+		if elapsed := time.Since(start).Seconds(); elapsed > 0 {
+			caps.LinkKbps = float64(len(snapshot)) * 8 / 1000 / elapsed
+		}
+	}
+
+	debugMsg("PTZ_CAPS", fmt.Sprintf("📋 Camera %s (firmware %s): absolute PTZ=%v, region exposure=%v, stream profiles=%v, link %.0f kbps",
+		caps.Model, caps.Firmware, caps.AbsolutePTZ, caps.RegionExposure, caps.StreamProfiles, caps.LinkKbps))
+	return caps, nil
+}
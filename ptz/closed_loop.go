@@ -0,0 +1,194 @@
+package ptz
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Closed-loop correction defaults
+const (
+	DefaultCorrectionAlpha      = 0.3 // EWMA weight of a new settle error
+	DefaultCorrectionMinSamples = 3   // Settled moves needed before corrections are applied
+	DefaultMaxCorrection        = 30  // Largest correction per axis (camera units)
+	correctionSettleTime        = time.Second
+	correctionOutlierFactor     = 3 // Errors beyond this many MaxCorrection are interrupted moves, not bias
+	panWrap                     = 3600
+)
+
+// AxisError is the learned settle error of one axis: where the camera ends up relative to where it was sent
+type AxisError struct {
+	Bias    float64 `json:"bias"`     // EWMA of actual - commanded (camera units)
+	MeanAbs float64 `json:"mean_abs"` // EWMA of |actual - commanded| before correction, for reporting
+	Samples int     `json:"samples"`
+}
+
+// observe folds one settle error into the axis model
+func (a *AxisError) observe(err, alpha float64) {
+	if a.Samples == 0 {
+		a.Bias = err
+		a.MeanAbs = math.Abs(err)
+	} else {
+		a.Bias += alpha * (err - a.Bias)
+		a.MeanAbs += alpha * (math.Abs(err) - a.MeanAbs)
+	}
+	a.Samples++
+}
+
+// ErrorModelFile is the on-disk form of a camera's error model
+type ErrorModelFile struct {
+	Camera string    `json:"camera,omitempty"`
+	Pan    AxisError `json:"pan"`
+	Tilt   AxisError `json:"tilt"`
+	Zoom   AxisError `json:"zoom"`
+	Saved  time.Time `json:"saved"`
+}
+
+// PositionCorrector closes the loop on absolute moves. After each move it compares the position the camera
+// settled at with the position it was sent to, keeps a per-axis model of that error, and pre-compensates
+// later commands so the camera lands where it was asked to. One corrector belongs to one camera.
+type PositionCorrector struct {
+	mu         sync.Mutex
+	camera     string
+	path       string
+	alpha      float64
+	minSamples int
+	maxCorr    float64
+	pan        AxisError
+	tilt       AxisError
+	zoom       AxisError
+	dirty      bool
+}
+
+// NewPositionCorrector creates a corrector for the named camera. With a path the model is loaded from it
+// (when present) and saved back by Save, so a restart does not have to relearn it.
+func NewPositionCorrector(camera, path string, maxCorrection float64) (*PositionCorrector, error) {
+	if maxCorrection <= 0 {
+		maxCorrection = DefaultMaxCorrection
+	}
+	pc := &PositionCorrector{
+		camera:     camera,
+		path:       path,
+		alpha:      DefaultCorrectionAlpha,
+		minSamples: DefaultCorrectionMinSamples,
+		maxCorr:    maxCorrection,
+	}
+	if path == "" {
+		return pc, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return pc, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read error model: %v", err)
+	}
+	var model ErrorModelFile
+	if err := json.Unmarshal(data, &model); err != nil {
+		return nil, fmt.Errorf("failed to parse error model %s: %v", path, err)
+	}
+	if model.Camera != "" && camera != "" && model.Camera != camera {
+		return nil, fmt.Errorf("error model %s belongs to camera %q, not %q", path, model.Camera, camera)
+	}
+	pc.pan, pc.tilt, pc.zoom = model.Pan, model.Tilt, model.Zoom
+	debugMsg("PTZ_CLOSED_LOOP", fmt.Sprintf("📐 Loaded error model from %s: bias Pan=%+.1f Tilt=%+.1f Zoom=%+.1f (%d moves)",
+		path, pc.pan.Bias, pc.tilt.Bias, pc.zoom.Bias, pc.pan.Samples))
+	return pc, nil
+}
+
+// Correct returns the command that should land the camera on the requested position: the request minus the
+// learned bias, each axis clamped to the maximum correction. Nothing changes until enough moves were seen.
+func (pc *PositionCorrector) Correct(pan, tilt, zoom float64) (float64, float64, float64) {
+	if pc == nil {
+		return pan, tilt, zoom
+	}
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	return pan - pc.offset(pc.pan), tilt - pc.offset(pc.tilt), zoom - pc.offset(pc.zoom)
+}
+
+// offset is the correction for one axis. Must be called with pc.mu held.
+func (pc *PositionCorrector) offset(axis AxisError) float64 {
+	if axis.Samples < pc.minSamples {
+		return 0
+	}
+	return math.Max(-pc.maxCorr, math.Min(pc.maxCorr, axis.Bias))
+}
+
+// Observe records where the camera settled after being sent to commanded. Moves that ended far from the
+// command (interrupted or blocked by a limit) are ignored.
+func (pc *PositionCorrector) Observe(commanded, actual PTZPosition) {
+	if pc == nil {
+		return
+	}
+	panErr := actual.Pan - commanded.Pan
+	if panErr > panWrap/2 {
+		panErr -= panWrap
+	} else if panErr < -panWrap/2 {
+		panErr += panWrap
+	}
+	tiltErr := actual.Tilt - commanded.Tilt
+	zoomErr := actual.Zoom - commanded.Zoom
+
+	outlier := pc.maxCorr * correctionOutlierFactor
+	if math.Abs(panErr) > outlier || math.Abs(tiltErr) > outlier || math.Abs(zoomErr) > outlier {
+		debugMsg("PTZ_CLOSED_LOOP", fmt.Sprintf("⚠️ Ignoring settle error Pan=%+.0f Tilt=%+.0f Zoom=%+.0f - move was interrupted",
+			panErr, tiltErr, zoomErr))
+		return
+	}
+
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	pc.pan.observe(panErr, pc.alpha)
+	pc.tilt.observe(tiltErr, pc.alpha)
+	pc.zoom.observe(zoomErr, pc.alpha)
+	pc.dirty = true
+
+	debugMsg("PTZ_CLOSED_LOOP", fmt.Sprintf("📐 Settle error Pan=%+.0f Tilt=%+.0f Zoom=%+.0f → bias Pan=%+.1f Tilt=%+.1f Zoom=%+.1f (%d moves)",
+		panErr, tiltErr, zoomErr, pc.pan.Bias, pc.tilt.Bias, pc.zoom.Bias, pc.pan.Samples))
+}
+
+// Model returns the current error model
+func (pc *PositionCorrector) Model() ErrorModelFile {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	return ErrorModelFile{Camera: pc.camera, Pan: pc.pan, Tilt: pc.tilt, Zoom: pc.zoom}
+}
+
+// Save writes the model atomically when it changed since the last save (no-op without a path)
+func (pc *PositionCorrector) Save() error {
+	if pc == nil || pc.path == "" {
+		return nil
+	}
+	pc.mu.Lock()
+	if !pc.dirty {
+		pc.mu.Unlock()
+		return nil
+	}
+	model := ErrorModelFile{Camera: pc.camera, Pan: pc.pan, Tilt: pc.tilt, Zoom: pc.zoom, Saved: time.Now()}
+	pc.dirty = false
+	pc.mu.Unlock()
+
+	data, err := json.MarshalIndent(model, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode error model: %v", err)
+	}
+	if dir := filepath.Dir(pc.path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create error model directory: %v", err)
+		}
+	}
+	tmpPath := pc.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write error model: %v", err)
+	}
+	if err := os.Rename(tmpPath, pc.path); err != nil {
+		return fmt.Errorf("failed to replace error model: %v", err)
+	}
+	return nil
+}
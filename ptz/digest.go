@@ -0,0 +1,265 @@
+package ptz
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// newPooledClient returns an HTTP client that keeps connections to the camera open between requests so
+// status polling and rapid tracking corrections can run in parallel without a TCP handshake each time
+func newPooledClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: newPooledTransport(),
+	}
+}
+
+// newPooledTransport returns the keep-alive transport used for camera connections
+func newPooledTransport() *http.Transport {
+	return &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		MaxIdleConns:        8,
+		MaxIdleConnsPerHost: 4, // Status polling, commands, imaging and streaming settings at once
+		IdleConnTimeout:     90 * time.Second,
+	}
+}
+
+// DigestTransport is an http.RoundTripper that authenticates requests with HTTP digest authentication
+// (RFC 7616) over pooled keep-alive connections. The camera's challenge is cached, so after the first
+// request every request is authorized up front instead of paying a 401 round trip; the nonce count and a
+// fresh client nonce go with each one. A stale nonce is refreshed from the camera's next 401 and the
+// request is retried once. One transport is safe for concurrent use by status polling and commands.
+type DigestTransport struct {
+	Base    http.RoundTripper // Underlying transport (connection pooling); nil uses a pooled default
+	session *digestSession
+}
+
+// NewDigestTransport creates a digest transport for one set of camera credentials
+func NewDigestTransport(user, pass string) *DigestTransport {
+	return &DigestTransport{
+		Base:    newPooledTransport(),
+		session: &digestSession{user: user, pass: pass},
+	}
+}
+
+// NewDigestClient returns an HTTP client that digest-authenticates every request over pooled connections
+func NewDigestClient(user, pass string, timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: NewDigestTransport(user, pass),
+	}
+}
+
+// RoundTrip sends the request pre-authorized from the cached challenge, retrying once on a 401
+func (t *DigestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	uri := req.URL.RequestURI()
+
+	authorized := req
+	if t.session.hasChallenge() {
+		authorized = req.Clone(req.Context())
+		authorized.Header.Set("Authorization", t.session.authorization(req.Method, uri))
+	}
+	resp, err := base.RoundTrip(authorized)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// No session yet or the nonce went stale - take the new challenge and retry once. A body that
+	// cannot be replayed leaves the 401 to the caller.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil
+	}
+	if err := t.session.update(resp.Header.Get("WWW-Authenticate")); err != nil {
+		return resp, nil
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return nil, fmt.Errorf("failed to replay request body: %v", err)
+		}
+	}
+	retry.Header.Set("Authorization", t.session.authorization(req.Method, uri))
+	return base.RoundTrip(retry)
+}
+
+// digestSession caches a camera's digest challenge. The nonce count increases per request and restarts
+// with every new nonce.
+type digestSession struct {
+	mu        sync.Mutex
+	user      string
+	pass      string
+	realm     string
+	nonce     string
+	opaque    string
+	qop       string
+	algorithm string
+	nc        uint32
+}
+
+// hasChallenge reports whether a challenge has been cached
+func (s *digestSession) hasChallenge() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.nonce != ""
+}
+
+// update stores the challenge from a WWW-Authenticate header and restarts the nonce count. A camera may
+// offer several digest challenges; SHA-256 is preferred over MD5.
+func (s *digestSession) update(header string) error {
+	if header == "" {
+		return fmt.Errorf("no WWW-Authenticate header in response")
+	}
+	params := parseDigestChallenge(header)
+	if params["realm"] == "" || params["nonce"] == "" {
+		return fmt.Errorf("invalid WWW-Authenticate header: %s", header)
+	}
+	algorithm := strings.ToUpper(params["algorithm"])
+	switch algorithm {
+	case "", "MD5":
+		algorithm = "MD5"
+	case "SHA-256":
+	default:
+		return fmt.Errorf("unsupported digest algorithm %q", params["algorithm"])
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.realm = params["realm"]
+	s.nonce = params["nonce"]
+	s.opaque = params["opaque"]
+	s.algorithm = algorithm
+	s.qop = ""
+	for _, qop := range strings.Split(params["qop"], ",") {
+		if strings.TrimSpace(qop) == "auth" {
+			s.qop = "auth"
+		}
+	}
+	s.nc = 0
+	return nil
+}
+
+// authorization returns the Authorization header for the next request using the cached challenge
+func (s *digestSession) authorization(method, uri string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hash := md5Hex
+	if s.algorithm == "SHA-256" {
+		hash = sha256Hex
+	}
+	ha1 := hash(fmt.Sprintf("%s:%s:%s", s.user, s.realm, s.pass))
+	ha2 := hash(fmt.Sprintf("%s:%s", method, uri))
+
+	header := fmt.Sprintf(`Digest username="%s", realm="%s", nonce="%s", uri="%s", algorithm=%s`, s.user, s.realm, s.nonce, uri, s.algorithm)
+	if s.qop == "auth" {
+		s.nc++
+		nc := fmt.Sprintf("%08x", s.nc)
+		cnonce := newCnonce()
+		response := hash(fmt.Sprintf("%s:%s:%s:%s:auth:%s", ha1, s.nonce, nc, cnonce, ha2))
+		header += fmt.Sprintf(`, cnonce="%s", nc=%s, qop=auth, response="%s"`, cnonce, nc, response)
+	} else {
+		header += fmt.Sprintf(`, response="%s"`, hash(fmt.Sprintf("%s:%s:%s", ha1, s.nonce, ha2)))
+	}
+	if s.opaque != "" {
+		header += fmt.Sprintf(`, opaque="%s"`, s.opaque)
+	}
+	return header
+}
+
+// parseDigestChallenge splits a WWW-Authenticate digest challenge into its parameters (quoted values may contain commas)
+func parseDigestChallenge(header string) map[string]string {
+	params := make(map[string]string)
+	header = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(header), "Digest"))
+
+	for header != "" {
+		key, rest, found := strings.Cut(header, "=")
+		if !found {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		rest = strings.TrimSpace(rest)
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+			value = strings.TrimSpace(value)
+		}
+		params[key] = value
+
+		header = strings.TrimLeft(strings.TrimSpace(rest), ",")
+	}
+	return params
+}
+
+// sha256Hex returns the lowercase hex SHA-256 digest of s
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// md5Hex returns the lowercase hex MD5 digest of s
+func md5Hex(s string) string {
+	sum := md5.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// newCnonce returns a random client nonce
+func newCnonce() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%016x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
+
+// isapiRequest performs an ISAPI request and returns the response body. The client's digest transport
+// authorizes it from the cached session.
+func (c *HikvisionController) isapiRequest(method, uri, xmlPayload string) ([]byte, error) {
+	url := fmt.Sprintf("http://%s:%s%s", c.ip, c.port, uri)
+
+	req, err := http.NewRequest(method, url, strings.NewReader(xmlPayload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	if xmlPayload != "" {
+		req.Header.Set("Content-Type", "application/xml")
+		req.ContentLength = int64(len(xmlPayload))
+	}
+	req.Header.Set("Host", fmt.Sprintf("%s:%s", c.ip, c.port))
+	req.Header.Set("User-Agent", "Go-http-client/1.1")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+	return body, nil
+}
@@ -0,0 +1,197 @@
+package ptz
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// HardwareLimits are the absolute position ranges and move speeds a camera reports supporting
+type HardwareLimits struct {
+	MinPan       float64 `json:"min_pan"`
+	MaxPan       float64 `json:"max_pan"`
+	MinTilt      float64 `json:"min_tilt"`
+	MaxTilt      float64 `json:"max_tilt"`
+	MinZoom      float64 `json:"min_zoom"`
+	MaxZoom      float64 `json:"max_zoom"`
+	MaxPanSpeed  float64 `json:"max_pan_speed,omitempty"` // Top of the continuous move speed range (0 = not reported)
+	MaxTiltSpeed float64 `json:"max_tilt_speed,omitempty"`
+	MaxZoomSpeed float64 `json:"max_zoom_speed,omitempty"`
+}
+
+// HardwareLimitProbe defines cameras that can report their physical position ranges
+type HardwareLimitProbe interface {
+	ProbeHardwareLimits() (HardwareLimits, error)
+}
+
+// SpeedLimiter defines controllers whose continuous moves are kept within the camera's reported speed range
+type SpeedLimiter interface {
+	SetSpeedLimits(pan, tilt float64)
+}
+
+// defaultMaxSpeed is the top of the continuous move speed range when the camera does not report one
+const defaultMaxSpeed = 100.0
+
+// DefaultLimits is the Hikvision range used when the camera's own can't be read: pan 0-3590, tilt 0-900 and
+// zoom 10-120, with the soft limits open to the full range
+func DefaultLimits() PTZLimits {
+	return PTZLimits{
+		SoftMinPan:  0,
+		SoftMaxPan:  3590,
+		SoftMinTilt: 0,
+		SoftMaxTilt: 900,
+		SoftMinZoom: 10,
+		SoftMaxZoom: 120,
+
+		HardMinPan:  0,
+		HardMaxPan:  3590,
+		HardMinTilt: 0,
+		HardMaxTilt: 900,
+		HardMinZoom: 10,
+		HardMaxZoom: 120,
+	}
+}
+
+// Validate checks that every range is ordered
+func (h HardwareLimits) Validate() error {
+	pairs := []struct {
+		axis     string
+		min, max float64
+	}{
+		{"pan", h.MinPan, h.MaxPan},
+		{"tilt", h.MinTilt, h.MaxTilt},
+		{"zoom", h.MinZoom, h.MaxZoom},
+	}
+	for _, pair := range pairs {
+		if pair.min >= pair.max {
+			return fmt.Errorf("%s minimum %.0f must be below maximum %.0f", pair.axis, pair.min, pair.max)
+		}
+	}
+	return nil
+}
+
+// Apply returns limits with the hardware range replaced by the reported one and the soft limits opened to it,
+// so user soft limits applied afterwards are clamped to what the camera can really do. Reported move speeds
+// replace the speed limits.
+func (h HardwareLimits) Apply(limits PTZLimits) PTZLimits {
+	limits.HardMinPan, limits.HardMaxPan = h.MinPan, h.MaxPan
+	limits.HardMinTilt, limits.HardMaxTilt = h.MinTilt, h.MaxTilt
+	limits.HardMinZoom, limits.HardMaxZoom = h.MinZoom, h.MaxZoom
+	limits.SoftMinPan, limits.SoftMaxPan = h.MinPan, h.MaxPan
+	limits.SoftMinTilt, limits.SoftMaxTilt = h.MinTilt, h.MaxTilt
+	limits.SoftMinZoom, limits.SoftMaxZoom = h.MinZoom, h.MaxZoom
+	if h.MaxPanSpeed > 0 {
+		limits.MaxPanSpeed = h.MaxPanSpeed
+	}
+	if h.MaxTiltSpeed > 0 {
+		limits.MaxTiltSpeed = h.MaxTiltSpeed
+	}
+	return limits
+}
+
+// ProbeHardwareLimits reads the absolute position ranges and continuous move speeds from the PTZ capabilities
+func (c *HikvisionController) ProbeHardwareLimits() (HardwareLimits, error) {
+	body, err := c.ptzCapabilities()
+	if err != nil {
+		return HardwareLimits{}, fmt.Errorf("failed to read PTZ capabilities: %v", err)
+	}
+	return parseHardwareLimits(body)
+}
+
+// ptzCapabilities returns the PTZ capabilities document, fetched once and shared by the capability and
+// hardware limit probes
+func (c *HikvisionController) ptzCapabilities() ([]byte, error) {
+	c.capsMu.Lock()
+	defer c.capsMu.Unlock()
+
+	if c.capsDocument != nil {
+		return c.capsDocument, nil
+	}
+	body, err := c.isapiRequest("GET", "/ISAPI/PTZCtrl/channels/1/capabilities", "")
+	if err != nil {
+		return nil, err
+	}
+	c.capsDocument = body
+	return body, nil
+}
+
+// SetSpeedLimits sets the top of the continuous pan and tilt speed ranges (0 = the default range)
+func (c *HikvisionController) SetSpeedLimits(pan, tilt float64) {
+	c.speedMu.Lock()
+	defer c.speedMu.Unlock()
+
+	c.maxPanSpeed, c.maxTiltSpeed = pan, tilt
+}
+
+// speedLimits returns the top of the continuous pan and tilt speed ranges
+func (c *HikvisionController) speedLimits() (pan, tilt float64) {
+	c.speedMu.Lock()
+	defer c.speedMu.Unlock()
+
+	pan, tilt = c.maxPanSpeed, c.maxTiltSpeed
+	if pan <= 0 {
+		pan = defaultMaxSpeed
+	}
+	if tilt <= 0 {
+		tilt = defaultMaxSpeed
+	}
+	return pan, tilt
+}
+
+// ptzCapabilities is the part of a Hikvision PTZChanelCap document holding the ranges. The absolute ranges are
+// given as min/max attributes in the same units as AbsoluteHigh commands; the continuous speed ranges as
+// Min/Max elements.
+type ptzCapabilities struct {
+	AbsoluteHigh struct {
+		Elevation    attributeRange `xml:"elevation"`
+		Azimuth      attributeRange `xml:"azimuth"`
+		AbsoluteZoom attributeRange `xml:"absoluteZoom"`
+	} `xml:"AbsoluteHigh"`
+	ContinuousPanTiltSpace struct {
+		XRange elementRange `xml:"XRange"`
+		YRange elementRange `xml:"YRange"`
+	} `xml:"ContinuousPanTiltSpace"`
+	ContinuousZoomSpace struct {
+		ZRange elementRange `xml:"ZRange"`
+	} `xml:"ContinuousZoomSpace"`
+}
+
+type attributeRange struct {
+	Min *float64 `xml:"min,attr"`
+	Max *float64 `xml:"max,attr"`
+}
+
+type elementRange struct {
+	Min float64 `xml:"Min"`
+	Max float64 `xml:"Max"`
+}
+
+// parseHardwareLimits extracts the ranges from a PTZ capabilities document
+func parseHardwareLimits(body []byte) (HardwareLimits, error) {
+	var caps ptzCapabilities
+	if err := xml.Unmarshal(body, &caps); err != nil {
+		return HardwareLimits{}, fmt.Errorf("failed to parse PTZ capabilities: %v", err)
+	}
+
+	absolute := caps.AbsoluteHigh
+	for _, r := range []attributeRange{absolute.Azimuth, absolute.Elevation, absolute.AbsoluteZoom} {
+		if r.Min == nil || r.Max == nil {
+			return HardwareLimits{}, fmt.Errorf("PTZ capabilities have no AbsoluteHigh azimuth, elevation and absoluteZoom ranges")
+		}
+	}
+
+	limits := HardwareLimits{
+		MinPan:       *absolute.Azimuth.Min,
+		MaxPan:       *absolute.Azimuth.Max,
+		MinTilt:      *absolute.Elevation.Min,
+		MaxTilt:      *absolute.Elevation.Max,
+		MinZoom:      *absolute.AbsoluteZoom.Min,
+		MaxZoom:      *absolute.AbsoluteZoom.Max,
+		MaxPanSpeed:  caps.ContinuousPanTiltSpace.XRange.Max,
+		MaxTiltSpeed: caps.ContinuousPanTiltSpace.YRange.Max,
+		MaxZoomSpeed: caps.ContinuousZoomSpace.ZRange.Max,
+	}
+	if err := limits.Validate(); err != nil {
+		return HardwareLimits{}, fmt.Errorf("invalid PTZ capabilities: %v", err)
+	}
+	return limits, nil
+}
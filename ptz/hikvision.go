@@ -1,14 +1,13 @@
 package ptz
 
 import (
-	"crypto/md5"
-	"encoding/base64"
 	"encoding/xml"
 	"fmt"
 	"io"
 	"math"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -23,6 +22,11 @@ type PTZCommand struct {
 	AbsolutePan  *float64 // Optional absolute pan position
 	AbsoluteTilt *float64 // Optional absolute tilt position
 	AbsoluteZoom *float64 // Optional absolute zoom position
+
+	Source   string // What issued the command (one of the Source constants), for the audit log
+	ObjectID string // Object the command follows, if any
+
+	done func(err error) // Reports the camera's answer to the audit log (set by the camera state manager)
 }
 
 // Controller defines the interface for PTZ camera controllers
@@ -58,12 +62,22 @@ type HikvisionController struct {
 	commandLock     chan struct{}
 	lastCommandEnd  time.Time
 	activeCommand   string
-	client          *http.Client
+	client          *http.Client // Digest-authenticating, pooled client shared by status polling and commands
 	currentPos      PTZPosition
 	statusChan      chan PTZPosition
 	OnPresetArrived func(presetName string)
 	frameWidth      int // Actual frame width
 	frameHeight     int // Actual frame height
+
+	capsMu       sync.Mutex
+	capsDocument []byte // PTZ capabilities, read once
+
+	speedMu      sync.Mutex
+	maxPanSpeed  float64 // Top of the camera's continuous speed ranges (0 = defaultMaxSpeed)
+	maxTiltSpeed float64
+
+	blcMu       sync.Mutex
+	blcOriginal []byte // BLC settings before the first exposure region override (nil = not overridden)
 }
 
 // NewHikvisionController creates a new Hikvision PTZ controller
@@ -76,9 +90,7 @@ func NewHikvisionController(ip, port, user, pass string) Controller {
 		commandChan: make(chan PTZCommand, 10),
 		commandLock: make(chan struct{}, 1),
 		statusChan:  make(chan PTZPosition, 10),
-		client: &http.Client{
-			Timeout: 5 * time.Second,
-		},
+		client:      NewDigestClient(user, pass, 5*time.Second),
 	}
 }
 
@@ -147,101 +159,26 @@ func (c *HikvisionController) processCommands() {
 			debugMsg("PTZ_DEBUG", fmt.Sprintf("Absolute position command - Pan: %.0f, Tilt: %.0f, Zoom: %.0f",
 				targetPos.Pan, targetPos.Tilt, targetPos.Zoom))
 
-			url := fmt.Sprintf("http://%s:%s/ISAPI/PTZCtrl/channels/1/absolute", c.ip, c.port)
 			uri := "/ISAPI/PTZCtrl/channels/1/absolute"
 
 			// Send command with retry
 			var lastErr error
 			for retries := 0; retries < 3; retries++ {
-				body := strings.NewReader(xmlPayload)
-				req, err := http.NewRequest("PUT", url, body)
-				if err != nil {
-					lastErr = fmt.Errorf("failed to create request: %v", err)
-					continue
-				}
-
-				req.Header.Set("Content-Type", "application/xml")
-				req.Header.Set("Host", fmt.Sprintf("%s:%s", c.ip, c.port))
-				req.Header.Set("User-Agent", "Go-http-client/1.1")
-				req.ContentLength = int64(len(xmlPayload))
-
-				resp, err := c.client.Do(req)
-				if err != nil {
-					lastErr = fmt.Errorf("request failed: %v", err)
-					continue
-				}
-
-				bodyBytes, _ := io.ReadAll(resp.Body)
-				resp.Body.Close()
-
-				if resp.StatusCode == 200 {
+				if _, lastErr = c.isapiRequest("PUT", uri, xmlPayload); lastErr == nil {
 					debugMsg("PTZ_DEBUG", "Absolute position command successful")
 					break
 				}
-
-				if resp.StatusCode == 401 {
-					authHeader := resp.Header.Get("WWW-Authenticate")
-					if authHeader == "" {
-						lastErr = fmt.Errorf("no WWW-Authenticate header in response")
-						continue
-					}
-
-					// Parse the WWW-Authenticate header
-					var realm, nonce string
-					parts := strings.Split(authHeader, ",")
-					for _, part := range parts {
-						part = strings.TrimSpace(part)
-						if strings.HasPrefix(part, "realm=") {
-							realm = strings.Trim(part[6:], "\"")
-						} else if strings.HasPrefix(part, "nonce=") {
-							nonce = strings.Trim(part[6:], "\"")
-						}
-					}
-
-					if realm == "" || nonce == "" {
-						lastErr = fmt.Errorf("invalid WWW-Authenticate header: %s", authHeader)
-						continue
-					}
-
-					// Create new request with digest authentication
-					body = strings.NewReader(xmlPayload)
-					req, err = http.NewRequest("PUT", url, body)
-					if err != nil {
-						lastErr = fmt.Errorf("failed to create request: %v", err)
-						continue
-					}
-
-					req.Header.Set("Authorization", c.getDigestAuth("PUT", uri, realm, nonce))
-					req.Header.Set("Content-Type", "application/xml")
-					req.Header.Set("Host", fmt.Sprintf("%s:%s", c.ip, c.port))
-					req.Header.Set("User-Agent", "Go-http-client/1.1")
-					req.ContentLength = int64(len(xmlPayload))
-
-					resp, err = c.client.Do(req)
-					if err != nil {
-						lastErr = fmt.Errorf("request failed: %v", err)
-						continue
-					}
-
-					bodyBytes, _ = io.ReadAll(resp.Body)
-					resp.Body.Close()
-
-					if resp.StatusCode == 200 {
-						debugMsg("PTZ_DEBUG", "Absolute position command successful")
-						break
-					}
-				}
-
-				lastErr = fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
 				debugMsg("PTZ_WARN", fmt.Sprintf("Command failed (attempt %d/3): %v", retries+1, lastErr))
 				time.Sleep(100 * time.Millisecond)
 			}
 
 			if lastErr != nil {
 				debugMsg("PTZ_ERROR", fmt.Sprintf("All command retries failed: %v", lastErr))
+				cmd.finish(lastErr)
 				<-c.commandLock
 				continue
 			}
+			cmd.finish(nil)
 
 			c.activeCommand = "absolutePosition"
 			c.lastCommandEnd = time.Now()
@@ -255,14 +192,17 @@ func (c *HikvisionController) processCommands() {
 			parts := strings.Split(cmd.Command, "name=")
 			if len(parts) != 2 {
 				debugMsg("PTZ_ERROR", fmt.Sprintf("Invalid preset command format: %s", cmd.Command))
+				cmd.finish(fmt.Errorf("invalid preset command format: %s", cmd.Command))
 				<-c.commandLock
 				continue
 			}
 			presetName := parts[1]
 
-			if err := c.sendPresetCommand(presetName); err != nil {
+			err := c.sendPresetCommand(presetName)
+			if err != nil {
 				debugMsg("PTZ_ERROR", fmt.Sprintf("Failed to send preset command: %v", err))
 			}
+			cmd.finish(err)
 			c.lastCommandEnd = time.Now()
 			<-c.commandLock
 			continue
@@ -294,109 +234,24 @@ func (c *HikvisionController) processCommands() {
 			url := fmt.Sprintf("http://%s:%s/ISAPI/PTZCtrl/channels/1/absolute", c.ip, c.port)
 			uri := "/ISAPI/PTZCtrl/channels/1/absolute"
 
-			// First request to get the WWW-Authenticate header
-			body := strings.NewReader(xmlPayload)
-			req, err := http.NewRequest("PUT", url, body)
-			if err != nil {
-				debugMsg("PTZ_ERROR", fmt.Sprintf("Failed to create request: %v", err))
-				<-c.commandLock
-				continue
-			}
-
-			req.Header.Set("Content-Type", "application/xml")
-			req.Header.Set("Host", fmt.Sprintf("%s:%s", c.ip, c.port))
-			req.Header.Set("User-Agent", "Go-http-client/1.1")
-			req.ContentLength = int64(len(xmlPayload))
-
 			// Send command with retry
 			var lastErr error
 			for retries := 0; retries < 3; retries++ {
-				// Create a new body reader for each retry
-				body = strings.NewReader(xmlPayload)
-				req.Body = io.NopCloser(body)
-				req.ContentLength = int64(len(xmlPayload))
-
-				resp, err := c.client.Do(req)
-				if err != nil {
-					lastErr = fmt.Errorf("request failed: %v", err)
-					debugMsg("PTZ_WARN", fmt.Sprintf("Command failed (attempt %d/3): %v", retries+1, err))
-					time.Sleep(100 * time.Millisecond)
-					continue
-				}
-
-				// Read and close the body
-				bodyBytes, _ := io.ReadAll(resp.Body)
-				resp.Body.Close()
-
-				if resp.StatusCode == 200 {
+				if _, lastErr = c.isapiRequest("PUT", uri, xmlPayload); lastErr == nil {
 					debugMsg("PTZ_DEBUG", "Zoom command successful")
 					break
 				}
-
-				// If we got a 401, extract the digest authentication parameters
-				if resp.StatusCode == 401 {
-					authHeader := resp.Header.Get("WWW-Authenticate")
-					if authHeader == "" {
-						lastErr = fmt.Errorf("no WWW-Authenticate header in response")
-						continue
-					}
-
-					// Parse the WWW-Authenticate header
-					var realm, nonce string
-					parts := strings.Split(authHeader, ",")
-					for _, part := range parts {
-						part = strings.TrimSpace(part)
-						if strings.HasPrefix(part, "realm=") {
-							realm = strings.Trim(part[6:], "\"")
-						} else if strings.HasPrefix(part, "nonce=") {
-							nonce = strings.Trim(part[6:], "\"")
-						}
-					}
-
-					if realm == "" || nonce == "" {
-						lastErr = fmt.Errorf("invalid WWW-Authenticate header: %s", authHeader)
-						continue
-					}
-
-					// Create new request with digest authentication
-					body = strings.NewReader(xmlPayload)
-					req, err = http.NewRequest("PUT", url, body)
-					if err != nil {
-						lastErr = fmt.Errorf("failed to create request: %v", err)
-						continue
-					}
-
-					req.Header.Set("Authorization", c.getDigestAuth("PUT", uri, realm, nonce))
-					req.Header.Set("Content-Type", "application/xml")
-					req.Header.Set("Host", fmt.Sprintf("%s:%s", c.ip, c.port))
-					req.Header.Set("User-Agent", "Go-http-client/1.1")
-					req.ContentLength = int64(len(xmlPayload))
-
-					resp, err = c.client.Do(req)
-					if err != nil {
-						lastErr = fmt.Errorf("request failed: %v", err)
-						continue
-					}
-
-					bodyBytes, _ = io.ReadAll(resp.Body)
-					resp.Body.Close()
-
-					if resp.StatusCode == 200 {
-						debugMsg("PTZ_DEBUG", "Zoom command successful")
-						break
-					}
-				}
-
-				lastErr = fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
 				debugMsg("PTZ_WARN", fmt.Sprintf("Command failed (attempt %d/3): %v", retries+1, lastErr))
 				time.Sleep(100 * time.Millisecond)
 			}
 
 			if lastErr != nil {
 				debugMsg("PTZ_ERROR", fmt.Sprintf("All command retries failed: %v", lastErr))
+				cmd.finish(lastErr)
 				<-c.commandLock
 				continue
 			}
+			cmd.finish(nil)
 
 			// Wait for the command duration
 			time.Sleep(cmd.Duration)
@@ -456,91 +311,26 @@ func (c *HikvisionController) processCommands() {
 			debugMsg("PTZ_DEBUG", fmt.Sprintf("Pan command - Current P: %.0f, Target P: %.0f",
 				currentPan, newPan))
 
-			url := fmt.Sprintf("http://%s:%s/ISAPI/PTZCtrl/channels/1/absolute", c.ip, c.port)
 			uri := "/ISAPI/PTZCtrl/channels/1/absolute"
-			// First request to get the WWW-Authenticate header
-			body := strings.NewReader(xmlPayload)
-			req, err := http.NewRequest("PUT", url, body)
-			if err != nil {
-				debugMsg("PTZ_ERROR", fmt.Sprintf("Failed to create request: %v", err))
-				<-c.commandLock
-				continue
-			}
-			req.Header.Set("Content-Type", "application/xml")
-			req.Header.Set("Host", fmt.Sprintf("%s:%s", c.ip, c.port))
-			req.Header.Set("User-Agent", "Go-http-client/1.1")
-			req.ContentLength = int64(len(xmlPayload))
+
 			// Send command with retry
 			var lastErr error
 			for retries := 0; retries < 3; retries++ {
-				body = strings.NewReader(xmlPayload)
-				req.Body = io.NopCloser(body)
-				req.ContentLength = int64(len(xmlPayload))
-				resp, err := c.client.Do(req)
-				if err != nil {
-					lastErr = fmt.Errorf("request failed: %v", err)
-					debugMsg("PTZ_WARN", fmt.Sprintf("Command failed (attempt %d/3): %v", retries+1, err))
-					time.Sleep(100 * time.Millisecond)
-					continue
-				}
-				bodyBytes, _ := io.ReadAll(resp.Body)
-				resp.Body.Close()
-				if resp.StatusCode == 200 {
+				if _, lastErr = c.isapiRequest("PUT", uri, xmlPayload); lastErr == nil {
 					debugMsg("PTZ_DEBUG", "Pan command successful")
 					break
 				}
-				if resp.StatusCode == 401 {
-					authHeader := resp.Header.Get("WWW-Authenticate")
-					if authHeader == "" {
-						lastErr = fmt.Errorf("no WWW-Authenticate header in response")
-						continue
-					}
-					var realm, nonce string
-					parts := strings.Split(authHeader, ",")
-					for _, part := range parts {
-						part = strings.TrimSpace(part)
-						if strings.HasPrefix(part, "realm=") {
-							realm = strings.Trim(part[6:], "\"")
-						} else if strings.HasPrefix(part, "nonce=") {
-							nonce = strings.Trim(part[6:], "\"")
-						}
-					}
-					if realm == "" || nonce == "" {
-						lastErr = fmt.Errorf("invalid WWW-Authenticate header: %s", authHeader)
-						continue
-					}
-					body = strings.NewReader(xmlPayload)
-					req, err = http.NewRequest("PUT", url, body)
-					if err != nil {
-						lastErr = fmt.Errorf("failed to create request: %v", err)
-						continue
-					}
-					req.Header.Set("Authorization", c.getDigestAuth("PUT", uri, realm, nonce))
-					req.Header.Set("Content-Type", "application/xml")
-					req.Header.Set("Host", fmt.Sprintf("%s:%s", c.ip, c.port))
-					req.Header.Set("User-Agent", "Go-http-client/1.1")
-					req.ContentLength = int64(len(xmlPayload))
-					resp, err = c.client.Do(req)
-					if err != nil {
-						lastErr = fmt.Errorf("request failed: %v", err)
-						continue
-					}
-					bodyBytes, _ = io.ReadAll(resp.Body)
-					resp.Body.Close()
-					if resp.StatusCode == 200 {
-						debugMsg("PTZ_DEBUG", "Pan command successful")
-						break
-					}
-				}
-				lastErr = fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
 				debugMsg("PTZ_WARN", fmt.Sprintf("Command failed (attempt %d/3): %v", retries+1, lastErr))
 				time.Sleep(100 * time.Millisecond)
 			}
+
 			if lastErr != nil {
 				debugMsg("PTZ_ERROR", fmt.Sprintf("All command retries failed: %v", lastErr))
+				cmd.finish(lastErr)
 				<-c.commandLock
 				continue
 			}
+			cmd.finish(nil)
 			c.activeCommand = cmd.Command
 			c.lastCommandEnd = time.Now()
 			<-c.commandLock
@@ -566,91 +356,26 @@ func (c *HikvisionController) processCommands() {
 			xmlPayload := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?><PTZData><AbsoluteHigh><azimuth>%.0f</azimuth><elevation>%.0f</elevation><absoluteZoom>%.0f</absoluteZoom></AbsoluteHigh></PTZData>`,
 				c.currentPos.Pan, newTilt, c.currentPos.Zoom)
 
-			url := fmt.Sprintf("http://%s:%s/ISAPI/PTZCtrl/channels/1/absolute", c.ip, c.port)
 			uri := "/ISAPI/PTZCtrl/channels/1/absolute"
-			// First request to get the WWW-Authenticate header
-			body := strings.NewReader(xmlPayload)
-			req, err := http.NewRequest("PUT", url, body)
-			if err != nil {
-				debugMsg("PTZ_ERROR", fmt.Sprintf("Failed to create request: %v", err))
-				<-c.commandLock
-				continue
-			}
-			req.Header.Set("Content-Type", "application/xml")
-			req.Header.Set("Host", fmt.Sprintf("%s:%s", c.ip, c.port))
-			req.Header.Set("User-Agent", "Go-http-client/1.1")
-			req.ContentLength = int64(len(xmlPayload))
+
 			// Send command with retry
 			var lastErr error
 			for retries := 0; retries < 3; retries++ {
-				body = strings.NewReader(xmlPayload)
-				req.Body = io.NopCloser(body)
-				req.ContentLength = int64(len(xmlPayload))
-				resp, err := c.client.Do(req)
-				if err != nil {
-					lastErr = fmt.Errorf("request failed: %v", err)
-					debugMsg("PTZ_WARN", fmt.Sprintf("Command failed (attempt %d/3): %v", retries+1, err))
-					time.Sleep(100 * time.Millisecond)
-					continue
-				}
-				bodyBytes, _ := io.ReadAll(resp.Body)
-				resp.Body.Close()
-				if resp.StatusCode == 200 {
+				if _, lastErr = c.isapiRequest("PUT", uri, xmlPayload); lastErr == nil {
 					debugMsg("PTZ_DEBUG", "Tilt command successful")
 					break
 				}
-				if resp.StatusCode == 401 {
-					authHeader := resp.Header.Get("WWW-Authenticate")
-					if authHeader == "" {
-						lastErr = fmt.Errorf("no WWW-Authenticate header in response")
-						continue
-					}
-					var realm, nonce string
-					parts := strings.Split(authHeader, ",")
-					for _, part := range parts {
-						part = strings.TrimSpace(part)
-						if strings.HasPrefix(part, "realm=") {
-							realm = strings.Trim(part[6:], "\"")
-						} else if strings.HasPrefix(part, "nonce=") {
-							nonce = strings.Trim(part[6:], "\"")
-						}
-					}
-					if realm == "" || nonce == "" {
-						lastErr = fmt.Errorf("invalid WWW-Authenticate header: %s", authHeader)
-						continue
-					}
-					body = strings.NewReader(xmlPayload)
-					req, err = http.NewRequest("PUT", url, body)
-					if err != nil {
-						lastErr = fmt.Errorf("failed to create request: %v", err)
-						continue
-					}
-					req.Header.Set("Authorization", c.getDigestAuth("PUT", uri, realm, nonce))
-					req.Header.Set("Content-Type", "application/xml")
-					req.Header.Set("Host", fmt.Sprintf("%s:%s", c.ip, c.port))
-					req.Header.Set("User-Agent", "Go-http-client/1.1")
-					req.ContentLength = int64(len(xmlPayload))
-					resp, err = c.client.Do(req)
-					if err != nil {
-						lastErr = fmt.Errorf("request failed: %v", err)
-						continue
-					}
-					bodyBytes, _ = io.ReadAll(resp.Body)
-					resp.Body.Close()
-					if resp.StatusCode == 200 {
-						debugMsg("PTZ_DEBUG", "Tilt command successful")
-						break
-					}
-				}
-				lastErr = fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
 				debugMsg("PTZ_WARN", fmt.Sprintf("Command failed (attempt %d/3): %v", retries+1, lastErr))
 				time.Sleep(100 * time.Millisecond)
 			}
+
 			if lastErr != nil {
 				debugMsg("PTZ_ERROR", fmt.Sprintf("All command retries failed: %v", lastErr))
+				cmd.finish(lastErr)
 				<-c.commandLock
 				continue
 			}
+			cmd.finish(nil)
 			c.activeCommand = cmd.Command
 			c.lastCommandEnd = time.Now()
 			<-c.commandLock
@@ -661,12 +386,14 @@ func (c *HikvisionController) processCommands() {
 		hikCmd := convertToHikvisionCommand(cmd.Command)
 		if hikCmd == "" {
 			debugMsg("PTZ_ERROR", fmt.Sprintf("Unknown command: %s", cmd.Command))
+			cmd.finish(fmt.Errorf("unknown command: %s", cmd.Command))
 			<-c.commandLock
 			continue
 		}
 
 		// Calculate relative movement based on current position
-		panSpeed, tiltSpeed, _ := calculateRelativeSpeed(c.currentPos, hikCmd)
+		maxPanSpeed, maxTiltSpeed := c.speedLimits()
+		panSpeed, tiltSpeed, _ := calculateRelativeSpeed(c.currentPos, hikCmd, maxPanSpeed, maxTiltSpeed)
 
 		// Create XML payload for continuous movement
 		xmlPayload := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
@@ -683,6 +410,7 @@ func (c *HikvisionController) processCommands() {
 		req, err := http.NewRequest("PUT", fmt.Sprintf("http://%s:%s/ISAPI/PTZCtrl/channels/1/continuous", c.ip, c.port), body)
 		if err != nil {
 			debugMsg("PTZ_ERROR", fmt.Sprintf("Failed to create request: %v", err))
+			cmd.finish(err)
 			<-c.commandLock
 			continue
 		}
@@ -711,10 +439,16 @@ func (c *HikvisionController) processCommands() {
 
 		if err != nil {
 			debugMsg("PTZ_ERROR", fmt.Sprintf("All command retries failed: %v", err))
+			cmd.finish(err)
 			<-c.commandLock
 			continue
 		}
 		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			cmd.finish(fmt.Errorf("camera answered %s", resp.Status))
+		} else {
+			cmd.finish(nil)
+		}
 
 		c.activeCommand = cmd.Command
 
@@ -777,10 +511,11 @@ func convertToHikvisionCommand(cmd string) string {
 	}
 }
 
-// calculateRelativeSpeed calculates the relative movement speed based on current position and command
-func calculateRelativeSpeed(current PTZPosition, cmd string) (float64, float64, float64) {
-	// Base speed for movement (in camera's -100 to 100 range)
-	baseSpeed := 50.0
+// calculateRelativeSpeed calculates the relative movement speed based on current position and command, as a
+// share of the camera's continuous speed ranges (top maxPan and maxTilt)
+func calculateRelativeSpeed(current PTZPosition, cmd string, maxPan, maxTilt float64) (float64, float64, float64) {
+	// Base speed for movement: half of the camera's range, 20% near an edge
+	const baseShare, edgeShare = 0.5, 0.2
 
 	// Calculate relative speeds based on current position and command
 	var panSpeed, tiltSpeed, zoomSpeed float64
@@ -789,30 +524,30 @@ func calculateRelativeSpeed(current PTZPosition, cmd string) (float64, float64,
 	case "left":
 		// If we're already at the left edge, reduce speed
 		if current.Pan <= 10 {
-			panSpeed = -20.0
+			panSpeed = -edgeShare * maxPan
 		} else {
-			panSpeed = -baseSpeed
+			panSpeed = -baseShare * maxPan
 		}
 	case "right":
 		// If we're already at the right edge, reduce speed
 		if current.Pan >= 3580 {
-			panSpeed = 20.0
+			panSpeed = edgeShare * maxPan
 		} else {
-			panSpeed = baseSpeed
+			panSpeed = baseShare * maxPan
 		}
 	case "up":
 		// If we're already at the top edge, reduce speed
 		if current.Tilt >= 890 {
-			tiltSpeed = 20.0
+			tiltSpeed = edgeShare * maxTilt
 		} else {
-			tiltSpeed = baseSpeed
+			tiltSpeed = baseShare * maxTilt
 		}
 	case "down":
 		// If we're already at the bottom edge, reduce speed
 		if current.Tilt <= 10 {
-			tiltSpeed = -20.0
+			tiltSpeed = -edgeShare * maxTilt
 		} else {
-			tiltSpeed = -baseSpeed
+			tiltSpeed = -baseShare * maxTilt
 		}
 	case "absolute":
 		// For absolute zoom, we'll handle this separately in processCommands
@@ -852,95 +587,13 @@ func (c *HikvisionController) monitorStatus() {
 	}
 }
 
-// getDigestAuth generates a digest authentication header based on the WWW-Authenticate response
-func (c *HikvisionController) getDigestAuth(method, uri, realm, nonce string) string {
-	// Generate client nonce (cnonce) and encode it in base64
-	cnonceBytes := md5.Sum([]byte(time.Now().String()))
-	cnonce := base64.StdEncoding.EncodeToString(cnonceBytes[:])
-
-	// Calculate HA1 = MD5(username:realm:password)
-	ha1 := fmt.Sprintf("%x", md5.Sum([]byte(fmt.Sprintf("%s:%s:%s", c.user, realm, c.pass))))
-
-	// Calculate HA2 = MD5(method:uri)
-	ha2 := fmt.Sprintf("%x", md5.Sum([]byte(fmt.Sprintf("%s:%s", method, uri))))
-
-	// Calculate response = MD5(HA1:nonce:nc:cnonce:qop:HA2)
-	response := fmt.Sprintf("%x", md5.Sum([]byte(fmt.Sprintf("%s:%s:00000001:%s:auth:%s", ha1, nonce, cnonce, ha2))))
-
-	// Construct Authorization header with all required fields
-	return fmt.Sprintf(`Digest username="%s", realm="%s", nonce="%s", uri="%s", cnonce="%s", nc=00000001, qop=auth, response="%s"`,
-		c.user, realm, nonce, uri, cnonce, response)
-}
-
 // getStatus retrieves the current PTZ status from the camera
 func (c *HikvisionController) getStatus() (*PTZStatus, error) {
-	url := fmt.Sprintf("http://%s:%s/ISAPI/PTZCtrl/channels/1/status", c.ip, c.port)
-	uri := "/ISAPI/PTZCtrl/channels/1/status"
-
-	// First request to get the WWW-Authenticate header
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
-	}
-
-	req.Header.Set("Host", fmt.Sprintf("%s:%s", c.ip, c.port))
-	req.Header.Set("User-Agent", "Go-http-client/1.1")
-
-	resp, err := c.client.Do(req)
+	body, err := c.isapiRequest("GET", "/ISAPI/PTZCtrl/channels/1/status", "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get status: %v", err)
 	}
 
-	// Read and close the body
-	body, _ := io.ReadAll(resp.Body)
-	resp.Body.Close()
-
-	// If we got a 401, extract the digest authentication parameters
-	if resp.StatusCode == 401 {
-		authHeader := resp.Header.Get("WWW-Authenticate")
-		if authHeader == "" {
-			return nil, fmt.Errorf("no WWW-Authenticate header in response")
-		}
-
-		// Parse the WWW-Authenticate header
-		var realm, nonce string
-		parts := strings.Split(authHeader, ",")
-		for _, part := range parts {
-			part = strings.TrimSpace(part)
-			if strings.HasPrefix(part, "realm=") {
-				realm = strings.Trim(part[6:], "\"")
-			} else if strings.HasPrefix(part, "nonce=") {
-				nonce = strings.Trim(part[6:], "\"")
-			}
-		}
-
-		if realm == "" || nonce == "" {
-			return nil, fmt.Errorf("invalid WWW-Authenticate header: %s", authHeader)
-		}
-
-		// Create new request with digest authentication
-		req, err = http.NewRequest("GET", url, nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create request: %v", err)
-		}
-
-		req.Header.Set("Authorization", c.getDigestAuth("GET", uri, realm, nonce))
-		req.Header.Set("Host", fmt.Sprintf("%s:%s", c.ip, c.port))
-		req.Header.Set("User-Agent", "Go-http-client/1.1")
-
-		resp, err = c.client.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get status: %v", err)
-		}
-
-		body, _ = io.ReadAll(resp.Body)
-		resp.Body.Close()
-	}
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
-	}
-
 	var status PTZStatus
 	if err := xml.Unmarshal(body, &status); err != nil {
 		return nil, fmt.Errorf("failed to decode status: %v", err)
@@ -969,97 +622,19 @@ func (c *HikvisionController) sendPresetCommand(presetName string) error {
 
 	debugMsg("PTZ", fmt.Sprintf("Moving to preset %d (%s)", presetID, presetName))
 
-	url := fmt.Sprintf("http://%s:%s/ISAPI/PTZCtrl/channels/1/presets/%d/goto", c.ip, c.port, presetID)
 	uri := fmt.Sprintf("/ISAPI/PTZCtrl/channels/1/presets/%d/goto", presetID)
 
-	// First request to get the WWW-Authenticate header
-	req, err := http.NewRequest("PUT", url, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %v", err)
-	}
-
-	req.Header.Set("Host", fmt.Sprintf("%s:%s", c.ip, c.port))
-	req.Header.Set("User-Agent", "Go-http-client/1.1")
-
 	// Send command with retry
 	var lastErr error
 	for retries := 0; retries < 3; retries++ {
-		resp, err := c.client.Do(req)
-		if err != nil {
-			lastErr = fmt.Errorf("request failed: %v", err)
-			time.Sleep(100 * time.Millisecond)
-			continue
-		}
-
-		// Read and close the body
-		body, _ := io.ReadAll(resp.Body)
-		resp.Body.Close()
-
-		if resp.StatusCode == 200 {
+		if _, lastErr = c.isapiRequest("PUT", uri, ""); lastErr == nil {
 			debugMsg("PTZ", fmt.Sprintf("Successfully moved to preset %d (%s)", presetID, presetName))
-			// Notify tracking system that camera is no longer moving
-			if c.statusChan != nil {
-				// Send a special status or call a callback if needed
-			}
 			// Set CameraMoving to false in the tracking system
 			if c.OnPresetArrived != nil {
 				c.OnPresetArrived(presetName)
 			}
 			return nil
 		}
-
-		// If we got a 401, extract the digest authentication parameters
-		if resp.StatusCode == 401 {
-			authHeader := resp.Header.Get("WWW-Authenticate")
-			if authHeader == "" {
-				lastErr = fmt.Errorf("no WWW-Authenticate header in response")
-				continue
-			}
-
-			// Parse the WWW-Authenticate header
-			var realm, nonce string
-			parts := strings.Split(authHeader, ",")
-			for _, part := range parts {
-				part = strings.TrimSpace(part)
-				if strings.HasPrefix(part, "realm=") {
-					realm = strings.Trim(part[6:], "\"")
-				} else if strings.HasPrefix(part, "nonce=") {
-					nonce = strings.Trim(part[6:], "\"")
-				}
-			}
-
-			if realm == "" || nonce == "" {
-				lastErr = fmt.Errorf("invalid WWW-Authenticate header: %s", authHeader)
-				continue
-			}
-
-			// Create new request with digest authentication
-			req, err = http.NewRequest("PUT", url, nil)
-			if err != nil {
-				lastErr = fmt.Errorf("failed to create request: %v", err)
-				continue
-			}
-
-			req.Header.Set("Authorization", c.getDigestAuth("PUT", uri, realm, nonce))
-			req.Header.Set("Host", fmt.Sprintf("%s:%s", c.ip, c.port))
-			req.Header.Set("User-Agent", "Go-http-client/1.1")
-
-			resp, err = c.client.Do(req)
-			if err != nil {
-				lastErr = fmt.Errorf("request failed: %v", err)
-				continue
-			}
-
-			body, _ = io.ReadAll(resp.Body)
-			resp.Body.Close()
-
-			if resp.StatusCode == 200 {
-				debugMsg("PTZ", fmt.Sprintf("Successfully moved to preset %d (%s)", presetID, presetName))
-				return nil
-			}
-		}
-
-		lastErr = fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
 		debugMsg("PTZ_WARN", fmt.Sprintf("Preset command failed (attempt %d/3): %v", retries+1, lastErr))
 		time.Sleep(100 * time.Millisecond)
 	}
@@ -0,0 +1,108 @@
+package ptz
+
+import (
+	"fmt"
+	"image"
+)
+
+// ExposureMetering defines cameras that can meter exposure on a region of the frame
+type ExposureMetering interface {
+	SetExposureRegion(region image.Rectangle, frameWidth, frameHeight int) error
+	ResetExposureRegion() error
+}
+
+// CameraImaging defines cameras that can sharpen and expose the picture for a target on request: a one-shot
+// autofocus plus region exposure metering
+type CameraImaging interface {
+	ExposureMetering
+	TriggerOneShotFocus() error
+}
+
+// Hikvision ISAPI region coordinates use a normalized 1000x1000 plane with the origin in the lower-left corner
+const isapiRegionPlaneSize = 1000
+
+// SetExposureRegion points the camera's backlight compensation (BLC) metering window at a pixel region of the frame
+func (c *HikvisionController) SetExposureRegion(region image.Rectangle, frameWidth, frameHeight int) error {
+	if frameWidth <= 0 || frameHeight <= 0 {
+		return fmt.Errorf("invalid frame dimensions: %dx%d", frameWidth, frameHeight)
+	}
+
+	// Clip region to the frame so the camera never receives out-of-range coordinates
+	region = region.Intersect(image.Rect(0, 0, frameWidth, frameHeight))
+	if region.Empty() {
+		return fmt.Errorf("exposure region is outside the frame")
+	}
+
+	// Convert pixel coordinates to ISAPI's normalized plane (Y axis is flipped)
+	toPlaneX := func(x int) int { return x * isapiRegionPlaneSize / frameWidth }
+	toPlaneY := func(y int) int { return isapiRegionPlaneSize - y*isapiRegionPlaneSize/frameHeight }
+	left, right := toPlaneX(region.Min.X), toPlaneX(region.Max.X)
+	top, bottom := toPlaneY(region.Min.Y), toPlaneY(region.Max.Y)
+
+	xmlPayload := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<BLC version="2.0" xmlns="http://www.hikvision.com/ver20/XMLSchema">
+    <enabled>true</enabled>
+    <BLCMode>REGION</BLCMode>
+    <BLCRegionList>
+        <BLCRegion>
+            <id>1</id>
+            <RegionCoordinatesList>
+                <RegionCoordinates><positionX>%d</positionX><positionY>%d</positionY></RegionCoordinates>
+                <RegionCoordinates><positionX>%d</positionX><positionY>%d</positionY></RegionCoordinates>
+                <RegionCoordinates><positionX>%d</positionX><positionY>%d</positionY></RegionCoordinates>
+                <RegionCoordinates><positionX>%d</positionX><positionY>%d</positionY></RegionCoordinates>
+            </RegionCoordinatesList>
+        </BLCRegion>
+    </BLCRegionList>
+</BLC>`, left, bottom, right, bottom, right, top, left, top)
+
+	c.blcMu.Lock()
+	defer c.blcMu.Unlock()
+
+	// Keep the installer's BLC settings so ResetExposureRegion can put them back exactly
+	if c.blcOriginal == nil {
+		original, err := c.isapiRequest("GET", "/ISAPI/Image/channels/1/BLC", "")
+		if err != nil {
+			return fmt.Errorf("failed to read BLC settings before overriding them: %v", err)
+		}
+		c.blcOriginal = original
+	}
+
+	debugMsg("PTZ_IMAGING", fmt.Sprintf("☀️ Setting exposure metering region: pixels %v → ISAPI (%d,%d)-(%d,%d)",
+		region, left, bottom, right, top))
+
+	return c.putImageSetting("/ISAPI/Image/channels/1/BLC", xmlPayload)
+}
+
+// ResetExposureRegion restores the BLC settings the camera had before the first SetExposureRegion
+func (c *HikvisionController) ResetExposureRegion() error {
+	c.blcMu.Lock()
+	defer c.blcMu.Unlock()
+
+	if c.blcOriginal == nil {
+		return nil // Never overridden
+	}
+
+	debugMsg("PTZ_IMAGING", "🔄 Restoring original exposure metering")
+
+	if err := c.putImageSetting("/ISAPI/Image/channels/1/BLC", string(c.blcOriginal)); err != nil {
+		return err
+	}
+	c.blcOriginal = nil // Read again before the next override, in case the settings are changed meanwhile
+	return nil
+}
+
+// TriggerOneShotFocus runs the camera's one-push autofocus once; the camera keeps its focus mode afterwards
+func (c *HikvisionController) TriggerOneShotFocus() error {
+	debugMsg("PTZ_IMAGING", "🎯 Triggering one-shot autofocus")
+
+	// "onepushfoucs" is how the ISAPI resource is spelled
+	_, err := c.isapiRequest("PUT", "/ISAPI/PTZCtrl/channels/1/onepushfoucs/start", "")
+	return err
+}
+
+// putImageSetting sends an XML image-settings payload to the camera using digest authentication
+func (c *HikvisionController) putImageSetting(uri, xmlPayload string) error {
+	_, err := c.isapiRequest("PUT", uri, xmlPayload)
+	return err
+}
@@ -0,0 +1,50 @@
+package ptz
+
+import (
+	"fmt"
+	"strings"
+)
+
+// leaseOverlayURI is the OSD text overlay used to store the instance lease. It stays disabled, so the
+// text is kept on the camera but never burned into the video.
+const leaseOverlayURI = "/ISAPI/System/Video/inputs/channels/1/overlays/text/8"
+
+// ReadLeaseField returns the instance lease stored on the camera ("" when none)
+func (c *HikvisionController) ReadLeaseField() (string, error) {
+	body, err := c.isapiRequest("GET", leaseOverlayURI, "")
+	if err != nil {
+		return "", err
+	}
+	if match := xmlTagValue("displayText").FindStringSubmatch(string(body)); match != nil {
+		return match[1], nil
+	}
+	return "", nil
+}
+
+// WriteLeaseField stores the instance lease on the camera, keeping the overlay hidden
+func (c *HikvisionController) WriteLeaseField(value string) error {
+	body, err := c.isapiRequest("GET", leaseOverlayURI, "")
+	if err != nil {
+		return err
+	}
+
+	document := string(body)
+	setTag := func(tag, value string) {
+		element := fmt.Sprintf("<%s>%s</%s>", tag, value, tag)
+		if pattern := xmlTagValue(tag); pattern.MatchString(document) {
+			document = pattern.ReplaceAllLiteralString(document, element)
+		} else {
+			document = strings.Replace(document, "<"+tag+"/>", element, 1)
+			if !strings.Contains(document, element) {
+				document = strings.Replace(document, "</TextOverlay>", element+"</TextOverlay>", 1)
+			}
+		}
+	}
+	setTag("enabled", "false")
+	setTag("displayText", value)
+
+	if _, err := c.isapiRequest("PUT", leaseOverlayURI, document); err != nil {
+		return fmt.Errorf("failed to update lease overlay: %v", err)
+	}
+	return nil
+}
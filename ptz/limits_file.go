@@ -0,0 +1,104 @@
+package ptz
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LimitsFile is the on-disk form of user soft limits (camera units), written by the live limit editor.
+// Unset values (nil) keep the hardware range for that bound.
+type LimitsFile struct {
+	MinPan  *float64  `json:"min_pan,omitempty"`
+	MaxPan  *float64  `json:"max_pan,omitempty"`
+	MinTilt *float64  `json:"min_tilt,omitempty"`
+	MaxTilt *float64  `json:"max_tilt,omitempty"`
+	MinZoom *float64  `json:"min_zoom,omitempty"`
+	MaxZoom *float64  `json:"max_zoom,omitempty"`
+	Saved   time.Time `json:"saved"`
+}
+
+// LoadLimitsFile reads soft limits written by SaveLimitsFile
+func LoadLimitsFile(path string) (*LimitsFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read limits file: %v", err)
+	}
+
+	var limitsFile LimitsFile
+	if err := json.Unmarshal(data, &limitsFile); err != nil {
+		return nil, fmt.Errorf("failed to parse limits file %s: %v", path, err)
+	}
+	return &limitsFile, nil
+}
+
+// SaveLimitsFile writes soft limits atomically so a crash mid-write never leaves a truncated config
+func SaveLimitsFile(path string, limitsFile *LimitsFile) error {
+	limitsFile.Saved = time.Now()
+
+	data, err := json.MarshalIndent(limitsFile, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode limits: %v", err)
+	}
+
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create limits directory: %v", err)
+		}
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write limits file: %v", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace limits file: %v", err)
+	}
+
+	debugMsg("PTZ_LIMITS", fmt.Sprintf("💾 Saved PTZ limits to %s", path))
+	return nil
+}
+
+// Apply returns limits with every bound set in the file applied as a soft limit, clamped to the hardware range
+func (lf *LimitsFile) Apply(limits PTZLimits) PTZLimits {
+	if lf.MinPan != nil {
+		limits.SoftMinPan = math.Max(*lf.MinPan, limits.HardMinPan)
+	}
+	if lf.MaxPan != nil {
+		limits.SoftMaxPan = math.Min(*lf.MaxPan, limits.HardMaxPan)
+	}
+	if lf.MinTilt != nil {
+		limits.SoftMinTilt = math.Max(*lf.MinTilt, limits.HardMinTilt)
+	}
+	if lf.MaxTilt != nil {
+		limits.SoftMaxTilt = math.Min(*lf.MaxTilt, limits.HardMaxTilt)
+	}
+	if lf.MinZoom != nil {
+		limits.SoftMinZoom = math.Max(*lf.MinZoom, limits.HardMinZoom)
+	}
+	if lf.MaxZoom != nil {
+		limits.SoftMaxZoom = math.Min(*lf.MaxZoom, limits.HardMaxZoom)
+	}
+	return limits
+}
+
+// Validate checks that each captured min/max pair is ordered
+func (lf *LimitsFile) Validate() error {
+	pairs := []struct {
+		axis     string
+		min, max *float64
+	}{
+		{"pan", lf.MinPan, lf.MaxPan},
+		{"tilt", lf.MinTilt, lf.MaxTilt},
+		{"zoom", lf.MinZoom, lf.MaxZoom},
+	}
+	for _, pair := range pairs {
+		if pair.min != nil && pair.max != nil && *pair.min >= *pair.max {
+			return fmt.Errorf("%s minimum %.0f must be below maximum %.0f", pair.axis, *pair.min, *pair.max)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,581 @@
+package ptz
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ONVIF namespaces used in requests
+const (
+	onvifEnvelopeNS = `xmlns:s="http://www.w3.org/2003/05/soap-envelope" xmlns:tds="http://www.onvif.org/ver10/device/wsdl" xmlns:trt="http://www.onvif.org/ver10/media/wsdl" xmlns:tptz="http://www.onvif.org/ver20/ptz/wsdl" xmlns:tt="http://www.onvif.org/ver10/schema"`
+	wsseNS          = "http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-wssecurity-secext-1.0.xsd"
+	wsuNS           = "http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-wssecurity-utility-1.0.xsd"
+	wssePasswordNS  = "http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-username-token-profile-1.0#PasswordDigest"
+	wsseEncodingNS  = "http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-soap-message-security-1.0#Base64Binary"
+)
+
+// DefaultONVIFDevicePath is the standard ONVIF device service endpoint
+const DefaultONVIFDevicePath = "/onvif/device_service"
+
+// ONVIFController implements the Controller interface for ONVIF Profile S cameras (Axis, Dahua, Hikvision
+// with ONVIF enabled, ...). Positions are kept in the same camera units as the Hikvision backend (pan 0-3590,
+// tilt 0-900, zoom 10-120) so the camera state manager, limits and tracking code are backend independent;
+// they are mapped onto ONVIF's normalized generic position spaces on the wire.
+type ONVIFController struct {
+	ip             string
+	port           string
+	user           string
+	pass           string
+	devicePath     string
+	invertTilt     bool // Cameras whose generic tilt space runs +1 = down
+	client         *http.Client
+	commandChan    chan PTZCommand
+	commandLock    chan struct{}
+	mu             sync.RWMutex
+	connected      bool
+	ptzAddr        string            // PTZ service XAddr
+	mediaAddr      string            // Media service XAddr
+	profileToken   string            // Media profile carrying the PTZ configuration
+	presets        map[string]string // Preset name -> preset token
+	clockOffset    time.Duration     // Camera clock minus local clock, for WS-Security timestamps
+	currentPos     PTZPosition
+	activeCommand  string
+	lastCommandEnd time.Time
+	frameWidth     int
+	frameHeight    int
+}
+
+// NewONVIFController creates a new ONVIF PTZ controller. devicePath is the device service path
+// (empty for DefaultONVIFDevicePath).
+func NewONVIFController(ip, port, user, pass, devicePath string, invertTilt bool) Controller {
+	if devicePath == "" || devicePath == "/" {
+		devicePath = DefaultONVIFDevicePath
+	}
+	return &ONVIFController{
+		ip:          ip,
+		port:        port,
+		user:        user,
+		pass:        pass,
+		devicePath:  devicePath,
+		invertTilt:  invertTilt,
+		client:      newPooledClient(5 * time.Second),
+		commandChan: make(chan PTZCommand, 10),
+		commandLock: make(chan struct{}, 1),
+		presets:     make(map[string]string),
+	}
+}
+
+// Start discovers the camera's PTZ service and profile and begins processing PTZ commands
+func (c *ONVIFController) Start() {
+	if err := c.connect(); err != nil {
+		debugMsg("PTZ_WARN", fmt.Sprintf("ONVIF setup failed (will retry on first command): %v", err))
+	} else if pos, err := c.getStatus(); err != nil {
+		debugMsg("PTZ_WARN", fmt.Sprintf("Failed to get initial status: %v", err))
+	} else {
+		c.setCurrentPosition(pos)
+		debugMsg("PTZ", fmt.Sprintf("Initial position - Pan: %.2f, Tilt: %.2f, Zoom: Z%03d",
+			pos.Pan, pos.Tilt, int((pos.Zoom-1)/10)+1))
+	}
+
+	go c.processCommands()
+	go c.monitorStatus()
+}
+
+// Stop stops the PTZ controller
+func (c *ONVIFController) Stop() {
+	close(c.commandChan)
+}
+
+// SendCommand sends a PTZ command to the camera
+func (c *ONVIFController) SendCommand(cmd PTZCommand) bool {
+	select {
+	case c.commandChan <- cmd:
+		return true // Command sent successfully
+	default:
+		return false // Channel full, skip command
+	}
+}
+
+// connect resolves the PTZ and media service addresses, the PTZ profile token and the preset list.
+// It is a no-op once it has succeeded.
+func (c *ONVIFController) connect() error {
+	c.mu.RLock()
+	connected := c.connected
+	c.mu.RUnlock()
+	if connected {
+		return nil
+	}
+
+	deviceAddr := fmt.Sprintf("http://%s:%s%s", c.ip, c.port, c.devicePath)
+
+	// WS-Security timestamps are rejected when the clocks differ by more than a few seconds, so
+	// measure the camera clock first (GetSystemDateAndTime needs no authentication)
+	if body, err := c.soapRequest(deviceAddr, `<tds:GetSystemDateAndTime/>`, false); err == nil {
+		if cameraTime, ok := parseONVIFDateTime(string(body)); ok {
+			c.mu.Lock()
+			c.clockOffset = time.Until(cameraTime)
+			c.mu.Unlock()
+		}
+	}
+
+	body, err := c.soapRequest(deviceAddr, `<tds:GetCapabilities><tds:Category>All</tds:Category></tds:GetCapabilities>`, true)
+	if err != nil {
+		return fmt.Errorf("GetCapabilities failed: %v", err)
+	}
+	ptzAddr := onvifServiceAddr(string(body), "PTZ")
+	if ptzAddr == "" {
+		return fmt.Errorf("camera does not report an ONVIF PTZ service")
+	}
+	mediaAddr := onvifServiceAddr(string(body), "Media")
+	if mediaAddr == "" {
+		mediaAddr = deviceAddr
+	}
+
+	body, err = c.soapRequest(mediaAddr, `<trt:GetProfiles/>`, true)
+	if err != nil {
+		return fmt.Errorf("GetProfiles failed: %v", err)
+	}
+	profileToken := onvifPTZProfileToken(string(body))
+	if profileToken == "" {
+		return fmt.Errorf("no media profile with a PTZ configuration")
+	}
+
+	c.mu.Lock()
+	c.ptzAddr = ptzAddr
+	c.mediaAddr = mediaAddr
+	c.profileToken = profileToken
+	c.connected = true
+	c.mu.Unlock()
+
+	debugMsg("PTZ", fmt.Sprintf("ONVIF connected - PTZ service %s, profile %s", ptzAddr, profileToken))
+
+	if err := c.refreshPresets(); err != nil {
+		debugMsg("PTZ_WARN", fmt.Sprintf("Failed to read ONVIF presets: %v", err))
+	}
+	return nil
+}
+
+// processCommands handles the PTZ command processing loop
+func (c *ONVIFController) processCommands() {
+	for cmd := range c.commandChan {
+		// Acquire command lock to ensure sequential processing
+		c.commandLock <- struct{}{}
+
+		debugMsg("PTZ", fmt.Sprintf("Executing command: %s (Reason: %s)", cmd.Command, cmd.Reason))
+
+		if err := c.connect(); err != nil {
+			debugMsg("PTZ_ERROR", fmt.Sprintf("ONVIF camera not available: %v", err))
+			cmd.finish(err)
+			<-c.commandLock
+			continue
+		}
+
+		var err error
+		current := c.GetCurrentPosition()
+		switch {
+		case cmd.Command == "absolutePosition":
+			if cmd.AbsolutePan == nil || cmd.AbsoluteTilt == nil || cmd.AbsoluteZoom == nil {
+				err = fmt.Errorf("absolutePosition command without a complete position")
+				break
+			}
+			// NOTE: Limit checking is handled in Camera State Manager
+			err = c.withRetry("AbsoluteMove", func() error {
+				return c.AbsoluteMove(PTZPosition{Pan: *cmd.AbsolutePan, Tilt: *cmd.AbsoluteTilt, Zoom: *cmd.AbsoluteZoom})
+			})
+
+		case strings.HasPrefix(cmd.Command, "ISAPI/PTZCtrl/channels/1/presets/"):
+			// Preset commands keep the ISAPI form used by the tracking code: ".../presets/...name=<preset>"
+			parts := strings.Split(cmd.Command, "name=")
+			if len(parts) != 2 {
+				err = fmt.Errorf("invalid preset command format: %s", cmd.Command)
+				break
+			}
+			err = c.withRetry("GotoPreset", func() error { return c.GotoPreset(parts[1]) })
+
+		case cmd.Command == "zoomIn" || cmd.Command == "zoomOut":
+			target := current
+			if cmd.Command == "zoomIn" {
+				target.Zoom = math.Min(current.Zoom+10, 120)
+			} else {
+				target.Zoom = math.Max(current.Zoom-10, 10)
+			}
+			debugMsg("PTZ_DEBUG", fmt.Sprintf("Zoom command - Current zoom: %.0f, Target zoom: %.0f", current.Zoom, target.Zoom))
+			err = c.withRetry("AbsoluteMove", func() error { return c.AbsoluteMove(target) })
+
+		case cmd.Command == "ptzMoveLeft" || cmd.Command == "ptzMoveRight" || cmd.Command == "ptzMoveUp" || cmd.Command == "ptzMoveDown":
+			step := 10.0 // Same step as the Hikvision backend, in camera units
+			var delta PTZPosition
+			switch cmd.Command {
+			case "ptzMoveLeft":
+				delta.Pan = -step
+			case "ptzMoveRight":
+				delta.Pan = step
+			case "ptzMoveUp":
+				delta.Tilt = step
+			case "ptzMoveDown":
+				delta.Tilt = -step
+			}
+			err = c.withRetry("RelativeMove", func() error { return c.RelativeMove(delta) })
+
+		default:
+			err = fmt.Errorf("unknown command: %s", cmd.Command)
+		}
+
+		cmd.finish(err)
+		if err != nil {
+			debugMsg("PTZ_ERROR", fmt.Sprintf("ONVIF command %s failed: %v", cmd.Command, err))
+			<-c.commandLock
+			continue
+		}
+
+		c.mu.Lock()
+		c.activeCommand = cmd.Command
+		c.lastCommandEnd = time.Now()
+		c.mu.Unlock()
+		<-c.commandLock
+	}
+}
+
+// withRetry runs an ONVIF operation up to 3 times
+func (c *ONVIFController) withRetry(operation string, fn func() error) error {
+	var lastErr error
+	for retries := 0; retries < 3; retries++ {
+		if lastErr = fn(); lastErr == nil {
+			return nil
+		}
+		debugMsg("PTZ_WARN", fmt.Sprintf("%s failed (attempt %d/3): %v", operation, retries+1, lastErr))
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("all %s retries failed: %v", operation, lastErr)
+}
+
+// AbsoluteMove moves the camera to a position in camera units
+func (c *ONVIFController) AbsoluteMove(pos PTZPosition) error {
+	x, y, z := c.toONVIF(pos)
+	body := fmt.Sprintf(`<tptz:AbsoluteMove><tptz:ProfileToken>%s</tptz:ProfileToken><tptz:Position><tt:PanTilt x="%.6f" y="%.6f"/><tt:Zoom x="%.6f"/></tptz:Position></tptz:AbsoluteMove>`,
+		xmlEscape(c.profile()), x, y, z)
+	_, err := c.soapRequest(c.ptzService(), body, true)
+	return err
+}
+
+// RelativeMove moves the camera by an offset in camera units
+func (c *ONVIFController) RelativeMove(delta PTZPosition) error {
+	x := delta.Pan / 1800
+	y := -delta.Tilt / 450
+	if c.invertTilt {
+		y = -y
+	}
+	z := delta.Zoom / 110
+	body := fmt.Sprintf(`<tptz:RelativeMove><tptz:ProfileToken>%s</tptz:ProfileToken><tptz:Translation><tt:PanTilt x="%.6f" y="%.6f"/><tt:Zoom x="%.6f"/></tptz:Translation></tptz:RelativeMove>`,
+		xmlEscape(c.profile()), x, y, z)
+	_, err := c.soapRequest(c.ptzService(), body, true)
+	return err
+}
+
+// GotoPreset moves the camera to a named preset. The preset list is refreshed once if the name is unknown.
+func (c *ONVIFController) GotoPreset(presetName string) error {
+	token, ok := c.presetToken(presetName)
+	if !ok {
+		if err := c.refreshPresets(); err != nil {
+			return err
+		}
+		if token, ok = c.presetToken(presetName); !ok {
+			return fmt.Errorf("unknown preset name: %s", presetName)
+		}
+	}
+
+	debugMsg("PTZ", fmt.Sprintf("Moving to preset %s (%s)", token, presetName))
+	body := fmt.Sprintf(`<tptz:GotoPreset><tptz:ProfileToken>%s</tptz:ProfileToken><tptz:PresetToken>%s</tptz:PresetToken></tptz:GotoPreset>`,
+		xmlEscape(c.profile()), xmlEscape(token))
+	_, err := c.soapRequest(c.ptzService(), body, true)
+	return err
+}
+
+// GetPresets returns the camera's presets as name -> preset token
+func (c *ONVIFController) GetPresets() map[string]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	presets := make(map[string]string, len(c.presets))
+	for name, token := range c.presets {
+		presets[name] = token
+	}
+	return presets
+}
+
+// refreshPresets re-reads the preset list from the camera
+func (c *ONVIFController) refreshPresets() error {
+	body, err := c.soapRequest(c.ptzService(), fmt.Sprintf(`<tptz:GetPresets><tptz:ProfileToken>%s</tptz:ProfileToken></tptz:GetPresets>`,
+		xmlEscape(c.profile())), true)
+	if err != nil {
+		return fmt.Errorf("GetPresets failed: %v", err)
+	}
+
+	presets := make(map[string]string)
+	for _, match := range onvifPresetPattern.FindAllStringSubmatch(string(body), -1) {
+		presets[strings.TrimSpace(match[2])] = match[1]
+	}
+
+	c.mu.Lock()
+	c.presets = presets
+	c.mu.Unlock()
+	debugMsg("PTZ", fmt.Sprintf("ONVIF presets: %d", len(presets)))
+	return nil
+}
+
+func (c *ONVIFController) presetToken(name string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	token, ok := c.presets[name]
+	return token, ok
+}
+
+// monitorStatus continuously monitors the camera's PTZ position
+func (c *ONVIFController) monitorStatus() {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		c.mu.RLock()
+		connected := c.connected
+		c.mu.RUnlock()
+		if !connected {
+			continue
+		}
+
+		pos, err := c.getStatus()
+		if err != nil {
+			debugMsg("PTZ_ERROR", fmt.Sprintf("Failed to get status: %v", err))
+			continue
+		}
+		c.setCurrentPosition(pos)
+	}
+}
+
+// getStatus reads the current position (GetStatus) in camera units
+func (c *ONVIFController) getStatus() (PTZPosition, error) {
+	body, err := c.soapRequest(c.ptzService(), fmt.Sprintf(`<tptz:GetStatus><tptz:ProfileToken>%s</tptz:ProfileToken></tptz:GetStatus>`,
+		xmlEscape(c.profile())), true)
+	if err != nil {
+		return PTZPosition{}, fmt.Errorf("failed to get status: %v", err)
+	}
+
+	document := string(body)
+	panTilt := onvifElementPattern("PanTilt").FindStringSubmatch(document)
+	zoom := onvifElementPattern("Zoom").FindStringSubmatch(document)
+	if panTilt == nil || zoom == nil {
+		return PTZPosition{}, fmt.Errorf("status has no position")
+	}
+	x, okX := xmlAttrFloat(panTilt[1], "x")
+	y, okY := xmlAttrFloat(panTilt[1], "y")
+	z, okZ := xmlAttrFloat(zoom[1], "x")
+	if !okX || !okY || !okZ {
+		return PTZPosition{}, fmt.Errorf("failed to decode status position")
+	}
+	return c.fromONVIF(x, y, z), nil
+}
+
+// toONVIF maps camera units onto the generic spaces: pan -1..1, tilt -1..1 (+1 = horizon), zoom 0..1
+func (c *ONVIFController) toONVIF(pos PTZPosition) (float64, float64, float64) {
+	x := clamp(pos.Pan/1800-1, -1, 1)
+	y := clamp(1-pos.Tilt/450, -1, 1)
+	if c.invertTilt {
+		y = -y
+	}
+	z := clamp((pos.Zoom-10)/110, 0, 1)
+	return x, y, z
+}
+
+// fromONVIF maps generic space coordinates back to camera units
+func (c *ONVIFController) fromONVIF(x, y, z float64) PTZPosition {
+	if c.invertTilt {
+		y = -y
+	}
+	return PTZPosition{
+		Pan:  math.Mod((x+1)*1800, 3600),
+		Tilt: (1 - y) * 450,
+		Zoom: 10 + z*110,
+	}
+}
+
+// soapRequest posts a SOAP 1.2 request to an ONVIF service and returns the response body
+func (c *ONVIFController) soapRequest(address, body string, authenticate bool) ([]byte, error) {
+	header := ""
+	if authenticate && c.user != "" {
+		header = "<s:Header>" + c.securityHeader() + "</s:Header>"
+	}
+	envelope := `<?xml version="1.0" encoding="UTF-8"?><s:Envelope ` + onvifEnvelopeNS + `>` + header + `<s:Body>` + body + `</s:Body></s:Envelope>`
+
+	req, err := http.NewRequest("POST", address, strings.NewReader(envelope))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/soap+xml; charset=utf-8")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if match := onvifFaultPattern.FindStringSubmatch(string(respBody)); match != nil {
+			return nil, fmt.Errorf("SOAP fault (status %d): %s", resp.StatusCode, strings.TrimSpace(match[1]))
+		}
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return respBody, nil
+}
+
+// securityHeader builds a WS-Security UsernameToken with a password digest:
+// Base64(SHA1(nonce + created + password))
+func (c *ONVIFController) securityHeader() string {
+	nonce := make([]byte, 16)
+	rand.Read(nonce)
+
+	c.mu.RLock()
+	created := time.Now().Add(c.clockOffset).UTC().Format("2006-01-02T15:04:05Z")
+	c.mu.RUnlock()
+
+	hash := sha1.New()
+	hash.Write(nonce)
+	hash.Write([]byte(created))
+	hash.Write([]byte(c.pass))
+	digest := base64.StdEncoding.EncodeToString(hash.Sum(nil))
+
+	return fmt.Sprintf(`<Security s:mustUnderstand="1" xmlns="%s"><UsernameToken><Username>%s</Username><Password Type="%s">%s</Password><Nonce EncodingType="%s">%s</Nonce><Created xmlns="%s">%s</Created></UsernameToken></Security>`,
+		wsseNS, xmlEscape(c.user), wssePasswordNS, digest, wsseEncodingNS, base64.StdEncoding.EncodeToString(nonce), wsuNS, created)
+}
+
+func (c *ONVIFController) ptzService() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ptzAddr
+}
+
+func (c *ONVIFController) profile() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.profileToken
+}
+
+func (c *ONVIFController) setCurrentPosition(pos PTZPosition) {
+	c.mu.Lock()
+	c.currentPos = pos
+	c.mu.Unlock()
+}
+
+// GetCurrentPosition returns the current PTZ position
+func (c *ONVIFController) GetCurrentPosition() PTZPosition {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.currentPos
+}
+
+// GetFrameWidth returns the frame width in pixels
+func (c *ONVIFController) GetFrameWidth() int {
+	if c.frameWidth > 0 {
+		return c.frameWidth
+	}
+	return 2688 // Fallback to default if not set
+}
+
+// GetFrameHeight returns the frame height in pixels
+func (c *ONVIFController) GetFrameHeight() int {
+	if c.frameHeight > 0 {
+		return c.frameHeight
+	}
+	return 1520 // Fallback to default if not set
+}
+
+// SetFrameDimensions sets the actual frame dimensions
+func (c *ONVIFController) SetFrameDimensions(width, height int) {
+	c.frameWidth = width
+	c.frameHeight = height
+}
+
+// ONVIF responses use varying namespace prefixes, so elements are matched with an optional prefix
+var (
+	onvifPresetPattern      = regexp.MustCompile(`<(?:\w+:)?Preset\b[^>]*\btoken="([^"]+)"[^>]*>\s*<(?:\w+:)?Name>([^<]*)</`)
+	onvifPresetBlockPattern = regexp.MustCompile(`(?s)<(?:\w+:)?Preset\b[^>]*>(.*?)</(?:\w+:)?Preset>`)
+	onvifProfilePattern     = regexp.MustCompile(`(?s)<(?:\w+:)?Profiles\b[^>]*\btoken="([^"]+)"[^>]*>(.*?)</(?:\w+:)?Profiles>`)
+	onvifFaultPattern       = regexp.MustCompile(`<(?:\w+:)?Text[^>]*>([^<]*)</`)
+)
+
+// onvifElementPattern matches an element's attribute list, e.g. <tt:PanTilt x="0.1" y="0.2" space="..."/>
+func onvifElementPattern(name string) *regexp.Regexp {
+	return regexp.MustCompile(`<(?:\w+:)?` + name + `\b([^>]*)/?>`)
+}
+
+// onvifServiceAddr extracts a service XAddr from a GetCapabilities response
+func onvifServiceAddr(document, service string) string {
+	pattern := regexp.MustCompile(`(?s)<(?:\w+:)?` + service + `>\s*<(?:\w+:)?XAddr>\s*([^<]+?)\s*</`)
+	if match := pattern.FindStringSubmatch(document); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// onvifPTZProfileToken returns the first media profile with a PTZ configuration
+func onvifPTZProfileToken(document string) string {
+	for _, match := range onvifProfilePattern.FindAllStringSubmatch(document, -1) {
+		if strings.Contains(match[2], "PTZConfiguration") {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+// parseONVIFDateTime reads the UTC time from a GetSystemDateAndTime response
+func parseONVIFDateTime(document string) (time.Time, bool) {
+	utc := regexp.MustCompile(`(?s)<(?:\w+:)?UTCDateTime>(.*?)</(?:\w+:)?UTCDateTime>`).FindStringSubmatch(document)
+	if utc == nil {
+		return time.Time{}, false
+	}
+	field := func(name string) int {
+		match := regexp.MustCompile(`<(?:\w+:)?` + name + `>\s*(\d+)\s*</`).FindStringSubmatch(utc[1])
+		if match == nil {
+			return -1
+		}
+		value, _ := strconv.Atoi(match[1])
+		return value
+	}
+	year, month, day := field("Year"), field("Month"), field("Day")
+	hour, minute, second := field("Hour"), field("Minute"), field("Second")
+	if year < 0 || month < 0 || day < 0 || hour < 0 || minute < 0 || second < 0 {
+		return time.Time{}, false
+	}
+	return time.Date(year, time.Month(month), day, hour, minute, second, 0, time.UTC), true
+}
+
+// xmlAttrFloat reads a numeric attribute from an element's attribute list
+func xmlAttrFloat(attributes, name string) (float64, bool) {
+	match := regexp.MustCompile(`\b` + name + `="([^"]+)"`).FindStringSubmatch(attributes)
+	if match == nil {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	return value, err == nil
+}
+
+// xmlEscape escapes text for use in an XML element or attribute
+func xmlEscape(value string) string {
+	var builder strings.Builder
+	xml.EscapeText(&builder, []byte(value))
+	return builder.String()
+}
@@ -0,0 +1,257 @@
+package ptz
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Preset sources
+const (
+	PresetFromConfig = "config"   // Defined in the presets file
+	PresetFromCamera = "camera"   // Learned from the camera's own preset list
+	PresetCaptured   = "captured" // Captured from the camera's current position at runtime
+)
+
+// Preset is a named absolute camera position
+type Preset struct {
+	Name   string  `json:"name"`
+	Pan    float64 `json:"pan"`
+	Tilt   float64 `json:"tilt"`
+	Zoom   float64 `json:"zoom"`
+	Source string  `json:"source,omitempty"`
+}
+
+// Position returns the preset as a camera position
+func (p Preset) Position() PTZPosition {
+	return PTZPosition{Pan: p.Pan, Tilt: p.Tilt, Zoom: p.Zoom}
+}
+
+// validate checks a preset's name and position
+func (p *Preset) validate() error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" || strings.Contains(p.Name, "/") {
+		return fmt.Errorf("preset needs a name without '/'")
+	}
+	if p.Pan < 0 || p.Pan >= 3600 || p.Tilt < 0 || p.Tilt > 900 || p.Zoom < 10 {
+		return fmt.Errorf("preset %q: position Pan=%.0f Tilt=%.0f Zoom=%.0f is outside the camera range", p.Name, p.Pan, p.Tilt, p.Zoom)
+	}
+	return nil
+}
+
+// PresetLister defines cameras that can report the positions of their own presets
+type PresetLister interface {
+	CameraPresets() ([]Preset, error)
+}
+
+// PresetStore holds the named presets and saves changes to the presets file (when one is configured)
+type PresetStore struct {
+	mu      sync.RWMutex
+	path    string
+	presets map[string]Preset
+}
+
+// LoadPresets reads a presets file (a JSON list of presets). A missing file is an empty store that is
+// created on the first change; an empty path keeps presets in memory only.
+func LoadPresets(path string) (*PresetStore, error) {
+	store := &PresetStore{path: path, presets: make(map[string]Preset)}
+	if path == "" {
+		return store, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read presets file: %v", err)
+	}
+
+	var presets []Preset
+	if err := json.Unmarshal(data, &presets); err != nil {
+		return nil, fmt.Errorf("failed to parse presets file %s: %v", path, err)
+	}
+	for _, preset := range presets {
+		if err := preset.validate(); err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		if _, exists := store.presets[preset.Name]; exists {
+			return nil, fmt.Errorf("%s: duplicate preset %q", path, preset.Name)
+		}
+		if preset.Source == "" {
+			preset.Source = PresetFromConfig
+		}
+		store.presets[preset.Name] = preset
+	}
+	return store, nil
+}
+
+// Get returns a preset by name
+func (s *PresetStore) Get(name string) (Preset, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	preset, ok := s.presets[name]
+	return preset, ok
+}
+
+// List returns every preset sorted by name
+func (s *PresetStore) List() []Preset {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	presets := make([]Preset, 0, len(s.presets))
+	for _, preset := range s.presets {
+		presets = append(presets, preset)
+	}
+	sort.Slice(presets, func(i, j int) bool { return presets[i].Name < presets[j].Name })
+	return presets
+}
+
+// Put adds or replaces a preset and saves the file
+func (s *PresetStore) Put(preset Preset) error {
+	if err := preset.validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.presets[preset.Name] = preset
+	return s.save()
+}
+
+// Delete removes a preset and saves the file
+func (s *PresetStore) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.presets[name]; !ok {
+		return fmt.Errorf("unknown preset %q", name)
+	}
+	delete(s.presets, name)
+	return s.save()
+}
+
+// Learn adds the camera's presets that are not defined yet (the file wins over the camera) and returns how
+// many were added. Learned presets are written to the file with the next change.
+func (s *PresetStore) Learn(presets []Preset) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	learned := 0
+	for _, preset := range presets {
+		if err := preset.validate(); err != nil {
+			debugMsg("PTZ_PRESETS", fmt.Sprintf("⚠️ Ignoring camera preset: %v", err))
+			continue
+		}
+		if _, exists := s.presets[preset.Name]; exists {
+			continue
+		}
+		preset.Source = PresetFromCamera
+		s.presets[preset.Name] = preset
+		learned++
+	}
+	return learned
+}
+
+// save writes the presets atomically. Must be called with s.mu held.
+func (s *PresetStore) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	presets := make([]Preset, 0, len(s.presets))
+	for _, preset := range s.presets {
+		presets = append(presets, preset)
+	}
+	sort.Slice(presets, func(i, j int) bool { return presets[i].Name < presets[j].Name })
+	data, err := json.MarshalIndent(presets, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode presets: %v", err)
+	}
+
+	if dir := filepath.Dir(s.path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create presets directory: %v", err)
+		}
+	}
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write presets file: %v", err)
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return fmt.Errorf("failed to replace presets file: %v", err)
+	}
+	debugMsg("PTZ_PRESETS", fmt.Sprintf("💾 Saved %d presets to %s", len(presets), s.path))
+	return nil
+}
+
+// hikvisionPresetList is the ISAPI preset list
+type hikvisionPresetList struct {
+	Presets []struct {
+		ID       int         `xml:"id"`
+		Name     string      `xml:"presetName"`
+		Enabled  *bool       `xml:"enabled"`
+		Position PTZPosition `xml:"AbsoluteHigh"`
+	} `xml:"PTZPreset"`
+}
+
+// CameraPresets reads the camera's configured presets with their positions
+func (c *HikvisionController) CameraPresets() ([]Preset, error) {
+	body, err := c.isapiRequest("GET", "/ISAPI/PTZCtrl/channels/1/presets", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read presets: %v", err)
+	}
+
+	var list hikvisionPresetList
+	if err := xml.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("failed to decode presets: %v", err)
+	}
+	var presets []Preset
+	for _, p := range list.Presets {
+		if p.Enabled != nil && !*p.Enabled {
+			continue
+		}
+		name := strings.TrimSpace(p.Name)
+		if name == "" {
+			name = fmt.Sprintf("preset%d", p.ID)
+		}
+		presets = append(presets, Preset{Name: name, Pan: p.Position.Pan, Tilt: p.Position.Tilt, Zoom: p.Position.Zoom})
+	}
+	return presets, nil
+}
+
+// CameraPresets reads the camera's presets with their positions (presets stored without a position are
+// skipped)
+func (c *ONVIFController) CameraPresets() ([]Preset, error) {
+	body, err := c.soapRequest(c.ptzService(), fmt.Sprintf(`<tptz:GetPresets><tptz:ProfileToken>%s</tptz:ProfileToken></tptz:GetPresets>`,
+		xmlEscape(c.profile())), true)
+	if err != nil {
+		return nil, fmt.Errorf("GetPresets failed: %v", err)
+	}
+
+	var presets []Preset
+	document := string(body)
+	for _, match := range onvifPresetBlockPattern.FindAllStringSubmatch(document, -1) {
+		block := match[1]
+		name := onvifPresetPattern.FindStringSubmatch(match[0])
+		panTilt := onvifElementPattern("PanTilt").FindStringSubmatch(block)
+		zoom := onvifElementPattern("Zoom").FindStringSubmatch(block)
+		if name == nil || panTilt == nil || zoom == nil {
+			continue
+		}
+		x, okX := xmlAttrFloat(panTilt[1], "x")
+		y, okY := xmlAttrFloat(panTilt[1], "y")
+		z, okZ := xmlAttrFloat(zoom[1], "x")
+		if !okX || !okY || !okZ {
+			continue
+		}
+		position := c.fromONVIF(x, y, z)
+		presets = append(presets, Preset{Name: strings.TrimSpace(name[2]), Pan: position.Pan, Tilt: position.Tilt, Zoom: position.Zoom})
+	}
+	return presets, nil
+}
@@ -0,0 +1,325 @@
+package ptz
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // Sprite formats
+	_ "image/png"
+	"math"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"rivercam/calibration"
+)
+
+// SceneConfig describes the synthetic river a simulated camera looks at. Positions are in the camera's
+// pan/tilt units, so boats keep their place in the world while the camera moves.
+type SceneConfig struct {
+	Width, Height  int         // Frame size in pixels
+	Boats          int         // Boats on the river
+	BoatSpeed      float64     // Pan units per second (boats alternate direction and vary ±30%)
+	BoatLength     float64     // Boat length in pan units
+	RiverTilt      float64     // Tilt of the boats' course (boats are spread ±20 units around it)
+	PanMin, PanMax float64     // Stretch of river the boats travel; they turn around at the ends
+	HorizonTilt    float64     // Tilt of the far bank (sky above it)
+	Sprite         image.Image // Boat image drawn facing right (nil: a drawn boat)
+}
+
+// ParseSceneConfig reads a synthetic scene from a sim:// input URL, e.g.
+// sim://?size=1920x1080&boats=2&boat-speed=20&boat-length=12&river-tilt=200&pan-min=2100&pan-max=2700&horizon-tilt=60&sprite=/path/boat.png
+func ParseSceneConfig(rawURL string) (SceneConfig, error) {
+	config := SceneConfig{
+		Width: 1920, Height: 1080,
+		Boats:       1,
+		BoatSpeed:   20,
+		BoatLength:  12,
+		RiverTilt:   200,
+		PanMin:      2100,
+		PanMax:      2700,
+		HorizonTilt: 60,
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || !strings.EqualFold(u.Scheme, "sim") {
+		return config, fmt.Errorf("synthetic input must be a sim:// URL, got %q", rawURL)
+	}
+
+	numbers := map[string]*float64{
+		"boat-speed":   &config.BoatSpeed,
+		"boat-length":  &config.BoatLength,
+		"river-tilt":   &config.RiverTilt,
+		"pan-min":      &config.PanMin,
+		"pan-max":      &config.PanMax,
+		"horizon-tilt": &config.HorizonTilt,
+	}
+	for key, values := range u.Query() {
+		value := values[0]
+		switch key {
+		case "size":
+			if _, err := fmt.Sscanf(value, "%dx%d", &config.Width, &config.Height); err != nil || config.Width < 64 || config.Height < 64 {
+				return config, fmt.Errorf("invalid synthetic frame size %q (use WIDTHxHEIGHT)", value)
+			}
+		case "boats":
+			boats, err := strconv.Atoi(value)
+			if err != nil || boats < 0 {
+				return config, fmt.Errorf("invalid synthetic boat count %q", value)
+			}
+			config.Boats = boats
+		case "sprite":
+			sprite, err := loadSprite(value)
+			if err != nil {
+				return config, err
+			}
+			config.Sprite = sprite
+		default:
+			target, ok := numbers[key]
+			if !ok {
+				return config, fmt.Errorf("unknown synthetic scene setting %q", key)
+			}
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return config, fmt.Errorf("invalid synthetic scene %s %q", key, value)
+			}
+			*target = number
+		}
+	}
+	if config.PanMax <= config.PanMin || config.BoatLength <= 0 {
+		return config, fmt.Errorf("synthetic scene needs pan-min < pan-max and a positive boat-length")
+	}
+	return config, nil
+}
+
+func loadSprite(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open boat sprite: %v", err)
+	}
+	defer file.Close()
+	sprite, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode boat sprite %s: %v", path, err)
+	}
+	return sprite, nil
+}
+
+// SimulatedBoat is a boat in the synthetic scene: the ground truth tracking results can be compared with
+type SimulatedBoat struct {
+	Pan   float64 // Center, camera pan units
+	Tilt  float64 // Center, camera tilt units
+	Speed float64 // Pan units per second; negative moves left
+}
+
+// SimulatedScene renders what a SimulatedController sees: sky, water and boats moving along the river,
+// drawn at the pixel positions the calibration table gives for the camera's current pan, tilt and zoom
+type SimulatedScene struct {
+	camera *SimulatedController
+	config SceneConfig
+	table  *calibration.Table
+
+	mu    sync.Mutex
+	boats []SimulatedBoat
+	last  time.Time
+}
+
+// NewSimulatedScene creates a scene for the simulated camera (table nil: the built-in calibration)
+func NewSimulatedScene(camera *SimulatedController, config SceneConfig, table *calibration.Table) *SimulatedScene {
+	if table == nil {
+		table = calibration.DefaultTable()
+	}
+	scene := &SimulatedScene{camera: camera, config: config, table: table}
+	span := config.PanMax - config.PanMin
+	for i := 0; i < config.Boats; i++ {
+		speed := config.BoatSpeed * (1 + 0.3*math.Sin(float64(i)*2.1))
+		if i%2 == 1 {
+			speed = -speed
+		}
+		scene.boats = append(scene.boats, SimulatedBoat{
+			Pan:   config.PanMin + span*(float64(i)+0.5)/float64(config.Boats),
+			Tilt:  config.RiverTilt + 20*math.Sin(float64(i)*1.3),
+			Speed: speed,
+		})
+	}
+	debugMsg("PTZ", fmt.Sprintf("🧪 Synthetic scene %dx%d: %d boat(s) at tilt %.0f between pan %.0f and %.0f",
+		config.Width, config.Height, config.Boats, config.RiverTilt, config.PanMin, config.PanMax))
+	return scene
+}
+
+// Boats returns where the boats are now
+func (s *SimulatedScene) Boats() []SimulatedBoat {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SimulatedBoat(nil), s.boats...)
+}
+
+// advance moves the boats to time now. Must be called with s.mu held.
+func (s *SimulatedScene) advance(now time.Time) {
+	if !s.last.IsZero() {
+		elapsed := math.Min(now.Sub(s.last).Seconds(), 1) // A stalled reader must not teleport boats
+		for i := range s.boats {
+			boat := &s.boats[i]
+			boat.Pan += boat.Speed * elapsed
+			if boat.Pan > s.config.PanMax {
+				boat.Pan = 2*s.config.PanMax - boat.Pan
+				boat.Speed = -boat.Speed
+			} else if boat.Pan < s.config.PanMin {
+				boat.Pan = 2*s.config.PanMin - boat.Pan
+				boat.Speed = -boat.Speed
+			}
+		}
+	}
+	s.last = now
+}
+
+// Render advances the boats to now and draws the camera's view
+func (s *SimulatedScene) Render(now time.Time) *image.RGBA {
+	s.mu.Lock()
+	s.advance(now)
+	boats := append([]SimulatedBoat(nil), s.boats...)
+	s.mu.Unlock()
+
+	pose := s.camera.Pose()
+	panPixelsPerUnit, tiltPixelsPerUnit := s.table.InterpolateAt(pose.Zoom)
+	if panPixelsPerUnit <= 0 || tiltPixelsPerUnit <= 0 {
+		panPixelsPerUnit, tiltPixelsPerUnit = calibration.DefaultTable().InterpolateAt(pose.Zoom)
+	}
+	width, height := s.config.Width, s.config.Height
+	frame := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	// Sky above the far bank, water below it with faint swell bands fixed in the world, so camera motion
+	// shows up in the image the way it does on a real river
+	for y := 0; y < height; y++ {
+		tilt := pose.Tilt + float64(y-height/2)/tiltPixelsPerUnit
+		var row color.RGBA
+		if tilt < s.config.HorizonTilt {
+			shade := uint8(math.Max(0, math.Min(60, (s.config.HorizonTilt-tilt)/4)))
+			row = color.RGBA{R: 170 - shade, G: 200 - shade/2, B: 235, A: 255}
+		} else {
+			swell := 6 * math.Sin(tilt*0.6)
+			row = color.RGBA{R: uint8(30 + swell), G: uint8(70 + swell), B: uint8(95 + swell), A: 255}
+		}
+		fillRow(frame, y, 0, width, row)
+	}
+
+	// Bank markers every 50 pan units give the image texture to follow while panning
+	horizonY := height/2 + int((s.config.HorizonTilt-pose.Tilt)*tiltPixelsPerUnit)
+	for marker := math.Ceil((pose.Pan-float64(width)/2/panPixelsPerUnit)/50) * 50; ; marker += 50 {
+		x := width/2 + int((marker-pose.Pan)*panPixelsPerUnit)
+		if x >= width {
+			break
+		}
+		markerHeight := int(3 * tiltPixelsPerUnit)
+		fillRect(frame, image.Rect(x-2, horizonY-markerHeight, x+2, horizonY), color.RGBA{R: 60, G: 55, B: 45, A: 255})
+	}
+
+	for _, boat := range boats {
+		panOffset := math.Mod(boat.Pan-pose.Pan+5400, 3600) - 1800 // Short way around the wrap
+		centerX := float64(width)/2 + panOffset*panPixelsPerUnit
+		centerY := float64(height)/2 + (boat.Tilt-pose.Tilt)*tiltPixelsPerUnit
+		length := s.config.BoatLength * panPixelsPerUnit
+		bounds := image.Rect(int(centerX-length/2), int(centerY-length*0.2), int(centerX+length/2), int(centerY+length*0.2))
+		if !bounds.Overlaps(frame.Bounds()) || length < 2 {
+			continue
+		}
+		if s.config.Sprite != nil {
+			drawSprite(frame, s.config.Sprite, bounds, boat.Speed < 0)
+		} else {
+			drawBoat(frame, bounds, boat.Speed < 0)
+		}
+	}
+	return frame
+}
+
+// drawBoat draws a simple motorboat (hull, cabin, wake) into bounds, bow in the direction of travel
+func drawBoat(frame *image.RGBA, bounds image.Rectangle, facingLeft bool) {
+	length, height := bounds.Dx(), bounds.Dy()
+	hullTop := bounds.Min.Y + height/2
+	bow := func(x int) int { // Mirror x inside bounds for boats heading left
+		if facingLeft {
+			return bounds.Max.X - (x - bounds.Min.X) - 1
+		}
+		return x
+	}
+
+	// Hull tapers toward the bow and is narrower at the waterline
+	for y := hullTop; y < bounds.Max.Y; y++ {
+		depth := float64(y-hullTop) / float64(bounds.Max.Y-hullTop)
+		sternInset := int(depth * float64(length) * 0.05)
+		bowInset := int((0.1 + depth*0.2) * float64(length))
+		from, to := bow(bounds.Min.X+sternInset), bow(bounds.Max.X-bowInset)
+		if from > to {
+			from, to = to, from
+		}
+		fillRow(frame, y, from, to, color.RGBA{R: 235, G: 235, B: 230, A: 255})
+	}
+	// Stripe along the hull and a cabin aft of midships
+	stripe := hullTop + (bounds.Max.Y-hullTop)/3
+	from, to := bow(bounds.Min.X+length/20), bow(bounds.Max.X-length/8)
+	if from > to {
+		from, to = to, from
+	}
+	fillRect(frame, image.Rect(from, stripe, to, stripe+max(1, height/12)), color.RGBA{R: 160, G: 30, B: 30, A: 255})
+	from, to = bow(bounds.Min.X+length/5), bow(bounds.Min.X+length*11/20)
+	if from > to {
+		from, to = to, from
+	}
+	fillRect(frame, image.Rect(from, bounds.Min.Y+height/6, to, hullTop), color.RGBA{R: 210, G: 215, B: 220, A: 255})
+	fillRect(frame, image.Rect(from+length/40, bounds.Min.Y+height/4, to-length/40, bounds.Min.Y+height/4+max(1, height/8)), color.RGBA{R: 40, G: 60, B: 80, A: 255})
+
+	// Wake behind the stern
+	wakeFrom, wakeTo := bounds.Min.X-length/3, bounds.Min.X
+	if facingLeft {
+		wakeFrom, wakeTo = bounds.Max.X, bounds.Max.X+length/3
+	}
+	fillRect(frame, image.Rect(wakeFrom, bounds.Max.Y-max(1, height/8), wakeTo, bounds.Max.Y), color.RGBA{R: 200, G: 215, B: 220, A: 255})
+}
+
+// drawSprite scales the sprite into bounds (nearest neighbor, alpha blended), mirrored for boats heading left
+func drawSprite(frame *image.RGBA, sprite image.Image, bounds image.Rectangle, mirror bool) {
+	source := sprite.Bounds()
+	// Keep the sprite's aspect ratio: height follows from the boat length
+	height := bounds.Dx() * source.Dy() / max(1, source.Dx())
+	top := bounds.Min.Y + bounds.Dy()/2 - height/2
+	target := image.Rect(bounds.Min.X, top, bounds.Max.X, top+height).Intersect(frame.Bounds())
+
+	for y := target.Min.Y; y < target.Max.Y; y++ {
+		sy := source.Min.Y + (y-top)*source.Dy()/max(1, height)
+		for x := target.Min.X; x < target.Max.X; x++ {
+			sx := source.Min.X + (x-bounds.Min.X)*source.Dx()/max(1, bounds.Dx())
+			if mirror {
+				sx = source.Max.X - 1 - (sx - source.Min.X)
+			}
+			r, g, b, a := sprite.At(sx, sy).RGBA()
+			if a == 0 {
+				continue
+			}
+			offset := frame.PixOffset(x, y)
+			pixel := frame.Pix[offset : offset+3 : offset+3]
+			pixel[0] = uint8((r + uint32(pixel[0])*(0xffff-a)/0xff) >> 8)
+			pixel[1] = uint8((g + uint32(pixel[1])*(0xffff-a)/0xff) >> 8)
+			pixel[2] = uint8((b + uint32(pixel[2])*(0xffff-a)/0xff) >> 8)
+		}
+	}
+}
+
+// fillRow fills pixels [from, to) of row y, clipped to the frame
+func fillRow(frame *image.RGBA, y, from, to int, c color.RGBA) {
+	bounds := frame.Bounds()
+	if y < bounds.Min.Y || y >= bounds.Max.Y {
+		return
+	}
+	from, to = max(from, bounds.Min.X), min(to, bounds.Max.X)
+	for x := from; x < to; x++ {
+		offset := frame.PixOffset(x, y)
+		frame.Pix[offset], frame.Pix[offset+1], frame.Pix[offset+2], frame.Pix[offset+3] = c.R, c.G, c.B, c.A
+	}
+}
+
+func fillRect(frame *image.RGBA, rect image.Rectangle, c color.RGBA) {
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		fillRow(frame, y, rect.Min.X, rect.Max.X, c)
+	}
+}
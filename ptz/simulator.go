@@ -0,0 +1,263 @@
+package ptz
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Simulator defaults: roughly a mid-range PTZ dome
+const (
+	DefaultSimPanSpeed  = 600.0 // Camera units per second (60°/s)
+	DefaultSimTiltSpeed = 300.0
+	DefaultSimZoomSpeed = 60.0 // Full zoom range in a little under 2 seconds
+	DefaultSimLatency   = 100 * time.Millisecond
+	simTickInterval     = 20 * time.Millisecond
+)
+
+// SimulatorConfig describes how the simulated camera moves and reports its position
+type SimulatorConfig struct {
+	PanSpeed       float64       // Pan speed in camera units per second
+	TiltSpeed      float64       // Tilt speed in camera units per second
+	ZoomSpeed      float64       // Zoom speed in zoom units per second
+	PositionNoise  float64       // Standard deviation of the reported pan/tilt (camera units)
+	CommandLatency time.Duration // Delay between a command being sent and the camera starting to move
+	Start          PTZPosition   // Initial position
+}
+
+// ParseSimulatorConfig reads the simulator settings from a sim:// PTZ URL's query, e.g.
+// sim://?pan-speed=400&tilt-speed=200&zoom-speed=40&noise=1.5&latency=250ms&pan=2570&tilt=130&zoom=50
+func ParseSimulatorConfig(query url.Values) (SimulatorConfig, error) {
+	config := SimulatorConfig{
+		PanSpeed:       DefaultSimPanSpeed,
+		TiltSpeed:      DefaultSimTiltSpeed,
+		ZoomSpeed:      DefaultSimZoomSpeed,
+		CommandLatency: DefaultSimLatency,
+		Start:          PTZPosition{Pan: 2570, Tilt: 130, Zoom: 50},
+	}
+	numbers := map[string]*float64{
+		"pan-speed":  &config.PanSpeed,
+		"tilt-speed": &config.TiltSpeed,
+		"zoom-speed": &config.ZoomSpeed,
+		"noise":      &config.PositionNoise,
+		"pan":        &config.Start.Pan,
+		"tilt":       &config.Start.Tilt,
+		"zoom":       &config.Start.Zoom,
+	}
+	for key, values := range query {
+		if key == "latency" {
+			latency, err := time.ParseDuration(values[0])
+			if err != nil || latency < 0 {
+				return config, fmt.Errorf("invalid simulator latency %q", values[0])
+			}
+			config.CommandLatency = latency
+			continue
+		}
+		target, ok := numbers[key]
+		if !ok {
+			return config, fmt.Errorf("unknown simulator setting %q", key)
+		}
+		value, err := strconv.ParseFloat(values[0], 64)
+		if err != nil || value < 0 {
+			return config, fmt.Errorf("invalid simulator %s %q", key, values[0])
+		}
+		*target = value
+	}
+	if config.PanSpeed == 0 || config.TiltSpeed == 0 || config.ZoomSpeed == 0 {
+		return config, fmt.Errorf("simulator speeds must be positive")
+	}
+	return config, nil
+}
+
+// SimulatedController implements the Controller interface for a camera that only exists in memory. It moves
+// toward commanded positions at its configured per-axis speeds, after a command latency, and reports its
+// position with optional noise, so tracking changes can be exercised without hardware (see SimulatedScene
+// for the matching synthetic video).
+type SimulatedController struct {
+	config      SimulatorConfig
+	commandChan chan PTZCommand
+	stop        chan struct{}
+	stopOnce    sync.Once
+	mu          sync.RWMutex
+	actual      PTZPosition // Where the simulated camera really points
+	target      PTZPosition
+	rng         *rand.Rand
+	frameWidth  int
+	frameHeight int
+}
+
+// NewSimulatedController creates a simulated PTZ camera
+func NewSimulatedController(config SimulatorConfig) *SimulatedController {
+	start := clampSimPosition(config.Start)
+	return &SimulatedController{
+		config:      config,
+		commandChan: make(chan PTZCommand, 10),
+		stop:        make(chan struct{}),
+		actual:      start,
+		target:      start,
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Start begins processing PTZ commands and moving the simulated camera
+func (c *SimulatedController) Start() {
+	debugMsg("PTZ", fmt.Sprintf("🧪 Simulated camera at Pan: %.0f, Tilt: %.0f, Zoom: %.0f (speeds %.0f/%.0f/%.0f units/s, noise %.1f, latency %v)",
+		c.actual.Pan, c.actual.Tilt, c.actual.Zoom, c.config.PanSpeed, c.config.TiltSpeed, c.config.ZoomSpeed,
+		c.config.PositionNoise, c.config.CommandLatency))
+	go c.processCommands()
+	go c.move()
+}
+
+// Stop stops the simulated camera
+func (c *SimulatedController) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+		close(c.commandChan)
+	})
+}
+
+// SendCommand queues a PTZ command
+func (c *SimulatedController) SendCommand(cmd PTZCommand) bool {
+	select {
+	case c.commandChan <- cmd:
+		return true // Command sent successfully
+	default:
+		return false // Channel full, skip command
+	}
+}
+
+// processCommands turns commands into new target positions after the command latency
+func (c *SimulatedController) processCommands() {
+	for cmd := range c.commandChan {
+		debugMsg("PTZ", fmt.Sprintf("Executing command: %s (Reason: %s)", cmd.Command, cmd.Reason))
+		time.Sleep(c.config.CommandLatency)
+
+		c.mu.Lock()
+		target := c.target
+		switch cmd.Command {
+		case "absolutePosition":
+			if cmd.AbsolutePan == nil || cmd.AbsoluteTilt == nil || cmd.AbsoluteZoom == nil {
+				debugMsg("PTZ_ERROR", "absolutePosition command without a complete position")
+				cmd.finish(fmt.Errorf("absolutePosition command without a complete position"))
+				c.mu.Unlock()
+				continue
+			}
+			target = PTZPosition{Pan: *cmd.AbsolutePan, Tilt: *cmd.AbsoluteTilt, Zoom: *cmd.AbsoluteZoom}
+		case "zoomIn":
+			target.Zoom += 10
+		case "zoomOut":
+			target.Zoom -= 10
+		case "ptzMoveLeft":
+			target.Pan -= 10 // Same step as the Hikvision backend, in camera units
+		case "ptzMoveRight":
+			target.Pan += 10
+		case "ptzMoveUp":
+			target.Tilt += 10
+		case "ptzMoveDown":
+			target.Tilt -= 10
+		default:
+			if strings.HasPrefix(cmd.Command, "ISAPI/PTZCtrl/channels/1/presets/") {
+				debugMsg("PTZ_ERROR", "Presets are not supported by the simulated camera")
+				cmd.finish(fmt.Errorf("presets are not supported by the simulated camera"))
+			} else {
+				debugMsg("PTZ_ERROR", fmt.Sprintf("Unknown command: %s", cmd.Command))
+				cmd.finish(fmt.Errorf("unknown command: %s", cmd.Command))
+			}
+			c.mu.Unlock()
+			continue
+		}
+		c.target = clampSimPosition(target)
+		c.mu.Unlock()
+		cmd.finish(nil)
+	}
+}
+
+// move advances the simulated camera toward its target at the configured speeds
+func (c *SimulatedController) move() {
+	ticker := time.NewTicker(simTickInterval)
+	defer ticker.Stop()
+	last := time.Now()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case now := <-ticker.C:
+			elapsed := now.Sub(last).Seconds()
+			last = now
+
+			c.mu.Lock()
+			// Pan takes the short way around the 0/3600 wrap
+			panDelta := math.Mod(c.target.Pan-c.actual.Pan+5400, 3600) - 1800
+			c.actual.Pan = math.Mod(c.actual.Pan+approach(0, panDelta, c.config.PanSpeed*elapsed)+3600, 3600)
+			c.actual.Tilt = approach(c.actual.Tilt, c.target.Tilt, c.config.TiltSpeed*elapsed)
+			c.actual.Zoom = approach(c.actual.Zoom, c.target.Zoom, c.config.ZoomSpeed*elapsed)
+			c.mu.Unlock()
+		}
+	}
+}
+
+// approach moves value toward target by at most step
+func approach(value, target, step float64) float64 {
+	if math.Abs(target-value) <= step {
+		return target
+	}
+	if target > value {
+		return value + step
+	}
+	return value - step
+}
+
+// clampSimPosition keeps a position inside the hardware ranges (pan 0-3590 wraps)
+func clampSimPosition(pos PTZPosition) PTZPosition {
+	pos.Pan = math.Mod(math.Mod(pos.Pan, 3600)+3600, 3600)
+	pos.Tilt = math.Max(0, math.Min(900, pos.Tilt))
+	pos.Zoom = math.Max(10, math.Min(120, pos.Zoom))
+	return pos
+}
+
+// GetCurrentPosition returns the position as the camera reports it (with the configured noise)
+func (c *SimulatedController) GetCurrentPosition() PTZPosition {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	pos := c.actual
+	if c.config.PositionNoise > 0 {
+		pos.Pan += c.rng.NormFloat64() * c.config.PositionNoise
+		pos.Tilt += c.rng.NormFloat64() * c.config.PositionNoise
+	}
+	return pos
+}
+
+// Pose returns where the simulated camera really points (no noise), for rendering synthetic frames
+func (c *SimulatedController) Pose() PTZPosition {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.actual
+}
+
+// GetFrameWidth returns the frame width in pixels
+func (c *SimulatedController) GetFrameWidth() int {
+	if c.frameWidth > 0 {
+		return c.frameWidth
+	}
+	return 2688 // Fallback to default if not set
+}
+
+// GetFrameHeight returns the frame height in pixels
+func (c *SimulatedController) GetFrameHeight() int {
+	if c.frameHeight > 0 {
+		return c.frameHeight
+	}
+	return 1520 // Fallback to default if not set
+}
+
+// SetFrameDimensions sets the actual frame dimensions
+func (c *SimulatedController) SetFrameDimensions(width, height int) {
+	c.frameWidth = width
+	c.frameHeight = height
+}
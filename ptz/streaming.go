@@ -0,0 +1,119 @@
+package ptz
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// StreamProfile is the video encoding of one camera streaming channel
+type StreamProfile struct {
+	Width       int `json:"width"`
+	Height      int `json:"height"`
+	BitrateKbps int `json:"bitrate_kbps"`
+	FPS         int `json:"fps"` // 0 keeps the camera's current frame rate
+}
+
+func (p StreamProfile) String() string {
+	if p.FPS > 0 {
+		return fmt.Sprintf("%dx%d@%dkbps/%dfps", p.Width, p.Height, p.BitrateKbps, p.FPS)
+	}
+	return fmt.Sprintf("%dx%d@%dkbps", p.Width, p.Height, p.BitrateKbps)
+}
+
+// StreamProfiles defines cameras whose streaming channel encoding can be read and changed at runtime
+type StreamProfiles interface {
+	GetStreamProfile(channelID string) (StreamProfile, error)
+	SetStreamProfile(channelID string, profile StreamProfile) error
+}
+
+// DefaultStreamChannel is the ISAPI streaming channel of channel 1's main stream
+const DefaultStreamChannel = "101"
+
+// streamChannelPath matches the channel of a Hikvision RTSP path, e.g. /Streaming/Channels/102
+var streamChannelPath = regexp.MustCompile(`(?i)/streaming/channels/(\d+)`)
+
+// StreamChannelFromURL returns the ISAPI streaming channel an RTSP URL reads, or DefaultStreamChannel when its
+// path names none
+func StreamChannelFromURL(rawURL string) string {
+	path := rawURL
+	if parsed, err := url.Parse(rawURL); err == nil {
+		path = parsed.Path
+	}
+	if match := streamChannelPath.FindStringSubmatch(path); match != nil {
+		return match[1]
+	}
+	return DefaultStreamChannel
+}
+
+// streamingChannelURI is the ISAPI resource of a streaming channel ("101" = channel 1 main stream)
+func streamingChannelURI(channelID string) string {
+	return "/ISAPI/Streaming/channels/" + channelID
+}
+
+// xmlTagValue matches a simple <tag>value</tag> element in an ISAPI document
+func xmlTagValue(tag string) *regexp.Regexp {
+	return regexp.MustCompile(`<` + tag + `>\s*([^<]*?)\s*</` + tag + `>`)
+}
+
+// GetStreamProfile reads the current encoding of a streaming channel
+func (c *HikvisionController) GetStreamProfile(channelID string) (StreamProfile, error) {
+	body, err := c.isapiRequest("GET", streamingChannelURI(channelID), "")
+	if err != nil {
+		return StreamProfile{}, fmt.Errorf("failed to read streaming channel %s: %v", channelID, err)
+	}
+
+	document := string(body)
+	readInt := func(tag string) int {
+		if match := xmlTagValue(tag).FindStringSubmatch(document); match != nil {
+			value, _ := strconv.Atoi(match[1])
+			return value
+		}
+		return 0
+	}
+
+	profile := StreamProfile{
+		Width:       readInt("videoResolutionWidth"),
+		Height:      readInt("videoResolutionHeight"),
+		BitrateKbps: readInt("constantBitRate"),
+		FPS:         readInt("maxFrameRate") / 100, // ISAPI frame rates are in 1/100 fps
+	}
+	if strings.Contains(document, "<videoQualityControlType>VBR") {
+		profile.BitrateKbps = readInt("vbrUpperCap")
+	}
+	if profile.Width == 0 || profile.Height == 0 {
+		return StreamProfile{}, fmt.Errorf("streaming channel %s has no video resolution", channelID)
+	}
+	return profile, nil
+}
+
+// SetStreamProfile changes the resolution, bitrate and (optionally) frame rate of a streaming channel. The current
+// channel document is read and edited in place so every other setting (codec, GOP, audio) is preserved.
+func (c *HikvisionController) SetStreamProfile(channelID string, profile StreamProfile) error {
+	uri := streamingChannelURI(channelID)
+	body, err := c.isapiRequest("GET", uri, "")
+	if err != nil {
+		return fmt.Errorf("failed to read streaming channel %s: %v", channelID, err)
+	}
+
+	document := string(body)
+	replace := func(tag string, value int) {
+		document = xmlTagValue(tag).ReplaceAllString(document, fmt.Sprintf("<%s>%d</%s>", tag, value, tag))
+	}
+	replace("videoResolutionWidth", profile.Width)
+	replace("videoResolutionHeight", profile.Height)
+	replace("constantBitRate", profile.BitrateKbps)
+	replace("vbrUpperCap", profile.BitrateKbps)
+	if profile.FPS > 0 {
+		replace("maxFrameRate", profile.FPS*100)
+	}
+
+	debugMsg("PTZ_STREAM", fmt.Sprintf("📶 Setting streaming channel %s profile to %s", channelID, profile))
+
+	if _, err := c.isapiRequest("PUT", uri, document); err != nil {
+		return fmt.Errorf("failed to update streaming channel %s: %v", channelID, err)
+	}
+	return nil
+}
@@ -0,0 +1,580 @@
+package retention
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Global debug function for retention package
+var debugMsgFunc func(string, string, ...string)
+
+// SetDebugFunction allows main package to provide debug function
+func SetDebugFunction(fn func(string, string, ...string)) {
+	debugMsgFunc = fn
+}
+
+// debugMsg is a wrapper that handles nil checks
+func debugMsg(component, message string, boatID ...string) {
+	if debugMsgFunc != nil {
+		debugMsgFunc(component, message, boatID...)
+	}
+}
+
+// DataClass groups stored data that shares a retention period
+type DataClass int
+
+const (
+	Snapshots    DataClass = iota // JPEG frames and crops (identifiable imagery)
+	Trajectories                  // Per-object tracking logs and paths
+	Events                        // Event records (locks, alerts, audit-style history)
+)
+
+func (c DataClass) String() string {
+	switch c {
+	case Snapshots:
+		return "snapshots"
+	case Trajectories:
+		return "trajectories"
+	case Events:
+		return "events"
+	default:
+		return "unknown"
+	}
+}
+
+// Policy holds the retention period for each data class (0 keeps data forever)
+type Policy struct {
+	Snapshots    time.Duration
+	Trajectories time.Duration
+	Events       time.Duration
+}
+
+// DefaultPolicy returns the standard retention periods: snapshots 30 days, trajectories 90 days, events 1 year
+func DefaultPolicy() Policy {
+	return Policy{
+		Snapshots:    30 * 24 * time.Hour,
+		Trajectories: 90 * 24 * time.Hour,
+		Events:       365 * 24 * time.Hour,
+	}
+}
+
+// PeriodFor returns the retention period for a data class
+func (p Policy) PeriodFor(class DataClass) time.Duration {
+	switch class {
+	case Snapshots:
+		return p.Snapshots
+	case Trajectories:
+		return p.Trajectories
+	case Events:
+		return p.Events
+	default:
+		return 0
+	}
+}
+
+// Source is a directory of files belonging to one data class
+type Source struct {
+	Dir      string
+	Class    DataClass
+	Patterns []string // Glob patterns matched against file base names (e.g. "*.jpg")
+	Exclude  []string // Glob patterns of matching files that are kept regardless (e.g. "classes.txt")
+
+	// Object returns the objectID a file name belongs to ("" = none), for right-to-erasure. nil matches the
+	// names that start with the objectID followed by "_" or ".".
+	Object func(name string) string
+
+	// Removed is told the paths of the files a purge removed, e.g. to drop them from an index file (nil = none)
+	Removed func(paths []string)
+}
+
+// EventLogWriter is the writer appending to an event log. The purger filters a copy of the file while the
+// writer carries on, then replaces the file between two of its records, so none is lost or cut in half.
+type EventLogWriter interface {
+	// BetweenWrites runs fn while no record is being written; when fn reports that it replaced the file, the
+	// writer reopens it
+	BetweenWrites(fn func() (replaced bool))
+}
+
+// EventLog is a JSON lines file of event records (audit logs, detection logs). Its records are purged one by
+// one: on expiry by their time field, and on right-to-erasure when their "object_id" names the object.
+type EventLog struct {
+	Path      string
+	TimeField string         // JSON field holding the record time (RFC 3339)
+	Writer    EventLogWriter // Writer in this process (nil = none)
+}
+
+// matches reports whether a file name belongs to this source
+func (s Source) matches(name string) bool {
+	for _, pattern := range s.Exclude {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return false
+		}
+	}
+	for _, pattern := range s.Patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// belongsTo reports whether a file name is one of objectID's files
+func (s Source) belongsTo(name, objectID string) bool {
+	if s.Object != nil {
+		return s.Object(name) == objectID
+	}
+	return name == objectID || strings.HasPrefix(name, objectID+"_") || strings.HasPrefix(name, objectID+".")
+}
+
+// ObjectEraser removes all data held for an objectID outside the file sources (databases, caches, ...)
+type ObjectEraser func(objectID string) (int, error)
+
+// PurgeReport summarizes what a purge removed
+type PurgeReport struct {
+	Started      time.Time
+	Duration     time.Duration
+	FilesRemoved map[DataClass]int
+	BytesFreed   int64
+	Errors       []string
+}
+
+// Purger enforces retention periods on registered sources and handles right-to-erasure requests
+type Purger struct {
+	mu       sync.Mutex
+	policy   Policy
+	sources  []Source
+	logs     []EventLog
+	erasers  map[string]ObjectEraser
+	interval time.Duration
+	stopChan chan struct{}
+	stopOnce sync.Once
+	lastRun  *PurgeReport
+}
+
+// NewPurger creates a purger with the given policy and scheduled purge interval
+func NewPurger(policy Policy, interval time.Duration) *Purger {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &Purger{
+		policy:   policy,
+		erasers:  make(map[string]ObjectEraser),
+		interval: interval,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// AddSource registers a directory whose matching files fall under a data class
+func (p *Purger) AddSource(dir string, class DataClass, patterns ...string) {
+	if dir == "" {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sources = append(p.sources, Source{Dir: dir, Class: class, Patterns: patterns})
+	debugMsg("RETENTION", fmt.Sprintf("Registered %s source: %s %v (retention: %s)", class, dir, patterns, formatPeriod(p.policy.PeriodFor(class))))
+}
+
+// AddObjectSource registers a directory like AddSource whose file names carry the objectID in a form object
+// extracts, for right-to-erasure. Files matching exclude are never removed.
+func (p *Purger) AddObjectSource(dir string, class DataClass, object func(name string) string, exclude []string, patterns ...string) {
+	if dir == "" {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sources = append(p.sources, Source{Dir: dir, Class: class, Patterns: patterns, Exclude: exclude, Object: object})
+	debugMsg("RETENTION", fmt.Sprintf("Registered %s source: %s %v (retention: %s)", class, dir, patterns, formatPeriod(p.policy.PeriodFor(class))))
+}
+
+// OnRemove sets the function told which files purges removed from the sources in dir, replacing an earlier one
+func (p *Purger) OnRemove(dir string, removed func(paths []string)) {
+	if dir == "" {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.sources {
+		if p.sources[i].Dir == dir {
+			p.sources[i].Removed = removed
+		}
+	}
+}
+
+// AddEventLog registers a JSON lines event log whose records expire with the events retention period.
+// Registering a path again replaces its entry, e.g. to add its writer once it is open.
+func (p *Purger) AddEventLog(path, timeField string, writer EventLogWriter) {
+	if path == "" {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	log := EventLog{Path: path, TimeField: timeField, Writer: writer}
+	for i := range p.logs {
+		if p.logs[i].Path == path {
+			p.logs[i] = log
+			return
+		}
+	}
+	p.logs = append(p.logs, log)
+	debugMsg("RETENTION", fmt.Sprintf("Registered event log: %s (retention: %s)", path, formatPeriod(p.policy.Events)))
+}
+
+// AddEraser registers a named store that must also be purged on right-to-erasure requests
+func (p *Purger) AddEraser(name string, eraser ObjectEraser) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.erasers[name] = eraser
+}
+
+// Start runs an immediate purge and then purges on the configured interval
+func (p *Purger) Start() {
+	go func() {
+		p.PurgeExpired()
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				p.PurgeExpired()
+			case <-p.stopChan:
+				return
+			}
+		}
+	}()
+	debugMsg("RETENTION", fmt.Sprintf("🗓️ Scheduled retention purge every %v", p.interval))
+}
+
+// Stop stops the scheduled purge job
+func (p *Purger) Stop() {
+	p.stopOnce.Do(func() { close(p.stopChan) })
+}
+
+// LastReport returns the result of the most recent scheduled purge (nil if none has run)
+func (p *Purger) LastReport() *PurgeReport {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lastRun
+}
+
+// PurgeExpired removes every file older than its data class retention period
+func (p *Purger) PurgeExpired() *PurgeReport {
+	p.mu.Lock()
+	sources := append([]Source(nil), p.sources...)
+	logs := append([]EventLog(nil), p.logs...)
+	policy := p.policy
+	p.mu.Unlock()
+
+	report := &PurgeReport{Started: time.Now(), FilesRemoved: make(map[DataClass]int)}
+
+	for _, source := range sources {
+		period := policy.PeriodFor(source.Class)
+		if period <= 0 {
+			continue // Keep forever
+		}
+		cutoff := report.Started.Add(-period)
+
+		p.walkSource(source, report, func(path string, info os.FileInfo) bool {
+			return info.ModTime().Before(cutoff)
+		})
+	}
+
+	if policy.Events > 0 {
+		cutoff := report.Started.Add(-policy.Events)
+		for _, log := range logs {
+			pruneEventLog(log, report, func(record map[string]json.RawMessage) bool {
+				var recorded time.Time
+				return json.Unmarshal(record[log.TimeField], &recorded) == nil && recorded.Before(cutoff)
+			})
+		}
+	}
+
+	report.Duration = time.Since(report.Started)
+
+	total := 0
+	for _, count := range report.FilesRemoved {
+		total += count
+	}
+	if total > 0 || len(report.Errors) > 0 {
+		debugMsg("RETENTION", fmt.Sprintf("🧹 Retention purge removed %d files (%s) in %v - snapshots:%d trajectories:%d events:%d errors:%d",
+			total, formatBytes(report.BytesFreed), report.Duration,
+			report.FilesRemoved[Snapshots], report.FilesRemoved[Trajectories], report.FilesRemoved[Events], len(report.Errors)))
+	}
+
+	p.mu.Lock()
+	p.lastRun = report
+	p.mu.Unlock()
+
+	return report
+}
+
+// PurgeObject erases every stored artifact for an objectID (right-to-erasure request): the files named after
+// it, the event records naming it and its rows in the registered stores.
+func (p *Purger) PurgeObject(objectID string) (*PurgeReport, error) {
+	objectID = strings.TrimSpace(objectID)
+	if objectID == "" || strings.ContainsAny(objectID, `/\*?[`) || strings.Contains(objectID, "..") {
+		return nil, fmt.Errorf("invalid objectID %q", objectID)
+	}
+
+	p.mu.Lock()
+	sources := append([]Source(nil), p.sources...)
+	logs := append([]EventLog(nil), p.logs...)
+	erasers := make(map[string]ObjectEraser, len(p.erasers))
+	for name, eraser := range p.erasers {
+		erasers[name] = eraser
+	}
+	p.mu.Unlock()
+
+	report := &PurgeReport{Started: time.Now(), FilesRemoved: make(map[DataClass]int)}
+
+	for _, source := range sources {
+		p.walkSource(source, report, func(path string, info os.FileInfo) bool {
+			return source.belongsTo(info.Name(), objectID)
+		})
+	}
+	for _, log := range logs {
+		pruneEventLog(log, report, func(record map[string]json.RawMessage) bool {
+			var recordObject string
+			return json.Unmarshal(record["object_id"], &recordObject) == nil && recordObject == objectID
+		})
+	}
+
+	// Purge external stores in a stable order so reports are reproducible
+	names := make([]string, 0, len(erasers))
+	for name := range erasers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		removed, err := erasers[name](objectID)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		report.FilesRemoved[Events] += removed
+	}
+
+	report.Duration = time.Since(report.Started)
+
+	debugMsg("RETENTION", fmt.Sprintf("🗑️ Right-to-erasure purge for %s: snapshots:%d trajectories:%d events:%d (%s freed, %d errors)",
+		objectID, report.FilesRemoved[Snapshots], report.FilesRemoved[Trajectories], report.FilesRemoved[Events],
+		formatBytes(report.BytesFreed), len(report.Errors)), objectID)
+
+	if len(report.Errors) > 0 {
+		return report, fmt.Errorf("erasure incomplete: %s", strings.Join(report.Errors, "; "))
+	}
+	return report, nil
+}
+
+// walkSource removes matching files in a source for which shouldRemove returns true,
+// then removes any directories the purge left empty
+func (p *Purger) walkSource(source Source, report *PurgeReport, shouldRemove func(path string, info os.FileInfo) bool) {
+	var emptiedDirs, removed []string
+
+	err := filepath.Walk(source.Dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			report.Errors = append(report.Errors, err.Error())
+			return nil
+		}
+		if info.IsDir() {
+			if path != source.Dir {
+				emptiedDirs = append(emptiedDirs, path)
+			}
+			return nil
+		}
+		if !source.matches(info.Name()) || !shouldRemove(path, info) {
+			return nil
+		}
+
+		if err := os.Remove(path); err != nil {
+			report.Errors = append(report.Errors, err.Error())
+			return nil
+		}
+		report.FilesRemoved[source.Class]++
+		report.BytesFreed += info.Size()
+		removed = append(removed, path)
+		return nil
+	})
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+	}
+	if len(removed) > 0 && source.Removed != nil {
+		source.Removed(removed)
+	}
+
+	// Deepest directories first so nested hour folders collapse cleanly
+	for i := len(emptiedDirs) - 1; i >= 0; i-- {
+		if entries, err := os.ReadDir(emptiedDirs[i]); err == nil && len(entries) == 0 {
+			os.Remove(emptiedDirs[i])
+		}
+	}
+}
+
+// pruneEventLog rewrites an event log without the records remove matches. Lines that are not JSON objects are
+// kept as they are.
+func pruneEventLog(log EventLog, report *PurgeReport, remove func(record map[string]json.RawMessage) bool) {
+	file, err := os.Open(log.Path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			report.Errors = append(report.Errors, err.Error())
+		}
+		return
+	}
+	info, err := file.Stat()
+	var data []byte
+	if err == nil {
+		data, err = io.ReadAll(file)
+	}
+	file.Close()
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", log.Path, err))
+		return
+	}
+
+	// A record still being written stays in the tail, which is copied as it is
+	data = data[:bytes.LastIndexByte(data, '\n')+1]
+	kept, removed := filterEventRecords(data, remove)
+	if removed == 0 {
+		return
+	}
+
+	if log.Writer != nil {
+		err = replaceEventLog(log, kept, int64(len(data)), info.Mode().Perm())
+	} else {
+		// Another process may be appending (O_APPEND) to the file: rewritten in place, its records follow the kept ones
+		err = rewriteEventLog(log.Path, kept, int64(len(data)))
+	}
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", log.Path, err))
+		return
+	}
+	report.FilesRemoved[Events] += removed
+	report.BytesFreed += int64(len(data) - len(kept))
+}
+
+// replaceEventLog writes the kept records to a temporary file and swaps it in for the log. The writer is only
+// held up for copying the records it appended after offset (where the filtered data ended) and the rename.
+func replaceEventLog(log EventLog, kept []byte, offset int64, mode os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(log.Path), filepath.Base(log.Path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // Already gone once swapped in
+	defer tmp.Close()
+	if err := tmp.Chmod(mode); err != nil {
+		return err
+	}
+	if _, err := tmp.Write(kept); err != nil {
+		return err
+	}
+
+	log.Writer.BetweenWrites(func() bool {
+		err = swapEventLog(log.Path, tmp, offset)
+		return err == nil
+	})
+	return err
+}
+
+// swapEventLog appends the log's records after offset to tmp and renames tmp over the log. Must be called
+// between two of the writer's records.
+func swapEventLog(path string, tmp *os.File, offset int64) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if info, err := file.Stat(); err != nil {
+		return err
+	} else if info.Size() < offset {
+		return fmt.Errorf("truncated while it was pruned")
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := io.Copy(tmp, file); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// rewriteEventLog replaces the log's first offset bytes with the kept records, in place
+func rewriteEventLog(path string, kept []byte, offset int64) error {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	tail, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+	if err := file.Truncate(0); err != nil {
+		return err
+	}
+	_, err = file.WriteAt(append(kept, tail...), 0)
+	return err
+}
+
+// filterEventRecords returns the JSON lines in data that remove does not match, and how many it dropped
+func filterEventRecords(data []byte, remove func(record map[string]json.RawMessage) bool) ([]byte, int) {
+	var kept bytes.Buffer
+	removed := 0
+	for len(data) > 0 {
+		end := bytes.IndexByte(data, '\n')
+		if end < 0 {
+			kept.Write(data) // Record still being written
+			break
+		}
+		line := data[:end+1]
+		data = data[end+1:]
+
+		var record map[string]json.RawMessage
+		if json.Unmarshal(line, &record) == nil && remove(record) {
+			removed++
+			continue
+		}
+		kept.Write(line)
+	}
+	return kept.Bytes(), removed
+}
+
+// formatPeriod renders a retention period in days
+func formatPeriod(period time.Duration) string {
+	if period <= 0 {
+		return "forever"
+	}
+	return fmt.Sprintf("%d days", int(period.Hours()/24))
+}
+
+// formatBytes renders a byte count for log messages
+func formatBytes(bytes int64) string {
+	switch {
+	case bytes >= 1<<30:
+		return fmt.Sprintf("%.1fGB", float64(bytes)/(1<<30))
+	case bytes >= 1<<20:
+		return fmt.Sprintf("%.1fMB", float64(bytes)/(1<<20))
+	case bytes >= 1<<10:
+		return fmt.Sprintf("%.1fKB", float64(bytes)/(1<<10))
+	default:
+		return fmt.Sprintf("%dB", bytes)
+	}
+}
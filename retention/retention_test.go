@@ -0,0 +1,162 @@
+package retention
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSourceMatches(t *testing.T) {
+	source := Source{Patterns: []string{"*.jpg", "*.txt"}, Exclude: []string{"classes.txt"}}
+	tests := map[string]bool{
+		"20240125-13-30.001_best.jpg": true,
+		"20240125-13-30.001.txt":      true,
+		"classes.txt":                 false,
+		"annotations.json":            false,
+		"clip.mp4":                    false,
+		"photo.jpeg":                  false,
+	}
+	for name, want := range tests {
+		if got := source.matches(name); got != want {
+			t.Errorf("matches(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestSourceBelongsTo(t *testing.T) {
+	const objectID = "20240125-13-30.001"
+	byPrefix := Source{}
+	tests := map[string]bool{
+		objectID + "_best.jpg":   true,
+		objectID + ".json":       true,
+		objectID:                 true,
+		objectID + "0_a.jpg":     false, // A later object with a longer sequence number
+		"x" + objectID + ".jpg":  false,
+		"20240125-13-30.002.jpg": false,
+	}
+	for name, want := range tests {
+		if got := byPrefix.belongsTo(name, objectID); got != want {
+			t.Errorf("belongsTo(%q) = %v, want %v", name, got, want)
+		}
+	}
+
+	// Names that carry the objectID elsewhere are matched by the source's extractor
+	byObject := Source{Object: func(name string) string {
+		parts := strings.Split(strings.TrimSuffix(name, filepath.Ext(name)), "_")
+		if len(parts) < 2 {
+			return ""
+		}
+		return parts[1]
+	}}
+	if !byObject.belongsTo("boat_"+objectID+".jpg", objectID) {
+		t.Errorf("extractor did not match its object")
+	}
+	if byObject.belongsTo(objectID+"_boat.jpg", objectID) {
+		t.Errorf("extractor must replace the prefix match")
+	}
+}
+
+// writeFiles creates empty files under dir with the given modification time
+func writeFiles(t *testing.T, dir string, modTime time.Time, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func remainingFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	var names []string
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			rel, _ := filepath.Rel(dir, path)
+			names = append(names, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	sort.Strings(names)
+	return names
+}
+
+func TestPurgeExpiredKeepsExcludedAndFreshFiles(t *testing.T) {
+	dir := t.TempDir()
+	old := time.Now().Add(-40 * 24 * time.Hour)
+	writeFiles(t, dir, old, "2024/01/a.jpg", "2024/01/a.txt", "classes.txt", "notes.md")
+	writeFiles(t, dir, time.Now(), "b.jpg")
+
+	purger := NewPurger(DefaultPolicy(), time.Hour)
+	purger.AddObjectSource(dir, Snapshots, nil, []string{"classes.txt"}, "*.jpg", "*.txt")
+	var removed []string
+	purger.OnRemove(dir, func(paths []string) { removed = append(removed, paths...) })
+
+	report := purger.PurgeExpired()
+	if report.FilesRemoved[Snapshots] != 2 {
+		t.Errorf("removed %d snapshots, want 2", report.FilesRemoved[Snapshots])
+	}
+	if got, want := strings.Join(remainingFiles(t, dir), ","), "b.jpg,classes.txt,notes.md"; got != want {
+		t.Errorf("remaining files %s, want %s", got, want)
+	}
+	if len(removed) != 2 {
+		t.Errorf("OnRemove told %v, want the 2 removed files", removed)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "2024")); !os.IsNotExist(err) {
+		t.Errorf("emptied directories were not removed")
+	}
+}
+
+func TestPurgeObject(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, time.Now(), "20240125-13-30.001_best.jpg", "20240125-13-30.001.json", "20240125-13-30.0010_best.jpg", "20240125-13-30.002_best.jpg")
+
+	purger := NewPurger(DefaultPolicy(), time.Hour)
+	purger.AddSource(dir, Snapshots, "*.jpg", "*.json")
+
+	if _, err := purger.PurgeObject("../etc"); err == nil {
+		t.Errorf("path traversal objectID accepted")
+	}
+	if _, err := purger.PurgeObject("2024*"); err == nil {
+		t.Errorf("glob objectID accepted")
+	}
+
+	report, err := purger.PurgeObject("20240125-13-30.001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.FilesRemoved[Snapshots] != 2 {
+		t.Errorf("removed %d files, want 2", report.FilesRemoved[Snapshots])
+	}
+	if got, want := strings.Join(remainingFiles(t, dir), ","), "20240125-13-30.0010_best.jpg,20240125-13-30.002_best.jpg"; got != want {
+		t.Errorf("remaining files %s, want %s", got, want)
+	}
+}
+
+func TestFilterEventRecords(t *testing.T) {
+	data := []byte(`{"object_id":"a","n":1}
+not json
+{"object_id":"b","n":2}
+{"object_id":"a","n":3}
+{"object_id":"a","partial"`)
+	kept, removed := filterEventRecords(data, func(record map[string]json.RawMessage) bool {
+		return string(record["object_id"]) == `"a"`
+	})
+	if removed != 2 {
+		t.Errorf("removed %d records, want 2", removed)
+	}
+	want := "not json\n{\"object_id\":\"b\",\"n\":2}\n{\"object_id\":\"a\",\"partial\""
+	if string(kept) != want {
+		t.Errorf("kept %q, want %q", kept, want)
+	}
+}
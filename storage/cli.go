@@ -0,0 +1,321 @@
+package storage
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"rivercam/tracking"
+)
+
+// DefaultTrackDB is the track database the tracks command reads when -db is not given
+const DefaultTrackDB = "tracks.db"
+
+// RunTracksCommand implements "NOLO tracks list|show|export|merge" for post-event analysis of the track database
+func RunTracksCommand(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: NOLO tracks list|show|export|merge [flags] (see NOLO tracks <command> -h)")
+	}
+
+	command, args := args[0], args[1:]
+	flags := flag.NewFlagSet("tracks "+command, flag.ContinueOnError)
+	dbPath := flags.String("db", DefaultTrackDB, "Track database written by -track-db")
+
+	switch command {
+	case "list":
+		since := flags.Duration("since", 0, "Only tracks seen within this long (0 = all)\n\t\tExample: -since=24h")
+		class := flags.String("class", "", "Only tracks of this classification\n\t\tExample: -class=boat")
+		limit := flags.Int("limit", 50, "Maximum number of tracks (0 = no limit)")
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		store, err := openExisting(*dbPath)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		return listTracks(store, out, TrackFilter{Since: sinceTime(*since), Classification: *class, Limit: *limit})
+
+	case "show":
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		if flags.NArg() != 1 {
+			return fmt.Errorf("usage: NOLO tracks show [-db tracks.db] <object ID>")
+		}
+		store, err := openExisting(*dbPath)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		return showTrack(store, out, flags.Arg(0))
+
+	case "export":
+		format := flags.String("format", "csv", "Export format: csv (one row per path point), json (tracks with their paths), geojson or kml (paths on a map, needs the camera pose)")
+		output := flags.String("o", "", "Output file (default: standard output)")
+		since := flags.Duration("since", 0, "Only tracks seen within this long (0 = all)")
+		class := flags.String("class", "", "Only tracks of this classification")
+		var pose GeoPose
+		flags.Float64Var(&pose.Latitude, "lat", 0, "Camera latitude in degrees (geojson/kml)\n\t\tExample: -lat=25.7743")
+		flags.Float64Var(&pose.Longitude, "lon", 0, "Camera longitude in degrees (geojson/kml)\n\t\tExample: -lon=-80.1937")
+		flags.Float64Var(&pose.HeightM, "height", 0, "Camera lens height above the water in meters (geojson/kml)")
+		flags.Float64Var(&pose.PanZeroBearing, "heading", 0, "Compass bearing in degrees the camera faces at pan 0 (geojson/kml)")
+		flags.Float64Var(&pose.TiltHorizon, "tilt-horizon", 0, "Tilt reading in camera units when level with the horizon (geojson/kml)")
+		smoothing := flags.Int("smooth", 5, "Path points averaged into each exported point (geojson/kml, 1 = raw path)")
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		switch *format {
+		case "csv", "json":
+		case "geojson", "kml":
+			set := make(map[string]bool)
+			flags.Visit(func(f *flag.Flag) { set[f.Name] = true })
+			if !set["lat"] || !set["lon"] || pose.HeightM <= 0 {
+				return fmt.Errorf("-format=%s needs the camera pose: -lat, -lon and -height (plus -heading and -tilt-horizon unless 0)", *format)
+			}
+		default:
+			return fmt.Errorf("unknown export format %q (valid formats are: csv, json, geojson, kml)", *format)
+		}
+		store, err := openExisting(*dbPath)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+
+		if *output != "" {
+			file, err := os.Create(*output)
+			if err != nil {
+				return fmt.Errorf("could not create %s: %v", *output, err)
+			}
+			defer file.Close()
+			out = file
+		}
+		filter := TrackFilter{Since: sinceTime(*since), Classification: *class}
+		if *format == "geojson" || *format == "kml" {
+			return exportGeoTracks(store, out, *format, filter, pose, *smoothing)
+		}
+		return exportTracks(store, out, *format, filter)
+
+	case "merge":
+		since := flags.Duration("since", 0, "Only merge tracks seen within this long (0 = all)\n\t\tExample: -since=24h")
+		config := tracking.DefaultTrackMergeConfig()
+		flags.DurationVar(&config.Window, "window", config.Window, "Longest gap between the end of one track and the start of the next")
+		flags.Float64Var(&config.MaxDistance, "distance", config.MaxDistance, "PTZ units the next track may start from where the earlier one was heading")
+		flags.Float64Var(&config.MaxSizeRatio, "size-ratio", config.MaxSizeRatio, "Largest size ratio at 1x zoom between the two tracks (0 = ignore sizes)")
+		dryRun := flags.Bool("dry-run", false, "Only report the merges, leave the database unchanged")
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		store, err := openExisting(*dbPath)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		return mergeTracks(store, out, TrackFilter{Since: sinceTime(*since)}, config, *dryRun)
+
+	default:
+		return fmt.Errorf("unknown tracks command %q (valid commands are: list, show, export, merge)", command)
+	}
+}
+
+// openExisting opens a track database for reading, refusing to create an empty one for a mistyped path
+func openExisting(path string) (*TrackStore, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("track database %s not found (record one with -track-db)", path)
+	}
+	return OpenTrackStore(path)
+}
+
+// sinceTime converts a -since duration into the earliest time to include
+func sinceTime(since time.Duration) time.Time {
+	if since <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(-since)
+}
+
+// listTracks prints one line per track
+func listTracks(store *TrackStore, out io.Writer, filter TrackFilter) error {
+	tracks, err := store.List(filter)
+	if err != nil {
+		return err
+	}
+
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "OBJECT ID\tCLASS\tFIRST SEEN\tDURATION\tDETECTIONS\tCONFIDENCE\tMAX ZOOM\tPEOPLE\tLOCKED")
+	for _, track := range tracks {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%v\t%d\t%.2f\t%.0f\t%s\t%s\n",
+			track.ObjectID, track.Classification, track.FirstSeen.Format("2006-01-02 15:04:05"),
+			track.Duration().Round(time.Second), track.Detections, track.AvgConfidence, track.MaxZoom, peopleLabel(track),
+			lockLabel(track))
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%d track(s)\n", len(tracks))
+	return nil
+}
+
+// showTrack prints every recording of an object with its path
+func showTrack(store *TrackStore, out io.Writer, objectID string) error {
+	tracks, err := store.Tracks(objectID)
+	if err != nil {
+		return err
+	}
+
+	for i, track := range tracks {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprintf(out, "Object ID:       %s\n", track.ObjectID)
+		if track.Fragment != "" {
+			fmt.Fprintf(out, "Fragment:        %s (merged after a detection dropout)\n", track.Fragment)
+		}
+		fmt.Fprintf(out, "Classification:  %s\n", track.Classification)
+		fmt.Fprintf(out, "First seen:      %s\n", track.FirstSeen.Format("2006-01-02 15:04:05.000"))
+		fmt.Fprintf(out, "Last seen:       %s (%v)\n", track.LastSeen.Format("2006-01-02 15:04:05.000"), track.Duration().Round(time.Second))
+		fmt.Fprintf(out, "Detections:      %d\n", track.Detections)
+		fmt.Fprintf(out, "Confidence:      min %.2f / avg %.2f / max %.2f\n", track.MinConfidence, track.AvgConfidence, track.MaxConfidence)
+		fmt.Fprintf(out, "Max zoom:        %.0f\n", track.MaxZoom)
+		fmt.Fprintf(out, "Max people:      %d\n", track.MaxPeople)
+		if occupancy := track.Occupancy; occupancy != nil {
+			if occupancy.NoPeopleVisible {
+				fmt.Fprintf(out, "Occupancy:       no people visible in %d locked frames (%.0fs)\n", occupancy.Samples, occupancy.LockedSeconds)
+			} else {
+				fmt.Fprintf(out, "Occupancy:       max %d / median %.1f / weighted %.1f over %d locked frames (%.0fs)\n",
+					occupancy.Max, occupancy.Median, occupancy.Weighted, occupancy.Samples, occupancy.LockedSeconds)
+			}
+		}
+		fmt.Fprintf(out, "Lock:            %s\n", lockLabel(track))
+		fmt.Fprintf(out, "Path:            %d point(s)\n", len(track.Path))
+
+		table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(table, "  TIME\tPAN\tTILT\tZOOM\tCONFIDENCE")
+		for _, point := range track.Path {
+			fmt.Fprintf(table, "  %s\t%.1f\t%.1f\t%.1f\t%.2f\n",
+				point.Time.Format("15:04:05.000"), point.Pan, point.Tilt, point.Zoom, point.Confidence)
+		}
+		if err := table.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportTracks writes the matching tracks as CSV (one row per path point) or JSON (tracks with paths)
+func exportTracks(store *TrackStore, out io.Writer, format string, filter TrackFilter) error {
+	tracks, err := store.List(filter)
+	if err != nil {
+		return err
+	}
+	for i := range tracks {
+		if tracks[i].Path, err = store.loadPath(tracks[i].ID); err != nil {
+			return err
+		}
+	}
+
+	if format == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if tracks == nil {
+			tracks = []StoredTrack{}
+		}
+		return encoder.Encode(tracks)
+	}
+
+	writer := csv.NewWriter(out)
+	writer.Write([]string{"track_id", "object_id", "classification", "first_seen", "last_seen", "detections",
+		"avg_confidence", "max_zoom", "max_people", "locked", "occupancy_median", "occupancy_weighted", "no_people_visible",
+		"time", "pan", "tilt", "zoom", "confidence"})
+	for _, track := range tracks {
+		summary := []string{
+			strconv.FormatInt(track.ID, 10), track.ObjectID, track.Classification,
+			track.FirstSeen.Format(time.RFC3339Nano), track.LastSeen.Format(time.RFC3339Nano),
+			strconv.Itoa(track.Detections), formatFloat(track.AvgConfidence), formatFloat(track.MaxZoom),
+			strconv.Itoa(track.MaxPeople), strconv.FormatBool(track.Locked),
+		}
+		if occupancy := track.Occupancy; occupancy != nil {
+			summary = append(summary, formatFloat(occupancy.Median), formatFloat(occupancy.Weighted), strconv.FormatBool(occupancy.NoPeopleVisible))
+		} else {
+			summary = append(summary, "", "", "")
+		}
+		// Tracks without a path still get a row so the summary is not lost
+		if len(track.Path) == 0 {
+			writer.Write(append(summary, "", "", "", "", ""))
+			continue
+		}
+		for _, point := range track.Path {
+			writer.Write(append(append([]string{}, summary...), point.Time.Format(time.RFC3339Nano),
+				formatFloat(point.Pan), formatFloat(point.Tilt), formatFloat(point.Zoom), formatFloat(point.Confidence)))
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// exportGeoTracks writes the matching tracks' paths, projected onto the water and smoothed, as GeoJSON or KML
+// with one feature per object ID
+func exportGeoTracks(store *TrackStore, out io.Writer, format string, filter TrackFilter, pose GeoPose, smoothing int) error {
+	tracks, err := store.List(filter)
+	if err != nil {
+		return err
+	}
+	for i := range tracks {
+		if tracks[i].Path, err = store.loadPath(tracks[i].ID); err != nil {
+			return err
+		}
+	}
+
+	objects := groupGeoTracks(tracks, pose, smoothing)
+	if format == "kml" {
+		return writeKML(out, objects)
+	}
+	return writeGeoJSON(out, objects)
+}
+
+// mergeTracks runs the offline merge pass and prints each merge
+func mergeTracks(store *TrackStore, out io.Writer, filter TrackFilter, config tracking.TrackMergeConfig, dryRun bool) error {
+	merges, err := store.MergeFragments(filter, config, dryRun)
+	for _, merge := range merges {
+		fmt.Fprintf(out, "%s → %s (%.1fs gap, %.0f PTZ units from the predicted start)\n",
+			merge.ObjectID, merge.MergedInto, merge.Gap, merge.Distance)
+	}
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		fmt.Fprintf(out, "%d track(s) would be merged (dry run)\n", len(merges))
+	} else {
+		fmt.Fprintf(out, "%d track(s) merged\n", len(merges))
+	}
+	return nil
+}
+
+// peopleLabel is the most people seen on board, or a warning for a locked boat nobody was seen on
+func peopleLabel(track StoredTrack) string {
+	if track.Occupancy != nil && track.Occupancy.NoPeopleVisible {
+		return "none seen"
+	}
+	return strconv.Itoa(track.MaxPeople)
+}
+
+func lockLabel(track StoredTrack) string {
+	switch {
+	case track.SuperLocked:
+		return "super"
+	case track.Locked:
+		return "yes"
+	default:
+		return "no"
+	}
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
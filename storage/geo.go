@@ -0,0 +1,217 @@
+package storage
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"rivercam/multicam"
+)
+
+// earthRadiusM is the mean Earth radius; over the few hundred meters a camera sees, a flat-Earth offset
+// from the camera's position is accurate to well under a meter
+const earthRadiusM = 6371000.0
+
+// GeoPose is where a camera is and how it is oriented, for turning pan/tilt paths into map coordinates
+type GeoPose struct {
+	Latitude       float64 // Degrees, north positive
+	Longitude      float64 // Degrees, east positive
+	HeightM        float64 // Lens height above the water
+	PanZeroBearing float64 // Compass bearing (degrees) the camera faces at pan 0
+	TiltHorizon    float64 // Tilt reading (camera units) when level with the horizon
+}
+
+// GeoPoint is a path point projected onto the water
+type GeoPoint struct {
+	Time      time.Time
+	Latitude  float64
+	Longitude float64
+}
+
+// camera expresses the pose as a camera at the origin of a local ground plane, so the projection is the one
+// multi-camera handoff uses
+func (p GeoPose) camera() multicam.CameraConfig {
+	return multicam.CameraConfig{HeightM: p.HeightM, PanZeroBearing: p.PanZeroBearing, TiltHorizon: p.TiltHorizon}
+}
+
+// toGeo converts meters east/north of the camera into latitude/longitude
+func (p GeoPose) toGeo(point multicam.WorldPoint) (latitude, longitude float64) {
+	latitude = p.Latitude + point.Y/earthRadiusM*180/math.Pi
+	longitude = p.Longitude + point.X/(earthRadiusM*math.Cos(p.Latitude*math.Pi/180))*180/math.Pi
+	return latitude, longitude
+}
+
+// ProjectPath converts a track's pan/tilt path into geographic points. Points at or above the horizon cannot
+// be placed on the water and are dropped. With smoothing > 1 every point is replaced by the average of the
+// surrounding window of that many points (on the ground plane), which removes detector jitter that range
+// amplifies far from the camera.
+func (p GeoPose) ProjectPath(track StoredTrack, smoothing int) []GeoPoint {
+	camera := p.camera()
+	var times []time.Time
+	var world []multicam.WorldPoint
+	for _, point := range track.Path {
+		if projected, ok := camera.ToWorld(point.Pan, point.Tilt); ok {
+			times = append(times, point.Time)
+			world = append(world, projected)
+		}
+	}
+
+	points := make([]GeoPoint, len(world))
+	half := smoothing / 2
+	for i := range world {
+		from, to := i-half, i+half
+		if smoothing > 1 && smoothing%2 == 0 {
+			to-- // Even windows lean toward the past
+		}
+		if from < 0 {
+			from = 0
+		}
+		if to > len(world)-1 {
+			to = len(world) - 1
+		}
+		var sum multicam.WorldPoint
+		for j := from; j <= to; j++ {
+			sum.X += world[j].X
+			sum.Y += world[j].Y
+		}
+		count := float64(to - from + 1)
+		latitude, longitude := p.toGeo(multicam.WorldPoint{X: sum.X / count, Y: sum.Y / count})
+		points[i] = GeoPoint{Time: times[i], Latitude: latitude, Longitude: longitude}
+	}
+	return points
+}
+
+// geoTrack is one object's projected recordings
+type geoTrack struct {
+	objectID string
+	tracks   []StoredTrack
+	paths    [][]GeoPoint
+}
+
+// groupGeoTracks projects every track and groups the recordings by object ID in first-seen order. Recordings
+// with fewer than two points on the water have no line to draw and are left out.
+func groupGeoTracks(tracks []StoredTrack, pose GeoPose, smoothing int) []*geoTrack {
+	var objects []*geoTrack
+	byID := make(map[string]*geoTrack)
+	for i := len(tracks) - 1; i >= 0; i-- { // List returns newest first
+		path := pose.ProjectPath(tracks[i], smoothing)
+		if len(path) < 2 {
+			continue
+		}
+		object, ok := byID[tracks[i].ObjectID]
+		if !ok {
+			object = &geoTrack{objectID: tracks[i].ObjectID}
+			byID[object.objectID] = object
+			objects = append(objects, object)
+		}
+		object.tracks = append(object.tracks, tracks[i])
+		object.paths = append(object.paths, path)
+	}
+	return objects
+}
+
+// writeGeoJSON writes a FeatureCollection with one feature per object: a LineString, or a MultiLineString when
+// the object was recorded more than once. Point times are in the coordTimes property (as GPX converters do).
+func writeGeoJSON(out io.Writer, objects []*geoTrack) error {
+	type feature struct {
+		Type       string                 `json:"type"`
+		Geometry   map[string]interface{} `json:"geometry"`
+		Properties map[string]interface{} `json:"properties"`
+	}
+	collection := struct {
+		Type     string    `json:"type"`
+		Features []feature `json:"features"`
+	}{Type: "FeatureCollection", Features: []feature{}}
+
+	for _, object := range objects {
+		var lines [][][2]float64
+		var times [][]string
+		for _, path := range object.paths {
+			line := make([][2]float64, len(path))
+			lineTimes := make([]string, len(path))
+			for i, point := range path {
+				line[i] = [2]float64{roundCoordinate(point.Longitude), roundCoordinate(point.Latitude)}
+				lineTimes[i] = point.Time.UTC().Format(time.RFC3339Nano)
+			}
+			lines = append(lines, line)
+			times = append(times, lineTimes)
+		}
+
+		geometry := map[string]interface{}{"type": "MultiLineString", "coordinates": lines}
+		var coordTimes interface{} = times
+		if len(lines) == 1 {
+			geometry = map[string]interface{}{"type": "LineString", "coordinates": lines[0]}
+			coordTimes = times[0]
+		}
+		first, last := object.tracks[0], object.tracks[len(object.tracks)-1]
+		collection.Features = append(collection.Features, feature{
+			Type:     "Feature",
+			Geometry: geometry,
+			Properties: map[string]interface{}{
+				"object_id":      object.objectID,
+				"classification": last.Classification,
+				"first_seen":     first.FirstSeen.UTC().Format(time.RFC3339),
+				"last_seen":      last.LastSeen.UTC().Format(time.RFC3339),
+				"recordings":     len(object.tracks),
+				"locked":         anyLocked(object.tracks),
+				"coordTimes":     coordTimes,
+			},
+		})
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(collection)
+}
+
+// writeKML writes a KML document with one placemark per object holding a line per recording
+func writeKML(out io.Writer, objects []*geoTrack) error {
+	if _, err := io.WriteString(out, xml.Header+`<kml xmlns="http://www.opengis.net/kml/2.2">`+"\n<Document>\n<name>NOLO tracks</name>\n"); err != nil {
+		return err
+	}
+	for _, object := range objects {
+		first, last := object.tracks[0], object.tracks[len(object.tracks)-1]
+		fmt.Fprintf(out, "<Placemark>\n<name>%s</name>\n", xmlEscape(object.objectID))
+		fmt.Fprintf(out, "<description>%s, %d recording(s)</description>\n", xmlEscape(last.Classification), len(object.tracks))
+		fmt.Fprintf(out, "<TimeSpan><begin>%s</begin><end>%s</end></TimeSpan>\n",
+			first.FirstSeen.UTC().Format(time.RFC3339), last.LastSeen.UTC().Format(time.RFC3339))
+		fmt.Fprint(out, "<MultiGeometry>\n")
+		for _, path := range object.paths {
+			fmt.Fprint(out, "<LineString><tessellate>1</tessellate><coordinates>")
+			for i, point := range path {
+				if i > 0 {
+					fmt.Fprint(out, " ")
+				}
+				fmt.Fprintf(out, "%s,%s,0", formatFloat(roundCoordinate(point.Longitude)), formatFloat(roundCoordinate(point.Latitude)))
+			}
+			fmt.Fprint(out, "</coordinates></LineString>\n")
+		}
+		fmt.Fprint(out, "</MultiGeometry>\n</Placemark>\n")
+	}
+	_, err := io.WriteString(out, "</Document>\n</kml>\n")
+	return err
+}
+
+// roundCoordinate keeps 7 decimals (about a centimeter), far below the projection's accuracy
+func roundCoordinate(degrees float64) float64 {
+	return math.Round(degrees*1e7) / 1e7
+}
+
+func anyLocked(tracks []StoredTrack) bool {
+	for _, track := range tracks {
+		if track.Locked {
+			return true
+		}
+	}
+	return false
+}
+
+func xmlEscape(text string) string {
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(text))
+	return escaped.String()
+}
@@ -0,0 +1,442 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3" // SQLite driver
+
+	"rivercam/tracking"
+)
+
+// Global debug function for storage package
+var debugMsgFunc func(string, string, ...string)
+
+// SetDebugFunction allows main package to provide debug function
+func SetDebugFunction(fn func(string, string, ...string)) {
+	debugMsgFunc = fn
+}
+
+// debugMsg is a wrapper that handles nil checks
+func debugMsg(component, message string, boatID ...string) {
+	if debugMsgFunc != nil {
+		debugMsgFunc(component, message, boatID...)
+	}
+}
+
+// trackSchema creates the track tables. An object ID can appear more than once when a lost track is
+// re-acquired under the same ID, so every recording gets its own row.
+const trackSchema = `
+CREATE TABLE IF NOT EXISTS tracks (
+	id              INTEGER PRIMARY KEY AUTOINCREMENT,
+	object_id       TEXT    NOT NULL,
+	classification  TEXT    NOT NULL,
+	first_seen      INTEGER NOT NULL, -- Unix milliseconds
+	last_seen       INTEGER NOT NULL,
+	detections      INTEGER NOT NULL,
+	min_confidence  REAL    NOT NULL,
+	max_confidence  REAL    NOT NULL,
+	avg_confidence  REAL    NOT NULL,
+	max_zoom        REAL    NOT NULL,
+	max_people      INTEGER NOT NULL,
+	locked          INTEGER NOT NULL,
+	super_locked    INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS tracks_object_id ON tracks (object_id);
+CREATE INDEX IF NOT EXISTS tracks_first_seen ON tracks (first_seen);
+CREATE TABLE IF NOT EXISTS track_points (
+	track_id   INTEGER NOT NULL REFERENCES tracks (id) ON DELETE CASCADE,
+	time       INTEGER NOT NULL, -- Unix milliseconds
+	pan        REAL    NOT NULL,
+	tilt       REAL    NOT NULL,
+	zoom       REAL    NOT NULL,
+	confidence REAL    NOT NULL
+);
+CREATE INDEX IF NOT EXISTS track_points_track_id ON track_points (track_id, time);
+`
+
+// trackColumnsAdded are columns added to the tracks table after its first release, so databases created by
+// older versions are migrated when opened
+var trackColumnsAdded = []struct{ name, definition string }{
+	{"occupancy_samples", "INTEGER NOT NULL DEFAULT 0"}, // Locked frames people were counted in (0 = no occupancy data)
+	{"occupancy_max", "INTEGER NOT NULL DEFAULT 0"},
+	{"occupancy_median", "REAL NOT NULL DEFAULT 0"},
+	{"occupancy_weighted", "REAL NOT NULL DEFAULT 0"},
+	{"occupancy_locked_seconds", "REAL NOT NULL DEFAULT 0"},
+	{"no_people_visible", "INTEGER NOT NULL DEFAULT 0"},
+	{"size", "REAL NOT NULL DEFAULT 0"},
+	{"fragment_id", "TEXT NOT NULL DEFAULT ''"}, // Tracker's ID of a recording merged into object_id ('' = not merged)
+}
+
+// StoredTrack is a track record as stored in the database
+type StoredTrack struct {
+	ID       int64  `json:"id"`                 // Database row ID (unique per recording)
+	Fragment string `json:"fragment,omitempty"` // ID the tracker gave this recording before it was merged into ObjectID
+	tracking.TrackRecord
+}
+
+// Duration is how long the object was tracked
+func (t StoredTrack) Duration() time.Duration {
+	return t.LastSeen.Sub(t.FirstSeen)
+}
+
+// TrackFilter selects tracks for List and Export (zero values match everything)
+type TrackFilter struct {
+	Since          time.Time
+	Until          time.Time
+	Classification string
+	ObjectID       string // Matches the object and the fragments merged into it, or a single fragment
+	Limit          int
+}
+
+// TrackStore persists track lifecycles in a SQLite database for post-event analysis
+type TrackStore struct {
+	mu   sync.Mutex
+	db   *sql.DB
+	path string
+}
+
+// OpenTrackStore opens (creating if needed) the track database at path
+func OpenTrackStore(path string) (*TrackStore, error) {
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?_busy_timeout=5000&_journal_mode=WAL&_foreign_keys=on", path))
+	if err != nil {
+		return nil, fmt.Errorf("could not open track database %s: %v", path, err)
+	}
+	// SQLite allows a single writer; serialize everything through one connection
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(trackSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("could not initialize track database %s: %v", path, err)
+	}
+	if err := migrateTracks(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("could not migrate track database %s: %v", path, err)
+	}
+	return &TrackStore{db: db, path: path}, nil
+}
+
+// migrateTracks adds the columns an older tracks table is missing
+func migrateTracks(db *sql.DB) error {
+	rows, err := db.Query(`PRAGMA table_info(tracks)`)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var cid, notNull, primaryKey int
+		var name, columnType string
+		var defaultValue sql.NullString
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultValue, &primaryKey); err != nil {
+			rows.Close()
+			return err
+		}
+		existing[name] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, column := range trackColumnsAdded {
+		if existing[column.name] {
+			continue
+		}
+		if _, err := db.Exec(fmt.Sprintf("ALTER TABLE tracks ADD COLUMN %s %s", column.name, column.definition)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Path returns the database file
+func (s *TrackStore) Path() string {
+	return s.path
+}
+
+// Save stores a finished track record together with its path. A record that continues an earlier fragment
+// is stored under the first fragment's object ID.
+func (s *TrackStore) Save(record tracking.TrackRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("could not start transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	var occupancy tracking.OccupancySummary
+	if record.Occupancy != nil {
+		occupancy = *record.Occupancy
+	}
+	objectID, fragment := record.ObjectID, ""
+	if record.MergedInto != "" {
+		objectID, fragment = record.MergedInto, record.ObjectID
+	}
+	result, err := tx.Exec(`INSERT INTO tracks (object_id, classification, first_seen, last_seen, detections,
+		min_confidence, max_confidence, avg_confidence, max_zoom, max_people, locked, super_locked,
+		occupancy_samples, occupancy_max, occupancy_median, occupancy_weighted, occupancy_locked_seconds, no_people_visible,
+		size, fragment_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		objectID, record.Classification, toMillis(record.FirstSeen), toMillis(record.LastSeen), record.Detections,
+		record.MinConfidence, record.MaxConfidence, record.AvgConfidence, record.MaxZoom, record.MaxPeople,
+		record.Locked, record.SuperLocked,
+		occupancy.Samples, occupancy.Max, occupancy.Median, occupancy.Weighted, occupancy.LockedSeconds, occupancy.NoPeopleVisible,
+		record.Size, fragment)
+	if err != nil {
+		return fmt.Errorf("could not store track %s: %v", record.ObjectID, err)
+	}
+	trackID, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("could not store track %s: %v", record.ObjectID, err)
+	}
+
+	insertPoint, err := tx.Prepare(`INSERT INTO track_points (track_id, time, pan, tilt, zoom, confidence) VALUES (?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("could not store path of track %s: %v", record.ObjectID, err)
+	}
+	defer insertPoint.Close()
+	for _, point := range record.Path {
+		if _, err := insertPoint.Exec(trackID, toMillis(point.Time), point.Pan, point.Tilt, point.Zoom, point.Confidence); err != nil {
+			return fmt.Errorf("could not store path of track %s: %v", record.ObjectID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("could not store track %s: %v", record.ObjectID, err)
+	}
+	return nil
+}
+
+// Record stores a finished track and logs failures; it is the tracking.ConfigureTrackRecorder callback
+func (s *TrackStore) Record(record tracking.TrackRecord) {
+	if err := s.Save(record); err != nil {
+		debugMsg("TRACK_DB", fmt.Sprintf("❌ %v", err), record.ObjectID)
+		return
+	}
+	debugMsg("TRACK_DB", fmt.Sprintf("🗃️ Stored %s track (%v, %d detections, %d path points)",
+		record.Classification, record.LastSeen.Sub(record.FirstSeen).Round(time.Second), record.Detections, len(record.Path)), record.ObjectID)
+}
+
+// List returns the tracks matching the filter, newest first, without their paths
+func (s *TrackStore) List(filter TrackFilter) ([]StoredTrack, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := `SELECT id, object_id, classification, first_seen, last_seen, detections, min_confidence, max_confidence,
+		avg_confidence, max_zoom, max_people, locked, super_locked, occupancy_samples, occupancy_max, occupancy_median,
+		occupancy_weighted, occupancy_locked_seconds, no_people_visible, size, fragment_id FROM tracks WHERE 1 = 1`
+	var args []interface{}
+	if !filter.Since.IsZero() {
+		query += " AND last_seen >= ?"
+		args = append(args, toMillis(filter.Since))
+	}
+	if !filter.Until.IsZero() {
+		query += " AND first_seen <= ?"
+		args = append(args, toMillis(filter.Until))
+	}
+	if filter.Classification != "" {
+		query += " AND classification = ?"
+		args = append(args, filter.Classification)
+	}
+	if filter.ObjectID != "" {
+		query += " AND (object_id = ? OR fragment_id = ?)"
+		args = append(args, filter.ObjectID, filter.ObjectID)
+	}
+	query += " ORDER BY first_seen DESC, id DESC"
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not query tracks: %v", err)
+	}
+	defer rows.Close()
+
+	var tracks []StoredTrack
+	for rows.Next() {
+		var track StoredTrack
+		var firstSeen, lastSeen int64
+		var occupancy tracking.OccupancySummary
+		if err := rows.Scan(&track.ID, &track.ObjectID, &track.Classification, &firstSeen, &lastSeen, &track.Detections,
+			&track.MinConfidence, &track.MaxConfidence, &track.AvgConfidence, &track.MaxZoom, &track.MaxPeople,
+			&track.Locked, &track.SuperLocked, &occupancy.Samples, &occupancy.Max, &occupancy.Median,
+			&occupancy.Weighted, &occupancy.LockedSeconds, &occupancy.NoPeopleVisible, &track.Size, &track.Fragment); err != nil {
+			return nil, fmt.Errorf("could not read track: %v", err)
+		}
+		track.FirstSeen = fromMillis(firstSeen)
+		track.LastSeen = fromMillis(lastSeen)
+		if occupancy.Samples > 0 {
+			track.Occupancy = &occupancy
+		}
+		tracks = append(tracks, track)
+	}
+	return tracks, rows.Err()
+}
+
+// Tracks returns every recording of an object, oldest first, with their paths
+func (s *TrackStore) Tracks(objectID string) ([]StoredTrack, error) {
+	tracks, err := s.List(TrackFilter{ObjectID: objectID})
+	if err != nil {
+		return nil, err
+	}
+	if len(tracks) == 0 {
+		return nil, fmt.Errorf("no track with object ID %s", objectID)
+	}
+	for i, j := 0, len(tracks)-1; i < j; i, j = i+1, j-1 {
+		tracks[i], tracks[j] = tracks[j], tracks[i]
+	}
+	for i := range tracks {
+		if tracks[i].Path, err = s.loadPath(tracks[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return tracks, nil
+}
+
+// loadPath loads the path points of one recording
+func (s *TrackStore) loadPath(trackID int64) ([]tracking.TrackPoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rows, err := s.db.Query(`SELECT time, pan, tilt, zoom, confidence FROM track_points WHERE track_id = ? ORDER BY time`, trackID)
+	if err != nil {
+		return nil, fmt.Errorf("could not query track path: %v", err)
+	}
+	defer rows.Close()
+
+	var points []tracking.TrackPoint
+	for rows.Next() {
+		var point tracking.TrackPoint
+		var at int64
+		if err := rows.Scan(&at, &point.Pan, &point.Tilt, &point.Zoom, &point.Confidence); err != nil {
+			return nil, fmt.Errorf("could not read track path: %v", err)
+		}
+		point.Time = fromMillis(at)
+		points = append(points, point)
+	}
+	return points, rows.Err()
+}
+
+// EraseObject deletes every recording of an object, including fragments merged into it (right-to-erasure),
+// and returns how many were removed
+func (s *TrackStore) EraseObject(objectID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, err := s.db.Exec(`DELETE FROM tracks WHERE object_id = ? OR fragment_id = ?`, objectID, objectID)
+	if err != nil {
+		return 0, fmt.Errorf("could not erase track %s: %v", objectID, err)
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("could not erase track %s: %v", objectID, err)
+	}
+	return int(removed), nil
+}
+
+// MergeFragments is the offline merge pass: it joins objects the tracker split into several IDs after
+// detection dropouts (recorded without online merging, or missed by it) by relabeling every object that
+// continues an earlier one with the earlier object's ID. With dryRun the merges are only reported.
+func (s *TrackStore) MergeFragments(filter TrackFilter, config tracking.TrackMergeConfig, dryRun bool) ([]tracking.TrackMerge, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	filter.ObjectID = ""
+	filter.Limit = 0
+	tracks, err := s.List(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// One record per object (all its recordings and merged fragments), in order of first appearance
+	var objects []*tracking.TrackRecord
+	byID := make(map[string]*tracking.TrackRecord)
+	for i := len(tracks) - 1; i >= 0; i-- {
+		track := tracks[i]
+		if track.Path, err = s.loadPath(track.ID); err != nil {
+			return nil, err
+		}
+		object, exists := byID[track.ObjectID]
+		if !exists {
+			object = &tracking.TrackRecord{ObjectID: track.ObjectID, Classification: track.Classification, FirstSeen: track.FirstSeen}
+			byID[track.ObjectID] = object
+			objects = append(objects, object)
+		}
+		extendObject(object, track.TrackRecord)
+	}
+
+	var merges []tracking.TrackMerge
+	var candidates []tracking.TrackRecord
+	for _, object := range objects {
+		kept := candidates[:0]
+		for _, candidate := range candidates {
+			if object.FirstSeen.Sub(candidate.LastSeen) <= config.Window {
+				kept = append(kept, candidate)
+			}
+		}
+		candidates = kept
+
+		merge, index := tracking.BestTrackFragment(candidates, *object, config)
+		if index < 0 {
+			candidates = append(candidates, *object)
+			continue
+		}
+		if !dryRun {
+			if err := s.relabel(object.ObjectID, merge.MergedInto); err != nil {
+				return merges, err
+			}
+		}
+		merges = append(merges, merge)
+		extendObject(&candidates[index], *object) // The boat continues from the end of this fragment
+	}
+	return merges, nil
+}
+
+// extendObject adds a later recording of the same boat to an object's combined record
+func extendObject(object *tracking.TrackRecord, recording tracking.TrackRecord) {
+	if recording.LastSeen.After(object.LastSeen) {
+		object.LastSeen = recording.LastSeen
+	}
+	if recording.Size > 0 {
+		if object.Size > 0 {
+			object.Size = (object.Size + recording.Size) / 2
+		} else {
+			object.Size = recording.Size
+		}
+	}
+	object.Path = append(object.Path, recording.Path...)
+}
+
+// relabel moves every recording of an object to another object ID, remembering the tracker's ID as the
+// fragment
+func (s *TrackStore) relabel(objectID, mergedInto string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.db.Exec(`UPDATE tracks SET fragment_id = CASE WHEN fragment_id = '' THEN object_id ELSE fragment_id END,
+		object_id = ? WHERE object_id = ?`, mergedInto, objectID)
+	if err != nil {
+		return fmt.Errorf("could not merge track %s into %s: %v", objectID, mergedInto, err)
+	}
+	return nil
+}
+
+// Close closes the database
+func (s *TrackStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.db.Close()
+}
+
+func toMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func fromMillis(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
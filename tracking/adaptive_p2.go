@@ -0,0 +1,80 @@
+package tracking
+
+import (
+	"fmt"
+	"image"
+	"math"
+)
+
+// Adaptive P2 confidence scaling bounds (multipliers applied to the base P2 threshold)
+const (
+	AdaptiveP2MinScale = 0.5 // Smallest vessels accept people at half the base confidence
+	AdaptiveP2MaxScale = 1.5 // Largest vessels require 1.5x the base confidence
+)
+
+// ConfigureAdaptiveP2Confidence enables scaling the P2 confidence threshold by the parent P1 bounding-box size.
+// referenceArea is the P1 box area (pixels) at which the base p2MinConfidence applies unchanged.
+func (si *SpatialIntegration) ConfigureAdaptiveP2Confidence(enabled bool, referenceArea float64) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.adaptiveP2Enabled = enabled
+	si.adaptiveP2ReferenceArea = referenceArea
+
+	if enabled {
+		si.debugMsg("ADAPTIVE_P2", fmt.Sprintf("👥 Adaptive P2 confidence enabled: base %.2f at %.0fpx² (range %.2f-%.2f)",
+			si.p2MinConfidence, referenceArea, si.p2MinConfidence*AdaptiveP2MinScale, si.p2MinConfidence*AdaptiveP2MaxScale))
+	}
+}
+
+// effectiveP2Confidence returns the P2 confidence threshold for people inside the given boat.
+// Small boats produce tiny, low-confidence person boxes, so the threshold scales with the square root
+// of the boat's area relative to the reference (i.e. linearly with its apparent size).
+func (si *SpatialIntegration) effectiveP2Confidence(boat *TrackedBoat) float64 {
+	return si.p2MinConfidence * si.adaptiveP2Scale(boat)
+}
+
+// adaptiveP2Scale returns the multiplier applied to the base P2 threshold for people inside the given boat
+// (1 when adaptive P2 is disabled or the boat has no size yet)
+func (si *SpatialIntegration) adaptiveP2Scale(boat *TrackedBoat) float64 {
+	if !si.adaptiveP2Enabled || si.adaptiveP2ReferenceArea <= 0 {
+		return 1
+	}
+
+	area := float64(boat.BoundingBox.Dx() * boat.BoundingBox.Dy())
+	if area <= 0 {
+		area = boat.PixelArea
+	}
+	if area <= 0 {
+		return 1
+	}
+
+	scale := math.Sqrt(area / si.adaptiveP2ReferenceArea)
+	return math.Max(AdaptiveP2MinScale, math.Min(AdaptiveP2MaxScale, scale))
+}
+
+// AdaptiveP2Scale returns the multiplier the detection prefilter may apply to the base P2 threshold for a person
+// detected at rect: the smallest scale of the boats whose box contains its center, so the prefilter never rejects
+// a person tracking would accept, and 1 for people outside every boat, who keep the base threshold.
+func (si *SpatialIntegration) AdaptiveP2Scale(rect image.Rectangle) float64 {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+
+	if !si.adaptiveP2Enabled {
+		return 1
+	}
+	center := image.Point{X: rect.Min.X + rect.Dx()/2, Y: rect.Min.Y + rect.Dy()/2}
+	scale := 1.0
+	found := false
+	for _, boat := range si.allBoats {
+		box := boat.BoundingBox
+		if boat.PersonOverboard || center.X < box.Min.X || center.X > box.Max.X || center.Y < box.Min.Y || center.Y > box.Max.Y {
+			continue
+		}
+		if boatScale := si.adaptiveP2Scale(boat); !found || boatScale < scale {
+			scale = boatScale
+			found = true
+		}
+	}
+	return scale
+}
@@ -0,0 +1,295 @@
+package tracking
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+)
+
+// Data association defaults
+const (
+	AssociationHungarian = "hungarian" // Joint assignment of all detections per frame
+	AssociationGreedy    = "greedy"    // Each detection takes its nearest boat in turn (findNearestBoat)
+
+	DefaultAssociationIoUWeight        = 0.5
+	DefaultAssociationDistanceWeight   = 0.35
+	DefaultAssociationAppearanceWeight = 0.15
+	DefaultAssociationMaxCost          = 1.0 // Only the distance/overlap gate rejects pairs
+)
+
+// forbiddenCost marks detection/boat pairs outside the matching gate. It dominates any sum of real costs, so
+// the solver only uses such a pair when a detection has nothing else left, and those pairs are discarded.
+const forbiddenCost = 1e6
+
+// AssociationConfig controls how detections are matched to tracked boats each frame
+type AssociationConfig struct {
+	Method           string  // AssociationHungarian or AssociationGreedy
+	IoUWeight        float64 // Weight of 1-IoU between the detection and the boat's last box
+	DistanceWeight   float64 // Weight of the center distance relative to the matching gate
+	AppearanceWeight float64 // Weight of class, size and aspect ratio dissimilarity
+	MaxCost          float64 // Pairs with a higher weighted cost (0-1) are never matched
+}
+
+// DefaultAssociationConfig returns the default joint assignment configuration
+func DefaultAssociationConfig() AssociationConfig {
+	return AssociationConfig{
+		Method:           AssociationHungarian,
+		IoUWeight:        DefaultAssociationIoUWeight,
+		DistanceWeight:   DefaultAssociationDistanceWeight,
+		AppearanceWeight: DefaultAssociationAppearanceWeight,
+		MaxCost:          DefaultAssociationMaxCost,
+	}
+}
+
+// ConfigureAssociation sets the detection-to-track matching method and cost weights
+func (si *SpatialIntegration) ConfigureAssociation(config AssociationConfig) error {
+	config.Method = strings.ToLower(strings.TrimSpace(config.Method))
+	if config.Method != AssociationHungarian && config.Method != AssociationGreedy {
+		return fmt.Errorf("unknown association method %q (valid methods are: %s, %s)", config.Method, AssociationHungarian, AssociationGreedy)
+	}
+	if config.IoUWeight < 0 || config.DistanceWeight < 0 || config.AppearanceWeight < 0 {
+		return fmt.Errorf("association weights must not be negative")
+	}
+	if config.IoUWeight+config.DistanceWeight+config.AppearanceWeight == 0 {
+		return fmt.Errorf("at least one association weight must be positive")
+	}
+	if config.MaxCost <= 0 || config.MaxCost > 1 {
+		return fmt.Errorf("association max cost must be between 0 and 1, got %.2f", config.MaxCost)
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	si.association = config
+
+	si.debugMsg("ASSOCIATION", fmt.Sprintf("🧩 Detection association: %s (IoU %.2f, distance %.2f, appearance %.2f, max cost %.2f)",
+		config.Method, config.IoUWeight, config.DistanceWeight, config.AppearanceWeight, config.MaxCost))
+	return nil
+}
+
+// associationCandidate is a detection that passed the P1 and size filters
+type associationCandidate struct {
+	index      int // Position in the detections passed to updateAllBoats
+	rect       image.Rectangle
+	centerX    int
+	centerY    int
+	area       float64
+	confidence float64
+	className  string
+	edge       EdgeSides // Frame borders the detection touches
+}
+
+// associateDetections matches all candidates to existing boats at once by minimizing the total cost. The
+// result has the matched boat for each candidate, or nil when it should start a new track. Must be called
+// with si.mu held.
+func (si *SpatialIntegration) associateDetections(candidates []associationCandidate) []*TrackedBoat {
+	matches := make([]*TrackedBoat, len(candidates))
+	if len(candidates) == 0 || len(si.allBoats) == 0 {
+		return matches
+	}
+
+	boats := make([]*TrackedBoat, 0, len(si.allBoats))
+	for _, boat := range si.allBoats {
+		boats = append(boats, boat)
+	}
+
+	gate, _, _ := si.matchingDistance()
+	cost := make([][]float64, len(candidates))
+	for i, candidate := range candidates {
+		cost[i] = make([]float64, len(boats))
+		for j, boat := range boats {
+			cost[i][j] = si.associationCost(candidate, boat, gate)
+		}
+	}
+
+	for i, j := range solveAssignment(cost) {
+		if j < 0 || cost[i][j] >= forbiddenCost {
+			continue
+		}
+		matches[i] = boats[j]
+		si.debugMsgVerbose("ASSOCIATION", fmt.Sprintf("🧩 Detection at (%d,%d) → %s (cost %.2f)",
+			candidates[i].centerX, candidates[i].centerY, boats[j].ID, cost[i][j]), boats[j].ID)
+	}
+	return matches
+}
+
+// associationCost is the weighted 0-1 cost of matching a detection to a boat, or forbiddenCost when the pair
+// is outside the gate findNearestBoat uses (no box overlap, too far from the last and predicted positions)
+func (si *SpatialIntegration) associationCost(candidate associationCandidate, boat *TrackedBoat, gate float64) float64 {
+	overlaps := boat.BoundingBox.Overlaps(candidate.rect)
+
+	// A detection or boat cut off by the frame has its center pulled inward: widen the gate
+	if candidate.edge != 0 || boat.EdgeClipped != 0 {
+		gate *= edgeGateFactor
+	}
+
+	// Distance to the last position; locked boats get 3x the tolerance
+	allowed := gate
+	if boat.IsLocked {
+		allowed = gate * 3.0
+	}
+	distance := math.Hypot(float64(candidate.centerX-boat.CurrentPixel.X), float64(candidate.centerY-boat.CurrentPixel.Y))
+	distanceRatio := distance / allowed
+
+	// Distance to the positions predicted 1 and 2 seconds ahead, with 50% more tolerance
+	if boat.PixelVelocity.X != 0 || boat.PixelVelocity.Y != 0 {
+		for _, seconds := range []float64{1.0, 2.0} {
+			predictedX := float64(boat.CurrentPixel.X) + boat.PixelVelocity.X*seconds
+			predictedY := float64(boat.CurrentPixel.Y) + boat.PixelVelocity.Y*seconds
+			predicted := math.Hypot(float64(candidate.centerX)-predictedX, float64(candidate.centerY)-predictedY)
+			distanceRatio = math.Min(distanceRatio, predicted/(gate*1.5))
+		}
+	}
+
+	if !overlaps && distanceRatio >= 1 {
+		return forbiddenCost
+	}
+
+	config := si.association
+	weighted := config.IoUWeight*(1-boxIoU(candidate.rect, boat.BoundingBox)) +
+		config.DistanceWeight*math.Min(distanceRatio, 1) +
+		config.AppearanceWeight*appearanceCost(candidate, boat)
+	total := weighted / (config.IoUWeight + config.DistanceWeight + config.AppearanceWeight)
+	if total > config.MaxCost {
+		return forbiddenCost
+	}
+	return total
+}
+
+// boxIoU returns the intersection over union of two rectangles
+func boxIoU(a, b image.Rectangle) float64 {
+	intersection := a.Intersect(b)
+	if intersection.Empty() {
+		return 0
+	}
+	intersectionArea := float64(intersection.Dx() * intersection.Dy())
+	union := float64(a.Dx()*a.Dy()+b.Dx()*b.Dy()) - intersectionArea
+	if union <= 0 {
+		return 0
+	}
+	return intersectionArea / union
+}
+
+// appearanceCost compares what the detector can tell about a detection's appearance - its class, size and
+// box shape - with the boat's last detection. 0 is identical, 1 is completely different. Size and shape say
+// nothing while either is cut off by the frame, so only the class is compared then.
+func appearanceCost(candidate associationCandidate, boat *TrackedBoat) float64 {
+	classCost := 0.0
+	if boat.Classification != "" && candidate.className != boat.Classification {
+		classCost = 1.0
+	}
+	if candidate.edge != 0 || boat.EdgeClipped != 0 {
+		return classCost
+	}
+
+	sizeCost := 0.0
+	if boat.PixelArea > 0 && candidate.area > 0 {
+		sizeCost = 1 - math.Min(boat.PixelArea, candidate.area)/math.Max(boat.PixelArea, candidate.area)
+	}
+
+	shapeCost := 0.0
+	if boat.BoundingBox.Dy() > 0 && candidate.rect.Dy() > 0 {
+		boatAspect := float64(boat.BoundingBox.Dx()) / float64(boat.BoundingBox.Dy())
+		candidateAspect := float64(candidate.rect.Dx()) / float64(candidate.rect.Dy())
+		if boatAspect > 0 && candidateAspect > 0 {
+			shapeCost = 1 - math.Min(boatAspect, candidateAspect)/math.Max(boatAspect, candidateAspect)
+		}
+	}
+
+	return (classCost + sizeCost + shapeCost) / 3
+}
+
+// solveAssignment finds the minimum-cost assignment of rows to columns (Hungarian algorithm with potentials,
+// O(n²m)). It returns the column assigned to each row, or -1 for rows left over when there are more rows
+// than columns.
+func solveAssignment(cost [][]float64) []int {
+	rows := len(cost)
+	if rows == 0 {
+		return nil
+	}
+	cols := len(cost[0])
+
+	// The algorithm needs rows <= columns; solve the transpose otherwise
+	if rows > cols {
+		transposed := make([][]float64, cols)
+		for j := range transposed {
+			transposed[j] = make([]float64, rows)
+			for i := range cost {
+				transposed[j][i] = cost[i][j]
+			}
+		}
+		assignment := make([]int, rows)
+		for i := range assignment {
+			assignment[i] = -1
+		}
+		for j, i := range solveAssignment(transposed) {
+			if i >= 0 {
+				assignment[i] = j
+			}
+		}
+		return assignment
+	}
+
+	// 1-based potentials u (rows) and v (columns); match[j] is the row holding column j (0 = free)
+	u := make([]float64, rows+1)
+	v := make([]float64, cols+1)
+	match := make([]int, cols+1)
+	way := make([]int, cols+1)
+
+	for i := 1; i <= rows; i++ {
+		match[0] = i
+		column := 0
+		minSlack := make([]float64, cols+1)
+		used := make([]bool, cols+1)
+		for j := range minSlack {
+			minSlack[j] = math.Inf(1)
+		}
+
+		for match[column] != 0 {
+			used[column] = true
+			row := match[column]
+			delta := math.Inf(1)
+			next := 0
+			for j := 1; j <= cols; j++ {
+				if used[j] {
+					continue
+				}
+				if slack := cost[row-1][j-1] - u[row] - v[j]; slack < minSlack[j] {
+					minSlack[j] = slack
+					way[j] = column
+				}
+				if minSlack[j] < delta {
+					delta = minSlack[j]
+					next = j
+				}
+			}
+			for j := 0; j <= cols; j++ {
+				if used[j] {
+					u[match[j]] += delta
+					v[j] -= delta
+				} else {
+					minSlack[j] -= delta
+				}
+			}
+			column = next
+		}
+
+		// Flip the augmenting path
+		for column != 0 {
+			previous := way[column]
+			match[column] = match[previous]
+			column = previous
+		}
+	}
+
+	assignment := make([]int, rows)
+	for i := range assignment {
+		assignment[i] = -1
+	}
+	for j := 1; j <= cols; j++ {
+		if match[j] != 0 {
+			assignment[match[j]-1] = j - 1
+		}
+	}
+	return assignment
+}
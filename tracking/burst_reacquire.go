@@ -0,0 +1,143 @@
+package tracking
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"time"
+)
+
+// Burst re-acquisition defaults
+const (
+	DefaultBurstTime            = 330 * time.Millisecond // How long after the last detection a coasting lock keeps its window open
+	DefaultBurstRadius          = 150.0                  // Window radius around the predicted position (pixels)
+	DefaultBurstConfidenceScale = 0.6                    // P1 threshold multiplier inside a window
+)
+
+// burstWindow is the area around a coasting locked target's predicted position where weaker detections count
+type burstWindow struct {
+	boatID string
+	center image.Point
+	radius float64
+}
+
+// ConfigureBurstReacquisition enables lowering the P1 confidence threshold to p1MinConfidence*confidenceScale
+// within radius pixels of a coasting locked target's predicted position for up to window after its last
+// detection.
+// Detections below p1MinConfidence outside every window are dropped, so the detector may hand over anything
+// down to the lowered threshold without adding false positives elsewhere.
+func (si *SpatialIntegration) ConfigureBurstReacquisition(enabled bool, window time.Duration, radius, confidenceScale float64) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if window <= 0 {
+		window = DefaultBurstTime
+	}
+	if radius <= 0 {
+		radius = DefaultBurstRadius
+	}
+	if confidenceScale <= 0 || confidenceScale > 1 {
+		confidenceScale = DefaultBurstConfidenceScale
+	}
+	si.burstEnabled = enabled
+	si.burstTime = window
+	si.updateFrameThresholds()
+	si.burstRadius = radius
+	si.burstConfidenceScale = confidenceScale
+
+	if enabled {
+		si.debugMsg("BURST_REACQUIRE", fmt.Sprintf("🎯 Burst re-acquisition enabled: P1 threshold %.2f → %.2f within %.0fpx of coasting locks for %v (%d frames)",
+			si.p1MinConfidence, si.p1MinConfidence*confidenceScale, radius, si.burstTime, si.burstFrames))
+	}
+}
+
+// burstWindows returns the re-acquisition windows of locked targets that are coasting. The predicted position
+// extrapolates the last pixel velocity from the last sighting; the radius grows with the boat's own size so
+// large vessels close to the camera are not cut off. Must be called with si.mu held.
+func (si *SpatialIntegration) burstWindows() []burstWindow {
+	var windows []burstWindow
+	for id, boat := range si.allBoats {
+		if !boat.IsLocked || boat.State != TrackCoasting || boat.LostFrames > si.burstFrames {
+			continue
+		}
+
+		elapsed := time.Since(boat.LastSeen).Seconds()
+		center := image.Point{
+			X: boat.CurrentPixel.X + int(boat.PixelVelocity.X*elapsed),
+			Y: boat.CurrentPixel.Y + int(boat.PixelVelocity.Y*elapsed),
+		}
+		radius := math.Max(si.burstRadius, math.Max(float64(boat.BoundingBox.Dx()), float64(boat.BoundingBox.Dy()))/2)
+		windows = append(windows, burstWindow{boatID: id, center: center, radius: radius})
+	}
+	return windows
+}
+
+// burstWindowAt returns the boat whose window contains point ("" = none)
+func burstWindowAt(windows []burstWindow, point image.Point) string {
+	for _, window := range windows {
+		dx, dy := float64(point.X-window.center.X), float64(point.Y-window.center.Y)
+		if math.Sqrt(dx*dx+dy*dy) <= window.radius {
+			return window.boatID
+		}
+	}
+	return ""
+}
+
+// InBurstWindow reports whether a detection is inside the re-acquisition window of a coasting locked target,
+// where P1 detections down to the lowered threshold count. Detection filtering ahead of tracking uses it to
+// pass those detections on without lowering the P1 threshold anywhere else in the frame.
+func (si *SpatialIntegration) InBurstWindow(rect image.Rectangle) bool {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+
+	if !si.burstEnabled {
+		return false
+	}
+	center := image.Point{X: rect.Min.X + rect.Dx()/2, Y: rect.Min.Y + rect.Dy()/2}
+	return burstWindowAt(si.burstWindows(), center) != ""
+}
+
+// filterBurstDetections drops P1 detections below p1MinConfidence unless they fall inside a burst window.
+// Must be called with si.mu held.
+func (si *SpatialIntegration) filterBurstDetections(detections []image.Rectangle, classNames []string, confidences []float64) ([]image.Rectangle, []string, []float64) {
+	if !si.burstEnabled {
+		return detections, classNames, confidences
+	}
+
+	var windows []burstWindow
+	windowsBuilt := false
+
+	keptRects := make([]image.Rectangle, 0, len(detections))
+	keptNames := make([]string, 0, len(classNames))
+	keptConfidences := make([]float64, 0, len(confidences))
+	var keptIndex []int
+	if si.verdictsEnabled {
+		keptIndex = make([]int, 0, len(detections))
+	}
+	for i, rect := range detections {
+		if si.isP1Object(classNames[i]) && confidences[i] < si.p1MinConfidence {
+			if !windowsBuilt {
+				windows = si.burstWindows()
+				windowsBuilt = true
+			}
+
+			center := image.Point{X: rect.Min.X + rect.Dx()/2, Y: rect.Min.Y + rect.Dy()/2}
+			inWindow := burstWindowAt(windows, center)
+			if inWindow == "" || confidences[i] < si.p1MinConfidence*si.burstConfidenceScale {
+				si.noteDetection(i, false, VerdictBurstFilter, "")
+				continue
+			}
+			si.debugMsg("BURST_REACQUIRE", fmt.Sprintf("🎯 Accepting %s at (%d,%d) conf %.2f (< %.2f) near predicted position",
+				classNames[i], center.X, center.Y, confidences[i], si.p1MinConfidence), inWindow)
+		}
+
+		keptRects = append(keptRects, rect)
+		keptNames = append(keptNames, classNames[i])
+		keptConfidences = append(keptConfidences, confidences[i])
+		if keptIndex != nil {
+			keptIndex = append(keptIndex, i)
+		}
+	}
+	si.verdictIndex = keptIndex
+	return keptRects, keptNames, keptConfidences
+}
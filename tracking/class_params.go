@@ -0,0 +1,164 @@
+package tracking
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Global lock and size settings, used for classes without their own parameters
+const (
+	defaultMinDetectionArea = 2000.0 // Smallest P1 detection tracked (px²)
+	defaultMinDetectionSide = 50     // P1 detections must be wider and taller than this (px)
+	minTrackingZoom         = 10.0   // Widest zoom the tracker commands
+	maxTrackingZoom         = 120.0  // Tightest zoom the tracker commands
+)
+
+// ClassParams overrides the global lock, size and zoom settings for one P1 class. Zero fields keep the
+// global value, so a distant kayak can be tracked from a smaller box and a cargo ship held at a wider zoom
+// without changing the other classes.
+type ClassParams struct {
+	MinDetectionsForLock int     `json:"min_detections_for_lock,omitempty"` // Detections before the track can lock
+	MinArea              float64 `json:"min_area,omitempty"`                // Smallest detection tracked (px²)
+	MinWidth             int     `json:"min_width,omitempty"`               // Detections must be wider than this (px)
+	MinHeight            int     `json:"min_height,omitempty"`              // Detections must be taller than this (px)
+	MinZoom              float64 `json:"min_zoom,omitempty"`                // Widest zoom used while tracking the class
+	MaxZoom              float64 `json:"max_zoom,omitempty"`                // Tightest zoom used while tracking the class
+}
+
+// LoadClassParams reads a per-class parameter file: an object keyed by class name, e.g.
+// {"surfboard": {"min_area": 600, "min_width": 20, "min_height": 12}, "boat": {"min_detections_for_lock": 4, "max_zoom": 60}}
+func LoadClassParams(path string) (map[string]ClassParams, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read class parameters: %v", err)
+	}
+
+	var params map[string]ClassParams
+	if err := json.Unmarshal(data, &params); err != nil {
+		return nil, fmt.Errorf("could not parse class parameters %s: %v", path, err)
+	}
+	for class, p := range params {
+		if strings.TrimSpace(class) == "" {
+			return nil, fmt.Errorf("%s: class parameters need a class name", path)
+		}
+		if p.MinDetectionsForLock < 0 || p.MinArea < 0 || p.MinWidth < 0 || p.MinHeight < 0 {
+			return nil, fmt.Errorf("%s: %s: lock and size limits must not be negative", path, class)
+		}
+		if (p.MinZoom != 0 && (p.MinZoom < minTrackingZoom || p.MinZoom > maxTrackingZoom)) ||
+			(p.MaxZoom != 0 && (p.MaxZoom < minTrackingZoom || p.MaxZoom > maxTrackingZoom)) {
+			return nil, fmt.Errorf("%s: %s: zoom limits must be between %.0f and %.0f", path, class, minTrackingZoom, maxTrackingZoom)
+		}
+		if p.MinZoom != 0 && p.MaxZoom != 0 && p.MinZoom > p.MaxZoom {
+			return nil, fmt.Errorf("%s: %s: min_zoom must not exceed max_zoom", path, class)
+		}
+	}
+	return params, nil
+}
+
+// ConfigureClassParams sets the per-class overrides of the lock, size and zoom settings (nil: global
+// settings for every class)
+func (si *SpatialIntegration) ConfigureClassParams(params map[string]ClassParams) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.classParams = params
+	if len(params) == 0 {
+		return
+	}
+
+	classes := make([]string, 0, len(params))
+	for class, p := range params {
+		var settings []string
+		if p.MinDetectionsForLock > 0 {
+			settings = append(settings, fmt.Sprintf("lock after %d", p.MinDetectionsForLock))
+		}
+		if p.MinArea > 0 || p.MinWidth > 0 || p.MinHeight > 0 {
+			area, width, height := si.minDetectionSize(class)
+			settings = append(settings, fmt.Sprintf("min %.0fpx² >%dx%d", area, width, height))
+		}
+		if p.MinZoom > 0 || p.MaxZoom > 0 {
+			minZoom, maxZoom := si.zoomRange(class)
+			settings = append(settings, fmt.Sprintf("zoom %.0f-%.0f", minZoom, maxZoom))
+		}
+		classes = append(classes, fmt.Sprintf("%s (%s)", class, strings.Join(settings, ", ")))
+	}
+	sort.Strings(classes)
+	si.debugMsg("TRACKING_CONFIG", fmt.Sprintf("🎛️ Per-class parameters: %s", strings.Join(classes, "; ")))
+}
+
+// lockDetections returns the detections a boat of the class needs before it can lock. Must be called with
+// si.mu held.
+func (si *SpatialIntegration) lockDetections(className string) int {
+	if p, ok := si.classParams[className]; ok && p.MinDetectionsForLock > 0 {
+		return p.MinDetectionsForLock
+	}
+	return si.minDetectionsForLock
+}
+
+// minDetectionSize returns the smallest area (px²) and the width and height a detection of the class must
+// exceed to be tracked. Must be called with si.mu held.
+func (si *SpatialIntegration) minDetectionSize(className string) (area float64, width, height int) {
+	area, width, height = defaultMinDetectionArea, defaultMinDetectionSide, defaultMinDetectionSide
+	if p, ok := si.classParams[className]; ok {
+		if p.MinArea > 0 {
+			area = p.MinArea
+		}
+		if p.MinWidth > 0 {
+			width = p.MinWidth
+		}
+		if p.MinHeight > 0 {
+			height = p.MinHeight
+		}
+	}
+	return area, width, height
+}
+
+// zoomRange returns the zoom limits for tracking a boat of the class. Must be called with si.mu held.
+func (si *SpatialIntegration) zoomRange(className string) (minZoom, maxZoom float64) {
+	minZoom, maxZoom = minTrackingZoom, maxTrackingZoom
+	if p, ok := si.classParams[className]; ok {
+		if p.MinZoom > 0 {
+			minZoom = p.MinZoom
+		}
+		if p.MaxZoom > 0 {
+			maxZoom = p.MaxZoom
+		}
+	}
+	if si.zoomPolicyMin > 0 {
+		minZoom, maxZoom = max(minZoom, si.zoomPolicyMin), max(maxZoom, si.zoomPolicyMin)
+	}
+	if si.zoomPolicyMax > 0 {
+		minZoom, maxZoom = min(minZoom, si.zoomPolicyMax), min(maxZoom, si.zoomPolicyMax)
+	}
+	return minZoom, maxZoom
+}
+
+// SetZoomPolicy narrows the tracking zoom range of every class to minZoom-maxZoom (0 = no limit on that side),
+// e.g. a wide view at night; a class range outside the policy is pulled into it
+func (si *SpatialIntegration) SetZoomPolicy(minZoom, maxZoom float64) error {
+	if (minZoom != 0 && (minZoom < minTrackingZoom || minZoom > maxTrackingZoom)) ||
+		(maxZoom != 0 && (maxZoom < minTrackingZoom || maxZoom > maxTrackingZoom)) {
+		return fmt.Errorf("zoom policy limits must be between %.0f and %.0f", minTrackingZoom, maxTrackingZoom)
+	}
+	if minZoom != 0 && maxZoom != 0 && minZoom > maxZoom {
+		return fmt.Errorf("zoom policy minimum %.0f exceeds maximum %.0f", minZoom, maxZoom)
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if minZoom == si.zoomPolicyMin && maxZoom == si.zoomPolicyMax {
+		return nil
+	}
+	si.zoomPolicyMin, si.zoomPolicyMax = minZoom, maxZoom
+	if minZoom == 0 && maxZoom == 0 {
+		si.debugMsg("TRACKING_CONFIG", "🔍 Zoom policy cleared")
+	} else {
+		lowest, highest := si.zoomRange("")
+		si.debugMsg("TRACKING_CONFIG", fmt.Sprintf("🔍 Zoom policy: tracking zoom %.0f-%.0f", lowest, highest))
+	}
+	return nil
+}
@@ -0,0 +1,285 @@
+package tracking
+
+import (
+	"fmt"
+	"time"
+)
+
+// Confidence auto-calibration defaults
+const (
+	DefaultCalibrationKeepRatio = 0.95 // Share of the detections on accepted tracks the recommended threshold keeps
+	DefaultCalibrationMinTracks = 20   // Accepted tracks a class group needs before a threshold is recommended
+)
+
+// confidenceBins is the resolution of the recorded confidence distributions (0.01)
+const confidenceBins = 100
+
+// calibrationBucketWidth is the width of the histogram buckets in the report
+const calibrationBucketWidth = 0.05
+
+// confidenceHistogram counts detections per 0.01 of confidence
+type confidenceHistogram [confidenceBins]int
+
+func (h *confidenceHistogram) add(confidence float64) {
+	bin := int(confidence * confidenceBins)
+	if bin < 0 {
+		bin = 0
+	} else if bin >= confidenceBins {
+		bin = confidenceBins - 1
+	}
+	h[bin]++
+}
+
+func (h *confidenceHistogram) merge(other *confidenceHistogram) {
+	for i, count := range other {
+		h[i] += count
+	}
+}
+
+func (h *confidenceHistogram) total() int {
+	total := 0
+	for _, count := range h {
+		total += count
+	}
+	return total
+}
+
+// atOrAbove counts the detections at or above bin
+func (h *confidenceHistogram) atOrAbove(bin int) int {
+	count := 0
+	for i := bin; i < confidenceBins; i++ {
+		count += h[i]
+	}
+	return count
+}
+
+// trackCalibration is the confidence distribution of one track's P1 and P2 detections, folded into the
+// calibration once the track's fate is known
+type trackCalibration struct {
+	p1, p2 confidenceHistogram
+}
+
+// calibrationGroup accumulates the detections of one class group by the fate of their tracks
+type calibrationGroup struct {
+	accepted, discarded             confidenceHistogram
+	acceptedTracks, discardedTracks int
+}
+
+// confidenceCalibration is a running calibration
+type confidenceCalibration struct {
+	config  ConfidenceCalibrationConfig
+	started time.Time
+	paused  bool
+	p1, p2  calibrationGroup
+}
+
+// ConfidenceCalibrationConfig configures a confidence calibration run
+type ConfidenceCalibrationConfig struct {
+	KeepRatio float64 // Share of the detections on accepted tracks the recommended threshold keeps (0 = default)
+	MinTracks int     // Accepted tracks a class group needs before a threshold is recommended (0 = default)
+}
+
+// ConfidenceBucket is one bucket of a calibration histogram
+type ConfidenceBucket struct {
+	Min       float64 `json:"min"`
+	Accepted  int     `json:"accepted"`
+	Discarded int     `json:"discarded"`
+}
+
+// ConfidenceCalibrationGroup is the calibration result of the P1 or P2 classes
+type ConfidenceCalibrationGroup struct {
+	Threshold           float64            `json:"threshold"`   // In effect when the calibration finished
+	Recommended         float64            `json:"recommended"` // 0 = not enough data (see Note)
+	AcceptedTracks      int                `json:"accepted_tracks"`
+	DiscardedTracks     int                `json:"discarded_tracks"` // Ghost tracks removed before they were ever confirmed
+	AcceptedDetections  int                `json:"accepted_detections"`
+	DiscardedDetections int                `json:"discarded_detections"`
+	KeptAccepted        float64            `json:"kept_accepted"`      // Share of accepted-track detections at or above Recommended
+	RejectedDiscarded   float64            `json:"rejected_discarded"` // Share of ghost-track detections below Recommended
+	Histogram           []ConfidenceBucket `json:"histogram"`
+	Note                string             `json:"note,omitempty"`
+}
+
+// ConfidenceCalibrationReport is the outcome of a calibration run
+type ConfidenceCalibrationReport struct {
+	Started   time.Time                  `json:"started"`
+	Finished  time.Time                  `json:"finished"`
+	KeepRatio float64                    `json:"keep_ratio"`
+	MinTracks int                        `json:"min_tracks"`
+	P1        ConfidenceCalibrationGroup `json:"p1"`
+	P2        ConfidenceCalibrationGroup `json:"p2"` // People, by the fate of the boat they were detected on
+}
+
+// StartConfidenceCalibration starts recording the confidence of every tracked detection. When a track ends its
+// detections count as accepted if it was ever confirmed and as ghosts if it was removed while still tentative.
+// FinishConfidenceCalibration recommends thresholds from the two distributions. Detections below the thresholds
+// in effect are never tracked, so start with low thresholds for the calibration to see the weak ones too.
+func (si *SpatialIntegration) StartConfidenceCalibration(config ConfidenceCalibrationConfig) error {
+	if config.KeepRatio == 0 {
+		config.KeepRatio = DefaultCalibrationKeepRatio
+	}
+	if config.MinTracks == 0 {
+		config.MinTracks = DefaultCalibrationMinTracks
+	}
+	if config.KeepRatio <= 0 || config.KeepRatio > 1 {
+		return fmt.Errorf("calibration keep ratio must be between 0 and 1, got %.2f", config.KeepRatio)
+	}
+	if config.MinTracks < 1 {
+		return fmt.Errorf("calibration needs at least one accepted track, got %d", config.MinTracks)
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.calibration = &confidenceCalibration{config: config, started: time.Now()}
+	si.debugMsg("CONFIDENCE_CALIBRATION", fmt.Sprintf("📊 Confidence calibration started: thresholds will keep %.0f%% of the detections on accepted tracks (P1 %.2f, P2 %.2f in effect)",
+		config.KeepRatio*100, si.p1MinConfidence, si.p2MinConfidence))
+	return nil
+}
+
+// PauseConfidenceCalibration stops (true) or resumes (false) recording, e.g. while night mode runs with other
+// thresholds and another model
+func (si *SpatialIntegration) PauseConfidenceCalibration(paused bool) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if si.calibration == nil || si.calibration.paused == paused {
+		return
+	}
+	si.calibration.paused = paused
+	state := "resumed"
+	if paused {
+		state = "paused"
+	}
+	si.debugMsg("CONFIDENCE_CALIBRATION", fmt.Sprintf("📊 Confidence calibration %s", state))
+}
+
+// FinishConfidenceCalibration ends the calibration and returns its report (nil when none is running). Tracks
+// still running count as accepted when confirmed; tentative ones are left out.
+func (si *SpatialIntegration) FinishConfidenceCalibration() *ConfidenceCalibrationReport {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	calibration := si.calibration
+	if calibration == nil {
+		return nil
+	}
+	for _, boat := range si.allBoats {
+		if boat.State != TrackTentative {
+			si.foldTrackCalibration(boat)
+		}
+		boat.calibration = nil
+	}
+	si.calibration = nil
+
+	config := calibration.config
+	report := &ConfidenceCalibrationReport{
+		Started:   calibration.started,
+		Finished:  time.Now(),
+		KeepRatio: config.KeepRatio,
+		MinTracks: config.MinTracks,
+		P1:        calibration.p1.result(si.p1MinConfidence, config),
+		P2:        calibration.p2.result(si.p2MinConfidence, config),
+	}
+	si.debugMsg("CONFIDENCE_CALIBRATION", fmt.Sprintf("📊 Confidence calibration finished after %v: P1 %s, P2 %s",
+		report.Finished.Sub(report.Started).Round(time.Minute), report.P1.summary(), report.P2.summary()))
+	return report
+}
+
+// recordCalibrationSample adds a detection's confidence to its track while a calibration runs. Tracks that
+// started before the calibration are left out, their early detections were not seen. Must be called with
+// si.mu held.
+func (si *SpatialIntegration) recordCalibrationSample(boat *TrackedBoat, confidence float64, person bool) {
+	if si.calibration == nil || si.calibration.paused || boat.FirstDetected.Before(si.calibration.started) {
+		return
+	}
+	if boat.calibration == nil {
+		boat.calibration = &trackCalibration{}
+	}
+	if person {
+		boat.calibration.p2.add(confidence)
+	} else {
+		boat.calibration.p1.add(confidence)
+	}
+}
+
+// foldTrackCalibration adds an ended track's detections to the calibration as accepted (the track was
+// confirmed) or ghosts (removed while tentative). Must be called with si.mu held.
+func (si *SpatialIntegration) foldTrackCalibration(boat *TrackedBoat) {
+	samples := boat.calibration
+	boat.calibration = nil
+	if si.calibration == nil || samples == nil {
+		return
+	}
+	accepted := boat.State != TrackTentative
+	si.calibration.p1.fold(&samples.p1, accepted)
+	si.calibration.p2.fold(&samples.p2, accepted)
+}
+
+func (g *calibrationGroup) fold(samples *confidenceHistogram, accepted bool) {
+	if samples.total() == 0 {
+		return
+	}
+	if accepted {
+		g.accepted.merge(samples)
+		g.acceptedTracks++
+	} else {
+		g.discarded.merge(samples)
+		g.discardedTracks++
+	}
+}
+
+// result recommends the highest threshold that keeps KeepRatio of the accepted-track detections
+func (g *calibrationGroup) result(threshold float64, config ConfidenceCalibrationConfig) ConfidenceCalibrationGroup {
+	result := ConfidenceCalibrationGroup{
+		Threshold:           threshold,
+		AcceptedTracks:      g.acceptedTracks,
+		DiscardedTracks:     g.discardedTracks,
+		AcceptedDetections:  g.accepted.total(),
+		DiscardedDetections: g.discarded.total(),
+	}
+
+	binsPerBucket := int(calibrationBucketWidth * confidenceBins)
+	for start := 0; start < confidenceBins; start += binsPerBucket {
+		bucket := ConfidenceBucket{Min: float64(start) / confidenceBins}
+		for i := start; i < start+binsPerBucket && i < confidenceBins; i++ {
+			bucket.Accepted += g.accepted[i]
+			bucket.Discarded += g.discarded[i]
+		}
+		if bucket.Accepted > 0 || bucket.Discarded > 0 {
+			result.Histogram = append(result.Histogram, bucket)
+		}
+	}
+
+	if g.acceptedTracks < config.MinTracks {
+		result.Note = fmt.Sprintf("not enough data: %d of %d accepted tracks", g.acceptedTracks, config.MinTracks)
+		return result
+	}
+
+	recommended := 0
+	for bin := confidenceBins - 1; bin >= 0; bin-- {
+		if float64(g.accepted.atOrAbove(bin)) >= config.KeepRatio*float64(result.AcceptedDetections) {
+			recommended = bin
+			break
+		}
+	}
+	result.Recommended = float64(recommended) / confidenceBins
+	result.KeptAccepted = float64(g.accepted.atOrAbove(recommended)) / float64(result.AcceptedDetections)
+	if result.DiscardedDetections > 0 {
+		result.RejectedDiscarded = 1 - float64(g.discarded.atOrAbove(recommended))/float64(result.DiscardedDetections)
+	} else {
+		result.Note = "no ghost tracks: a higher threshold would only drop real objects"
+		result.Recommended = threshold
+		result.KeptAccepted = 1
+	}
+	return result
+}
+
+// summary describes the result in one line for the log
+func (r ConfidenceCalibrationGroup) summary() string {
+	if r.Recommended == 0 {
+		return r.Note
+	}
+	return fmt.Sprintf("%.2f → %.2f (keeps %.0f%% of the detections on %d accepted tracks, drops %.0f%% of those on %d ghost tracks)",
+		r.Threshold, r.Recommended, r.KeptAccepted*100, r.AcceptedTracks, r.RejectedDiscarded*100, r.DiscardedTracks)
+}
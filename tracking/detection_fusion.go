@@ -0,0 +1,200 @@
+package tracking
+
+import (
+	"fmt"
+	"image"
+	"math"
+)
+
+// Multi-frame detection fusion defaults: a new track needs a consistent detection in 3 of the last 5 frames
+const (
+	DefaultFusionWindow  = 5
+	DefaultFusionMinHits = 3
+	fusionMinRadius      = 60.0 // Minimum pixel distance for two detections to belong to the same cluster
+)
+
+// fusionCandidate is an unmatched P1 detection waiting for confirmation in later frames
+type fusionCandidate struct {
+	rect       image.Rectangle
+	center     image.Point
+	area       float64
+	confidence float64
+	className  string
+	wake       wakeMeasure       // Region measurements for the wake filter (zero when it is off)
+	history    []fusionCandidate // Detections that confirmed a fused candidate, oldest first
+}
+
+// detectionFusion buffers unmatched detections over the last N frames so one-frame junk never becomes a TrackedBoat
+type detectionFusion struct {
+	window  int
+	minHits int
+	frames  [][]fusionCandidate // Oldest first; last entry is the current frame
+}
+
+// newDetectionFusion creates a fusion buffer requiring minHits of the last window frames
+func newDetectionFusion(window, minHits int) *detectionFusion {
+	if window < 1 {
+		window = 1
+	}
+	if minHits < 1 {
+		minHits = 1
+	}
+	if minHits > window {
+		minHits = window
+	}
+	return &detectionFusion{window: window, minHits: minHits}
+}
+
+// enabled reports whether detections are fused at all (1-of-N creates tracks immediately)
+func (df *detectionFusion) enabled() bool {
+	return df != nil && df.minHits > 1
+}
+
+// beginFrame opens a new frame slot and drops frames that fell out of the window
+func (df *detectionFusion) beginFrame() {
+	df.frames = append(df.frames, nil)
+	if len(df.frames) > df.window {
+		df.frames = df.frames[len(df.frames)-df.window:]
+	}
+}
+
+// reset discards all pending candidates (pixel positions are meaningless after the camera moves)
+func (df *detectionFusion) reset() {
+	df.frames = nil
+}
+
+// pending returns the number of buffered candidates across the window
+func (df *detectionFusion) pending() int {
+	count := 0
+	for _, frame := range df.frames {
+		count += len(frame)
+	}
+	return count
+}
+
+// observe adds an unmatched detection to the current frame and reports how many frames in the window contain
+// a spatially consistent detection. When the cluster reaches minHits its candidates are consumed and the
+// fused candidate (latest position, mean confidence) is returned for track creation.
+func (df *detectionFusion) observe(candidate fusionCandidate) (int, *fusionCandidate) {
+	if len(df.frames) == 0 {
+		df.beginFrame()
+	}
+
+	radius := math.Max(fusionMinRadius, 0.5*math.Max(float64(candidate.rect.Dx()), float64(candidate.rect.Dy())))
+
+	// Find the nearest consistent candidate in each earlier frame
+	type clusterMember struct {
+		frame, index int
+	}
+	var members []clusterMember
+	var history []fusionCandidate
+	confidenceSum := candidate.confidence
+	for f := 0; f < len(df.frames)-1; f++ {
+		bestIndex := -1
+		bestDistance := radius
+		for i, previous := range df.frames[f] {
+			dx := float64(previous.center.X - candidate.center.X)
+			dy := float64(previous.center.Y - candidate.center.Y)
+			if distance := math.Sqrt(dx*dx + dy*dy); distance <= bestDistance {
+				bestIndex = i
+				bestDistance = distance
+			}
+		}
+		if bestIndex >= 0 {
+			members = append(members, clusterMember{frame: f, index: bestIndex})
+			history = append(history, df.frames[f][bestIndex])
+			confidenceSum += df.frames[f][bestIndex].confidence
+		}
+	}
+
+	hits := len(members) + 1
+	if hits < df.minHits {
+		current := len(df.frames) - 1
+		df.frames[current] = append(df.frames[current], candidate)
+		return hits, nil
+	}
+
+	// Confirmed: consume the cluster so it cannot spawn a second track
+	for i := len(members) - 1; i >= 0; i-- {
+		frame := df.frames[members[i].frame]
+		df.frames[members[i].frame] = append(frame[:members[i].index], frame[members[i].index+1:]...)
+	}
+
+	fused := candidate
+	fused.confidence = confidenceSum / float64(hits)
+	fused.history = append(history, candidate)
+	return hits, &fused
+}
+
+// ConfigureDetectionFusion sets how many of the last window frames must contain a consistent detection
+// before a new track is created. minHits of 1 disables fusion (tracks are created from single detections).
+func (si *SpatialIntegration) ConfigureDetectionFusion(window, minHits int) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.detectionFusion = newDetectionFusion(window, minHits)
+
+	if si.detectionFusion.enabled() {
+		si.debugMsg("DETECTION_FUSION", fmt.Sprintf("🧩 Detection fusion enabled: new tracks need %d of the last %d frames",
+			si.detectionFusion.minHits, si.detectionFusion.window))
+	} else {
+		si.debugMsg("DETECTION_FUSION", "🧩 Detection fusion disabled: tracks are created from single detections")
+	}
+}
+
+// fuseUnmatchedDetection buffers an unmatched detection and creates a TrackedBoat once it is confirmed.
+// Returns nil and the reason (VerdictPending or VerdictWake) while no track is created. Must be called with
+// si.mu held.
+func (si *SpatialIntegration) fuseUnmatchedDetection(detection image.Rectangle, centerX, centerY int, area, confidence float64, className string) (*TrackedBoat, string) {
+	candidate := fusionCandidate{
+		rect:       detection,
+		center:     image.Point{X: centerX, Y: centerY},
+		area:       area,
+		confidence: confidence,
+		className:  className,
+	}
+	if si.wakeFilterEnabled() {
+		candidate.wake = si.measureWake(detection)
+	}
+
+	if !si.detectionFusion.enabled() {
+		if si.rejectWake(&candidate, []fusionCandidate{candidate}) {
+			return nil, VerdictWake
+		}
+		return si.createNewTrackedObject(detection, centerX, centerY, area, confidence, className), ""
+	}
+
+	hits, fused := si.detectionFusion.observe(candidate)
+	if fused == nil {
+		si.debugMsgVerbose("DETECTION_FUSION", fmt.Sprintf("⏳ Pending %s at (%d,%d) conf=%.2f: %d/%d frames (window %d)",
+			className, centerX, centerY, confidence, hits, si.detectionFusion.minHits, si.detectionFusion.window))
+		return nil, VerdictPending
+	}
+	if si.rejectWake(fused, fused.history) {
+		return nil, VerdictWake
+	}
+
+	boat := si.createNewTrackedObject(fused.rect, fused.center.X, fused.center.Y, fused.area, fused.confidence, fused.className)
+	boat.DetectionCount = hits // Confirmed frames count toward lock progress
+
+	si.debugMsg("DETECTION_FUSION", fmt.Sprintf("🧩 Confirmed %s after %d/%d frames (mean conf %.2f)",
+		fused.className, hits, si.detectionFusion.window, fused.confidence), boat.ID)
+
+	return boat, ""
+}
+
+// rejectWake reports (and logs) a confirmed candidate that the wake filter takes for a wake or foam. Must be
+// called with si.mu held.
+func (si *SpatialIntegration) rejectWake(candidate *fusionCandidate, history []fusionCandidate) bool {
+	if !si.wakeFilterEnabled() {
+		return false
+	}
+	wake, cues := si.isWake(history)
+	if !wake {
+		return false
+	}
+	si.wakeRejections++
+	si.debugMsg("WAKE_FILTER", fmt.Sprintf("🌊 Rejected %s at (%d,%d) conf=%.2f as wake/foam (%s) - %d rejected so far",
+		candidate.className, candidate.center.X, candidate.center.Y, candidate.confidence, cues, si.wakeRejections))
+	return true
+}
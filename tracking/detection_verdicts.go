@@ -0,0 +1,73 @@
+package tracking
+
+// Detection verdict reasons
+const (
+	VerdictBurstFilter = "burst_filter" // Weak P1 detection outside every burst re-acquisition window
+	VerdictNotP1       = "not_p1"       // Class is not tracked in the detection's class zone
+	VerdictP2          = "p2"           // P2 object, used for people inside tracked boats rather than as a track
+	VerdictMinArea     = "min_area"     // Smaller than the class's minimum area
+	VerdictMinSize     = "min_size"     // Narrower or lower than the class's minimum dimensions
+	VerdictMatched     = "matched"      // Updated an existing track
+	VerdictNewTrack    = "new_track"    // Started a new track
+	VerdictPending     = "pending"      // Waiting in the detection fusion window for more hits
+	VerdictWake        = "wake"         // Taken for a wake or foam by the wake filter
+	VerdictGlare       = "glare"        // Inside an active sun glare or reflection window
+)
+
+// DetectionVerdict is what tracking did with one detection
+type DetectionVerdict struct {
+	Accepted bool   // The detection updated or started a track, or was used as a P2 object
+	Reason   string // One of the Verdict reasons
+	ObjectID string // Track the detection updated or started
+}
+
+// EnableDetectionVerdicts makes UpdateTracking record a verdict for every detection it is given, for
+// DetectionVerdicts to read back after the call
+func (si *SpatialIntegration) EnableDetectionVerdicts(enabled bool) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.verdictsEnabled = enabled
+	si.verdicts = nil
+	si.verdictIndex = nil
+}
+
+// DetectionVerdicts returns the verdicts of the last UpdateTracking call, one per detection in the order they
+// were passed, or nil when verdicts are not enabled
+func (si *SpatialIntegration) DetectionVerdicts() []DetectionVerdict {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if !si.verdictsEnabled {
+		return nil
+	}
+	verdicts := make([]DetectionVerdict, len(si.verdicts))
+	copy(verdicts, si.verdicts)
+	return verdicts
+}
+
+// beginVerdicts resets the verdicts for a frame of n detections. Must be called with si.mu held.
+func (si *SpatialIntegration) beginVerdicts(n int) {
+	if !si.verdictsEnabled {
+		return
+	}
+	si.verdicts = make([]DetectionVerdict, n)
+	si.verdictIndex = nil
+}
+
+// noteDetection records the verdict of detection i, counted after the burst filter once it has run. Must be
+// called with si.mu held.
+func (si *SpatialIntegration) noteDetection(i int, accepted bool, reason, objectID string) {
+	if !si.verdictsEnabled {
+		return
+	}
+	if si.verdictIndex != nil {
+		if i >= len(si.verdictIndex) {
+			return
+		}
+		i = si.verdictIndex[i]
+	}
+	if i >= 0 && i < len(si.verdicts) {
+		si.verdicts[i] = DetectionVerdict{Accepted: accepted, Reason: reason, ObjectID: objectID}
+	}
+}
@@ -0,0 +1,89 @@
+package tracking
+
+import (
+	"fmt"
+	"math"
+
+	"rivercam/calibration"
+)
+
+// egoMotionMaxZoomChange is the largest zoom change (camera units) across the velocity window that can be
+// compensated; zooming scales the image about its center, which a pan/tilt shift cannot describe
+const egoMotionMaxZoomChange = 2.0
+
+// ConfigureEgoMotionCompensation lets velocity estimation continue while the camera moves: the pan/tilt the
+// camera reports over the velocity window is converted into a pixel shift with the calibration table and
+// removed from the boat's apparent movement. Disabled, velocity is only updated while the camera is IDLE.
+func (si *SpatialIntegration) ConfigureEgoMotionCompensation(enabled bool) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.egoMotionEnabled = enabled
+	if enabled {
+		si.debugMsg("EGO_MOTION", "🎥 Ego-motion compensation enabled - velocity keeps updating while the camera moves")
+	}
+}
+
+// recordCameraPosition stores the camera position next to the pixel history point just added. Must be called
+// with si.mu held.
+func (si *SpatialIntegration) recordCameraPosition(boat *TrackedBoat) {
+	if !si.egoMotionEnabled || si.ptzCtrl == nil {
+		return
+	}
+	position := si.ptzCtrl.GetCurrentPosition()
+	boat.cameraHistory = append(boat.cameraHistory, SpatialCoordinate{Pan: position.Pan, Tilt: position.Tilt, Zoom: position.Zoom})
+	if len(boat.cameraHistory) > 20 {
+		boat.cameraHistory = boat.cameraHistory[1:]
+	}
+}
+
+// egoMotionShift returns the pixel shift the camera's own movement caused over the last count pixel history
+// points. ok is false when the camera positions for those points are unknown or the zoom changed too much.
+// Must be called with si.mu held.
+func (si *SpatialIntegration) egoMotionShift(boat *TrackedBoat, count int) (shiftX, shiftY float64, ok bool) {
+	if count < 2 || len(boat.cameraHistory) < count || len(boat.PixelHistory) < count {
+		return 0, 0, false
+	}
+	from := boat.cameraHistory[len(boat.cameraHistory)-count]
+	to := boat.cameraHistory[len(boat.cameraHistory)-1]
+	shiftX, shiftY, ok = si.cameraShift(from, to)
+	if ok && (shiftX != 0 || shiftY != 0) {
+		si.debugMsgVerbose("EGO_MOTION", fmt.Sprintf("🎥 Camera moved Pan=%+.0f Tilt=%+.0f over %d points → image shift (%+.0f,%+.0f)px",
+			wrapPanDelta(to.Pan-from.Pan), to.Tilt-from.Tilt, count, shiftX, shiftY), boat.ID)
+	}
+	return shiftX, shiftY, ok
+}
+
+// wrapPanDelta returns a pan difference the short way round (pan wraps at 3600)
+func wrapPanDelta(panDelta float64) float64 {
+	if panDelta > 1800 {
+		panDelta -= 3600
+	} else if panDelta < -1800 {
+		panDelta += 3600
+	}
+	return panDelta
+}
+
+// cameraShift returns the pixel shift a camera move from one position to another causes in the image. ok is
+// false when the zoom changed too much to describe as a shift. Must be called with si.mu held.
+func (si *SpatialIntegration) cameraShift(from, to SpatialCoordinate) (shiftX, shiftY float64, ok bool) {
+	if math.Abs(to.Zoom-from.Zoom) > egoMotionMaxZoomChange {
+		return 0, 0, false
+	}
+
+	panDelta := wrapPanDelta(to.Pan - from.Pan)
+	tiltDelta := to.Tilt - from.Tilt
+	if panDelta == 0 && tiltDelta == 0 {
+		return 0, 0, true
+	}
+
+	zoom := (from.Zoom + to.Zoom) / 2
+	panPixelsPerUnit := si.spatialTracker.InterpolatePanCalibration(zoom)
+	tiltPixelsPerUnit := si.spatialTracker.InterpolateTiltCalibration(zoom)
+	if panPixelsPerUnit <= 0 || tiltPixelsPerUnit <= 0 {
+		panPixelsPerUnit, tiltPixelsPerUnit = calibration.DefaultTable().InterpolateAt(zoom)
+	}
+
+	// Panning right (+pan) moves the scene left in the image, tilting down (+tilt) moves it up
+	return -panDelta * panPixelsPerUnit, -tiltDelta * tiltPixelsPerUnit, true
+}
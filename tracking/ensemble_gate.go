@@ -0,0 +1,141 @@
+package tracking
+
+import (
+	"fmt"
+	"image"
+	"time"
+)
+
+// Ensemble verification retry: a rejected boat is re-checked after this long (it may have come closer or turned)
+const ensembleRetryInterval = 10 * time.Second
+
+// CriticalZone is an area (spatial pan/tilt coordinates) where false locks are costly, e.g. a harbor entrance.
+// Boats inside a critical zone cannot lock until a second model confirms the primary detection.
+type CriticalZone struct {
+	Name             string
+	MinPan, MaxPan   float64
+	MinTilt, MaxTilt float64
+}
+
+// Contains reports whether a spatial coordinate lies inside the zone
+func (z CriticalZone) Contains(coord SpatialCoordinate) bool {
+	return coord.Pan >= z.MinPan && coord.Pan <= z.MaxPan && coord.Tilt >= z.MinTilt && coord.Tilt <= z.MaxTilt
+}
+
+// EnsembleCheck asks the caller to run the secondary model on one boat's detection
+type EnsembleCheck struct {
+	BoatID         string
+	Classification string
+	Rect           image.Rectangle // Latest detection box in frame pixels
+	Zone           string
+}
+
+// ensembleVerdict is the secondary model's answer for one boat
+type ensembleVerdict struct {
+	confirmed  bool
+	confidence float64
+	checked    time.Time
+}
+
+// ConfigureCriticalZones sets the zones where locks require ensemble confirmation (nil disables the gate)
+func (si *SpatialIntegration) ConfigureCriticalZones(zones []CriticalZone) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.criticalZones = zones
+	si.ensembleVerdicts = make(map[string]ensembleVerdict)
+
+	for _, zone := range zones {
+		si.debugMsg("ENSEMBLE", fmt.Sprintf("🛡️ Critical zone %s: pan %.0f-%.0f tilt %.0f-%.0f - locks need secondary model confirmation",
+			zone.Name, zone.MinPan, zone.MaxPan, zone.MinTilt, zone.MaxTilt))
+	}
+}
+
+// criticalZoneFor returns the critical zone a boat is in, if any. Must be called with si.mu held.
+func (si *SpatialIntegration) criticalZoneFor(boat *TrackedBoat) (CriticalZone, bool) {
+	if len(si.criticalZones) == 0 {
+		return CriticalZone{}, false
+	}
+	spatial := si.calculateSpatialCoordinatesForPixel(boat.CurrentPixel.X, boat.CurrentPixel.Y)
+	for _, zone := range si.criticalZones {
+		if zone.Contains(spatial) {
+			return zone, true
+		}
+	}
+	return CriticalZone{}, false
+}
+
+// PendingEnsembleChecks returns the lock-ready boats inside critical zones that still need a secondary model verdict
+func (si *SpatialIntegration) PendingEnsembleChecks() []EnsembleCheck {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if len(si.criticalZones) == 0 {
+		return nil
+	}
+
+	// Forget verdicts for boats that are gone
+	for id := range si.ensembleVerdicts {
+		if _, exists := si.allBoats[id]; !exists {
+			delete(si.ensembleVerdicts, id)
+		}
+	}
+
+	var checks []EnsembleCheck
+	for _, boat := range si.allBoats {
+		if boat.IsLocked || boat.LostFrames > 0 || boat.DetectionCount < si.lockDetections(boat.Classification) {
+			continue
+		}
+		if verdict, checked := si.ensembleVerdicts[boat.ID]; checked {
+			if verdict.confirmed || time.Since(verdict.checked) < ensembleRetryInterval {
+				continue
+			}
+		}
+		zone, inZone := si.criticalZoneFor(boat)
+		if !inZone {
+			continue
+		}
+		checks = append(checks, EnsembleCheck{
+			BoatID:         boat.ID,
+			Classification: boat.Classification,
+			Rect:           boat.BoundingBox,
+			Zone:           zone.Name,
+		})
+	}
+	return checks
+}
+
+// ReportEnsembleResult records the secondary model's verdict for a boat
+func (si *SpatialIntegration) ReportEnsembleResult(boatID string, confirmed bool, confidence float64) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if si.ensembleVerdicts == nil {
+		si.ensembleVerdicts = make(map[string]ensembleVerdict)
+	}
+	si.ensembleVerdicts[boatID] = ensembleVerdict{confirmed: confirmed, confidence: confidence, checked: time.Now()}
+
+	if confirmed {
+		si.debugMsg("ENSEMBLE", fmt.Sprintf("✅ Secondary model confirmed %s (conf %.2f) - lock allowed", boatID, confidence), boatID)
+	} else {
+		si.debugMsg("ENSEMBLE", fmt.Sprintf("❌ Secondary model rejected %s (best conf %.2f) - lock blocked, retry in %v",
+			boatID, confidence, ensembleRetryInterval), boatID)
+	}
+}
+
+// ensembleAllowsLock reports whether a boat may lock: always outside critical zones, and inside them only once
+// the secondary model has confirmed it. Existing locks are never revoked. Must be called with si.mu held.
+func (si *SpatialIntegration) ensembleAllowsLock(boat *TrackedBoat) bool {
+	if boat.IsLocked || boat.PersonOverboard {
+		return true
+	}
+	zone, inZone := si.criticalZoneFor(boat)
+	if !inZone {
+		return true
+	}
+	if verdict, checked := si.ensembleVerdicts[boat.ID]; checked && verdict.confirmed {
+		return true
+	}
+	si.debugMsgVerbose("ENSEMBLE", fmt.Sprintf("⏳ Lock on %s held in critical zone %s until the secondary model confirms it", boat.ID, zone.Name), boat.ID)
+	return false
+}
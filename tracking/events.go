@@ -0,0 +1,42 @@
+package tracking
+
+import "fmt"
+
+// ConfigureEventSink registers a function receiving every tracking debug message (component, message,
+// object ID and, for decision log entries, their data) so they can be turned into structured events. The
+// sink may be called with si.mu held and must not call back into SpatialIntegration. Set it before tracking starts.
+func (si *SpatialIntegration) ConfigureEventSink(sink func(component, message, objectID string, data map[string]interface{})) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.eventSink = sink
+}
+
+// emitEvent passes a debug message to the event sink
+func (si *SpatialIntegration) emitEvent(component, message string, boatID ...string) {
+	if si.eventSink == nil {
+		return
+	}
+	objectID := ""
+	if len(boatID) > 0 {
+		objectID = boatID[0]
+	}
+	si.eventSink(component, message, objectID, nil)
+}
+
+// reportPeopleOnBoard publishes a PEOPLE_ON_BOARD event the first time people are seen on a boat and whenever
+// more are seen than before, so a flickering person detection does not repeat the event every frame
+func (si *SpatialIntegration) reportPeopleOnBoard(boat *TrackedBoat) {
+	if boat.P2Count <= boat.peopleSeen {
+		return
+	}
+	boat.peopleSeen = boat.P2Count
+
+	si.logDebugMessage(fmt.Sprintf("👥 %d people on board %s", boat.P2Count, boat.Classification), "PEOPLE_ON_BOARD", 1, map[string]interface{}{
+		"object_id":      boat.ID,
+		"classification": boat.Classification,
+		"people":         boat.P2Count,
+		"confidence":     boat.P2Confidence,
+		"locked":         boat.IsLocked,
+	})
+}
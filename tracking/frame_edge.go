@@ -0,0 +1,141 @@
+package tracking
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+)
+
+// Frame edge handling. A boat cut off by the frame border is detected as its visible part only, so its
+// center is pulled toward the middle of the frame and its area, size and shape shrink.
+const (
+	frameEdgeMargin     = 3    // Pixels from a border within which a detection counts as touching it
+	edgeGateFactor      = 1.5  // Matching tolerance multiplier for edge-clipped detections and tracks
+	edgeSizeZoomDrift   = 0.10 // Relative zoom change after which a remembered full size is no longer used
+	edgeFullSizeMinGain = 1.05 // A remembered size must exceed the visible one by this factor to correct the center
+)
+
+// EdgeSides is the set of frame borders a detection touches
+type EdgeSides uint8
+
+const (
+	EdgeLeft EdgeSides = 1 << iota
+	EdgeRight
+	EdgeTop
+	EdgeBottom
+)
+
+func (e EdgeSides) String() string {
+	if e == 0 {
+		return "none"
+	}
+	var sides []string
+	for _, side := range []struct {
+		bit  EdgeSides
+		name string
+	}{{EdgeLeft, "left"}, {EdgeRight, "right"}, {EdgeTop, "top"}, {EdgeBottom, "bottom"}} {
+		if e&side.bit != 0 {
+			sides = append(sides, side.name)
+		}
+	}
+	return strings.Join(sides, "+")
+}
+
+// edgeSides returns the frame borders a detection touches. Must be called with si.mu held.
+func (si *SpatialIntegration) edgeSides(rect image.Rectangle) EdgeSides {
+	var sides EdgeSides
+	if rect.Min.X <= frameEdgeMargin {
+		sides |= EdgeLeft
+	}
+	if rect.Max.X >= si.frameWidth-frameEdgeMargin {
+		sides |= EdgeRight
+	}
+	if rect.Min.Y <= frameEdgeMargin {
+		sides |= EdgeTop
+	}
+	if rect.Max.Y >= si.frameHeight-frameEdgeMargin {
+		sides |= EdgeBottom
+	}
+	return sides
+}
+
+// observeEdge records whether a boat's detection is cut off by the frame and returns the center and area to
+// track it by. A fully visible detection is remembered as the boat's full size; a clipped one is re-anchored on
+// its visible inner edge using that size, as long as the zoom has not changed since. A boat never seen whole
+// keeps the visible center. Must be called with si.mu held.
+func (si *SpatialIntegration) observeEdge(boat *TrackedBoat, rect image.Rectangle, centerX, centerY int, area float64) (int, int, float64) {
+	sides := si.edgeSides(rect)
+	if sides != boat.EdgeClipped {
+		si.debugMsgVerbose("FRAME_EDGE", fmt.Sprintf("🖼️ Detection touches frame border: %s (was %s)", sides, boat.EdgeClipped), boat.ID)
+	}
+	boat.EdgeClipped = sides
+
+	zoom := si.ptzCtrl.GetCurrentPosition().Zoom
+	if sides == 0 {
+		boat.fullSize = rect.Size()
+		boat.fullSizeZoom = zoom
+		return centerX, centerY, area
+	}
+	if boat.fullSize == (image.Point{}) || boat.fullSizeZoom <= 0 || math.Abs(zoom-boat.fullSizeZoom)/boat.fullSizeZoom > edgeSizeZoomDrift {
+		return centerX, centerY, area
+	}
+
+	width, height := boat.fullSize.X, boat.fullSize.Y
+	correctedX, correctedY := centerX, centerY
+	if float64(width) > float64(rect.Dx())*edgeFullSizeMinGain {
+		switch sides & (EdgeLeft | EdgeRight) {
+		case EdgeLeft:
+			correctedX = rect.Max.X - width/2
+		case EdgeRight:
+			correctedX = rect.Min.X + width/2
+		}
+	}
+	if float64(height) > float64(rect.Dy())*edgeFullSizeMinGain {
+		switch sides & (EdgeTop | EdgeBottom) {
+		case EdgeTop:
+			correctedY = rect.Max.Y - height/2
+		case EdgeBottom:
+			correctedY = rect.Min.Y + height/2
+		}
+	}
+	if correctedX != centerX || correctedY != centerY {
+		si.debugMsgVerbose("FRAME_EDGE", fmt.Sprintf("🖼️ Clipped at %s: center (%d,%d) → (%d,%d) from full size %dx%d",
+			sides, centerX, centerY, correctedX, correctedY, width, height), boat.ID)
+	}
+	return correctedX, correctedY, math.Max(area, float64(width*height))
+}
+
+// edgeAimPoint is the pixel the camera centers on a boat: its center, or - for a clipped boat whose full size
+// is not known - the clipped border, so the camera first turns to bring the hidden part into view. Must be
+// called with si.mu held.
+func (si *SpatialIntegration) edgeAimPoint(boat *TrackedBoat) (int, int) {
+	x, y := boat.CurrentPixel.X, boat.CurrentPixel.Y
+	if boat.EdgeClipped == 0 || boat.fullSize != (image.Point{}) {
+		return x, y
+	}
+	switch boat.EdgeClipped & (EdgeLeft | EdgeRight) {
+	case EdgeLeft:
+		x = 0
+	case EdgeRight:
+		x = si.frameWidth
+	}
+	switch boat.EdgeClipped & (EdgeTop | EdgeBottom) {
+	case EdgeTop:
+		y = 0
+	case EdgeBottom:
+		y = si.frameHeight
+	}
+	return x, y
+}
+
+// holdZoomAtEdge keeps the zoom from increasing while a boat is cut off by the frame: zooming in would crop
+// it further, so the camera centers it first. Must be called with si.mu held.
+func (si *SpatialIntegration) holdZoomAtEdge(boat *TrackedBoat, currentZoom, targetZoom float64) float64 {
+	if boat.EdgeClipped == 0 || targetZoom <= currentZoom {
+		return targetZoom
+	}
+	si.debugMsg("FRAME_EDGE", fmt.Sprintf("🖼️ Holding zoom at %.1f (wanted %.1f) - %s clipped at %s border",
+		currentZoom, targetZoom, boat.ID, boat.EdgeClipped), boat.ID)
+	return currentZoom
+}
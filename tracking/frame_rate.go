@@ -0,0 +1,123 @@
+package tracking
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// DefaultFrameRate is the tracking update rate assumed until one is set or measured
+const DefaultFrameRate = 30.0
+
+// Time-based tracking thresholds. Tracks count missed updates in frames (LostFrames), so every threshold is
+// converted to frames at the measured update rate: a 60 fps stream, or a 30 fps stream detected on every
+// other frame by the governor, loses a boat after the same time as a plain 30 fps stream.
+const (
+	DefaultMaxLostTime          = 5 * time.Second // Boats undetected this long are removed
+	DefaultTargetSwitchCooldown = 4 * time.Second // Least time between two target switches
+
+	briefLossTime       = 170 * time.Millisecond  // A locked target missed this long keeps SUPER LOCK and its cooldown
+	gapToleranceTime    = 330 * time.Millisecond  // Detection gaps a locked target rides out without re-selection
+	staleTrackTime      = 830 * time.Millisecond  // Boats undetected longer are no longer candidates for targeting
+	predictiveTrackTime = 2000 * time.Millisecond // A locked target is followed on its prediction this long before recovery
+)
+
+// Frame rate measurement
+const (
+	frameRateSmoothing = 0.05                   // Weight of each new frame interval in the smoothed rate
+	minFrameInterval   = time.Second / 240      // Shorter intervals are duplicate timestamps
+	maxFrameInterval   = 2 * time.Second        // Longer intervals are stalls, not the frame rate
+	frameRateLogChange = 0.1                    // Relative change of the measured rate that is logged
+	maxTrackingRate    = 240.0                  // Highest update rate accepted by SetFrameRate
+	minTrackingRate    = 1.0                    // Lowest update rate accepted by SetFrameRate
+	frameRateMinSpan   = 500 * time.Millisecond // Tracking must run this long before the measured rate is used
+)
+
+// SetFrameRate sets the tracking update rate assumed until it is measured: the stream's frame rate (from the
+// stream or configured). The rate is then measured from the capture times passed to SetDetectionFrame, so
+// variable frame-rate streams and detection intervals raised by the governor are followed.
+func (si *SpatialIntegration) SetFrameRate(fps float64) error {
+	if fps < minTrackingRate || fps > maxTrackingRate || math.IsNaN(fps) {
+		return fmt.Errorf("frame rate must be between %.0f and %.0f fps, got %v", minTrackingRate, maxTrackingRate, fps)
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.frameRate = fps
+	si.frameRateLogged = fps
+	si.updateFrameThresholds()
+	si.debugMsg("FRAME_RATE", fmt.Sprintf("🎞️ Tracking at %.1f fps until measured: boats dropped after %d lost frames (%v), target switch cooldown %d frames (%v)",
+		fps, si.maxLostFrames, si.maxLostTime, si.targetSwitchCooldown, si.targetSwitchTime))
+	return nil
+}
+
+// FrameRate returns the tracking update rate in use (measured once tracking has run for a moment)
+func (si *SpatialIntegration) FrameRate() float64 {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	return si.frameRate
+}
+
+// observeFrameRate folds the capture time of the frame being tracked into the measured update rate. Frames
+// without a capture time (SetDetectionFrame not called) leave the rate as set. Must be called with si.mu held.
+func (si *SpatialIntegration) observeFrameRate() {
+	captured := si.detectionFrame.captured
+	if captured.IsZero() || !captured.After(si.lastFrameCaptured) {
+		return
+	}
+	previous := si.lastFrameCaptured
+	si.lastFrameCaptured = captured
+	if previous.IsZero() {
+		si.frameRateSince = captured
+		return
+	}
+
+	interval := captured.Sub(previous)
+	if interval < minFrameInterval || interval > maxFrameInterval {
+		return
+	}
+	if si.frameInterval == 0 {
+		si.frameInterval = interval.Seconds()
+	} else {
+		si.frameInterval += (interval.Seconds() - si.frameInterval) * frameRateSmoothing
+	}
+	if captured.Sub(si.frameRateSince) < frameRateMinSpan {
+		return
+	}
+
+	si.frameRate = math.Max(minTrackingRate, math.Min(maxTrackingRate, 1/si.frameInterval))
+	si.updateFrameThresholds()
+	if math.Abs(si.frameRate-si.frameRateLogged) > si.frameRateLogged*frameRateLogChange {
+		si.debugMsg("FRAME_RATE", fmt.Sprintf("🎞️ Tracking rate now %.1f fps (was %.1f): boats dropped after %d lost frames, target switch cooldown %d frames",
+			si.frameRate, si.frameRateLogged, si.maxLostFrames, si.targetSwitchCooldown))
+		si.frameRateLogged = si.frameRate
+	}
+}
+
+// updateFrameThresholds converts the time-based thresholds to frames at the current rate. Must be called with
+// si.mu held.
+func (si *SpatialIntegration) updateFrameThresholds() {
+	si.maxLostFrames = si.framesFor(si.maxLostTime)
+	si.targetSwitchCooldown = si.framesFor(si.targetSwitchTime)
+	if si.burstTime > 0 {
+		si.burstFrames = si.framesFor(si.burstTime)
+	}
+}
+
+// framesFor converts a duration to tracking updates at the current rate (at least one). Must be called with
+// si.mu held.
+func (si *SpatialIntegration) framesFor(d time.Duration) int {
+	return max(1, int(math.Round(d.Seconds()*si.frameRate)))
+}
+
+// frameSeconds converts a number of tracking updates to seconds at the current rate. Must be called with
+// si.mu held.
+func (si *SpatialIntegration) frameSeconds(frames int) float64 {
+	return float64(frames) / si.frameRate
+}
+
+// lostLongerThan reports whether a boat has gone undetected for longer than d. Must be called with si.mu held.
+func (si *SpatialIntegration) lostLongerThan(boat *TrackedBoat, d time.Duration) bool {
+	return boat.LostFrames > si.framesFor(d)
+}
@@ -0,0 +1,338 @@
+package tracking
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"rivercam/pkg/daynight"
+)
+
+// Glare window defaults
+const (
+	DefaultGlareSunWidth   = 20.0 // Degrees of pan either side of the sun's azimuth
+	DefaultGlareTiltMargin = 10.0 // Degrees of tilt either side of where the sun's reflection is seen
+)
+
+// GlareWindow is a region whose detections are dropped while it is active: either a sector around the sun's
+// reflection on the water, which follows the sun through the day, or a fixed pixel region of the frame.
+// Either can be limited to a daily time window.
+type GlareWindow struct {
+	Name   string `json:"name"`
+	Window string `json:"window,omitempty"` // Local HH:MM-HH:MM, may wrap past midnight (default: all day)
+
+	// Sun sector: the reflection is seen at the sun's azimuth, as far below the horizon as the sun is above it
+	Sun          bool    `json:"sun,omitempty"`
+	SunWidth     float64 `json:"sun_width,omitempty"`     // Degrees of pan either side of the sun's azimuth (default: 20)
+	TiltMargin   float64 `json:"tilt_margin,omitempty"`   // Degrees of tilt either side of the reflection (default: 10)
+	MinElevation float64 `json:"min_elevation,omitempty"` // Sun elevation range (degrees) in which the sector is active (default: 0-90)
+	MaxElevation float64 `json:"max_elevation,omitempty"`
+
+	// Pixel region of the frame: [x1, y1, x2, y2]
+	ROI []int `json:"roi,omitempty"`
+
+	schedule *daynight.Schedule
+	rect     image.Rectangle
+}
+
+// GlareConfig is the glare suppression setup: the windows and the site and camera geometry the sun sectors
+// are placed with
+type GlareConfig struct {
+	Windows        []GlareWindow
+	Latitude       float64 // Site position, for the sun's position
+	Longitude      float64
+	PanZeroBearing float64 // Compass bearing (degrees) the camera faces at pan 0
+	TiltHorizon    float64 // Tilt reading (camera units) when level with the horizon
+}
+
+// glareWindowState is whether a window is active and what it has dropped since
+type glareWindowState struct {
+	active     bool
+	since      time.Time
+	suppressed int
+}
+
+// glareRegion is an active window as tested against this frame's detections
+type glareRegion struct {
+	index     int
+	sun       bool
+	pan, tilt float64 // Center of the sun sector (camera units)
+	halfPan   float64 // Half width and height of the sun sector (camera units)
+	halfTilt  float64
+	rect      image.Rectangle
+}
+
+// LoadGlareWindows reads and validates a glare window file: {"windows": [...]}
+func LoadGlareWindows(path string) ([]GlareWindow, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read glare windows: %v", err)
+	}
+
+	var file struct {
+		Windows []GlareWindow `json:"windows"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("could not parse glare windows %s: %v", path, err)
+	}
+	if len(file.Windows) == 0 {
+		return nil, fmt.Errorf("%s: no glare windows", path)
+	}
+	for i := range file.Windows {
+		if err := file.Windows[i].validate(i); err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+	}
+	return file.Windows, nil
+}
+
+// validate fills defaults and checks the window (index i names unnamed windows)
+func (w *GlareWindow) validate(i int) error {
+	w.Name = strings.TrimSpace(w.Name)
+	if w.Name == "" {
+		w.Name = fmt.Sprintf("glare %d", i+1)
+	}
+	if w.Sun == (len(w.ROI) > 0) {
+		return fmt.Errorf("glare window %q needs either \"sun\": true or a pixel \"roi\"", w.Name)
+	}
+
+	w.schedule = nil
+	if w.Window != "" {
+		schedule, err := daynight.ParseSchedule(w.Window)
+		if err != nil {
+			return fmt.Errorf("glare window %q: %v", w.Name, err)
+		}
+		w.schedule = &schedule
+	}
+
+	if w.Sun {
+		if w.SunWidth == 0 {
+			w.SunWidth = DefaultGlareSunWidth
+		}
+		if w.TiltMargin == 0 {
+			w.TiltMargin = DefaultGlareTiltMargin
+		}
+		if w.MaxElevation == 0 {
+			w.MaxElevation = 90
+		}
+		if w.SunWidth < 0 || w.SunWidth > 180 || w.TiltMargin < 0 || w.TiltMargin > 90 {
+			return fmt.Errorf("glare window %q: sun_width must be 0-180 and tilt_margin 0-90 degrees", w.Name)
+		}
+		if w.MinElevation < 0 || w.MinElevation >= w.MaxElevation || w.MaxElevation > 90 {
+			return fmt.Errorf("glare window %q: sun elevation range %.0f-%.0f must lie within 0-90 degrees", w.Name, w.MinElevation, w.MaxElevation)
+		}
+		return nil
+	}
+
+	if len(w.ROI) != 4 {
+		return fmt.Errorf("glare window %q: roi must be [x1, y1, x2, y2]", w.Name)
+	}
+	w.rect = image.Rect(w.ROI[0], w.ROI[1], w.ROI[2], w.ROI[3])
+	if w.rect.Empty() || w.rect.Min.X < 0 || w.rect.Min.Y < 0 {
+		return fmt.Errorf("glare window %q: roi %v is empty or outside the frame", w.Name, w.ROI)
+	}
+	return nil
+}
+
+// describe summarizes the window for the startup log
+func (w GlareWindow) describe() string {
+	when := "all day"
+	if w.schedule != nil {
+		when = w.schedule.String()
+	}
+	if w.Sun {
+		return fmt.Sprintf("%s: sun reflection ±%.0f° pan, ±%.0f° tilt while the sun is %.0f-%.0f° up, %s",
+			w.Name, w.SunWidth, w.TiltMargin, w.MinElevation, w.MaxElevation, when)
+	}
+	return fmt.Sprintf("%s: pixels (%d,%d)-(%d,%d), %s", w.Name, w.rect.Min.X, w.rect.Min.Y, w.rect.Max.X, w.rect.Max.Y, when)
+}
+
+// ConfigureGlareSuppression sets the glare windows (none disables suppression). Detections whose center falls
+// inside an active window are dropped before tracking, except those continuing a locked boat, which was
+// confirmed outside the glare. A GLARE event is logged whenever a window becomes active or inactive.
+func (si *SpatialIntegration) ConfigureGlareSuppression(config GlareConfig) error {
+	for i := range config.Windows {
+		if err := config.Windows[i].validate(i); err != nil {
+			return err
+		}
+		if config.Windows[i].Sun && config.Latitude == 0 && config.Longitude == 0 {
+			return fmt.Errorf("glare window %q follows the sun and needs the site position", config.Windows[i].Name)
+		}
+	}
+	if config.Latitude < -90 || config.Latitude > 90 || config.Longitude < -180 || config.Longitude > 180 {
+		return fmt.Errorf("site position %.4f,%.4f is out of range", config.Latitude, config.Longitude)
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.glare = config
+	si.glareStates = make([]glareWindowState, len(config.Windows))
+	for _, window := range config.Windows {
+		si.debugMsg("GLARE_CONFIG", fmt.Sprintf("☀️ Glare window %s", window.describe()))
+	}
+	return nil
+}
+
+// ActiveGlareWindows returns the names of the glare windows suppressing detections right now
+func (si *SpatialIntegration) ActiveGlareWindows() []string {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	return si.activeGlareWindows()
+}
+
+// activeGlareWindows is ActiveGlareWindows for callers already holding si.mu
+func (si *SpatialIntegration) activeGlareWindows() []string {
+	var names []string
+	for i, state := range si.glareStates {
+		if state.active {
+			names = append(names, si.glare.Windows[i].Name)
+		}
+	}
+	return names
+}
+
+// updateGlareRegions works out which windows are active now, logging the changes, and returns their regions.
+// Must be called with si.mu held.
+func (si *SpatialIntegration) updateGlareRegions(now time.Time) []glareRegion {
+	sunChecked := false
+	var elevation, azimuth float64
+
+	var regions []glareRegion
+	for i, window := range si.glare.Windows {
+		active := window.schedule == nil || window.schedule.Contains(now)
+		region := glareRegion{index: i, sun: window.Sun, rect: window.rect}
+		if active && window.Sun {
+			if !sunChecked {
+				elevation, azimuth = daynight.SunPosition(now, si.glare.Latitude, si.glare.Longitude)
+				sunChecked = true
+			}
+			active = elevation >= window.MinElevation && elevation <= window.MaxElevation
+			region.pan = math.Mod(math.Mod((azimuth-si.glare.PanZeroBearing)*10, 3600)+3600, 3600)
+			region.tilt = si.glare.TiltHorizon + elevation*10 // The reflection is as far below the horizon as the sun is above it
+			region.halfPan = window.SunWidth * 10
+			region.halfTilt = window.TiltMargin * 10
+		}
+
+		state := &si.glareStates[i]
+		if active != state.active {
+			si.logGlareChange(window, state, active, now, elevation, azimuth)
+		}
+		if active {
+			regions = append(regions, region)
+		}
+	}
+	return regions
+}
+
+// logGlareChange records a window becoming active or inactive. Must be called with si.mu held.
+func (si *SpatialIntegration) logGlareChange(window GlareWindow, state *glareWindowState, active bool, now time.Time, elevation, azimuth float64) {
+	if active {
+		data := map[string]interface{}{"window": window.Name}
+		message := fmt.Sprintf("☀️ Glare suppression on: %s", window.Name)
+		if window.Sun {
+			message += fmt.Sprintf(" (sun at %.0f° azimuth, %.0f° up)", azimuth, elevation)
+			data["sun_azimuth"] = azimuth
+			data["sun_elevation"] = elevation
+		}
+		si.logDebugMessage(message, "GLARE", 1, data)
+		*state = glareWindowState{active: true, since: now}
+		return
+	}
+
+	duration := now.Sub(state.since).Round(time.Second)
+	si.logDebugMessage(fmt.Sprintf("☀️ Glare suppression off: %s after %v, %d detections dropped", window.Name, duration, state.suppressed),
+		"GLARE", 1, map[string]interface{}{
+			"window":     window.Name,
+			"duration_s": duration.Seconds(),
+			"suppressed": state.suppressed,
+		})
+	*state = glareWindowState{}
+}
+
+// filterGlareDetections drops the detections inside an active glare window. Must be called with si.mu held,
+// after the burst filter.
+func (si *SpatialIntegration) filterGlareDetections(detections []image.Rectangle, classNames []string, confidences []float64) ([]image.Rectangle, []string, []float64) {
+	if len(si.glare.Windows) == 0 {
+		return detections, classNames, confidences
+	}
+	regions := si.updateGlareRegions(time.Now())
+	if len(regions) == 0 {
+		return detections, classNames, confidences
+	}
+
+	keptRects := make([]image.Rectangle, 0, len(detections))
+	keptNames := make([]string, 0, len(classNames))
+	keptConfidences := make([]float64, 0, len(confidences))
+	var keptIndex []int
+	if si.verdictsEnabled {
+		keptIndex = make([]int, 0, len(detections))
+	}
+	dropped := 0
+	for i, rect := range detections {
+		if region := si.glareRegionAt(regions, rect); region != nil && !si.continuesLockedBoat(rect) {
+			si.noteDetection(i, false, VerdictGlare, "")
+			si.glareStates[region.index].suppressed++
+			dropped++
+			continue
+		}
+
+		keptRects = append(keptRects, rect)
+		keptNames = append(keptNames, classNames[i])
+		keptConfidences = append(keptConfidences, confidences[i])
+		if keptIndex != nil {
+			original := i
+			if si.verdictIndex != nil && i < len(si.verdictIndex) {
+				original = si.verdictIndex[i] // Already re-indexed by the burst filter
+			}
+			keptIndex = append(keptIndex, original)
+		}
+	}
+	if dropped > 0 && si.frameCount%30 == 0 {
+		si.debugMsg("GLARE", fmt.Sprintf("☀️ Dropped %d detection(s) in the glare (%s)", dropped, strings.Join(si.activeGlareWindows(), ", ")))
+	}
+	if keptIndex != nil {
+		si.verdictIndex = keptIndex
+	}
+	return keptRects, keptNames, keptConfidences
+}
+
+// glareRegionAt returns the active region containing the detection's center, or nil. Must be called with
+// si.mu held.
+func (si *SpatialIntegration) glareRegionAt(regions []glareRegion, rect image.Rectangle) *glareRegion {
+	center := image.Point{X: rect.Min.X + rect.Dx()/2, Y: rect.Min.Y + rect.Dy()/2}
+	spatialDone := false
+	var spatial SpatialCoordinate
+	for i := range regions {
+		region := &regions[i]
+		if !region.sun {
+			if center.In(region.rect) {
+				return region
+			}
+			continue
+		}
+		if !spatialDone {
+			spatial = si.calculateSpatialCoordinatesForPixel(center.X, center.Y)
+			spatialDone = true
+		}
+		if math.Abs(panDifference(spatial.Pan, region.pan)) <= region.halfPan && math.Abs(spatial.Tilt-region.tilt) <= region.halfTilt {
+			return region
+		}
+	}
+	return nil
+}
+
+// continuesLockedBoat reports whether a detection overlaps a locked boat's last box. Must be called with
+// si.mu held.
+func (si *SpatialIntegration) continuesLockedBoat(rect image.Rectangle) bool {
+	for _, boat := range si.allBoats {
+		if boat.IsLocked && rect.Overlaps(boat.BoundingBox) {
+			return true
+		}
+	}
+	return false
+}
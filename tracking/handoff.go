@@ -0,0 +1,94 @@
+package tracking
+
+import (
+	"fmt"
+	"time"
+
+	"rivercam/ptz"
+)
+
+// HandoffHold is how long a camera cued for a handoff waits at the cued position for the target before
+// scanning again
+const HandoffHold = 30 * time.Second
+
+// LockedTargetDirection is where the locked target is in absolute PTZ units, for handing it to another camera
+type LockedTargetDirection struct {
+	ObjectID  string
+	ClassName string
+	Pan       float64 // Pan at which the target would be centered
+	Tilt      float64 // Tilt at which the target would be centered
+	Zoom      float64 // Current camera zoom
+	LastSeen  time.Time
+}
+
+// GetLockedTargetDirection converts the locked target's pixel position into absolute pan/tilt using the
+// current camera position and calibration. ok is false when no lock is held.
+func (si *SpatialIntegration) GetLockedTargetDirection() (direction LockedTargetDirection, ok bool) {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+
+	if si.targetBoat == nil || !si.targetBoat.IsLocked {
+		return LockedTargetDirection{}, false
+	}
+
+	current := si.ptzCtrl.GetCurrentPosition()
+	panPixelsPerUnit := si.spatialTracker.InterpolatePanCalibration(current.Zoom)
+	tiltPixelsPerUnit := si.spatialTracker.InterpolateTiltCalibration(current.Zoom)
+	if panPixelsPerUnit <= 0 || tiltPixelsPerUnit <= 0 {
+		return LockedTargetDirection{}, false
+	}
+
+	return LockedTargetDirection{
+		ObjectID:  si.targetBoat.ID,
+		ClassName: si.targetBoat.Classification,
+		Pan:       current.Pan + float64(si.targetBoat.CurrentPixel.X-si.frameCenterX)/panPixelsPerUnit,
+		Tilt:      current.Tilt + float64(si.targetBoat.CurrentPixel.Y-si.frameCenterY)/tiltPixelsPerUnit,
+		Zoom:      current.Zoom,
+		LastSeen:  si.targetBoat.LastSeen,
+	}, true
+}
+
+// CueHandoff aims the camera at the position another camera predicted for the target it is handing over, and
+// holds the scan there for HandoffHold or until something is tracked. A camera that holds a lock of its own or
+// is under manual control declines the cue.
+func (si *SpatialIntegration) CueHandoff(pan, tilt, zoom float64) bool {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if si.manualControl || (si.targetBoat != nil && si.targetBoat.IsLocked) {
+		return false
+	}
+
+	cmd := ptz.PTZCommand{
+		Command:      "absolutePosition",
+		Reason:       "Multi-camera handoff cue",
+		Duration:     500 * time.Millisecond,
+		AbsolutePan:  &pan,
+		AbsoluteTilt: &tilt,
+		AbsoluteZoom: &zoom,
+		Source:       ptz.SourceHandoff,
+	}
+	var sent bool
+	if si.cameraStateManager != nil {
+		sent = si.cameraStateManager.SendCommand(cmd)
+	} else {
+		sent = si.ptzCtrl.SendCommand(cmd)
+	}
+	if !sent {
+		return false
+	}
+
+	si.handoffHoldUntil = time.Now().Add(HandoffHold)
+	si.debugMsg("HANDOFF", fmt.Sprintf("🤝 Handoff cue to Pan=%.0f Tilt=%.0f Zoom=%.0f - holding %v for the handed-over target",
+		pan, tilt, zoom, HandoffHold))
+	return true
+}
+
+// holdingForHandoff reports whether the scan is held at a handoff cue; the hold ends once something is
+// tracked. Must be called with si.mu held.
+func (si *SpatialIntegration) holdingForHandoff() bool {
+	if len(si.allBoats) > 0 {
+		si.handoffHoldUntil = time.Time{}
+	}
+	return time.Now().Before(si.handoffHoldUntil)
+}
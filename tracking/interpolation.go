@@ -0,0 +1,100 @@
+package tracking
+
+import (
+	"image"
+	"time"
+)
+
+// maxInterpolation caps how far ahead a track is extrapolated on frames without detection
+const maxInterpolation = time.Second
+
+// clearInterpolation drops the interpolated offsets once real detections arrive. Must be called with si.mu held.
+func (si *SpatialIntegration) clearInterpolation() {
+	for _, boat := range si.allBoats {
+		boat.interpolated = image.Point{}
+	}
+}
+
+// frameStamp identifies the frame a set of detections was taken from
+type frameStamp struct {
+	sequence int64             // Output frame the detections were paired with
+	captured time.Time         // Capture time of the image the detector saw
+	camera   SpatialCoordinate // Camera position when the detections arrived
+}
+
+// FrameSync is how far the overlay drawn on a frame lags the detections it shows, for the boat lagging most
+type FrameSync struct {
+	Boats  int           // Detected boats whose displayed position was aligned
+	Frames int64         // Output frames since their detections (0 = drawn on the frame detected on)
+	Lag    time.Duration // Capture time between the detected image and the frame drawn on
+}
+
+// SetDetectionFrame tells tracking which frame the detections of the next UpdateTracking come from: the output
+// frame's sequence number and the capture time of the image the detector actually saw, which for a substream
+// is a little off the display frame it was paired with
+func (si *SpatialIntegration) SetDetectionFrame(sequence int64, captured time.Time) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	si.detectionFrame = frameStamp{sequence: sequence, captured: captured, camera: si.cameraPosition()}
+}
+
+// cameraPosition returns where the camera is (zero without a PTZ controller). Must be called with si.mu held.
+func (si *SpatialIntegration) cameraPosition() SpatialCoordinate {
+	if si.ptzCtrl == nil {
+		return SpatialCoordinate{}
+	}
+	position := si.ptzCtrl.GetCurrentPosition()
+	return SpatialCoordinate{Pan: position.Pan, Tilt: position.Tilt, Zoom: position.Zoom}
+}
+
+// AlignTracksToFrame moves the displayed position of every currently detected boat along its pixel velocity
+// from the frame it was detected on to the frame about to be drawn, so boxes sit on the boat rather than where
+// it was when the detector saw it, and by the image shift of any pan/tilt since then. Frames the detection
+// governor skipped are aligned the same way. Track state is untouched; the next UpdateTracking replaces the
+// aligned positions with detections.
+func (si *SpatialIntegration) AlignTracksToFrame(sequence int64, captured time.Time) FrameSync {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	var sync FrameSync
+	camera := si.cameraPosition()
+	for _, boat := range si.allBoats {
+		boat.interpolated = image.Point{}
+		if boat.LostFrames > 0 || boat.detectedFrame.captured.IsZero() {
+			continue
+		}
+		lag := captured.Sub(boat.detectedFrame.captured)
+		if lag < 0 {
+			lag = 0
+		}
+		sync.Boats++
+		sync.Frames = max(sync.Frames, sequence-boat.detectedFrame.sequence)
+		sync.Lag = max(sync.Lag, lag)
+
+		if lag > maxInterpolation {
+			lag = maxInterpolation
+		}
+		offsetX, offsetY := boat.PixelVelocity.X*lag.Seconds(), boat.PixelVelocity.Y*lag.Seconds()
+		if si.ptzCtrl != nil {
+			if shiftX, shiftY, ok := si.cameraShift(boat.detectedFrame.camera, camera); ok {
+				offsetX += shiftX
+				offsetY += shiftY
+			}
+		}
+		boat.interpolated = image.Pt(int(offsetX), int(offsetY))
+	}
+	return sync
+}
+
+// stampDetectedBoats records the detection frame on every boat detected in it. Must be called with si.mu held,
+// after the detections were matched.
+func (si *SpatialIntegration) stampDetectedBoats() {
+	if si.detectionFrame.captured.IsZero() {
+		return
+	}
+	for _, boat := range si.allBoats {
+		if boat.LostFrames == 0 {
+			boat.detectedFrame = si.detectionFrame
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package tracking
+
+import (
+	"fmt"
+	"time"
+)
+
+// Lock criteria shared by target selection, camera tracking and the lifecycle state machine
+const (
+	lockMinConfidence   = 0.30 // Confidence a boat needs (with minDetectionsForLock detections) to be lockable
+	earlyLockConfidence = 0.80 // Confidence at which a single detection counts as ready for an early lock
+	superLockDetections = 24   // Detection count at which a lock becomes SUPER LOCK
+)
+
+// TrackState is the lifecycle state of a tracked object
+type TrackState int
+
+const (
+	TrackTentative   TrackState = iota // Detected, not yet enough detections/confidence to lock
+	TrackConfirmed                     // Meets the lock criteria but is not locked
+	TrackLocked                        // Locked for camera tracking
+	TrackSuperLocked                   // Locked with superLockDetections+ detections (MEGA ZOOM, PIP)
+	TrackCoasting                      // A confirmed or locked track that missed detections; position is predicted
+	TrackLost                          // Removed from tracking (terminal)
+)
+
+func (s TrackState) String() string {
+	switch s {
+	case TrackTentative:
+		return "TENTATIVE"
+	case TrackConfirmed:
+		return "CONFIRMED"
+	case TrackLocked:
+		return "LOCKED"
+	case TrackSuperLocked:
+		return "SUPER_LOCKED"
+	case TrackCoasting:
+		return "COASTING"
+	case TrackLost:
+		return "LOST"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// trackTransitions lists the allowed lifecycle transitions. Anything else is rejected and the track keeps its
+// state - e.g. a confirmed track whose smoothed confidence dips below lockMinConfidence stays CONFIRMED.
+var trackTransitions = map[TrackState][]TrackState{
+	TrackTentative:   {TrackConfirmed, TrackLocked, TrackLost},
+	TrackConfirmed:   {TrackLocked, TrackCoasting, TrackLost},
+	TrackLocked:      {TrackSuperLocked, TrackConfirmed, TrackCoasting, TrackLost},
+	TrackSuperLocked: {TrackConfirmed, TrackCoasting, TrackLost},
+	TrackCoasting:    {TrackConfirmed, TrackLocked, TrackSuperLocked, TrackLost},
+}
+
+// CanTransition reports whether the state machine allows moving from one state to another
+func (s TrackState) CanTransition(to TrackState) bool {
+	for _, allowed := range trackTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TrackLifecycleEvent is emitted for every accepted lifecycle transition
+type TrackLifecycleEvent struct {
+	Time     time.Time
+	ObjectID string
+	From     TrackState
+	To       TrackState
+	Reason   string
+}
+
+// ConfigureLifecycleEvents registers a listener for track lifecycle transitions. The listener is called with
+// si.mu held and must not call back into SpatialIntegration.
+func (si *SpatialIntegration) ConfigureLifecycleEvents(listener func(TrackLifecycleEvent)) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.lifecycleListener = listener
+}
+
+// lockCriteria reports whether a boat has enough detections and enough confidence to be locked
+func (si *SpatialIntegration) lockCriteria(boat *TrackedBoat) (meetsDetections, meetsConfidence bool) {
+	return boat.DetectionCount >= si.lockDetections(boat.Classification), boat.Confidence > lockMinConfidence
+}
+
+// meetsLockCriteria reports whether a boat is ready for a standard lock
+func (si *SpatialIntegration) meetsLockCriteria(boat *TrackedBoat) bool {
+	meetsDetections, meetsConfidence := si.lockCriteria(boat)
+	return meetsDetections && meetsConfidence
+}
+
+// meetsEarlyLockCriteria reports whether a single high-confidence detection would qualify for an early lock
+func meetsEarlyLockCriteria(boat *TrackedBoat) bool {
+	return boat.Confidence >= earlyLockConfidence && boat.DetectionCount >= 1
+}
+
+// isSuperLocked reports whether a locked boat has matured into SUPER LOCK
+func isSuperLocked(boat *TrackedBoat) bool {
+	return boat.IsLocked && boat.DetectionCount >= superLockDetections
+}
+
+// deriveTrackState maps a boat's current detection, lock and loss data onto a lifecycle state. A tentative
+// track that misses detections stays tentative: only confirmed tracks are worth predicting forward. Once
+// confirmed, a track stays at least CONFIRMED even if its smoothed confidence dips.
+func (si *SpatialIntegration) deriveTrackState(boat *TrackedBoat) (TrackState, string) {
+	switch {
+	case boat.LostFrames > 0 && boat.State != TrackTentative:
+		return TrackCoasting, fmt.Sprintf("no detection for %d frame(s)", boat.LostFrames)
+	case isSuperLocked(boat):
+		return TrackSuperLocked, fmt.Sprintf("%d detections while locked", boat.DetectionCount)
+	case boat.IsLocked:
+		return TrackLocked, fmt.Sprintf("locked (strength %.2f)", boat.LockStrength)
+	case si.meetsLockCriteria(boat) || boat.State != TrackTentative:
+		return TrackConfirmed, fmt.Sprintf("%d/%d detections, confidence %.2f", boat.DetectionCount, si.lockDetections(boat.Classification), boat.Confidence)
+	default:
+		return TrackTentative, ""
+	}
+}
+
+// transitionTrack moves a track to a new state if the state machine allows it and emits the event.
+// Must be called with si.mu held.
+func (si *SpatialIntegration) transitionTrack(objectID string, from, to TrackState, reason string) bool {
+	if from == to {
+		return false
+	}
+	if !from.CanTransition(to) {
+		si.debugMsgVerbose("TRACK_STATE", fmt.Sprintf("⛔ Rejected %s → %s (%s)", from, to, reason), objectID)
+		return false
+	}
+
+	event := TrackLifecycleEvent{Time: time.Now(), ObjectID: objectID, From: from, To: to, Reason: reason}
+	si.debugMsg("TRACK_STATE", fmt.Sprintf("🔁 %s → %s (%s)", from, to, reason), objectID)
+	if si.lifecycleListener != nil {
+		si.lifecycleListener(event)
+	}
+	return true
+}
+
+// updateTrackLifecycles advances every track's state machine after detection matching, target selection and
+// locking for this frame, and moves tracks that left allBoats to LOST. Must be called with si.mu held.
+func (si *SpatialIntegration) updateTrackLifecycles() {
+	if si.lifecycleTracks == nil {
+		si.lifecycleTracks = make(map[string]*TrackedBoat)
+	}
+
+	for id, boat := range si.allBoats {
+		if _, known := si.lifecycleTracks[id]; !known {
+			boat.State = TrackTentative
+			si.debugMsgVerbose("TRACK_STATE", fmt.Sprintf("🆕 New track (%s)", TrackTentative), id)
+		}
+		si.lifecycleTracks[id] = boat
+
+		next, reason := si.deriveTrackState(boat)
+		if si.transitionTrack(id, boat.State, next, reason) {
+			boat.State = next
+		}
+	}
+
+	// Boats removed anywhere this frame (cleanup, ghost removal, recovery) end here
+	for id, boat := range si.lifecycleTracks {
+		if _, tracked := si.allBoats[id]; tracked {
+			continue
+		}
+		si.transitionTrack(id, boat.State, TrackLost, fmt.Sprintf("removed after %d lost frame(s)", boat.LostFrames))
+		si.foldTrackCalibration(boat)
+		boat.State = TrackLost
+		delete(si.lifecycleTracks, id)
+	}
+}
+
+// GetTrackState returns the lifecycle state of a tracked object (TrackLost when it is not tracked)
+func (si *SpatialIntegration) GetTrackState(objectID string) TrackState {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+
+	if boat, tracked := si.allBoats[objectID]; tracked {
+		return boat.State
+	}
+	return TrackLost
+}
@@ -0,0 +1,197 @@
+package tracking
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"rivercam/ptz"
+)
+
+// Manual control defaults
+const (
+	DefaultManualTimeout  = 30 * time.Second // Inactivity after which automatic tracking resumes
+	DefaultManualPanStep  = 150.0            // Pan units per full-deflection move at 1x zoom
+	DefaultManualTiltStep = 80.0             // Tilt units per full-deflection move at 1x zoom
+	DefaultManualZoomStep = 10.0             // Zoom units per full-deflection move
+)
+
+// ManualControlStatus is the operator override state
+type ManualControlStatus struct {
+	Active    bool      `json:"active"`
+	Timeout   float64   `json:"timeout_s"`   // Inactivity timeout in seconds
+	Remaining float64   `json:"remaining_s"` // Seconds until tracking resumes (0 when inactive)
+	LastInput time.Time `json:"last_input,omitempty"`
+	TargetID  string    `json:"target_id,omitempty"` // Locked target kept for when tracking resumes
+}
+
+// ConfigureManualControl sets the inactivity timeout after which manual control hands the camera back to
+// automatic tracking
+func (si *SpatialIntegration) ConfigureManualControl(timeout time.Duration) error {
+	if timeout <= 0 {
+		return fmt.Errorf("manual control timeout must be positive, got %v", timeout)
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	si.manualTimeout = timeout
+	return nil
+}
+
+// EnterManualControl suspends automatic camera movement (tracking, scanning, recovery) so the operator can
+// drive the camera. Tracks keep updating, and a locked target that is still visible when control is handed
+// back is tracked again.
+func (si *SpatialIntegration) EnterManualControl() {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	si.enterManualControl()
+}
+
+// ExitManualControl hands the camera back to automatic tracking
+func (si *SpatialIntegration) ExitManualControl() {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	si.exitManualControl("operator")
+}
+
+// IsManualControl reports whether the operator is driving the camera
+func (si *SpatialIntegration) IsManualControl() bool {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	return si.manualControl
+}
+
+// GetManualControlStatus returns the operator override state
+func (si *SpatialIntegration) GetManualControlStatus() ManualControlStatus {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+
+	status := ManualControlStatus{
+		Active:    si.manualControl,
+		Timeout:   si.manualControlTimeout().Seconds(),
+		LastInput: si.manualLastInput,
+	}
+	if si.manualControl {
+		status.Remaining = math.Max(0, (si.manualControlTimeout() - time.Since(si.manualLastInput)).Seconds())
+		if si.targetBoat != nil {
+			status.TargetID = si.targetBoat.ID
+		}
+	}
+	return status
+}
+
+// ManualMove drives the camera at joystick-style rates: pan, tilt and zoom are deflections from -1 to 1
+// (positive = right, down, zoom in). Each call moves one step, finer at higher zoom. Enters manual control.
+// The moves are audited as coming from the control API.
+func (si *SpatialIntegration) ManualMove(pan, tilt, zoom float64) error {
+	return si.ManualMoveFrom(ptz.SourceAPI, pan, tilt, zoom)
+}
+
+// ManualMoveFrom is ManualMove with the command source recorded in the PTZ audit log (e.g. ptz.SourceManual
+// for the joystick)
+func (si *SpatialIntegration) ManualMoveFrom(source string, pan, tilt, zoom float64) error {
+	if math.Abs(pan) > 1 || math.Abs(tilt) > 1 || math.Abs(zoom) > 1 {
+		return fmt.Errorf("pan, tilt and zoom rates must be between -1 and 1")
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	si.enterManualControl()
+
+	current := si.ptzCtrl.GetCurrentPosition()
+	zoomFactor := 10 / math.Max(10, current.Zoom) // Zoom 10 is 1x
+	return si.sendManualPosition(source,
+		math.Mod(current.Pan+pan*DefaultManualPanStep*zoomFactor+3600, 3600),
+		current.Tilt+tilt*DefaultManualTiltStep*zoomFactor,
+		current.Zoom+zoom*DefaultManualZoomStep)
+}
+
+// ManualGoto moves the camera to an absolute position (PTZ limits still apply). Enters manual control.
+func (si *SpatialIntegration) ManualGoto(pan, tilt, zoom float64) error {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	si.enterManualControl()
+	return si.sendManualPosition(ptz.SourceAPI, pan, tilt, zoom)
+}
+
+// manualControlTimeout is the configured inactivity timeout. Must be called with si.mu held.
+func (si *SpatialIntegration) manualControlTimeout() time.Duration {
+	if si.manualTimeout <= 0 {
+		return DefaultManualTimeout
+	}
+	return si.manualTimeout
+}
+
+// enterManualControl starts (or extends) manual control. Must be called with si.mu held.
+func (si *SpatialIntegration) enterManualControl() {
+	si.manualLastInput = time.Now()
+	if si.manualControl {
+		return
+	}
+	si.manualControl = true
+
+	targetID := "none"
+	if si.targetBoat != nil {
+		targetID = si.targetBoat.ID
+	}
+	si.debugMsg("MANUAL_CONTROL", fmt.Sprintf("🕹️ Operator took manual control - automatic tracking suspended (target kept: %s, resumes after %v without input)",
+		targetID, si.manualControlTimeout()))
+}
+
+// exitManualControl resumes automatic tracking. The target is kept only if it is still visible: the
+// operator moved the camera, so the recovery prediction for a target that went out of view is meaningless.
+// Must be called with si.mu held.
+func (si *SpatialIntegration) exitManualControl(reason string) {
+	if !si.manualControl {
+		return
+	}
+	si.manualControl = false
+	si.isInRecovery = false
+	si.recoveryData = nil
+
+	if si.targetBoat != nil {
+		if boat, tracked := si.allBoats[si.targetBoat.ID]; tracked && boat == si.targetBoat && boat.LostFrames == 0 {
+			si.debugMsg("MANUAL_CONTROL", fmt.Sprintf("🕹️ Manual control ended (%s) - resuming tracking of %s", reason, boat.ID), boat.ID)
+			return
+		}
+		si.targetBoat.IsLocked = false
+		si.targetBoat = nil
+	}
+	si.debugMsg("MANUAL_CONTROL", fmt.Sprintf("🕹️ Manual control ended (%s) - resuming automatic tracking", reason))
+}
+
+// checkManualTimeout ends manual control after the inactivity timeout and reports whether it is still
+// active. Must be called with si.mu held.
+func (si *SpatialIntegration) checkManualTimeout() bool {
+	if si.manualControl && time.Since(si.manualLastInput) >= si.manualControlTimeout() {
+		si.exitManualControl(fmt.Sprintf("no input for %v", si.manualControlTimeout()))
+	}
+	return si.manualControl
+}
+
+// sendManualPosition sends an operator move through the camera state manager so limits apply. Must be
+// called with si.mu held.
+func (si *SpatialIntegration) sendManualPosition(source string, pan, tilt, zoom float64) error {
+	pan, tilt, zoom = math.Round(pan), math.Round(tilt), math.Round(zoom)
+	cmd := ptz.PTZCommand{
+		Command:      "absolutePosition",
+		Reason:       "Operator manual control",
+		Duration:     500 * time.Millisecond,
+		AbsolutePan:  &pan,
+		AbsoluteTilt: &tilt,
+		AbsoluteZoom: &zoom,
+		Source:       source,
+	}
+
+	var sent bool
+	if si.cameraStateManager != nil {
+		sent = si.cameraStateManager.SendCommand(cmd)
+	} else {
+		sent = si.ptzCtrl.SendCommand(cmd)
+	}
+	if !sent {
+		return fmt.Errorf("camera did not accept the command (rate limited or busy)")
+	}
+	si.debugMsgVerbose("MANUAL_CONTROL", fmt.Sprintf("🕹️ Manual move to Pan=%.0f Tilt=%.0f Zoom=%.0f", pan, tilt, zoom))
+	return nil
+}
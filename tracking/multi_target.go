@@ -0,0 +1,194 @@
+package tracking
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// DefaultSwapLookahead is how far ahead the primary's pan is predicted when checking it stays reachable
+const DefaultSwapLookahead = 2 * time.Second
+
+// MultiTargetConfig enables the ranked target list: the camera follows the primary while secondaries are
+// ranked by score and exposed for an operator or a second camera
+type MultiTargetConfig struct {
+	MaxTargets    int           // Primary plus secondaries kept in the list (< 2 disables the mode)
+	SwapLookahead time.Duration // Primary and secondary swap when the primary's pan this far ahead is out of range
+}
+
+// RankedTarget is one entry of the target list: rank 1 is the primary the camera follows
+type RankedTarget struct {
+	Rank      int       `json:"rank"`
+	ObjectID  string    `json:"object_id"`
+	ClassName string    `json:"class_name"`
+	Score     float64   `json:"score"`
+	Locked    bool      `json:"locked"`
+	Pan       float64   `json:"pan"`       // Pan at which the object would be centered
+	Tilt      float64   `json:"tilt"`      // Tilt at which the object would be centered
+	Reachable bool      `json:"reachable"` // Inside the camera's pan range now and at the predicted position
+	CenterX   int       `json:"center_x"`  // Frame pixels
+	CenterY   int       `json:"center_y"`
+	Width     int       `json:"width"`
+	Height    int       `json:"height"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// ConfigureMultiTarget enables (MaxTargets ≥ 2) or disables the ranked target list
+func (si *SpatialIntegration) ConfigureMultiTarget(config MultiTargetConfig) error {
+	if config.MaxTargets < 0 || config.SwapLookahead < 0 {
+		return fmt.Errorf("multi-target count and swap lookahead must not be negative")
+	}
+	if config.SwapLookahead == 0 {
+		config.SwapLookahead = DefaultSwapLookahead
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.multiTarget = config
+	si.rankedTargets = nil
+	if config.MaxTargets >= 2 {
+		si.debugMsg("MULTI_TARGET", fmt.Sprintf("🎯 Multi-target mode: primary plus up to %d secondary targets, swap when the primary leaves the pan range within %v",
+			config.MaxTargets-1, config.SwapLookahead))
+	}
+	return nil
+}
+
+// GetRankedTargets returns the current target list, primary first (empty when the mode is off)
+func (si *SpatialIntegration) GetRankedTargets() []RankedTarget {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	return append([]RankedTarget{}, si.rankedTargets...)
+}
+
+// GetSecondaryTargetDirections returns where the secondary targets are in absolute PTZ units, for cueing
+// another camera
+func (si *SpatialIntegration) GetSecondaryTargetDirections() []LockedTargetDirection {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+
+	var directions []LockedTargetDirection
+	zoom := si.ptzCtrl.GetCurrentPosition().Zoom
+	for _, target := range si.rankedTargets {
+		if target.Rank == 1 {
+			continue
+		}
+		directions = append(directions, LockedTargetDirection{
+			ObjectID:  target.ObjectID,
+			ClassName: target.ClassName,
+			Pan:       target.Pan,
+			Tilt:      target.Tilt,
+			Zoom:      zoom,
+			LastSeen:  target.LastSeen,
+		})
+	}
+	return directions
+}
+
+// panReachable reports whether the camera can point at a pan position (soft limits; a full circle or no
+// limits reaches everything). Must be called with si.mu held.
+func (si *SpatialIntegration) panReachable(pan float64) bool {
+	if si.cameraStateManager == nil {
+		return true
+	}
+	limits := si.cameraStateManager.GetLimits()
+	if limits.SoftMaxPan <= limits.SoftMinPan || limits.SoftMaxPan-limits.SoftMinPan >= 3600 {
+		return true
+	}
+	pan = math.Mod(pan+3600, 3600)
+	return pan >= limits.SoftMinPan && pan <= limits.SoftMaxPan
+}
+
+// updateTargetRanking ranks the tracked boats after target selection and swaps the primary for the best
+// reachable secondary when the primary is about to leave the pan range. Operator pins, person-overboard
+// targets, tours and manual control are never overridden. Must be called with si.mu held.
+func (si *SpatialIntegration) updateTargetRanking() {
+	if si.multiTarget.MaxTargets < 2 {
+		return
+	}
+
+	current := si.ptzCtrl.GetCurrentPosition()
+	panPixelsPerUnit := si.spatialTracker.InterpolatePanCalibration(current.Zoom)
+	tiltPixelsPerUnit := si.spatialTracker.InterpolateTiltCalibration(current.Zoom)
+	if panPixelsPerUnit <= 0 || tiltPixelsPerUnit <= 0 {
+		si.rankedTargets = nil
+		return
+	}
+
+	lookahead := si.multiTarget.SwapLookahead.Seconds()
+	rank := func(boat *TrackedBoat) RankedTarget {
+		pan := current.Pan + float64(boat.CurrentPixel.X-si.frameCenterX)/panPixelsPerUnit
+		predictedPan := pan + boat.PixelVelocity.X*lookahead/panPixelsPerUnit
+		return RankedTarget{
+			ObjectID:  boat.ID,
+			ClassName: boat.Classification,
+			Score:     si.calculateTargetingScore(boat),
+			Locked:    boat.IsLocked,
+			Pan:       math.Mod(pan+3600, 3600),
+			Tilt:      current.Tilt + float64(boat.CurrentPixel.Y-si.frameCenterY)/tiltPixelsPerUnit,
+			Reachable: si.panReachable(pan) && si.panReachable(predictedPan),
+			CenterX:   boat.CurrentPixel.X,
+			CenterY:   boat.CurrentPixel.Y,
+			Width:     boat.BoundingBox.Dx(),
+			Height:    boat.BoundingBox.Dy(),
+			LastSeen:  boat.LastSeen,
+		}
+	}
+
+	var primary *RankedTarget
+	var secondaries []RankedTarget
+	for _, boat := range si.allBoats {
+		if si.lostLongerThan(boat, staleTrackTime) {
+			continue
+		}
+		target := rank(boat)
+		if boat == si.targetBoat {
+			primary = &target
+			continue
+		}
+		if boat.Quarantined {
+			continue
+		}
+		secondaries = append(secondaries, target)
+	}
+	sort.Slice(secondaries, func(i, j int) bool { return secondaries[i].Score > secondaries[j].Score })
+
+	// Automatic swap: the camera cannot follow the primary much longer, but it can follow a secondary
+	swappable := si.pinnedTargetID == "" && si.tourTargetID == "" && !si.manualControl && !(si.targetBoat != nil && si.targetBoat.PersonOverboard)
+	if primary != nil && !primary.Reachable && swappable {
+		for i, candidate := range secondaries {
+			if !candidate.Reachable || candidate.Score <= 0 {
+				continue
+			}
+			boat := si.allBoats[candidate.ObjectID]
+			si.debugMsg("TARGET_SWAP", fmt.Sprintf("🔀 Primary %s is leaving the pan range (Pan=%.0f) - swapping to secondary %s (%s, score %.2f)",
+				primary.ObjectID, primary.Pan, candidate.ObjectID, candidate.ClassName, candidate.Score), candidate.ObjectID)
+			si.targetBoat = boat
+			si.lastTargetSwitch = si.frameCount
+			si.isInRecovery = false
+			si.recoveryData = nil
+
+			previous := *primary
+			*primary = candidate
+			secondaries[i] = previous
+			sort.Slice(secondaries, func(i, j int) bool { return secondaries[i].Score > secondaries[j].Score })
+			break
+		}
+	}
+
+	ranked := make([]RankedTarget, 0, si.multiTarget.MaxTargets)
+	if primary != nil {
+		ranked = append(ranked, *primary)
+	}
+	for _, target := range secondaries {
+		if len(ranked) >= si.multiTarget.MaxTargets {
+			break
+		}
+		ranked = append(ranked, target)
+	}
+	for i := range ranked {
+		ranked[i].Rank = i + 1
+	}
+	si.rankedTargets = ranked
+}
@@ -0,0 +1,273 @@
+package tracking
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ObjectID schemes
+const (
+	IDSchemeTimestamp = "timestamp" // 20240125-13-30.001: 24-hour minute and a counter within the minute
+	IDSchemeULID      = "ulid"      // 01HN3X4Y5Z6A7B8C9D0E1F2G3H: sortable, 80 random bits
+	IDSchemeUUID      = "uuid"      // UUIDv7 (time-ordered): 018d3f6e-8a2b-7c4d-9e1f-2a3b4c5d6e7f
+)
+
+// objectIDMinuteFormat is the minute part of timestamp IDs. It sorts lexically, so a later minute is also a
+// larger key.
+const objectIDMinuteFormat = "20060102-15-04"
+
+// crockfordAlphabet is the base32 alphabet of ULIDs
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ObjectIDConfig configures how new tracked objects are named
+type ObjectIDConfig struct {
+	Scheme    string // IDSchemeTimestamp (default), IDSchemeULID or IDSchemeUUID
+	Prefix    string // Prepended as "<prefix>-" so several instances can share a track database (letters, digits, '-')
+	StatePath string // Timestamp scheme: file keeping the last minute and counter, so a restart never reissues an ID
+}
+
+// ObjectIDGenerator issues unique ObjectIDs. Timestamp IDs stay unique across restarts through the state file
+// and across clock steps backwards by never going below the last minute issued; ULIDs and UUIDs are unique
+// through their random bits. The state file is written in the background, so issuing an ID never waits for
+// the disk; Close writes the last state before exit.
+type ObjectIDGenerator struct {
+	config  ObjectIDConfig
+	minute  string // Minute of the last timestamp ID
+	counter int    // Timestamp IDs issued in that minute
+
+	lastULIDTime int64    // Millisecond of the last ULID
+	lastULID     [16]byte // Last ULID, incremented for ULIDs within the same millisecond
+
+	saves   chan objectIDState // Latest timestamp state waiting for the background writer
+	written chan struct{}      // Closed when the background writer has saved the last state
+	closeMu sync.Mutex
+	closed  bool
+	errMu   sync.Mutex
+	saveErr error // Last failed state write, reported by the next call to Next
+}
+
+// objectIDState is the timestamp scheme's state file
+type objectIDState struct {
+	Minute  string `json:"minute"`
+	Counter int    `json:"counter"`
+}
+
+// NewObjectIDGenerator validates the configuration and loads the timestamp scheme's state file
+func NewObjectIDGenerator(config ObjectIDConfig) (*ObjectIDGenerator, error) {
+	if config.Scheme == "" {
+		config.Scheme = IDSchemeTimestamp
+	}
+	switch config.Scheme {
+	case IDSchemeTimestamp, IDSchemeULID, IDSchemeUUID:
+	default:
+		return nil, fmt.Errorf("unknown object ID scheme %q (use %s, %s or %s)", config.Scheme, IDSchemeTimestamp, IDSchemeULID, IDSchemeUUID)
+	}
+	// '_' separates the ObjectID from the rest of debug and snapshot file names, so it may not be part of one
+	for _, r := range config.Prefix {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+			return nil, fmt.Errorf("object ID prefix %q may only contain letters, digits and '-'", config.Prefix)
+		}
+	}
+
+	generator := &ObjectIDGenerator{config: config}
+	if config.Scheme == IDSchemeTimestamp && config.StatePath != "" {
+		data, err := os.ReadFile(config.StatePath)
+		switch {
+		case os.IsNotExist(err):
+		case err != nil:
+			return nil, fmt.Errorf("failed to read object ID state: %v", err)
+		default:
+			var state objectIDState
+			if err := json.Unmarshal(data, &state); err != nil {
+				return nil, fmt.Errorf("corrupt object ID state %s: %v", config.StatePath, err)
+			}
+			generator.minute, generator.counter = state.Minute, state.Counter
+		}
+		generator.saves = make(chan objectIDState, 1)
+		generator.written = make(chan struct{})
+		go generator.writeStates()
+	}
+	return generator, nil
+}
+
+// Scheme returns the ID scheme in use
+func (g *ObjectIDGenerator) Scheme() string {
+	return g.config.Scheme
+}
+
+// Next issues the ID of a new object detected at now. The ID is valid even when the error (an earlier failure
+// to save the timestamp scheme's state) is not nil; only uniqueness across a restart is then at risk. Not safe
+// for concurrent use.
+func (g *ObjectIDGenerator) Next(now time.Time) (string, error) {
+	var id string
+	var err error
+	switch g.config.Scheme {
+	case IDSchemeULID:
+		id = g.nextULID(now)
+	case IDSchemeUUID:
+		id = newUUIDv7(now)
+	default:
+		id, err = g.nextTimestamp(now)
+	}
+	if g.config.Prefix != "" {
+		id = g.config.Prefix + "-" + id
+	}
+	return id, err
+}
+
+// nextTimestamp issues 20240125-13-30.001, never below the last minute issued
+func (g *ObjectIDGenerator) nextTimestamp(now time.Time) (string, error) {
+	minute := now.Format(objectIDMinuteFormat)
+	if minute > g.minute {
+		g.minute = minute
+		g.counter = 0
+	}
+	g.counter++
+	id := fmt.Sprintf("%s.%03d", g.minute, g.counter)
+	g.queueState(objectIDState{Minute: g.minute, Counter: g.counter})
+	return id, g.takeSaveError()
+}
+
+// queueState hands the state to the background writer, replacing a state it has not picked up yet
+func (g *ObjectIDGenerator) queueState(state objectIDState) {
+	g.closeMu.Lock()
+	defer g.closeMu.Unlock()
+	if g.saves == nil || g.closed {
+		return
+	}
+	select {
+	case <-g.saves:
+	default:
+	}
+	g.saves <- state
+}
+
+// writeStates saves each queued state until Close
+func (g *ObjectIDGenerator) writeStates() {
+	defer close(g.written)
+	for state := range g.saves {
+		if err := g.saveState(state); err != nil {
+			g.errMu.Lock()
+			g.saveErr = err
+			g.errMu.Unlock()
+		}
+	}
+}
+
+// Close waits for the background writer to save the last timestamp state and reports a failed write. IDs
+// issued after Close are no longer saved.
+func (g *ObjectIDGenerator) Close() error {
+	if g.saves == nil {
+		return nil
+	}
+	g.closeMu.Lock()
+	if !g.closed {
+		g.closed = true
+		close(g.saves)
+	}
+	g.closeMu.Unlock()
+	<-g.written
+	return g.takeSaveError()
+}
+
+// takeSaveError returns and clears the last failed state write
+func (g *ObjectIDGenerator) takeSaveError() error {
+	g.errMu.Lock()
+	defer g.errMu.Unlock()
+	err := g.saveErr
+	g.saveErr = nil
+	return err
+}
+
+// saveState writes the timestamp scheme's state atomically (temporary file and rename)
+func (g *ObjectIDGenerator) saveState(state objectIDState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode object ID state: %v", err)
+	}
+	if dir := filepath.Dir(g.config.StatePath); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create object ID state directory: %v", err)
+		}
+	}
+	tmpPath := g.config.StatePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write object ID state: %v", err)
+	}
+	if err := os.Rename(tmpPath, g.config.StatePath); err != nil {
+		return fmt.Errorf("failed to replace object ID state: %v", err)
+	}
+	return nil
+}
+
+// nextULID issues a ULID: 48 bits of Unix milliseconds and 80 random bits, incremented instead of drawn again
+// within the same millisecond so IDs stay in order
+func (g *ObjectIDGenerator) nextULID(now time.Time) string {
+	ms := now.UnixMilli()
+	if ms <= g.lastULIDTime {
+		for i := 15; i >= 6; i-- {
+			g.lastULID[i]++
+			if g.lastULID[i] != 0 {
+				break
+			}
+		}
+	} else {
+		g.lastULIDTime = ms
+		var timestamp [8]byte
+		binary.BigEndian.PutUint64(timestamp[:], uint64(ms))
+		copy(g.lastULID[:6], timestamp[2:])
+		rand.Read(g.lastULID[6:])
+	}
+	return encodeULID(g.lastULID)
+}
+
+// encodeULID writes 128 bits as 26 Crockford base32 characters, most significant first
+func encodeULID(value [16]byte) string {
+	var text [26]byte
+	for i := range text {
+		lowestBit := 125 - 5*i // Bit position (from the least significant) of this character's lowest bit
+		digit := 0
+		for j := 0; j < 5; j++ {
+			if bit := lowestBit + j; bit < 128 {
+				digit |= int(value[15-bit/8]>>(bit%8)&1) << j
+			}
+		}
+		text[i] = crockfordAlphabet[digit]
+	}
+	return string(text[:])
+}
+
+// newUUIDv7 returns a time-ordered UUID: 48 bits of Unix milliseconds, version 7 and 74 random bits
+func newUUIDv7(now time.Time) string {
+	var uuid [16]byte
+	var timestamp [8]byte
+	binary.BigEndian.PutUint64(timestamp[:], uint64(now.UnixMilli()))
+	copy(uuid[:6], timestamp[2:])
+	rand.Read(uuid[6:])
+	uuid[6] = uuid[6]&0x0f | 0x70 // Version 7
+	uuid[8] = uuid[8]&0x3f | 0x80 // RFC 4122 variant
+
+	text := hex.EncodeToString(uuid[:])
+	return strings.Join([]string{text[:8], text[8:12], text[12:16], text[16:20], text[20:]}, "-")
+}
+
+// SetObjectIDGenerator replaces the generator naming new tracked objects. Objects already tracked keep their IDs.
+func (si *SpatialIntegration) SetObjectIDGenerator(generator *ObjectIDGenerator) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.objectIDs = generator
+	prefix := "none"
+	if generator.config.Prefix != "" {
+		prefix = generator.config.Prefix
+	}
+	si.debugMsg("OBJECT_ID", fmt.Sprintf("🏷️ Object IDs: %s scheme, prefix %s", generator.config.Scheme, prefix))
+}
@@ -0,0 +1,149 @@
+package tracking
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultOccupancyMinTime is how long a boat must be watched while locked before "no people visible" is
+// reported; a short lock says little about who is on board
+const DefaultOccupancyMinTime = time.Second
+
+// OccupancySummary is the people count of a boat over the frames it was locked and detected in
+type OccupancySummary struct {
+	Samples         int     `json:"samples"`           // Locked frames the boat was detected in
+	Max             int     `json:"max"`               // Most people seen at once
+	Median          float64 `json:"median"`            // Median people per frame
+	Weighted        float64 `json:"weighted"`          // Mean per-frame sum of person confidences (doubtful people count less)
+	NoPeopleVisible bool    `json:"no_people_visible"` // Watched for at least the minimum time and nobody was ever seen
+	LockedSeconds   float64 `json:"locked_seconds"`    // First to last sampled frame
+}
+
+// occupancyStats accumulates a boat's per-frame people counts while it is locked
+type occupancyStats struct {
+	histogram   []int // Frames seen with n people on board, indexed by n
+	samples     int
+	weightedSum float64
+	firstFrame  time.Time
+	lastFrame   time.Time
+}
+
+// observe adds one locked frame with count people whose confidences sum to weighted
+func (o *occupancyStats) observe(count int, weighted float64, at time.Time) {
+	for len(o.histogram) <= count {
+		o.histogram = append(o.histogram, 0)
+	}
+	o.histogram[count]++
+	o.samples++
+	o.weightedSum += weighted
+	if o.firstFrame.IsZero() {
+		o.firstFrame = at
+	}
+	o.lastFrame = at
+}
+
+// summary derives the occupancy figures; minTime is the watch time needed to flag a boat as empty
+func (o *occupancyStats) summary(minTime time.Duration) OccupancySummary {
+	summary := OccupancySummary{Samples: o.samples}
+	if o.samples == 0 {
+		return summary
+	}
+	summary.Max = len(o.histogram) - 1
+	summary.Weighted = o.weightedSum / float64(o.samples)
+	summary.NoPeopleVisible = summary.Max == 0 && o.lastFrame.Sub(o.firstFrame) >= minTime
+	summary.LockedSeconds = o.lastFrame.Sub(o.firstFrame).Seconds()
+
+	// Median from the histogram: average of the two middle samples when the count is even
+	lower, upper := (o.samples-1)/2, o.samples/2
+	seen := 0
+	lowerValue, upperValue := -1, -1
+	for count, frames := range o.histogram {
+		seen += frames
+		if lowerValue < 0 && seen > lower {
+			lowerValue = count
+		}
+		if seen > upper {
+			upperValue = count
+			break
+		}
+	}
+	summary.Median = float64(lowerValue+upperValue) / 2
+	return summary
+}
+
+// ConfigureOccupancyAnalytics enables per-boat people counting over the lock duration. When a counted boat
+// stops being tracked an OCCUPANCY event with its summary is published (and stored with its track record);
+// boats locked for at least minTime without anybody seen on board are flagged.
+func (si *SpatialIntegration) ConfigureOccupancyAnalytics(enabled bool, minTime time.Duration) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if !enabled {
+		si.occupancy = nil
+		return
+	}
+	if minTime <= 0 {
+		minTime = DefaultOccupancyMinTime
+	}
+	si.occupancy = make(map[string]*occupancyStats)
+	si.occupancyMinTime = minTime
+	si.debugMsg("OCCUPANCY_CONFIG", fmt.Sprintf("👥 Occupancy analytics enabled (empty boats flagged after %v locked)", minTime))
+}
+
+// occupancySummary returns the occupancy of a tracked boat so far (nil when none was counted). Must be called
+// with si.mu held.
+func (si *SpatialIntegration) occupancySummary(objectID string) *OccupancySummary {
+	stats, ok := si.occupancy[objectID]
+	if !ok || stats.samples == 0 {
+		return nil
+	}
+	summary := stats.summary(si.occupancyMinTime)
+	return &summary
+}
+
+// recordOccupancy samples the people count of every locked boat detected this frame and reports the boats
+// that stopped being tracked. Must be called with si.mu held, after the P2 detections were assigned.
+func (si *SpatialIntegration) recordOccupancy() {
+	if si.occupancy == nil {
+		return
+	}
+
+	now := time.Now()
+	for id, boat := range si.allBoats {
+		if !boat.IsLocked || boat.LostFrames > 0 {
+			continue
+		}
+		stats, exists := si.occupancy[id]
+		if !exists {
+			stats = &occupancyStats{}
+			si.occupancy[id] = stats
+		}
+		stats.observe(boat.P2Count, boat.p2ConfidenceSum, now)
+	}
+
+	for id, stats := range si.occupancy {
+		if _, tracked := si.allBoats[id]; tracked {
+			continue
+		}
+		delete(si.occupancy, id)
+		si.reportOccupancy(id, stats.summary(si.occupancyMinTime))
+	}
+}
+
+// reportOccupancy publishes the occupancy of a boat that stopped being tracked
+func (si *SpatialIntegration) reportOccupancy(objectID string, summary OccupancySummary) {
+	message := fmt.Sprintf("👥 Occupancy over %.0fs locked: max %d, median %.1f, weighted %.1f people",
+		summary.LockedSeconds, summary.Max, summary.Median, summary.Weighted)
+	if summary.NoPeopleVisible {
+		message = fmt.Sprintf("⚠️ No people visible during %.0fs locked (%d frames)", summary.LockedSeconds, summary.Samples)
+	}
+	si.logDebugMessage(message, "OCCUPANCY", 1, map[string]interface{}{
+		"object_id":         objectID,
+		"samples":           summary.Samples,
+		"max":               summary.Max,
+		"median":            summary.Median,
+		"weighted":          summary.Weighted,
+		"no_people_visible": summary.NoPeopleVisible,
+		"locked_seconds":    summary.LockedSeconds,
+	})
+}
@@ -0,0 +1,212 @@
+package tracking
+
+import (
+	"fmt"
+	"time"
+)
+
+// TrackLists is the P1 (primary target) and P2 (enhancement) class configuration
+type TrackLists struct {
+	P1    []string `json:"p1"`
+	P2    []string `json:"p2"`
+	P1All bool     `json:"p1_all"`
+	P2All bool     `json:"p2_all"`
+}
+
+// ForceTarget makes a tracked object the locked target until ReleaseTarget or until the object is no longer
+// tracked. Person-overboard targets still take priority.
+func (si *SpatialIntegration) ForceTarget(objectID string) error {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	boat, exists := si.allBoats[objectID]
+	if !exists {
+		return fmt.Errorf("object %s is not tracked", objectID)
+	}
+
+	si.pinnedTargetID = objectID
+	si.selectPinnedTarget()
+	si.debugMsg("OPERATOR", fmt.Sprintf("📌 Operator pinned target %s (%s)", objectID, boat.Classification), objectID)
+	return nil
+}
+
+// ReleaseTarget hands target selection back to the automatic scoring
+func (si *SpatialIntegration) ReleaseTarget() {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if si.pinnedTargetID != "" {
+		si.debugMsg("OPERATOR", "📌 Operator released pinned target", si.pinnedTargetID)
+	}
+	si.pinnedTargetID = ""
+}
+
+// GetPinnedTarget returns the operator-pinned object ID ("" when selection is automatic)
+func (si *SpatialIntegration) GetPinnedTarget() string {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	return si.pinnedTargetID
+}
+
+// selectPinnedTarget keeps the pinned object as the locked target. It returns false (and drops the pin) once
+// the object is no longer tracked. Must be called with si.mu held.
+func (si *SpatialIntegration) selectPinnedTarget() bool {
+	if si.pinnedTargetID == "" {
+		return false
+	}
+
+	boat, exists := si.allBoats[si.pinnedTargetID]
+	if !exists {
+		si.debugMsg("OPERATOR", "📌 Pinned target is no longer tracked - returning to automatic selection", si.pinnedTargetID)
+		si.pinnedTargetID = ""
+		return false
+	}
+
+	if si.targetBoat != boat {
+		si.targetBoat = boat
+		si.lastTargetSwitch = si.frameCount
+	}
+	boat.IsLocked = true
+	si.isInRecovery = false
+	si.recoveryData = nil
+	return true
+}
+
+// SetScanningEnabled pauses or resumes the river scan pattern. While paused the camera holds its position
+// when there is nothing to track; tracking itself is unaffected.
+func (si *SpatialIntegration) SetScanningEnabled(enabled bool) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.scanningPaused = !enabled
+	si.spatialTracker.SetScanningPaused(!enabled)
+	if enabled {
+		si.debugMsg("OPERATOR", "▶️ River scanning resumed")
+	} else {
+		si.debugMsg("OPERATOR", "⏸️ River scanning paused")
+	}
+}
+
+// IsScanningEnabled reports whether the river scan pattern runs when there is nothing to track
+func (si *SpatialIntegration) IsScanningEnabled() bool {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	return !si.scanningPaused
+}
+
+// SetTrackingEnabled switches between normal operation and scan only. While tracking is off the caller
+// passes no detections, so no new tracks start and existing ones run out; the scan pattern continues.
+// Entering scan only releases the target at once, without recovery or the post-lock holdover.
+func (si *SpatialIntegration) SetTrackingEnabled(enabled bool) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if si.trackingPaused == !enabled {
+		return
+	}
+	si.trackingPaused = !enabled
+	if enabled {
+		si.debugMsg("OPERATOR", "▶️ Tracking resumed")
+	} else {
+		si.debugMsg("OPERATOR", "⏸️ Tracking paused - scan only")
+		si.releaseForScanOnly()
+	}
+}
+
+// releaseForScanOnly drops the target, its locks and any recovery or holdover and hands the camera back to
+// the scan. Must be called with si.mu held.
+func (si *SpatialIntegration) releaseForScanOnly() {
+	if si.targetBoat != nil {
+		si.debugMsg("OPERATOR", fmt.Sprintf("🔓 Released target %s for the scan-only window", si.targetBoat.ID), si.targetBoat.ID)
+	}
+	for _, boat := range si.allBoats {
+		boat.IsLocked = false
+		boat.LockStrength = 0
+	}
+	si.targetBoat = nil
+	si.pinnedTargetID = ""
+	si.isInRecovery = false
+	si.recoveryData = nil
+	si.lastLockLoss = time.Time{}
+	si.holdoverPositionSet = false
+	si.spatialTracker.SetScanningMode(true)
+}
+
+// IsTrackingEnabled reports whether detections are tracked (false: scan only)
+func (si *SpatialIntegration) IsTrackingEnabled() bool {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	return !si.trackingPaused
+}
+
+// GetTrackLists returns the P1/P2 class configuration in effect
+func (si *SpatialIntegration) GetTrackLists() TrackLists {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+
+	return TrackLists{
+		P1:    append([]string(nil), si.p1TrackList...),
+		P2:    append([]string(nil), si.p2TrackList...),
+		P1All: si.p1TrackAll,
+		P2All: si.p2TrackAll,
+	}
+}
+
+// SetTrackLists changes which classes are P1 targets and P2 enhancements. Boats already tracked keep their
+// tracks; the new lists apply to detections from the next frame on.
+func (si *SpatialIntegration) SetTrackLists(lists TrackLists) error {
+	if !lists.P1All && len(lists.P1) == 0 {
+		return fmt.Errorf("at least one P1 class (or p1_all) is required")
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.p1TrackList = append([]string(nil), lists.P1...)
+	si.p2TrackList = append([]string(nil), lists.P2...)
+	si.p1TrackAll = lists.P1All
+	si.p2TrackAll = lists.P2All
+	si.spatialTracker.SetClassificationRules(si.p1TrackList, si.p2TrackList, si.p1TrackAll, si.p2TrackAll, si.p1MinConfidence)
+
+	si.debugMsg("TRACKING_CONFIG", fmt.Sprintf("🎯 Track lists changed: P1=%v (all=%v) P2=%v (all=%v)",
+		si.p1TrackList, si.p1TrackAll, si.p2TrackList, si.p2TrackAll))
+	return nil
+}
+
+// SetP1MinConfidence changes the P1 confidence threshold (night mode relaxes it for dim IR/thermal frames).
+// Boats already tracked keep their tracks; the threshold applies to detections from the next frame on.
+func (si *SpatialIntegration) SetP1MinConfidence(p1MinConfidence float64) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.p1MinConfidence = p1MinConfidence
+	si.spatialTracker.SetClassificationRules(si.p1TrackList, si.p2TrackList, si.p1TrackAll, si.p2TrackAll, si.p1MinConfidence)
+
+	si.debugMsg("TRACKING_CONFIG", fmt.Sprintf("🎯 P1 confidence threshold changed to %.2f", p1MinConfidence))
+}
+
+// GetMinConfidence returns the P1 and P2 confidence thresholds in effect
+func (si *SpatialIntegration) GetMinConfidence() (p1MinConfidence, p2MinConfidence float64) {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	return si.p1MinConfidence, si.p2MinConfidence
+}
+
+// SetMinConfidence changes both confidence thresholds at once (configuration reload). Boats already tracked
+// keep their tracks; the thresholds apply to detections from the next frame on.
+func (si *SpatialIntegration) SetMinConfidence(p1MinConfidence, p2MinConfidence float64) error {
+	if p1MinConfidence < 0 || p1MinConfidence > 1 || p2MinConfidence < 0 || p2MinConfidence > 1 {
+		return fmt.Errorf("confidence thresholds must be between 0 and 1, got P1 %.2f and P2 %.2f", p1MinConfidence, p2MinConfidence)
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.p1MinConfidence = p1MinConfidence
+	si.p2MinConfidence = p2MinConfidence
+	si.spatialTracker.SetClassificationRules(si.p1TrackList, si.p2TrackList, si.p1TrackAll, si.p2TrackAll, si.p1MinConfidence)
+	si.spatialTracker.SetP2MinConfidence(p2MinConfidence)
+
+	si.debugMsg("TRACKING_CONFIG", fmt.Sprintf("🎯 Confidence thresholds changed: P1 %.2f, P2 %.2f", p1MinConfidence, p2MinConfidence))
+	return nil
+}
@@ -0,0 +1,290 @@
+package tracking
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"time"
+)
+
+// Person-overboard detection defaults
+const (
+	DefaultOverboardDelay        = 3 * time.Second
+	DefaultOverboardVesselRadius = 60              // Pixels around a vessel's box within which a person is still aboard (or alongside)
+	overboardMatchRadius         = 150.0           // Max pixel distance between detections of the same person
+	overboardCandidateLost       = 2 * time.Second // Unseen candidates are forgotten after this long
+	overboardClassName           = "person"        // Classification given to overboard tracks
+	overboardPriority            = 1000.0          // TrackingPriority of an overboard track (beats any boat)
+	overboardZoomStep            = 25.0            // Zoom change per update toward maximum zoom (the SUPER LOCK rate)
+)
+
+// WaterZone is an area of water in spatial (pan/tilt) coordinates, so it stays fixed while the camera moves
+type WaterZone struct {
+	MinPan, MaxPan   float64
+	MinTilt, MaxTilt float64
+}
+
+// Contains reports whether a spatial coordinate lies inside the zone
+func (z WaterZone) Contains(coord SpatialCoordinate) bool {
+	return coord.Pan >= z.MinPan && coord.Pan <= z.MaxPan && coord.Tilt >= z.MinTilt && coord.Tilt <= z.MaxTilt
+}
+
+// OverboardEvent describes a person confirmed in the water without a vessel
+type OverboardEvent struct {
+	ObjectID   string
+	Time       time.Time
+	FirstSeen  time.Time
+	Pixel      image.Point
+	Spatial    SpatialCoordinate
+	Confidence float64
+}
+
+// orphanP2 is a P2 detection no active vessel's box contains, handed from detectP2ObjectsInP1Targets to the
+// person-overboard evaluation
+type orphanP2 struct {
+	rect       image.Rectangle
+	center     image.Point
+	confidence float64
+}
+
+// overboardCandidate is a P2 detection outside every vessel, waiting to persist for the configured delay
+type overboardCandidate struct {
+	center     image.Point
+	rect       image.Rectangle
+	confidence float64
+	firstSeen  time.Time
+	lastSeen   time.Time
+	spatial    SpatialCoordinate
+	boatID     string // Set once the candidate has been promoted to an overboard track
+}
+
+// ConfigureOverboard enables person-overboard mode: a P2 detection inside a water zone (all of the view when
+// zones is empty) with no P1 vessel within vesselRadius pixels of it for longer than delay becomes a
+// maximum-priority locked target, followed at maximum zoom. onAlert is called once per confirmed person, on its
+// own goroutine.
+func (si *SpatialIntegration) ConfigureOverboard(enabled bool, delay time.Duration, vesselRadius int, zones []WaterZone, onAlert func(OverboardEvent)) error {
+	if vesselRadius < 0 {
+		return fmt.Errorf("overboard vessel radius must not be negative, got %d", vesselRadius)
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.overboardEnabled = enabled
+	si.overboardDelay = delay
+	si.overboardVesselRadius = vesselRadius
+	si.overboardZones = zones
+	si.overboardAlert = onAlert
+	si.overboardCandidates = nil
+
+	if !enabled {
+		return nil
+	}
+	zoneDesc := "entire view"
+	if len(zones) > 0 {
+		zoneDesc = fmt.Sprintf("%d water zone(s)", len(zones))
+	}
+	si.debugMsg("OVERBOARD", fmt.Sprintf("🛟 Person-overboard mode enabled: alert after %v without a vessel within %dpx (%s)", delay, vesselRadius, zoneDesc))
+	return nil
+}
+
+// inWaterZone checks a spatial coordinate against the configured water zones. Must be called with si.mu held.
+func (si *SpatialIntegration) inWaterZone(coord SpatialCoordinate) bool {
+	if len(si.overboardZones) == 0 {
+		return true
+	}
+	for _, zone := range si.overboardZones {
+		if zone.Contains(coord) {
+			return true
+		}
+	}
+	return false
+}
+
+// isVessel reports whether a boat counts as a vessel people can be aboard: detected this frame and not itself
+// a person overboard
+func isVessel(boat *TrackedBoat) bool {
+	return !boat.PersonOverboard && boat.LostFrames == 0
+}
+
+// nearVessel reports whether a point lies inside or within the vessel radius of any vessel's bounding box. Must be
+// called with si.mu held.
+func (si *SpatialIntegration) nearVessel(point image.Point) bool {
+	for _, boat := range si.allBoats {
+		if !isVessel(boat) {
+			continue
+		}
+		box := boat.BoundingBox
+		dx := max(box.Min.X-point.X, 0, point.X-box.Max.X)
+		dy := max(box.Min.Y-point.Y, 0, point.Y-box.Max.Y)
+		if dx*dx+dy*dy <= si.overboardVesselRadius*si.overboardVesselRadius {
+			return true
+		}
+	}
+	return false
+}
+
+// updateOverboardCandidates follows the orphan P2 detections with no vessel near them, promotes those that persist
+// past the configured delay to overboard tracks and keeps existing overboard tracks fed with detections.
+// Must be called with si.mu held, from detectP2ObjectsInP1Targets.
+func (si *SpatialIntegration) updateOverboardCandidates(orphans []orphanP2) {
+	if !si.overboardEnabled {
+		return
+	}
+	now := time.Now()
+	matched := make(map[*overboardCandidate]bool)
+
+	for _, orphan := range orphans {
+		detection, center, confidence := orphan.rect, orphan.center, orphan.confidence
+		if si.nearVessel(center) {
+			continue
+		}
+
+		// Nearest unmatched candidate within the match radius
+		var best *overboardCandidate
+		bestDistance := overboardMatchRadius
+		for _, candidate := range si.overboardCandidates {
+			if matched[candidate] {
+				continue
+			}
+			dx := float64(candidate.center.X - center.X)
+			dy := float64(candidate.center.Y - center.Y)
+			if distance := math.Sqrt(dx*dx + dy*dy); distance <= bestDistance {
+				best = candidate
+				bestDistance = distance
+			}
+		}
+
+		spatial := si.calculateSpatialCoordinatesForPixel(center.X, center.Y)
+		if best == nil {
+			if !si.inWaterZone(spatial) {
+				continue
+			}
+			best = &overboardCandidate{firstSeen: now}
+			si.overboardCandidates = append(si.overboardCandidates, best)
+			si.debugMsgVerbose("OVERBOARD", fmt.Sprintf("👤 Person at (%d,%d) conf=%.2f in water with no vessel - watching",
+				center.X, center.Y, confidence))
+		}
+		matched[best] = true
+		best.center = center
+		best.rect = detection
+		best.confidence = confidence
+		best.lastSeen = now
+		best.spatial = spatial
+
+		if best.boatID != "" {
+			if boat, exists := si.allBoats[best.boatID]; exists {
+				si.updateExistingBoat(boat, center.X, center.Y, float64(detection.Dx()*detection.Dy()), confidence, overboardClassName)
+				boat.BoundingBox = detection
+			}
+			continue
+		}
+		if now.Sub(best.firstSeen) >= si.overboardDelay {
+			si.promoteOverboardCandidate(best)
+		}
+	}
+
+	// Forget candidates that have not been seen recently (and whose tracks are gone)
+	kept := si.overboardCandidates[:0]
+	for _, candidate := range si.overboardCandidates {
+		_, tracked := si.allBoats[candidate.boatID]
+		if now.Sub(candidate.lastSeen) > overboardCandidateLost && !tracked {
+			continue
+		}
+		kept = append(kept, candidate)
+	}
+	si.overboardCandidates = kept
+}
+
+// promoteOverboardCandidate creates a locked, maximum-priority track for a confirmed person overboard and
+// fires the alert callback. Must be called with si.mu held.
+func (si *SpatialIntegration) promoteOverboardCandidate(candidate *overboardCandidate) {
+	area := float64(candidate.rect.Dx() * candidate.rect.Dy())
+	boat := si.createNewTrackedObject(candidate.rect, candidate.center.X, candidate.center.Y, area, candidate.confidence, overboardClassName)
+	boat.PersonOverboard = true
+	boat.TrackingPriority = overboardPriority
+	boat.DetectionCount = int(math.Max(float64(si.lockDetections(boat.Classification)), 1))
+	boat.FirstDetected = candidate.firstSeen
+	si.allBoats[boat.ID] = boat
+	candidate.boatID = boat.ID
+
+	si.debugMsg("OVERBOARD", fmt.Sprintf("🚨🛟 PERSON OVERBOARD: %s at (%d,%d) pan=%.1f tilt=%.1f - in water without a vessel for %v",
+		boat.ID, candidate.center.X, candidate.center.Y, candidate.spatial.Pan, candidate.spatial.Tilt,
+		time.Since(candidate.firstSeen).Round(100*time.Millisecond)), boat.ID)
+	si.logDebugMessage("🚨 Person overboard", "OVERBOARD", 1, map[string]interface{}{
+		"object_id":  boat.ID,
+		"pixel":      fmt.Sprintf("(%d,%d)", candidate.center.X, candidate.center.Y),
+		"pan":        candidate.spatial.Pan,
+		"tilt":       candidate.spatial.Tilt,
+		"confidence": candidate.confidence,
+		"first_seen": candidate.firstSeen.Format(time.RFC3339),
+	})
+
+	if si.overboardAlert != nil {
+		event := OverboardEvent{
+			ObjectID:   boat.ID,
+			Time:       time.Now(),
+			FirstSeen:  candidate.firstSeen,
+			Pixel:      candidate.center,
+			Spatial:    candidate.spatial,
+			Confidence: candidate.confidence,
+		}
+		go si.overboardAlert(event)
+	}
+}
+
+// selectOverboardTarget forces the camera onto a detected overboard track, overriding cooldowns, recovery and the
+// current target. Returns true when an overboard target is selected; a lost overboard target falls through to the
+// normal locked-target handling (predictive hold, then RECOVERY). Must be called with si.mu held.
+func (si *SpatialIntegration) selectOverboardTarget() bool {
+	if !si.overboardEnabled {
+		return false
+	}
+
+	var best *TrackedBoat
+	for _, boat := range si.allBoats {
+		if !boat.PersonOverboard || boat.LostFrames > 0 {
+			continue
+		}
+		if best == nil || boat.FirstDetected.Before(best.FirstDetected) {
+			best = boat
+		}
+	}
+	if best == nil {
+		return false
+	}
+
+	if si.targetBoat != best {
+		si.debugMsg("OVERBOARD", fmt.Sprintf("🎯🛟 Locking onto person overboard %s with maximum priority", best.ID), best.ID)
+		si.targetBoat = best
+		si.lastTargetSwitch = si.frameCount
+	}
+	best.IsLocked = true
+	best.LockStrength = 1.0
+	si.isInRecovery = false
+	si.recoveryData = nil
+	return true
+}
+
+// IsOverboardActive reports whether an overboard person is currently being tracked
+func (si *SpatialIntegration) IsOverboardActive() bool {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+
+	for _, boat := range si.allBoats {
+		if boat.PersonOverboard {
+			return true
+		}
+	}
+	return false
+}
+
+// overboardZoom steps the zoom toward the class's maximum, so a person in the water fills as much of the view as
+// the camera allows. Must be called with si.mu held.
+func (si *SpatialIntegration) overboardZoom(boat *TrackedBoat, currentZoom float64) float64 {
+	minZoom, maxZoom := si.zoomRange(boat.Classification)
+	targetZoom := math.Max(minZoom, math.Min(maxZoom, currentZoom+overboardZoomStep))
+	si.debugMsg("ZOOM_FINAL", fmt.Sprintf("🛟 Person overboard %s: Current=%.1f → Target=%.1f (maximum %.0f)",
+		boat.ID, currentZoom, targetZoom, maxZoom), boat.ID)
+	return targetZoom
+}
@@ -0,0 +1,259 @@
+package tracking
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"rivercam/ptz"
+)
+
+// PresetList is the named presets and the home-on-idle policy
+type PresetList struct {
+	Home      string       `json:"home,omitempty"`
+	HomeAfter float64      `json:"home_after_s,omitempty"` // Seconds without tracked objects before returning home
+	HomeHold  float64      `json:"home_hold_s,omitempty"`  // Longest hold at home in seconds (0 = until something is tracked)
+	AtHome    bool         `json:"at_home"`
+	Presets   []ptz.Preset `json:"presets"`
+}
+
+// ConfigurePresets makes named presets available as scan waypoints and sets the home-on-idle policy: after
+// homeAfter without tracked objects the camera returns to the home preset and holds there until something
+// is tracked again, instead of lingering wherever the scan left it (homePreset "" disables the policy). A
+// positive homeHold bounds the hold: the scan then resumes for another homeAfter before returning home.
+// Scan waypoints that name a preset take the preset's position.
+func (si *SpatialIntegration) ConfigurePresets(store *ptz.PresetStore, homePreset string, homeAfter, homeHold time.Duration) error {
+	if store == nil {
+		return fmt.Errorf("preset store is required")
+	}
+	if homePreset != "" {
+		if _, ok := store.Get(homePreset); !ok {
+			return fmt.Errorf("home preset %q is not defined", homePreset)
+		}
+		if homeAfter <= 0 {
+			return fmt.Errorf("home-on-idle delay must be positive, got %v", homeAfter)
+		}
+		if homeHold < 0 {
+			return fmt.Errorf("home hold must not be negative, got %v", homeHold)
+		}
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.presets = store
+	si.homePreset = homePreset
+	si.homeAfter = homeAfter
+	si.homeHold = homeHold
+	si.lastActivity = time.Now()
+	si.atHome = false
+	if err := si.spatialTracker.ResolveScanPresets(store); err != nil {
+		return err
+	}
+
+	si.debugMsg("PRESETS", fmt.Sprintf("📍 %d PTZ presets available", len(store.List())))
+	if homePreset != "" {
+		si.debugMsg("PRESETS", fmt.Sprintf("🏠 Returning to home preset '%s' after %v without tracked objects", homePreset, homeAfter))
+		if homeHold > 0 {
+			si.debugMsg("PRESETS", fmt.Sprintf("🏠 Scanning resumes after holding at home for %v", homeHold))
+		}
+	}
+	return nil
+}
+
+// GetPresets returns the presets and the home-on-idle state
+func (si *SpatialIntegration) GetPresets() PresetList {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+
+	list := PresetList{Home: si.homePreset, AtHome: si.atHome, Presets: []ptz.Preset{}}
+	if si.homePreset != "" {
+		list.HomeAfter = si.homeAfter.Seconds()
+		list.HomeHold = si.homeHold.Seconds()
+	}
+	if si.presets != nil {
+		list.Presets = si.presets.List()
+	}
+	return list
+}
+
+// SavePreset adds or replaces a preset; scan waypoints using it move with it
+func (si *SpatialIntegration) SavePreset(preset ptz.Preset) error {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if si.presets == nil {
+		return fmt.Errorf("presets are not configured")
+	}
+	if err := si.presets.Put(preset); err != nil {
+		return err
+	}
+	si.debugMsg("OPERATOR", fmt.Sprintf("📍 Preset '%s' saved at Pan=%.0f Tilt=%.0f Zoom=%.0f", preset.Name, preset.Pan, preset.Tilt, preset.Zoom))
+	return si.spatialTracker.ResolveScanPresets(si.presets)
+}
+
+// CapturePreset saves the camera's current position as a preset
+func (si *SpatialIntegration) CapturePreset(name string) (ptz.Preset, error) {
+	current := si.ptzCtrl.GetCurrentPosition()
+	preset := ptz.Preset{
+		Name:   name,
+		Pan:    math.Round(current.Pan),
+		Tilt:   math.Round(current.Tilt),
+		Zoom:   math.Round(current.Zoom),
+		Source: ptz.PresetCaptured,
+	}
+	return preset, si.SavePreset(preset)
+}
+
+// DeletePreset removes a preset that is neither home nor a scan waypoint
+func (si *SpatialIntegration) DeletePreset(name string) error {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if si.presets == nil {
+		return fmt.Errorf("presets are not configured")
+	}
+	if name == si.homePreset {
+		return fmt.Errorf("preset %q is the home preset", name)
+	}
+	if profile := si.spatialTracker.scanProfileUsingPreset(name); profile != "" {
+		return fmt.Errorf("preset %q is a waypoint of scan profile %q", name, profile)
+	}
+	if err := si.presets.Delete(name); err != nil {
+		return err
+	}
+	si.debugMsg("OPERATOR", fmt.Sprintf("📍 Preset '%s' deleted", name))
+	return nil
+}
+
+// GotoPreset takes manual control and moves the camera to a preset
+func (si *SpatialIntegration) GotoPreset(name string) error {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if si.presets == nil {
+		return fmt.Errorf("presets are not configured")
+	}
+	preset, ok := si.presets.Get(name)
+	if !ok {
+		return fmt.Errorf("unknown preset %q", name)
+	}
+	si.enterManualControl()
+	return si.sendManualPosition(ptz.SourceAPI, preset.Pan, preset.Tilt, preset.Zoom)
+}
+
+// noteIdleActivity postpones the return home while anything is tracked. Must be called with si.mu held.
+func (si *SpatialIntegration) noteIdleActivity() {
+	if len(si.allBoats) > 0 || si.manualControl {
+		si.lastActivity = time.Now()
+		si.atHome = false
+	}
+}
+
+// returnHomeWhenIdle sends the camera to the home preset once nothing has been tracked for the configured
+// time and reports whether it is going or holding there (the scan is skipped meanwhile). After the home hold
+// the scan resumes and the idle time starts over. Must be called with si.mu held.
+func (si *SpatialIntegration) returnHomeWhenIdle() bool {
+	if si.homePreset == "" || si.presets == nil || time.Since(si.lastActivity) < si.homeAfter {
+		return false
+	}
+	if si.atHome {
+		if si.homeHold > 0 && time.Since(si.homeSince) >= si.homeHold {
+			si.debugMsg("PRESETS", fmt.Sprintf("🏠 Held at home preset '%s' for %v - resuming the scan", si.homePreset, si.homeHold))
+			si.atHome = false
+			si.lastActivity = time.Now()
+			return false
+		}
+		return true
+	}
+
+	home, ok := si.presets.Get(si.homePreset)
+	if !ok {
+		return false
+	}
+	pan, tilt, zoom := home.Pan, home.Tilt, home.Zoom
+	cmd := ptz.PTZCommand{
+		Command:      "absolutePosition",
+		Reason:       "Home on idle",
+		Duration:     2 * time.Second,
+		AbsolutePan:  &pan,
+		AbsoluteTilt: &tilt,
+		AbsoluteZoom: &zoom,
+		Source:       ptz.SourceScan,
+	}
+	var sent bool
+	if si.cameraStateManager != nil {
+		sent = si.cameraStateManager.SendCommand(cmd)
+	} else {
+		sent = si.ptzCtrl.SendCommand(cmd)
+	}
+	if sent {
+		si.atHome = true
+		si.homeSince = time.Now()
+		si.debugMsg("PRESETS", fmt.Sprintf("🏠 Nothing tracked for %v - returning to home preset '%s' (Pan=%.0f Tilt=%.0f Zoom=%.0f)",
+			si.homeAfter, si.homePreset, pan, tilt, zoom))
+	}
+	return true // Retry on the next frame if the camera was busy, without scanning meanwhile
+}
+
+// resolveScanPresets gives the waypoints that name a preset the preset's position and reports whether any
+// position changed
+func resolveScanPresets(profile *CustomScanningPattern, presets *ptz.PresetStore) (bool, error) {
+	changed := false
+	for i := range profile.Positions {
+		position := &profile.Positions[i]
+		if position.Preset == "" {
+			continue
+		}
+		if presets == nil {
+			return false, fmt.Errorf("scan profile %q: position %d uses preset %q, but no presets are configured", profile.Name, i+1, position.Preset)
+		}
+		preset, ok := presets.Get(position.Preset)
+		if !ok {
+			return false, fmt.Errorf("scan profile %q: position %d uses unknown preset %q", profile.Name, i+1, position.Preset)
+		}
+		if position.Position != preset.Position() {
+			position.Position = preset.Position()
+			changed = true
+		}
+		if position.Name == "" {
+			position.Name = preset.Name
+		}
+	}
+	return changed, nil
+}
+
+// ResolveScanPresets updates the waypoints of every scan profile that name a preset, restarting the running
+// profile if its waypoints moved
+func (st *SpatialTracker) ResolveScanPresets(presets *ptz.PresetStore) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	for _, name := range st.scanProfileOrder {
+		profile := *st.scanProfiles[name]
+		profile.Positions = append([]CustomScanPosition(nil), profile.Positions...)
+		changed, err := resolveScanPresets(&profile, presets)
+		if err != nil {
+			return err
+		}
+		if changed {
+			st.putScanProfile(profile)
+		}
+	}
+	return nil
+}
+
+// scanProfileUsingPreset returns the first scan profile with a waypoint at the preset ("" if none)
+func (st *SpatialTracker) scanProfileUsingPreset(preset string) string {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	for _, name := range st.scanProfileOrder {
+		for _, position := range st.scanProfiles[name].Positions {
+			if position.Preset == preset {
+				return name
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,143 @@
+package tracking
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Recovery re-binding defaults
+const (
+	DefaultRebindDistance  = 80.0 // PTZ units a recovered detection may be from the lost boat's predicted position
+	DefaultRebindSizeRatio = 2.5  // Largest ratio between the lost boat's and the detection's size at 1x zoom
+)
+
+// Recovery re-binding tuning
+const (
+	rebindMaxPrediction = 30 * time.Second // Prediction beyond this is meaningless (same clamp as the predicted moves)
+	rebindDriftShare    = 0.5              // Extra tolerance per unit of distance the boat covered since it was lost
+	rebindAspectRatio   = 2.0              // Largest ratio between the two bounding box aspect ratios
+)
+
+// RecoveryRebindConfig bounds how closely a track confirmed during recovery must match the lost boat to take
+// over its ObjectID
+type RecoveryRebindConfig struct {
+	MaxDistance  float64 // PTZ units between the predicted position and the new track (0 disables re-binding)
+	MaxSizeRatio float64 // Largest size ratio at 1x zoom (0 = sizes are not compared)
+}
+
+// DefaultRecoveryRebindConfig returns the re-binding bounds used when nothing is configured
+func DefaultRecoveryRebindConfig() RecoveryRebindConfig {
+	return RecoveryRebindConfig{MaxDistance: DefaultRebindDistance, MaxSizeRatio: DefaultRebindSizeRatio}
+}
+
+// Validate checks the re-binding bounds
+func (c RecoveryRebindConfig) Validate() error {
+	if c.MaxDistance < 0 {
+		return fmt.Errorf("recovery rebind distance must not be negative, got %v", c.MaxDistance)
+	}
+	if c.MaxSizeRatio != 0 && c.MaxSizeRatio < 1 {
+		return fmt.Errorf("recovery rebind size ratio must be at least 1 (or 0 to ignore sizes), got %v", c.MaxSizeRatio)
+	}
+	return nil
+}
+
+// ConfigureRecoveryRebind sets how a boat found during recovery is recognized as the lost one: a new track of
+// the same class near the lost boat's predicted position, of a similar size and shape, keeps the lost boat's
+// ObjectID so the track database, timeline and debug session continue instead of starting over
+func (si *SpatialIntegration) ConfigureRecoveryRebind(config RecoveryRebindConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.recoveryRebind = config
+	if config.MaxDistance > 0 {
+		si.debugMsg("RECOVERY_CONFIG", fmt.Sprintf("🔁 Recovery re-binding: within %.0f PTZ units of the predicted position, size ratio ≤ %.1f",
+			config.MaxDistance, config.MaxSizeRatio))
+	}
+	return nil
+}
+
+// rebindRecoveredBoat gives a track confirmed during recovery the lost boat's ObjectID when it matches the lost
+// boat's class, predicted position, size and shape. A lost boat is re-bound at most once. Must be called with
+// si.mu held, before the new boat is added to allBoats.
+func (si *SpatialIntegration) rebindRecoveredBoat(boat *TrackedBoat) bool {
+	rd := si.recoveryData
+	if !si.isInRecovery || rd == nil || rd.Rebound || si.recoveryRebind.MaxDistance <= 0 {
+		return false
+	}
+	if boat.Classification != rd.Classification {
+		return false
+	}
+	if _, taken := si.allBoats[rd.ObjectID]; taken {
+		return false
+	}
+
+	// Where the lost boat should be by now, with more tolerance the further it may have travelled
+	elapsed := math.Min(time.Since(rd.LossTime).Seconds(), rebindMaxPrediction.Seconds())
+	predictedPan := rd.LastKnownSpatialPos.Pan + rd.SpatialVelocity.Pan*elapsed
+	predictedTilt := rd.LastKnownSpatialPos.Tilt + rd.SpatialVelocity.Tilt*elapsed
+	position := boat.CurrentSpatial
+	if position.Pan == 0 && position.Tilt == 0 { // Not yet computed while the camera moves
+		position = si.calculateSpatialCoordinatesForPixel(boat.CurrentPixel.X, boat.CurrentPixel.Y)
+	}
+	distance := math.Hypot(panDifference(position.Pan, predictedPan), position.Tilt-predictedTilt)
+	covered := math.Hypot(rd.SpatialVelocity.Pan, rd.SpatialVelocity.Tilt) * elapsed
+	allowed := si.recoveryRebind.MaxDistance + rebindDriftShare*covered
+	if distance > allowed {
+		si.debugMsg("RECOVERY_REBIND", fmt.Sprintf("↔️ New %s %s is %.0f PTZ units from %s's predicted position (max %.0f) - not re-bound",
+			boat.Classification, boat.ID, distance, rd.ObjectID, allowed), rd.ObjectID)
+		return false
+	}
+
+	size := rebindSize(boat.PixelArea, si.ptzCtrl.GetCurrentPosition().Zoom)
+	if si.recoveryRebind.MaxSizeRatio > 0 && rd.Size > 0 && size > 0 &&
+		math.Max(rd.Size, size)/math.Min(rd.Size, size) > si.recoveryRebind.MaxSizeRatio {
+		si.debugMsg("RECOVERY_REBIND", fmt.Sprintf("↔️ New %s %s size %.0f vs %.0f at 1x zoom - not re-bound",
+			boat.Classification, boat.ID, size, rd.Size), rd.ObjectID)
+		return false
+	}
+	if aspect := boxAspect(boat.BoundingBox.Dx(), boat.BoundingBox.Dy()); rd.Aspect > 0 && aspect > 0 &&
+		math.Max(rd.Aspect, aspect)/math.Min(rd.Aspect, aspect) > rebindAspectRatio {
+		si.debugMsg("RECOVERY_REBIND", fmt.Sprintf("↔️ New %s %s aspect %.2f vs %.2f - not re-bound",
+			boat.Classification, boat.ID, aspect, rd.Aspect), rd.ObjectID)
+		return false
+	}
+
+	newID := boat.ID
+	boat.ID = rd.ObjectID
+	boat.FirstDetected = rd.FirstDetected
+	boat.DetectionCount += rd.DetectionCount
+	rd.Rebound = true
+	si.totalDetectedObjectsCounter-- // The lost boat was already counted
+
+	si.logDebugMessage(fmt.Sprintf("🔁 Recovered %s as %s: %.0f PTZ units from the predicted position after %.1fs (was new track %s)",
+		boat.Classification, boat.ID, distance, elapsed, newID), "RECOVERY_REBIND", 1, map[string]interface{}{
+		"object_id": boat.ID,
+		"new_id":    newID,
+		"distance":  distance,
+		"elapsed_s": elapsed,
+		"size":      size,
+		"lost_size": rd.Size,
+	})
+	return true
+}
+
+// rebindSize is a detection's size at 1x zoom, comparable across zoom levels (Zoom 10 is 1x)
+func rebindSize(area, zoom float64) float64 {
+	if area <= 0 {
+		return 0
+	}
+	return math.Sqrt(area) * 10 / math.Max(10, zoom)
+}
+
+// boxAspect is a bounding box's width over height (0 for an empty box)
+func boxAspect(width, height int) float64 {
+	if width <= 0 || height <= 0 {
+		return 0
+	}
+	return float64(width) / float64(height)
+}
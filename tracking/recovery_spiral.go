@@ -0,0 +1,135 @@
+package tracking
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Spiral search defaults
+const (
+	DefaultSpiralStep  = 100.0 // Pan/tilt units between search positions (10°)
+	DefaultSpiralDwell = 1500 * time.Millisecond
+	spiralMoveTimeout  = 10 * time.Second // Give up waiting for the camera at one position after this
+)
+
+// SpiralSearchConfig describes the optional fourth recovery phase: after the predicted moves, the camera
+// visits positions on square rings of growing size around the last predicted position, so a boat that
+// turned away from its predicted course can still be found
+type SpiralSearchConfig struct {
+	Radius float64       // Farthest pan/tilt offset searched (camera units, 0 disables the phase)
+	Step   float64       // Distance between neighbouring positions (camera units)
+	Dwell  time.Duration // How long to look at each position once the camera has settled
+}
+
+// ConfigureSpiralSearch enables (radius > 0) or disables the spiral search recovery phase
+func (si *SpatialIntegration) ConfigureSpiralSearch(config SpiralSearchConfig) error {
+	if config.Radius < 0 || config.Step < 0 || config.Dwell < 0 {
+		return fmt.Errorf("spiral search radius, step and dwell must not be negative")
+	}
+	if config.Step == 0 {
+		config.Step = DefaultSpiralStep
+	}
+	if config.Dwell == 0 {
+		config.Dwell = DefaultSpiralDwell
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.spiralSearch = config
+	if config.Radius > 0 {
+		si.debugMsg("RECOVERY_CONFIG", fmt.Sprintf("🌀 Recovery spiral search: radius %.0f, step %.0f units (%d positions), %.1fs dwell",
+			config.Radius, config.Step, len(spiralOffsets(config.Radius, config.Step)), config.Dwell.Seconds()))
+	}
+	return nil
+}
+
+// spiralOffsets returns the pan/tilt offsets of the search positions, ring by ring from the center outward,
+// each ring walked clockwise from its top-left corner. Offsets are clamped to the radius.
+func spiralOffsets(radius, step float64) []SpatialCoordinate {
+	rings := int(math.Ceil(radius / step))
+	var offsets []SpatialCoordinate
+	add := func(i, j int) {
+		offsets = append(offsets, SpatialCoordinate{
+			Pan:  math.Max(-radius, math.Min(radius, float64(i)*step)),
+			Tilt: math.Max(-radius, math.Min(radius, float64(j)*step)),
+		})
+	}
+	for k := 1; k <= rings; k++ {
+		for i := -k; i < k; i++ { // Top edge, left to right
+			add(i, -k)
+		}
+		for j := -k; j < k; j++ { // Right edge, downward
+			add(k, j)
+		}
+		for i := k; i > -k; i-- { // Bottom edge, right to left
+			add(i, k)
+		}
+		for j := k; j > -k; j-- { // Left edge, upward
+			add(-k, j)
+		}
+	}
+	return offsets
+}
+
+// afterPredictedMoves is the phase that follows the last predicted move: the spiral search when it is
+// enabled, otherwise the end of recovery. Must be called with si.mu held.
+func (si *SpatialIntegration) afterPredictedMoves() RecoveryPhase {
+	if si.spiralSearch.Radius > 0 {
+		return RECOVERY_SPIRAL_SEARCH
+	}
+	return RECOVERY_COMPLETE
+}
+
+// executeSpiralSearch visits the spiral positions around the last predicted position one at a time, waiting
+// for the camera to settle and dwelling at each, and ends recovery after the last one
+func (si *SpatialIntegration) executeSpiralSearch() {
+	rd := si.recoveryData
+	if rd.SpiralOffsets == nil {
+		rd.SpiralOffsets = spiralOffsets(si.spiralSearch.Radius, si.spiralSearch.Step)
+		rd.SpiralCenter = rd.PhaseTarget
+		rd.SpiralCenter.Zoom = si.ptzCtrl.GetCurrentPosition().Zoom // Keep the zoomed-out view
+		rd.SpiralIndex = 0
+		rd.WaitingForArrival = false
+		si.debugMsg("RECOVERY_PHASE4", fmt.Sprintf("🌀 Spiral search around Pan=%.0f Tilt=%.0f: %d positions within %.0f units",
+			rd.SpiralCenter.Pan, rd.SpiralCenter.Tilt, len(rd.SpiralOffsets), si.spiralSearch.Radius), rd.ObjectID)
+	}
+
+	if !rd.WaitingForArrival {
+		if rd.SpiralIndex >= len(rd.SpiralOffsets) {
+			si.debugMsg("RECOVERY_PHASE4", "✅ Spiral search complete - no boat found", rd.ObjectID)
+			rd.CurrentPhase = RECOVERY_COMPLETE
+			return
+		}
+		offset := rd.SpiralOffsets[rd.SpiralIndex]
+		limits := si.hardwareLimits()
+		target := SpatialCoordinate{
+			Pan:  math.Mod(rd.SpiralCenter.Pan+offset.Pan+3600, 3600),
+			Tilt: math.Max(limits.HardMinTilt, math.Min(limits.HardMaxTilt, rd.SpiralCenter.Tilt+offset.Tilt)),
+			Zoom: rd.SpiralCenter.Zoom,
+		}
+		rd.PhaseTarget = target
+		rd.PhaseStartTime = time.Now()
+		rd.LingerStartTime = time.Time{}
+		rd.WaitingForArrival = true
+		si.executePTZMovement(target, fmt.Sprintf("RECOVERY: Spiral search %d/%d", rd.SpiralIndex+1, len(rd.SpiralOffsets)))
+		si.debugMsg("RECOVERY_PHASE4", fmt.Sprintf("🌀 Position %d/%d: offset (%.0f,%.0f) → Pan=%.0f Tilt=%.0f",
+			rd.SpiralIndex+1, len(rd.SpiralOffsets), offset.Pan, offset.Tilt, target.Pan, target.Tilt), rd.ObjectID)
+		return
+	}
+
+	// Dwell once the camera has settled (or stopped answering)
+	if rd.LingerStartTime.IsZero() {
+		cameraIdle := si.cameraStateManager != nil && si.cameraStateManager.IsIdle()
+		if !cameraIdle && time.Since(rd.PhaseStartTime) < spiralMoveTimeout {
+			return
+		}
+		rd.LingerStartTime = time.Now()
+	}
+	if time.Since(rd.LingerStartTime) < si.spiralSearch.Dwell {
+		return
+	}
+	rd.SpiralIndex++
+	rd.WaitingForArrival = false
+}
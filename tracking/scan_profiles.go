@@ -0,0 +1,302 @@
+package tracking
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ScanProfileSet is the -scan-profiles file: named scan patterns (same format as scanning.json) and the one
+// to start with
+type ScanProfileSet struct {
+	Active   string                  `json:"active,omitempty"`
+	Profiles []CustomScanningPattern `json:"profiles"`
+}
+
+// ScanProfileList describes the available scan profiles and the one in use
+type ScanProfileList struct {
+	Active   string                  `json:"active"`
+	Profiles []CustomScanningPattern `json:"profiles"`
+}
+
+// LoadScanProfiles reads and validates a scan profile file
+func LoadScanProfiles(path string) (*ScanProfileSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read scan profiles: %v", err)
+	}
+
+	var set ScanProfileSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("could not parse scan profiles %s: %v", path, err)
+	}
+	seen := make(map[string]bool)
+	for i := range set.Profiles {
+		if err := ValidateScanProfile(&set.Profiles[i]); err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		if seen[set.Profiles[i].Name] {
+			return nil, fmt.Errorf("%s: duplicate scan profile %q", path, set.Profiles[i].Name)
+		}
+		seen[set.Profiles[i].Name] = true
+	}
+	if set.Active != "" && !seen[set.Active] {
+		return nil, fmt.Errorf("%s: active scan profile %q is not defined", path, set.Active)
+	}
+	return &set, nil
+}
+
+// Save writes the profile set atomically (temporary file + rename)
+func (set *ScanProfileSet) Save(path string) error {
+	data, err := json.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".scan-profiles-*.json")
+	if err != nil {
+		return fmt.Errorf("could not save scan profiles: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("could not save scan profiles: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("could not save scan profiles: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("could not save scan profiles: %v", err)
+	}
+	return nil
+}
+
+// ValidateScanProfile checks a profile's waypoints and fills in IDs and the position count
+func ValidateScanProfile(profile *CustomScanningPattern) error {
+	profile.Name = strings.TrimSpace(profile.Name)
+	if profile.Name == "" || strings.Contains(profile.Name, "/") {
+		return fmt.Errorf("scan profile needs a name without '/'")
+	}
+	if len(profile.Positions) == 0 {
+		return fmt.Errorf("scan profile %q has no positions", profile.Name)
+	}
+	if profile.DwellJitter < 0 || profile.DwellJitter > 1 {
+		return fmt.Errorf("scan profile %q: dwell_jitter must be between 0 and 1, got %.2f", profile.Name, profile.DwellJitter)
+	}
+	for i := range profile.Positions {
+		position := &profile.Positions[i]
+		if position.DwellTime < 0 {
+			return fmt.Errorf("scan profile %q: position %d has a negative dwell time", profile.Name, i+1)
+		}
+		if position.ID == 0 {
+			position.ID = i + 1
+		}
+		if position.Name == "" {
+			position.Name = fmt.Sprintf("Position %d", i+1)
+		}
+	}
+	profile.TotalCount = len(profile.Positions)
+	return nil
+}
+
+// SetScanProfiles adds (or replaces) scan profiles next to scanning.json. dwellJitter is the random ± dwell
+// fraction for profiles without their own.
+func (st *SpatialTracker) SetScanProfiles(profiles []CustomScanningPattern, dwellJitter float64) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.scanDwellJitter = dwellJitter
+	for i := range profiles {
+		st.putScanProfile(profiles[i])
+	}
+}
+
+// putScanProfile adds or replaces a profile, restarting it when it is the one in use. Must be called with
+// st.mu held.
+func (st *SpatialTracker) putScanProfile(profile CustomScanningPattern) {
+	stored := profile
+	if _, exists := st.scanProfiles[profile.Name]; !exists {
+		st.scanProfileOrder = append(st.scanProfileOrder, profile.Name)
+	}
+	st.scanProfiles[profile.Name] = &stored
+	if st.customScanPattern != nil && st.customScanPattern.Name == profile.Name {
+		st.activateScanProfile(&stored)
+	}
+}
+
+// SelectScanProfile switches the scan to the named profile, starting at its first position
+func (st *SpatialTracker) SelectScanProfile(name string) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	profile, ok := st.scanProfiles[name]
+	if !ok {
+		return fmt.Errorf("unknown scan profile %q (available: %s)", name, strings.Join(st.scanProfileOrder, ", "))
+	}
+	st.activateScanProfile(profile)
+	return nil
+}
+
+// activateScanProfile makes profile the running scan. Must be called with st.mu held.
+func (st *SpatialTracker) activateScanProfile(profile *CustomScanningPattern) {
+	var scanPattern []SpatialCoordinate
+	for _, pos := range profile.Positions {
+		scanPattern = append(scanPattern, SpatialCoordinate{
+			Pan:  pos.Position.Pan,
+			Tilt: pos.Position.Tilt,
+			Zoom: pos.Position.Zoom,
+		})
+	}
+	st.customScanPattern = profile
+	st.scanPattern = scanPattern
+	st.currentScanIndex = 0
+	st.scanPositionStartTime = time.Time{} // Move to the first position before dwelling
+	st.lastScanTime = time.Time{}
+
+	spatialDebugMsg("SCAN_PROFILE", fmt.Sprintf("🗺️ Scan profile '%s' active (%d positions, dwell jitter ±%.0f%%)",
+		profile.Name, len(profile.Positions), st.profileDwellJitter(profile)*100))
+}
+
+// ScanProfiles returns the available profiles in the order they were added and the one in use
+func (st *SpatialTracker) ScanProfiles() ScanProfileList {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	list := ScanProfileList{Profiles: make([]CustomScanningPattern, 0, len(st.scanProfileOrder))}
+	if st.customScanPattern != nil {
+		list.Active = st.customScanPattern.Name
+	}
+	for _, name := range st.scanProfileOrder {
+		profile := *st.scanProfiles[name]
+		profile.Positions = append([]CustomScanPosition(nil), profile.Positions...)
+		list.Profiles = append(list.Profiles, profile)
+	}
+	return list
+}
+
+// PutScanProfile adds or replaces a profile at runtime (scan pattern editor)
+func (st *SpatialTracker) PutScanProfile(profile CustomScanningPattern) error {
+	if err := ValidateScanProfile(&profile); err != nil {
+		return err
+	}
+	profile.Positions = append([]CustomScanPosition(nil), profile.Positions...)
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.putScanProfile(profile)
+	return nil
+}
+
+// profileDwellJitter is the random ± dwell fraction of a profile. Must be called with st.mu held.
+func (st *SpatialTracker) profileDwellJitter(profile *CustomScanningPattern) float64 {
+	if profile.DwellJitter > 0 {
+		return profile.DwellJitter
+	}
+	return st.scanDwellJitter
+}
+
+// scanDwell draws the dwell time at a scan position; with jitter it varies randomly around the configured
+// dwell so the camera's pattern cannot be timed from the water. Must be called with st.mu held.
+func (st *SpatialTracker) scanDwell(position CustomScanPosition) time.Duration {
+	dwell := time.Duration(position.DwellTime) * time.Second
+	jitter := st.profileDwellJitter(st.customScanPattern)
+	if jitter <= 0 {
+		return dwell
+	}
+	return time.Duration(float64(dwell) * (1 + jitter*(2*rand.Float64()-1)))
+}
+
+// ConfigureScanProfiles loads named scan profiles next to scanning.json and starts the requested one ("" keeps
+// the file's active profile, or scanning.json). Profiles changed at runtime are saved back to path.
+func (si *SpatialIntegration) ConfigureScanProfiles(set *ScanProfileSet, path, active string, dwellJitter float64) error {
+	if dwellJitter < 0 || dwellJitter > 1 {
+		return fmt.Errorf("scan dwell jitter must be between 0 and 1, got %.2f", dwellJitter)
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if set == nil {
+		set = &ScanProfileSet{}
+	}
+	for i := range set.Profiles {
+		if _, err := resolveScanPresets(&set.Profiles[i], si.presets); err != nil {
+			return err
+		}
+	}
+	si.scanProfileSet = set
+	si.scanProfilePath = path
+	si.spatialTracker.SetScanProfiles(set.Profiles, dwellJitter)
+
+	if active == "" {
+		active = set.Active
+	}
+	if active != "" {
+		if err := si.spatialTracker.SelectScanProfile(active); err != nil {
+			return err
+		}
+	} else if dwellJitter > 0 {
+		si.debugMsg("SCAN_PROFILE", fmt.Sprintf("🎲 Scan dwell times randomized by ±%.0f%%", dwellJitter*100))
+	}
+	return nil
+}
+
+// GetScanProfiles returns the available scan profiles and the one in use
+func (si *SpatialIntegration) GetScanProfiles() ScanProfileList {
+	return si.spatialTracker.ScanProfiles()
+}
+
+// SelectScanProfile switches the river scan to another profile
+func (si *SpatialIntegration) SelectScanProfile(name string) error {
+	if err := si.spatialTracker.SelectScanProfile(name); err != nil {
+		return err
+	}
+	si.debugMsg("OPERATOR", fmt.Sprintf("🗺️ Scan profile switched to '%s'", name))
+	return nil
+}
+
+// SaveScanProfile adds or replaces a scan profile and saves the profile file when one is configured
+func (si *SpatialIntegration) SaveScanProfile(profile CustomScanningPattern) error {
+	si.mu.RLock()
+	presets := si.presets
+	si.mu.RUnlock()
+	if _, err := resolveScanPresets(&profile, presets); err != nil {
+		return err
+	}
+	if err := ValidateScanProfile(&profile); err != nil {
+		return err
+	}
+	profile.UpdatedAt = time.Now()
+	if profile.CreatedAt.IsZero() {
+		profile.CreatedAt = profile.UpdatedAt
+	}
+	if err := si.spatialTracker.PutScanProfile(profile); err != nil {
+		return err
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if si.scanProfileSet == nil {
+		si.scanProfileSet = &ScanProfileSet{}
+	}
+	replaced := false
+	for i := range si.scanProfileSet.Profiles {
+		if si.scanProfileSet.Profiles[i].Name == profile.Name {
+			si.scanProfileSet.Profiles[i] = profile
+			replaced = true
+		}
+	}
+	if !replaced {
+		si.scanProfileSet.Profiles = append(si.scanProfileSet.Profiles, profile)
+	}
+	si.debugMsg("OPERATOR", fmt.Sprintf("🗺️ Scan profile '%s' saved (%d positions)", profile.Name, len(profile.Positions)))
+
+	if si.scanProfilePath == "" {
+		return nil
+	}
+	return si.scanProfileSet.Save(si.scanProfilePath)
+}
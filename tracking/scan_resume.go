@@ -0,0 +1,98 @@
+package tracking
+
+import (
+	"fmt"
+	"time"
+
+	"rivercam/ptz"
+)
+
+// ScanPoint is where the river scan is in its pattern, so a restart can carry on from there
+type ScanPoint struct {
+	Profile string `json:"profile,omitempty"` // Scan profile in use ("" for the built-in pattern)
+	Index   int    `json:"index"`             // Position in the pattern the scan is at or moving to
+}
+
+// ScanPoint returns the scan profile in use and the position the scan is at
+func (st *SpatialTracker) ScanPoint() ScanPoint {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	point := ScanPoint{Index: st.currentScanIndex}
+	if st.customScanPattern != nil {
+		point.Profile = st.customScanPattern.Name
+	}
+	return point
+}
+
+// ResumeScanAt continues the scan at a saved point: the profile is selected (when it still exists) and the
+// next scan step moves to the saved position instead of the pattern's first one
+func (st *SpatialTracker) ResumeScanAt(point ScanPoint) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if point.Profile != "" && (st.customScanPattern == nil || st.customScanPattern.Name != point.Profile) {
+		profile, ok := st.scanProfiles[point.Profile]
+		if !ok {
+			return fmt.Errorf("scan profile %q no longer exists", point.Profile)
+		}
+		st.activateScanProfile(profile)
+	}
+	if point.Index < 0 || point.Index >= len(st.scanPattern) {
+		return fmt.Errorf("scan position %d is outside the %d-position pattern", point.Index+1, len(st.scanPattern))
+	}
+	st.currentScanIndex = point.Index
+	st.scanPositionStartTime = time.Time{} // Move to the saved position before dwelling
+	st.lastScanTime = time.Time{}
+	return nil
+}
+
+// ScanPoint returns where the river scan is, for the state file
+func (si *SpatialIntegration) ScanPoint() ScanPoint {
+	return si.spatialTracker.ScanPoint()
+}
+
+// ResumeScan carries on scanning from a point saved before a restart
+func (si *SpatialIntegration) ResumeScan(point ScanPoint) error {
+	if err := si.spatialTracker.ResumeScanAt(point); err != nil {
+		return err
+	}
+	si.debugMsg("SCAN_PROFILE", fmt.Sprintf("⏯️ Scan resumed at position %d of '%s'", point.Index+1, point.Profile))
+	return nil
+}
+
+// ScanWaypoint is the scan profile position the camera is dwelling at
+type ScanWaypoint struct {
+	Profile string    // Scan profile name
+	ID      int       // Position ID in the profile
+	Name    string    // Position name in the profile
+	Arrived time.Time // When the camera reached the position
+}
+
+// DwellingWaypoint returns the scan profile position the camera has reached and is dwelling at. It returns false
+// while moving between positions, tracking, paused, or scanning the built-in pattern (its points have no names).
+func (st *SpatialTracker) DwellingWaypoint() (ScanWaypoint, bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if !st.scanningMode || st.scanningPaused || st.customScanPattern == nil || st.scanPositionStartTime.IsZero() ||
+		st.currentScanIndex >= len(st.customScanPattern.Positions) {
+		return ScanWaypoint{}, false
+	}
+	position := st.customScanPattern.Positions[st.currentScanIndex]
+	current := ptz.PTZPosition{Pan: st.currentPTZPosition.Pan, Tilt: st.currentPTZPosition.Tilt, Zoom: st.currentPTZPosition.Zoom}
+	if !st.isAtTargetPosition(current, position.Position, 30.0, 20.0, 20.0) { // Back from tracking, not yet returned
+		return ScanWaypoint{}, false
+	}
+	return ScanWaypoint{
+		Profile: st.customScanPattern.Name,
+		ID:      position.ID,
+		Name:    position.Name,
+		Arrived: st.scanPositionStartTime,
+	}, true
+}
+
+// DwellingWaypoint returns the scan profile position the camera is dwelling at while scanning idle
+func (si *SpatialIntegration) DwellingWaypoint() (ScanWaypoint, bool) {
+	return si.spatialTracker.DwellingWaypoint()
+}
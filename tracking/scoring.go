@@ -0,0 +1,149 @@
+package tracking
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ScoringContext carries the per-candidate information a TargetScorer may need besides the boat itself
+type ScoringContext struct {
+	BaseScore        float64 // Score from the scoring strategy (detections, confidence, center, size, P2 bonus, ...)
+	FrameWidth       int
+	FrameHeight      int
+	FrameCount       int
+	IsCurrentTarget  bool
+	VesselOfInterest bool // Boat ID or classification was flagged by the operator
+}
+
+// TargetScorer computes the target-selection score for a candidate boat. Higher scores win.
+// Implementations that only want to adjust the default can start from ctx.BaseScore.
+type TargetScorer interface {
+	ScoreTarget(boat *TrackedBoat, ctx ScoringContext) float64
+}
+
+// TargetScorerFunc adapts a plain function to the TargetScorer interface
+type TargetScorerFunc func(boat *TrackedBoat, ctx ScoringContext) float64
+
+// ScoreTarget calls f(boat, ctx)
+func (f TargetScorerFunc) ScoreTarget(boat *TrackedBoat, ctx ScoringContext) float64 {
+	return f(boat, ctx)
+}
+
+// ScoreTerm is one weighted, named contribution to a composite score
+type ScoreTerm struct {
+	Name   string
+	Weight float64
+	Value  func(boat *TrackedBoat, ctx ScoringContext) float64 // Expected range 0-1
+}
+
+// CompositeScorer adds weighted site-specific terms on top of the built-in score
+type CompositeScorer struct {
+	Terms []ScoreTerm
+}
+
+// NewCompositeScorer creates a scorer of BaseScore + Σ weight×term
+func NewCompositeScorer(terms ...ScoreTerm) *CompositeScorer {
+	return &CompositeScorer{Terms: terms}
+}
+
+// ScoreTarget implements TargetScorer
+func (cs *CompositeScorer) ScoreTarget(boat *TrackedBoat, ctx ScoringContext) float64 {
+	total := ctx.BaseScore
+	var parts []string
+	for _, term := range cs.Terms {
+		contribution := term.Weight * term.Value(boat, ctx)
+		total += contribution
+		if contribution != 0 {
+			parts = append(parts, fmt.Sprintf("%s=%+.2f", term.Name, contribution))
+		}
+	}
+
+	if len(parts) > 0 {
+		spatialDebugMsgVerbose("SCORE_PLUGIN", fmt.Sprintf("%s %s: base=%.3f %s → TOTAL=%.3f",
+			boat.Classification, boat.ID, ctx.BaseScore, strings.Join(parts, " "), total), boat.ID)
+	}
+	return total
+}
+
+// DirectionTerm favors boats whose pixel velocity points along (dirX, dirY), e.g. vessels heading upstream
+// toward a dam. Returns the cosine between the velocity and the preferred direction (0 for opposite or stationary).
+func DirectionTerm(weight, dirX, dirY, minSpeed float64) ScoreTerm {
+	length := math.Sqrt(dirX*dirX + dirY*dirY)
+	return ScoreTerm{
+		Name:   "direction",
+		Weight: weight,
+		Value: func(boat *TrackedBoat, ctx ScoringContext) float64 {
+			vx, vy := boat.PixelVelocity.X, boat.PixelVelocity.Y
+			speed := math.Sqrt(vx*vx + vy*vy)
+			if length == 0 || speed < minSpeed {
+				return 0
+			}
+			return math.Max(0, (vx*dirX+vy*dirY)/(speed*length))
+		},
+	}
+}
+
+// VesselOfInterestTerm adds a full bonus for boats the operator flagged as vessels of interest
+func VesselOfInterestTerm(weight float64) ScoreTerm {
+	return ScoreTerm{
+		Name:   "interest",
+		Weight: weight,
+		Value: func(boat *TrackedBoat, ctx ScoringContext) float64 {
+			if ctx.VesselOfInterest {
+				return 1
+			}
+			return 0
+		},
+	}
+}
+
+// SetTargetScorer replaces the target-selection scoring (nil restores the built-in formula)
+func (si *SpatialIntegration) SetTargetScorer(scorer TargetScorer) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.targetScorer = scorer
+	if scorer == nil {
+		si.debugMsg("SCORE_PLUGIN", "🎯 Using built-in target scoring")
+	} else {
+		si.debugMsg("SCORE_PLUGIN", fmt.Sprintf("🎯 Using custom target scorer %T", scorer))
+	}
+}
+
+// FlagVesselOfInterest marks an objectID or classification (e.g. "ferry") as a vessel of interest
+func (si *SpatialIntegration) FlagVesselOfInterest(idOrClass string) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if si.vesselsOfInterest == nil {
+		si.vesselsOfInterest = make(map[string]bool)
+	}
+	si.vesselsOfInterest[idOrClass] = true
+	si.debugMsg("SCORE_PLUGIN", fmt.Sprintf("⭐ Flagged vessel of interest: %s", idOrClass))
+}
+
+// UnflagVesselOfInterest removes a vessel-of-interest flag
+func (si *SpatialIntegration) UnflagVesselOfInterest(idOrClass string) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	delete(si.vesselsOfInterest, idOrClass)
+}
+
+// isVesselOfInterest checks a boat's ID and classification against the flagged set. Must be called with si.mu held.
+func (si *SpatialIntegration) isVesselOfInterest(boat *TrackedBoat) bool {
+	return si.vesselsOfInterest[boat.ID] || si.vesselsOfInterest[boat.Classification]
+}
+
+// scoringContext builds the ScoringContext for a candidate. Must be called with si.mu held.
+func (si *SpatialIntegration) scoringContext(boat *TrackedBoat, baseScore float64) ScoringContext {
+	return ScoringContext{
+		BaseScore:        baseScore,
+		FrameWidth:       si.frameWidth,
+		FrameHeight:      si.frameHeight,
+		FrameCount:       si.frameCount,
+		IsCurrentTarget:  si.targetBoat != nil && si.targetBoat.ID == boat.ID,
+		VesselOfInterest: si.isVesselOfInterest(boat),
+	}
+}
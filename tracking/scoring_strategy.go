@@ -0,0 +1,201 @@
+package tracking
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Built-in scoring strategies
+const (
+	StrategyBalanced     = "balanced"      // The original formula: a bit of everything, large boats favored
+	StrategyLargestFirst = "largest-first" // Biggest boat in the frame
+	StrategyCenterFirst  = "center-first"  // Boat closest to the frame center (least camera movement)
+	StrategyPeopleFirst  = "people-first"  // Boats with people on board
+	StrategyNewestFirst  = "newest-first"  // Most recently detected boat
+)
+
+// scoringNewnessHalfAge is the age at which the newness factor has dropped to 0.5
+const scoringNewnessHalfAge = time.Minute
+
+// ScoreFactors are the normalized measurements of a candidate boat a ScoringStrategy weighs
+type ScoreFactors struct {
+	Detections float64 // Detection count / 20, capped at 1.5
+	Confidence float64 // Detector confidence
+	Center     float64 // 1 at the frame center, 0 in a corner
+	Size       float64 // Pixel area / 10000, capped at 1
+	Stability  float64 // 0.2 for the current target, otherwise 0
+	People     float64 // 0.5 + 0.2 per P2 object on board, 0 without
+	Newness    float64 // 1 for a boat just detected, 0.5 after a minute, falling toward 0
+	Presence   float64 // Multiplier: 1 while detected, 0 at the lost-frames limit
+}
+
+// ScoringWeights are the weights of the score factors (Presence is always a multiplier)
+type ScoringWeights struct {
+	Detections float64 `json:"detections"`
+	Confidence float64 `json:"confidence"`
+	Center     float64 `json:"center"`
+	Size       float64 `json:"size"`
+	Stability  float64 `json:"stability"`
+	People     float64 `json:"people"`
+	Newness    float64 `json:"newness"`
+}
+
+// fields maps the factor names used in overrides to the weights
+func (w *ScoringWeights) fields() map[string]*float64 {
+	return map[string]*float64{
+		"detections": &w.Detections,
+		"confidence": &w.Confidence,
+		"center":     &w.Center,
+		"size":       &w.Size,
+		"stability":  &w.Stability,
+		"people":     &w.People,
+		"newness":    &w.Newness,
+	}
+}
+
+// WithOverrides returns the weights with some replaced by name (detections, confidence, center, size,
+// stability, people, newness)
+func (w ScoringWeights) WithOverrides(overrides map[string]float64) (ScoringWeights, error) {
+	fields := w.fields()
+	for name, weight := range overrides {
+		field, exists := fields[strings.ToLower(name)]
+		if !exists {
+			names := make([]string, 0, len(fields))
+			for known := range fields {
+				names = append(names, known)
+			}
+			sort.Strings(names)
+			return w, fmt.Errorf("unknown score weight %q (known: %s)", name, strings.Join(names, ", "))
+		}
+		if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return w, fmt.Errorf("score weight %s must be a non-negative number, got %v", name, weight)
+		}
+		*field = weight
+	}
+	return w, nil
+}
+
+// ScoringStrategy turns a candidate's score factors into its target-selection score. Higher scores win.
+type ScoringStrategy interface {
+	Name() string
+	Weights() ScoringWeights
+	Score(factors ScoreFactors) float64
+}
+
+// WeightedStrategy scores a weighted sum of the factors, scaled by Presence so fading boats lose out
+type WeightedStrategy struct {
+	name    string
+	weights ScoringWeights
+}
+
+// NewWeightedStrategy creates a named weighted-sum strategy
+func NewWeightedStrategy(name string, weights ScoringWeights) *WeightedStrategy {
+	return &WeightedStrategy{name: name, weights: weights}
+}
+
+// Name implements ScoringStrategy
+func (s *WeightedStrategy) Name() string { return s.name }
+
+// Weights implements ScoringStrategy
+func (s *WeightedStrategy) Weights() ScoringWeights { return s.weights }
+
+// Score implements ScoringStrategy
+func (s *WeightedStrategy) Score(f ScoreFactors) float64 {
+	w := s.weights
+	return (f.Detections*w.Detections + f.Confidence*w.Confidence + f.Center*w.Center + f.Size*w.Size +
+		f.Stability*w.Stability + f.People*w.People + f.Newness*w.Newness) * f.Presence
+}
+
+// builtinScoringWeights are the weights of the built-in strategies. Every strategy keeps some stability so the
+// camera doesn't flip between two near-equal boats.
+var builtinScoringWeights = map[string]ScoringWeights{
+	StrategyBalanced:     {Detections: 0.15, Confidence: 0.15, Center: 0.10, Size: 0.25, Stability: 0.15, People: 0.20},
+	StrategyLargestFirst: {Detections: 0.05, Confidence: 0.05, Size: 0.75, Stability: 0.15},
+	StrategyCenterFirst:  {Detections: 0.05, Confidence: 0.05, Center: 0.75, Stability: 0.15},
+	StrategyPeopleFirst:  {Detections: 0.05, Confidence: 0.05, Size: 0.10, Stability: 0.15, People: 0.65},
+	StrategyNewestFirst:  {Detections: 0.05, Confidence: 0.05, Stability: 0.15, Newness: 0.75},
+}
+
+// ScoringStrategyNames lists the built-in strategies
+func ScoringStrategyNames() []string {
+	names := make([]string, 0, len(builtinScoringWeights))
+	for name := range builtinScoringWeights {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewScoringStrategy creates a built-in strategy with some of its weights overridden (nil keeps them all)
+func NewScoringStrategy(name string, overrides map[string]float64) (ScoringStrategy, error) {
+	weights, exists := builtinScoringWeights[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown scoring strategy %q (available: %s)", name, strings.Join(ScoringStrategyNames(), ", "))
+	}
+	weights, err := weights.WithOverrides(overrides)
+	if err != nil {
+		return nil, err
+	}
+	return NewWeightedStrategy(name, weights), nil
+}
+
+// ScoringStrategyInfo describes the strategy in effect, as reported over the API
+type ScoringStrategyInfo struct {
+	Strategy  string         `json:"strategy"`
+	Weights   ScoringWeights `json:"weights"`
+	Available []string       `json:"available"`
+}
+
+// SetScoringStrategy switches target selection to a built-in strategy with optional weight overrides. It takes
+// effect on the next frame; the current target keeps its stability bonus.
+func (si *SpatialIntegration) SetScoringStrategy(name string, overrides map[string]float64) error {
+	strategy, err := NewScoringStrategy(name, overrides)
+	if err != nil {
+		return err
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.scoringStrategy = strategy
+	w := strategy.Weights()
+	si.debugMsg("SCORE_STRATEGY", fmt.Sprintf("🎯 Target scoring: %s (det %.2f, conf %.2f, center %.2f, size %.2f, stable %.2f, people %.2f, new %.2f)",
+		name, w.Detections, w.Confidence, w.Center, w.Size, w.Stability, w.People, w.Newness))
+	return nil
+}
+
+// GetScoringStrategy returns the target-selection strategy in effect and the available built-ins
+func (si *SpatialIntegration) GetScoringStrategy() ScoringStrategyInfo {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+
+	strategy := si.scoring()
+	return ScoringStrategyInfo{
+		Strategy:  strategy.Name(),
+		Weights:   strategy.Weights(),
+		Available: ScoringStrategyNames(),
+	}
+}
+
+// scoring returns the strategy in effect (balanced until one is set). Must be called with si.mu held.
+func (si *SpatialIntegration) scoring() ScoringStrategy {
+	if si.scoringStrategy == nil {
+		return NewWeightedStrategy(StrategyBalanced, builtinScoringWeights[StrategyBalanced])
+	}
+	return si.scoringStrategy
+}
+
+// newnessFactor is 1 for a boat just detected and halves over scoringNewnessHalfAge
+func newnessFactor(firstDetected time.Time) float64 {
+	if firstDetected.IsZero() {
+		return 0
+	}
+	age := time.Since(firstDetected)
+	if age < 0 {
+		age = 0
+	}
+	return 1 / (1 + age.Seconds()/scoringNewnessHalfAge.Seconds())
+}
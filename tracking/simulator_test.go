@@ -0,0 +1,96 @@
+package tracking
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"rivercam/calibration"
+	"rivercam/ptz"
+)
+
+// simulatedDetection returns where the scene draws a boat in the camera's current view: the detection a
+// perfect detector would report for it
+func simulatedDetection(camera *ptz.SimulatedController, config ptz.SceneConfig, boat ptz.SimulatedBoat) image.Rectangle {
+	pose := camera.Pose()
+	panPixelsPerUnit, tiltPixelsPerUnit := calibration.DefaultTable().InterpolateAt(pose.Zoom)
+	centerX := float64(config.Width)/2 + (math.Mod(boat.Pan-pose.Pan+5400, 3600)-1800)*panPixelsPerUnit
+	centerY := float64(config.Height)/2 + (boat.Tilt-pose.Tilt)*tiltPixelsPerUnit
+	length := config.BoatLength * panPixelsPerUnit
+	return image.Rect(int(centerX-length/2), int(centerY-length*0.2), int(centerX+length/2), int(centerY+length*0.2))
+}
+
+// useScanningPattern runs the test in a directory whose scanning.json (required by the spatial tracker) holds a
+// single position
+func useScanningPattern(t *testing.T, pan, tilt, zoom float64) {
+	t.Helper()
+	dir := t.TempDir()
+	pattern := fmt.Sprintf(`{"name": "test", "positions": [{"id": 1, "name": "river", "position": {"Pan": %g, "Tilt": %g, "Zoom": %g}, "dwell_time_seconds": 15}]}`, pan, tilt, zoom)
+	if err := os.WriteFile(filepath.Join(dir, "scanning.json"), []byte(pattern), 0644); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func TestSpatialIntegrationTracksSimulatedBoat(t *testing.T) {
+	useScanningPattern(t, 2380, 195, 50)
+	scene := ptz.SceneConfig{
+		Width: 960, Height: 540,
+		Boats:       1,
+		BoatSpeed:   10,
+		BoatLength:  12,
+		RiverTilt:   200,
+		PanMin:      2100,
+		PanMax:      2700,
+		HorizonTilt: 60,
+	}
+	// The boat starts at pan 2400, tilt 200 heading right; the camera starts off to its side with the boat in view
+	camera := ptz.NewSimulatedController(ptz.SimulatorConfig{
+		PanSpeed:       ptz.DefaultSimPanSpeed,
+		TiltSpeed:      ptz.DefaultSimTiltSpeed,
+		ZoomSpeed:      ptz.DefaultSimZoomSpeed,
+		CommandLatency: 20 * time.Millisecond,
+		Start:          ptz.PTZPosition{Pan: 2380, Tilt: 195, Zoom: 50},
+	})
+	camera.SetFrameDimensions(scene.Width, scene.Height)
+	camera.Start()
+	defer camera.Stop()
+	world := ptz.NewSimulatedScene(camera, scene, nil)
+	si := NewSpatialIntegration(camera, scene.Width, scene.Height, nil, []string{"boat"}, nil, false, false, 0.3, 0.3)
+
+	view := image.Rect(0, 0, scene.Width, scene.Height)
+	var boat ptz.SimulatedBoat
+	for i := 0; i < 90; i++ {
+		world.Render(time.Now())
+		boat = world.Boats()[0]
+		var rects []image.Rectangle
+		if rect := simulatedDetection(camera, scene, boat); rect.Overlaps(view) {
+			rects = append(rects, rect)
+		}
+		si.UpdateTracking(rects, []string{"boat"}[:len(rects)], []float64{0.9}[:len(rects)], nil)
+		time.Sleep(33 * time.Millisecond)
+	}
+
+	if si.GetLockedTarget() == nil {
+		t.Fatalf("the simulated boat is not locked (%d tracks)", len(si.GetAllBoats()))
+	}
+	if tracks := len(si.GetAllBoats()); tracks != 1 {
+		t.Errorf("%d tracks for one boat", tracks)
+	}
+	// After three seconds the camera follows the boat: it stays well inside the frame, near the center
+	pose := camera.Pose()
+	if math.Abs(boat.Pan-pose.Pan) > 5 || math.Abs(boat.Tilt-pose.Tilt) > 5 {
+		t.Errorf("camera at pan %.0f tilt %.0f, boat at pan %.0f tilt %.0f", pose.Pan, pose.Tilt, boat.Pan, boat.Tilt)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"image"
 	"math"
+	"strings"
 	"sync"
 	"time"
 
+	"rivercam/calibration"
 	"rivercam/ptz"
 )
 
@@ -39,11 +41,20 @@ type SpatialIntegration struct {
 	pixelTrackingHistory []image.Point           // Clean pixel history for overlay
 
 	// Target locking settings
-	minDetectionsForLock int // Minimum detections before boat can be locked
-	maxLostFrames        int // Max frames a boat can be lost before removal
-	targetSwitchCooldown int // Cooldown frames before switching targets
-	lastTargetSwitch     int // Frame count when target was last switched
-	frameCount           int // Current frame count
+	minDetectionsForLock int           // Minimum detections before boat can be locked
+	maxLostFrames        int           // Max frames a boat can be lost before removal (maxLostTime at the current rate)
+	targetSwitchCooldown int           // Cooldown frames before switching targets (targetSwitchTime at the current rate)
+	lastTargetSwitch     int           // Frame count when target was last switched
+	frameCount           int           // Current frame count
+	maxLostTime          time.Duration // How long a boat can be lost before removal
+	targetSwitchTime     time.Duration // Cooldown before switching targets
+
+	// Tracking update rate, measured from the capture times of the tracked frames
+	frameRate         float64   // Updates per second
+	frameRateLogged   float64   // Rate last reported in the log
+	frameInterval     float64   // Smoothed seconds between updates (0 = not measured yet)
+	frameRateSince    time.Time // Capture time of the first measured frame
+	lastFrameCaptured time.Time
 
 	// Post-lock holdover (linger after losing locked boat before resuming scanning)
 	lastLockLoss        time.Time         // When we lost the last locked boat
@@ -81,22 +92,167 @@ type SpatialIntegration struct {
 	renderer     interface{} // Reference to renderer for terminal logging (avoid import cycle)
 
 	// Unified boat ID generation system
-	lastMinuteTimestamp         string // Track current minute for counter reset (20240125-12-30)
-	currentMinuteCounter        int    // Counter that resets each minute (1, 2, 3...)
-	totalDetectedObjectsCounter int64  // Session-wide counter, never resets
+	objectIDs                   *ObjectIDGenerator // Names new tracked objects (20240125-13-30.001 unless configured)
+	totalDetectedObjectsCounter int64              // Session-wide counter, never resets
 
 	// RECOVERY mode state
 	recoveryData *RecoveryData // Recovery data for lost boat prediction
 	isInRecovery bool          // Whether we're currently in recovery mode
 
 	// Dynamic tracking priority configuration
-	p1TrackList     []string      // P1 objects (primary tracking targets)
-	p1TrackAll      bool          // P1 tracks all detected objects
-	p2TrackList     []string      // P2 objects (enhancement objects)
-	p2TrackAll      bool          // P2 tracks all non-P1 objects
-	p1MinConfidence float64       // Minimum confidence threshold for P1 targets (boats)
-	p2MinConfidence float64       // Minimum confidence threshold for P2 targets (people)
+	p1TrackList     []string // P1 objects (primary tracking targets)
+	p1TrackAll      bool     // P1 tracks all detected objects
+	p2TrackList     []string // P2 objects (enhancement objects)
+	p2TrackAll      bool     // P2 tracks all non-P1 objects
+	p1MinConfidence float64  // Minimum confidence threshold for P1 targets (boats)
+	p2MinConfidence float64  // Minimum confidence threshold for P2 targets (people)
+
+	// Adaptive P2 confidence (scale threshold by parent P1 box size)
+	adaptiveP2Enabled       bool
+	adaptiveP2ReferenceArea float64
+
 	recoveryTimeout time.Duration // Maximum time to spend in recovery (30 seconds)
+	spiralSearch    SpiralSearchConfig
+	recoveryRebind  RecoveryRebindConfig
+
+	// Per-object event timelines for the debug overlay
+	timelines map[string]*objectTimeline
+
+	// Multi-frame detection fusion (buffers unmatched detections before track creation)
+	detectionFusion *detectionFusion
+
+	// Wake/foam filter applied before new tracks are created
+	wakeFilterLevel string
+	wakeRejections  int
+	currentFrame    []byte // BGR pixels of the frame being tracked (only during UpdateTracking)
+
+	// Sun glare and reflection windows whose detections are dropped
+	glare       GlareConfig
+	glareStates []glareWindowState
+
+	// Operator manual control (automatic camera movement suspended until the inactivity timeout)
+	manualControl   bool
+	manualTimeout   time.Duration
+	manualLastInput time.Time
+
+	// Multi-camera handoff: the scan holds at the position another camera cued until this time
+	handoffHoldUntil time.Time
+
+	// Named PTZ presets and the home-on-idle policy
+	presets      *ptz.PresetStore
+	homePreset   string
+	homeAfter    time.Duration
+	homeHold     time.Duration // Longest hold at home before scanning resumes (0 = until something is tracked)
+	lastActivity time.Time     // Last frame with tracked objects (or manual control)
+	atHome       bool
+	homeSince    time.Time // When the camera was sent home
+
+	// Ranked primary/secondary targets (multi-target mode)
+	multiTarget   MultiTargetConfig
+	rankedTargets []RankedTarget
+
+	// Quarantine and removal of low-quality tracks
+	trackQuality TrackQualityConfig
+
+	// Round-robin attention across lock-eligible boats (tour mode)
+	targetTour   TargetTourConfig
+	tourTargetID string    // Boat whose turn it is ("" = no tour in progress)
+	tourSince    time.Time // When its turn started
+
+	// Target-selection scoring plug-in (nil = built-in formula) and operator-flagged vessels of interest
+	targetScorer      TargetScorer
+	scoringStrategy   ScoringStrategy        // nil = balanced
+	calibration       *confidenceCalibration // Running confidence calibration (nil = none)
+	vesselsOfInterest map[string]bool
+
+	// Person-overboard mode (people in the water without a vessel)
+	overboardEnabled      bool
+	overboardDelay        time.Duration
+	overboardZones        []WaterZone
+	overboardVesselRadius int
+	overboardAlert        func(OverboardEvent)
+	overboardCandidates   []*overboardCandidate
+
+	// Model ensemble for critical zones (locks there need a secondary model verdict)
+	criticalZones    []CriticalZone
+	ensembleVerdicts map[string]ensembleVerdict
+
+	// Per-class velocity sanity bounds (px/s at 1x zoom)
+	classMaxSpeeds  map[string]float64
+	defaultMaxSpeed float64
+
+	// Per-class overrides of the lock, size and zoom settings
+	classParams map[string]ClassParams
+
+	// Zoom policy narrowing every class's tracking zoom range (0 = no limit), set by the behavior schedule
+	zoomPolicyMin float64
+	zoomPolicyMax float64
+
+	// Dwell time accounting per river zone (daily summaries for harbor reporting)
+	dwellZones      []RiverZone
+	loiterThreshold time.Duration
+	dwellDayClosed  func([]ZoneDailyStats)
+	zoneVisits      map[string]*zoneVisit
+	dwellDay        string
+	dwellStats      map[string]*ZoneDailyStats
+
+	// Track lifecycle recording (per-object summaries and paths for the track database)
+	trackPathInterval time.Duration
+	trackEnded        func(TrackRecord)
+	trackRecordings   map[string]*trackRecording
+	trackMerge        *TrackMergeConfig // nil = fragments are not merged
+	endedTracks       []TrackRecord     // Recently finished tracks a new track may continue
+
+	// People counting per locked boat (nil = disabled)
+	occupancy        map[string]*occupancyStats
+	occupancyMinTime time.Duration
+
+	// Real-world speed of locked boats (nil = disabled)
+	speedConfig *SpeedConfig
+	speeds      map[string]*speedStats
+
+	// Track lifecycle state machine (last known boat per ID, to detect removals) and its event listener
+	lifecycleTracks   map[string]*TrackedBoat
+	lifecycleListener func(TrackLifecycleEvent)
+
+	// Burst re-acquisition (lower P1 threshold near coasting locked targets' predicted positions)
+	burstEnabled         bool
+	burstFrames          int           // burstTime at the current rate
+	burstTime            time.Duration // How long after the last detection a coasting lock keeps its window open
+	burstRadius          float64
+	burstConfidenceScale float64
+
+	// Per-zone P1/P2 class lists (nil = global lists everywhere)
+	classZones []ClassZone
+
+	// Lens distortion correction of pixel offsets before pixel→PTZ conversion (nil = linear mapping)
+	lensModel *calibration.LensModel
+
+	// Ego-motion compensation (velocity keeps updating while the camera moves)
+	egoMotionEnabled bool
+
+	// Frame the detections of the current UpdateTracking were taken from (SetDetectionFrame)
+	detectionFrame frameStamp
+
+	// Operator control (pinned target, paused scanning, scan-only operation)
+	pinnedTargetID string
+	scanningPaused bool
+	trackingPaused bool
+
+	// Named scan profiles (-scan-profiles); edits made over the API are saved back to the file
+	scanProfileSet  *ScanProfileSet
+	scanProfilePath string
+
+	// Event stream: receives every debug message so milestones can be published as structured events
+	eventSink func(component, message, objectID string, data map[string]interface{})
+
+	// Detection-to-track association (joint Hungarian assignment or greedy nearest boat)
+	association AssociationConfig
+
+	// Per-detection verdicts of the last UpdateTracking call (-detections-log)
+	verdictsEnabled bool
+	verdicts        []DetectionVerdict
+	verdictIndex    []int // Original index of each detection left after the burst filter (nil = unchanged)
 }
 
 // TrackedBoat represents a boat we're actively tracking
@@ -159,6 +315,7 @@ const (
 	RECOVERY_MOVE_TO_PREDICTED_1 RecoveryPhase = iota
 	RECOVERY_ZOOM_OUT
 	RECOVERY_MOVE_TO_PREDICTED_2
+	RECOVERY_SPIRAL_SEARCH // Optional (see ConfigureSpiralSearch)
 	RECOVERY_COMPLETE
 )
 
@@ -170,6 +327,8 @@ func (r RecoveryPhase) String() string {
 		return "RECOVERY PHASE 2"
 	case RECOVERY_MOVE_TO_PREDICTED_2:
 		return "RECOVERY PHASE 3"
+	case RECOVERY_SPIRAL_SEARCH:
+		return "RECOVERY PHASE 4"
 	case RECOVERY_COMPLETE:
 		return "RECOVERY COMPLETE"
 	default:
@@ -184,6 +343,7 @@ type RecoveryData struct {
 	LastKnownSpatialPos  SpatialCoordinate // Last spatial position
 	AverageDirection     float64           // From DirectionHistory (radians)
 	AverageSpeedPixelSec float64           // From SpeedHistory
+	MaxSpeedPixelSec     float64           // Class velocity bound at the zoom the boat was lost at
 	LossTime             time.Time         // When boat was lost
 	OriginalZoom         float64           // Zoom level when lost
 	CurrentPhase         RecoveryPhase     // Current recovery phase
@@ -193,6 +353,20 @@ type RecoveryData struct {
 	PhaseStartTime       time.Time         // When current phase started
 	PhaseTarget          SpatialCoordinate // Target position for current phase
 	WaitingForArrival    bool              // Whether we're waiting for camera to reach target
+
+	// Spiral search (phase 4)
+	SpiralCenter  SpatialCoordinate   // Last predicted position the spiral is centered on
+	SpiralOffsets []SpatialCoordinate // Pan/tilt offsets still to visit, in order
+	SpiralIndex   int                 // Offset the camera is at or moving to
+
+	// Re-binding a boat found during recovery to the lost boat's ObjectID
+	Classification  string                      // Lost boat's class
+	FirstDetected   time.Time                   // When the lost boat was first detected
+	DetectionCount  int                         // Detections the lost boat had accumulated
+	Size            float64                     // Lost boat's size at 1x zoom
+	Aspect          float64                     // Lost boat's bounding box width/height
+	SpatialVelocity struct{ Pan, Tilt float64 } // Lost boat's velocity in PTZ units/s
+	Rebound         bool                        // A new track has already taken over the ObjectID
 }
 
 type TrackedBoat struct {
@@ -207,6 +381,7 @@ type TrackedBoat struct {
 	// Pixel tracking (for overlay)
 	CurrentPixel   image.Point
 	PixelHistory   []image.Point
+	cameraHistory  []SpatialCoordinate // Camera position at each PixelHistory point (the two align from the end)
 	PredictedPixel image.Point
 	PixelArea      float64         // Store actual detection area in pixels
 	BoundingBox    image.Rectangle // Current bounding box for person detection
@@ -218,12 +393,14 @@ type TrackedBoat struct {
 	LastP2Seen   time.Time // When P2 objects were last seen in P1 target
 
 	// Enhanced P2 tracking for LOCK/SUPER LOCK modes
-	P2Positions []image.Point   // Individual P2 object pixel positions
-	P2Centroid  image.Point     // Calculated centroid of all P2 objects
-	P2Bounds    image.Rectangle // Bounding box containing all P2 objects
-	P2Spread    float64         // Distance between furthest P2 objects (for zoom calc)
-	P2Quality   float64         // Quality score for P2 tracking (0-1)
-	UseP2Target bool            // TRUE when using P2 centroid for LOCK targeting
+	P2Positions     []image.Point   // Individual P2 object pixel positions
+	P2Centroid      image.Point     // Calculated centroid of all P2 objects
+	P2Bounds        image.Rectangle // Bounding box containing all P2 objects
+	P2Spread        float64         // Distance between furthest P2 objects (for zoom calc)
+	P2Quality       float64         // Quality score for P2 tracking (0-1)
+	UseP2Target     bool            // TRUE when using P2 centroid for LOCK targeting
+	peopleSeen      int             // Most people reported on board so far (PEOPLE_ON_BOARD events)
+	p2ConfidenceSum float64         // Sum of this frame's P2 confidences (confidence-weighted people count)
 
 	// Spatial tracking (for camera control)
 	CurrentSpatial   SpatialCoordinate
@@ -233,15 +410,32 @@ type TrackedBoat struct {
 	// Movement analysis
 	PixelVelocity   struct{ X, Y float64 }
 	SpatialVelocity struct{ Pan, Tilt float64 }
+	interpolated    image.Point // Display offset along PixelVelocity and camera motion on frames after detection (AlignTracksToFrame)
+	detectedFrame   frameStamp  // Frame of the last detection, for aligning the display to later frames
 	IsLocked        bool
 	LockStrength    float64
+	State           TrackState // Lifecycle state, advanced once per frame by updateTrackLifecycles
 
 	// Target selection priority
 	TrackingPriority float64 // Higher = more likely to be selected as target
+	PersonOverboard  bool    // Person in the water without a vessel (overrides normal target selection)
 
 	// Debug session logging (spatial calculation details)
 	HasSpatialDebugData bool                   // Flag indicating debug data is ready
 	SpatialDebugData    map[string]interface{} // Detailed spatial calculation data for debug sessions
+
+	// Detection confidences recorded for a running confidence calibration
+	calibration *trackCalibration
+
+	// Track quality (position smoothness, velocity consistency, class stability)
+	Quality     float64 // 0-1, 1 until measured
+	Quarantined bool    // Low quality: never selected as target until it recovers
+	quality     *trackQuality
+
+	// Frame edge handling
+	EdgeClipped  EdgeSides   // Frame borders the last detection touched (cut off by the frame)
+	fullSize     image.Point // Detection size when last fully in frame
+	fullSizeZoom float64     // Zoom at which fullSize was measured
 }
 
 // NewSpatialIntegration creates a clean, multi-object tracking system
@@ -257,9 +451,11 @@ func NewSpatialIntegration(ptzCtrl ptz.Controller, frameWidth, frameHeight int,
 
 		// Initialize multi-object tracking
 		allBoats:             make(map[string]*TrackedBoat),
-		minDetectionsForLock: 2,   // LIGHTNING-FAST: Camera movement at 2 detections (~0.07s) for instant tracking responsiveness
-		maxLostFrames:        150, // Remove boats after 150 lost frames (5.0s at 30fps)
-		targetSwitchCooldown: 120, // INCREASED from 30 to 120 frames for more stable switching
+		minDetectionsForLock: 2,                           // LIGHTNING-FAST: Camera movement at 2 detections (~0.07s) for instant tracking responsiveness
+		maxLostTime:          DefaultMaxLostTime,          // Remove boats after 5 seconds without a detection
+		targetSwitchTime:     DefaultTargetSwitchCooldown, // INCREASED from 1s to 4s for more stable switching
+		frameRate:            DefaultFrameRate,
+		frameRateLogged:      DefaultFrameRate,
 		lastTargetSwitch:     0,
 		frameCount:           0,
 
@@ -276,8 +472,17 @@ func NewSpatialIntegration(ptzCtrl ptz.Controller, frameWidth, frameHeight int,
 		p2TrackAll:      p2TrackAll,
 		p1MinConfidence: p1MinConfidence,
 		p2MinConfidence: p2MinConfidence,
+
+		timelines:       make(map[string]*objectTimeline),
+		detectionFusion: newDetectionFusion(DefaultFusionWindow, DefaultFusionMinHits),
+		classMaxSpeeds:  DefaultClassMaxSpeeds(),
+		defaultMaxSpeed: DefaultMaxPixelSpeed,
+		association:     DefaultAssociationConfig(),
+		recoveryRebind:  DefaultRecoveryRebindConfig(),
 	}
 
+	integration.updateFrameThresholds()
+
 	// Initialize smart PTZ tracking configuration
 	integration.smartPTZEnabled = true  // Enable smart tracking by default
 	integration.ptzPredictionTime = 3.0 // Look ahead 3 seconds by default
@@ -296,15 +501,15 @@ func NewSpatialIntegration(ptzCtrl ptz.Controller, frameWidth, frameHeight int,
 	// DUAL LOGGING: Initialization complete
 	integration.logDebugMessage("🔧 Spatial tracking initialized", "SPATIAL_INIT", 1, map[string]interface{}{
 		"min_detections":         integration.minDetectionsForLock,
-		"min_confidence":         0.30,
+		"min_confidence":         lockMinConfidence,
 		"max_lost_frames":        integration.maxLostFrames,
 		"target_switch_cooldown": integration.targetSwitchCooldown,
 		"tracking_mode":          "LIGHTNING_FAST",
 	})
 
 	integration.debugMsg("MULTI_TRACKING", fmt.Sprintf("Initialized multi-object tracking system (%dx%d)", frameWidth, frameHeight))
-	integration.debugMsg("MULTI_TRACKING", fmt.Sprintf("LIGHTNING-FAST Lock: %d detections (~%.2fs), max %d lost frames (%.1fs at 30fps)",
-		integration.minDetectionsForLock, float64(integration.minDetectionsForLock)/30.0, integration.maxLostFrames, float64(integration.maxLostFrames)/30.0))
+	integration.debugMsg("MULTI_TRACKING", fmt.Sprintf("LIGHTNING-FAST Lock: %d detections (~%.2fs), max %d lost frames (%.1fs at %.0ffps)",
+		integration.minDetectionsForLock, integration.frameSeconds(integration.minDetectionsForLock), integration.maxLostFrames, integration.maxLostTime.Seconds(), integration.frameRate))
 	integration.debugMsg("MULTI_TRACKING", "Matching: YOLO bounding box overlap (priority) + 200px distance fallback")
 	integration.debugMsg("MULTI_TRACKING", "📊 DETAILED DEBUG LOGS: Console output reduced, full tracking analysis in /tmp/debugMode/<session>/tracking_session.txt")
 	integration.debugMsg("MULTI_TRACKING", fmt.Sprintf("Post-lock holdover: %.1fs (linger after losing locked boat before scanning)",
@@ -355,6 +560,7 @@ func (si *SpatialIntegration) isP1Object(className string) bool {
 
 // debugMsg is a convenience method for unified debug logging with boat IDs
 func (si *SpatialIntegration) debugMsg(component, message string, boatID ...string) {
+	si.emitEvent(component, message, boatID...)
 	if si.debugLogger != nil {
 		// Try to cast to the debug logger interface and call debugMsg
 		if dl, ok := si.debugLogger.(interface {
@@ -366,6 +572,7 @@ func (si *SpatialIntegration) debugMsg(component, message string, boatID ...stri
 }
 
 func (si *SpatialIntegration) debugMsgVerbose(component, message string, boatID ...string) {
+	si.emitEvent(component, message, boatID...)
 	if si.debugLogger != nil {
 		// Try to cast to the debug logger interface and call debugMsgVerbose
 		if dl, ok := si.debugLogger.(interface {
@@ -383,16 +590,31 @@ func (si *SpatialIntegration) UpdateTracking(detections []image.Rectangle, class
 	defer si.mu.Unlock()
 
 	si.frameCount++
+	si.clearInterpolation()
+	si.observeFrameRate()
+
+	// The frame's pixels are only valid for this call
+	si.currentFrame = frameData
+	defer func() { si.currentFrame = nil }()
 
 	// Clean up stale data when camera moves
 	si.detectAndCleanupCameraMovement()
 
+	si.beginVerdicts(len(detections))
+
+	// Weak P1 detections only count near the predicted position of a coasting locked target
+	detections, classNames, confidences = si.filterBurstDetections(detections, classNames, confidences)
+
+	// Detections in an active sun glare or reflection window are dropped
+	detections, classNames, confidences = si.filterGlareDetections(detections, classNames, confidences)
+
 	// RATE LIMITED TRACKING: Always process all YOLO detections
 	// Rate limiting in CameraStateManager prevents command flooding
 
 	// Process all YOLO detections and update/create boats
 	boatsBeforeUpdate := len(si.allBoats)
 	si.updateAllBoats(detections, classNames, confidences)
+	si.stampDetectedBoats()
 	boatsAfterUpdate := len(si.allBoats)
 	newBoatsCreated := boatsAfterUpdate - boatsBeforeUpdate
 
@@ -401,19 +623,27 @@ func (si *SpatialIntegration) UpdateTracking(detections []image.Rectangle, class
 			si.frameCount, newBoatsCreated, boatsBeforeUpdate, boatsAfterUpdate))
 	}
 
-	// Detect P2 objects inside P1 targets for enhanced targeting
+	// Detect P2 objects inside P1 targets for enhanced targeting, and people in the water with no vessel around
+	// them (person-overboard)
 	si.detectP2ObjectsInP1Targets(detections, classNames, confidences)
 
 	// NEW: Feed locked boats with ALL detections in their area to maintain tracking
 	si.feedLockedBoatsWithClusterDetections(detections, classNames, confidences)
 
-	// Clean up lost boats
+	// Clean up lost boats and quarantine or remove tracks that alternate between boats or jump around
 	si.cleanupLostBoats()
+	si.pruneLowQualityTracks()
+	si.noteIdleActivity()
 
-	// Select target boat for camera tracking
-	si.selectTargetBoat()
+	// Select target boat for camera tracking (none while scan only: coasting tracks must not take the camera back)
+	if !si.trackingPaused {
+		si.selectTargetBoat()
+	}
+	si.updateTargetRanking()
 
-	if si.targetBoat != nil {
+	if si.checkManualTimeout() {
+		// The operator is driving the camera: tracks keep updating, but nothing moves it until control is handed back
+	} else if si.targetBoat != nil {
 		// Store safe reference to target boat to prevent nil pointer issues if it gets modified during processing
 		targetBoat := si.targetBoat
 
@@ -455,13 +685,35 @@ func (si *SpatialIntegration) UpdateTracking(detections []image.Rectangle, class
 				si.spatialTracker.SetScanningMode(true)
 			}
 
-			// Execute river scanning pattern
-			if si.spatialTracker.IsScanning() {
+			// Execute river scanning pattern, unless idle long enough to hold at the home preset
+			if si.holdingForHandoff() {
+				// Waiting where another camera cued us for the target it is handing over
+			} else if si.returnHomeWhenIdle() {
+				// Holding at home until something is tracked
+			} else if si.spatialTracker.IsScanning() {
 				si.spatialTracker.ExecuteRiverScan()
 			}
 		}
 	}
 
+	// Advance the per-track lifecycle state machines now that matching, selection and locking are done
+	si.updateTrackLifecycles()
+
+	// Record detection/lock/recovery transitions for the overlay timeline
+	si.recordTimelineEvents()
+
+	// Accumulate per-zone dwell time for the daily summaries
+	si.recordZoneDwell()
+
+	// Count people on locked boats and report the boats that are gone
+	si.recordOccupancy()
+
+	// Convert locked boats' velocities to speed over the water and report the boats that are gone
+	si.recordSpeeds()
+
+	// Accumulate per-track lifecycle records and hand off the tracks that ended
+	si.recordTracks()
+
 	// COMPREHENSIVE FRAME SUMMARY DEBUG (show every 30 frames to avoid spam)
 	if si.frameCount%30 == 0 || len(si.allBoats) > 0 {
 		si.logFrameSummary(detections, classNames, confidences)
@@ -519,9 +771,8 @@ func (si *SpatialIntegration) logFrameSummary(detections []image.Rectangle, clas
 
 	var boatStatusDetails []string
 	for _, boat := range si.allBoats {
-		meetsDetectionCriteria := boat.DetectionCount >= si.minDetectionsForLock
-		meetsConfidenceCriteria := boat.Confidence > 0.30
-		meetsEarlyLockCriteria := boat.Confidence >= 0.80 && boat.DetectionCount >= 1
+		meetsDetectionCriteria, meetsConfidenceCriteria := si.lockCriteria(boat)
+		meetsEarlyLockCriteria := meetsEarlyLockCriteria(boat)
 
 		if boat.IsLocked {
 			lockedBoats++
@@ -529,12 +780,12 @@ func (si *SpatialIntegration) logFrameSummary(detections []image.Rectangle, clas
 		} else if meetsEarlyLockCriteria || (meetsDetectionCriteria && meetsConfidenceCriteria) {
 			readyToLockBoats++
 			boatStatusDetails = append(boatStatusDetails, fmt.Sprintf("%s:🎯READY", boat.ID))
-		} else if boat.LostFrames > 5 {
+		} else if si.lostLongerThan(boat, briefLossTime) {
 			lostBoats++
 			boatStatusDetails = append(boatStatusDetails, fmt.Sprintf("%s:❌LOST(%d)", boat.ID, boat.LostFrames))
 		} else {
 			buildingBoats++
-			needed := si.minDetectionsForLock - boat.DetectionCount
+			needed := si.lockDetections(boat.Classification) - boat.DetectionCount
 			boatStatusDetails = append(boatStatusDetails, fmt.Sprintf("%s:🔨BUILDING(need %d)", boat.ID, needed))
 		}
 	}
@@ -619,7 +870,7 @@ func (si *SpatialIntegration) logFrameSummary(detections []image.Rectangle, clas
 		si.logDebugMessage(fmt.Sprintf("ISSUE: Too many boats (%d)", totalBoats), "ISSUE", 1,
 			map[string]interface{}{"total_boats": totalBoats})
 	}
-	if si.targetBoat != nil && !si.targetBoat.IsLocked && si.targetBoat.DetectionCount >= si.minDetectionsForLock {
+	if si.targetBoat != nil && !si.targetBoat.IsLocked && si.meetsLockCriteria(si.targetBoat) {
 		si.debugMsg("FRAME_SUMMARY", "⚠️  ISSUE: Target boat meets criteria but not locked", si.targetBoat.ID)
 		si.logDebugMessage(fmt.Sprintf("ISSUE: Target %s meets criteria but not locked", si.targetBoat.ID), "ISSUE", 2,
 			map[string]interface{}{"target_boat": si.targetBoat.ID, "detections": si.targetBoat.DetectionCount, "confidence": si.targetBoat.Confidence})
@@ -633,11 +884,14 @@ func (si *SpatialIntegration) updateAllBoats(detections []image.Rectangle, class
 		boat.LostFrames++
 	}
 
+	// Open a new frame in the detection fusion window
+	si.detectionFusion.beginFrame()
+
 	// LOCK DEBUG: Show current lock status before processing
 	lockCandidates := 0
 	lockedBoats := 0
 	for _, boat := range si.allBoats {
-		if boat.DetectionCount >= si.minDetectionsForLock && boat.Confidence > si.p1MinConfidence {
+		if si.meetsLockCriteria(boat) {
 			lockCandidates++
 		}
 		if boat.IsLocked {
@@ -651,13 +905,19 @@ func (si *SpatialIntegration) updateAllBoats(detections []image.Rectangle, class
 			si.frameCount, len(si.allBoats), lockCandidates, si.minDetectionsForLock, lockedBoats))
 	}
 
-	// Process each detection
+	// Filter detections, then match them to existing boats
+	var candidates []associationCandidate
 	for i, detection := range detections {
 		className := classNames[i]
 		confidence := confidences[i]
 
-		// Filter by P1 tracking configuration
-		if !si.isP1Object(className) {
+		// Filter by P1 tracking configuration (the detection's class zone may have its own lists)
+		if p1, p2, _ := si.detectionRoles(detection, className); !p1 {
+			if p2 {
+				si.noteDetection(i, true, VerdictP2, "")
+			} else {
+				si.noteDetection(i, false, VerdictNotP1, "")
+			}
 			continue
 		}
 
@@ -672,34 +932,66 @@ func (si *SpatialIntegration) updateAllBoats(detections []image.Rectangle, class
 				i+1, centerX, centerY, detection.Dx(), detection.Dy(), area, confidence))
 		}
 
-		// Filter by minimum size
-		if area < 2000 {
-			si.debugMsg("MULTI_FILTER", fmt.Sprintf("❌ Rejecting detection #%d: area %.0f < 2000 pixels", i+1, area))
+		// Filter by minimum size (per class: distant kayaks are far smaller than ships)
+		minArea, minWidth, minHeight := si.minDetectionSize(className)
+		if area < minArea {
+			si.debugMsg("MULTI_FILTER", fmt.Sprintf("❌ Rejecting detection #%d: area %.0f < %.0f pixels", i+1, area, minArea))
+			si.noteDetection(i, false, VerdictMinArea, "")
 			continue
 		}
 
 		// Filter by pixel dimensions
-		if detection.Dx() <= 50 || detection.Dy() <= 50 {
-			si.debugMsg("MULTI_FILTER", fmt.Sprintf("❌ Rejecting detection #%d: dimensions %dx%d (≤50x50 pixels)",
-				i+1, detection.Dx(), detection.Dy()))
+		if detection.Dx() <= minWidth || detection.Dy() <= minHeight {
+			si.debugMsg("MULTI_FILTER", fmt.Sprintf("❌ Rejecting detection #%d: dimensions %dx%d (≤%dx%d pixels)",
+				i+1, detection.Dx(), detection.Dy(), minWidth, minHeight))
+			si.noteDetection(i, false, VerdictMinSize, "")
 			continue
 		}
 
 		si.debugMsg("DETECTION_DEBUG", fmt.Sprintf("✅ Detection #%d passed filters, looking for nearest boat...", i+1))
+		candidates = append(candidates, associationCandidate{
+			index:      i,
+			rect:       detection,
+			centerX:    centerX,
+			centerY:    centerY,
+			area:       area,
+			confidence: confidence,
+			className:  className,
+			edge:       si.edgeSides(detection),
+		})
+	}
+
+	// Joint assignment considers every detection/boat pair at once so boats passing close to each other keep
+	// their own detections; greedy matching lets each detection take its nearest boat in turn
+	var assigned []*TrackedBoat
+	if si.association.Method == AssociationHungarian {
+		assigned = si.associateDetections(candidates)
+	}
 
-		// Find nearest existing boat within matching distance
-		matchedBoat := si.findNearestBoat(detection, centerX, centerY)
+	for k, candidate := range candidates {
+		detection, centerX, centerY := candidate.rect, candidate.centerX, candidate.centerY
+		area, confidence, className := candidate.area, candidate.confidence, candidate.className
+
+		var matchedBoat *TrackedBoat
+		if assigned != nil {
+			matchedBoat = assigned[k]
+		} else {
+			// Find nearest existing boat within matching distance
+			matchedBoat = si.findNearestBoat(detection, centerX, centerY)
+		}
 
 		if matchedBoat != nil {
 			// Update existing boat
 			oldDetectionCount := matchedBoat.DetectionCount
 			oldLocked := matchedBoat.IsLocked
 
+			centerX, centerY, area = si.observeEdge(matchedBoat, detection, centerX, centerY, area)
 			si.updateExistingBoat(matchedBoat, centerX, centerY, area, confidence, className)
+			si.noteDetection(candidate.index, true, VerdictMatched, matchedBoat.ID)
 
 			// LOCK PROGRESSION DEBUG
-			newLocked := matchedBoat.IsLocked || (matchedBoat.DetectionCount >= si.minDetectionsForLock && matchedBoat.Confidence > 0.30)
-			lockProgress := fmt.Sprintf("(%d/%d detections needed)", matchedBoat.DetectionCount, si.minDetectionsForLock)
+			newLocked := matchedBoat.IsLocked || si.meetsLockCriteria(matchedBoat)
+			lockProgress := fmt.Sprintf("(%d/%d detections needed)", matchedBoat.DetectionCount, si.lockDetections(matchedBoat.Classification))
 			if newLocked && !oldLocked {
 				lockProgress = "🔒 JUST LOCKED!"
 			} else if matchedBoat.IsLocked {
@@ -709,22 +1001,35 @@ func (si *SpatialIntegration) updateAllBoats(detections []image.Rectangle, class
 			si.debugMsg("MULTI_UPDATE", fmt.Sprintf("✅ Updated boat at (%d,%d), detections: %d→%d %s, lost: %d",
 				centerX, centerY, oldDetectionCount, matchedBoat.DetectionCount, lockProgress, matchedBoat.LostFrames), matchedBoat.ID)
 		} else {
-			// Create new boat
-			newBoat := si.createNewTrackedObject(centerX, centerY, area, confidence, className)
+			// Create new boat once the detection is confirmed across frames
+			newBoat, reason := si.fuseUnmatchedDetection(detection, centerX, centerY, area, confidence, className)
+			if newBoat == nil {
+				si.noteDetection(candidate.index, false, reason, "")
+				continue
+			}
+			si.observeEdge(newBoat, detection, centerX, centerY, area)
+			if si.rebindRecoveredBoat(newBoat) {
+				si.allBoats[newBoat.ID] = newBoat
+				si.noteDetection(candidate.index, true, VerdictMatched, newBoat.ID)
+				continue
+			}
 			si.allBoats[newBoat.ID] = newBoat
-			si.debugMsg("MULTI_NEW", fmt.Sprintf("🆕 Created new boat at (%d,%d), total boats: %d, detections: 1/%d needed for lock",
-				centerX, centerY, len(si.allBoats), si.minDetectionsForLock), newBoat.ID)
+			si.noteDetection(candidate.index, true, VerdictNewTrack, newBoat.ID)
+			si.debugMsg("MULTI_NEW", fmt.Sprintf("🆕 Created new boat at (%d,%d), total boats: %d, detections: %d/%d needed for lock",
+				newBoat.CurrentPixel.X, newBoat.CurrentPixel.Y, len(si.allBoats), newBoat.DetectionCount, si.lockDetections(newBoat.Classification)), newBoat.ID)
 		}
 	}
 }
 
-// detectP2ObjectsInP1Targets scans for P2 (enhancement) objects inside P1 (primary) target bounding boxes for enhanced tracking
+// detectP2ObjectsInP1Targets scans for P2 (enhancement) objects inside P1 (primary) target bounding boxes for enhanced tracking.
+// P2 objects inside no vessel are orphans, evaluated by person-overboard mode.
 func (si *SpatialIntegration) detectP2ObjectsInP1Targets(detections []image.Rectangle, classNames []string, confidences []float64) {
 	// First, reset P2 object detection for all P1 targets
 	for _, boat := range si.allBoats {
 		boat.HasP2Objects = false
 		boat.P2Count = 0
 		boat.P2Confidence = 0.0
+		boat.p2ConfidenceSum = 0
 		boat.P2Positions = nil // Clear previous P2 object positions
 		boat.P2Centroid = image.Point{}
 		boat.P2Bounds = image.Rectangle{}
@@ -734,12 +1039,13 @@ func (si *SpatialIntegration) detectP2ObjectsInP1Targets(detections []image.Rect
 	}
 
 	// Process all person detections
+	var orphans []orphanP2
 	for i, detection := range detections {
 		className := classNames[i]
 		confidence := confidences[i]
 
-		// Only process P2 (enhancement) object detections
-		if !si.isP2Object(className) {
+		// Only process P2 (enhancement) object detections (the detection's class zone may have its own lists)
+		if _, p2, _ := si.detectionRoles(detection, className); !p2 {
 			continue
 		}
 
@@ -755,8 +1061,12 @@ func (si *SpatialIntegration) detectP2ObjectsInP1Targets(detections []image.Rect
 		var candidateBoats []*TrackedBoat
 		var candidateDistances []float64
 
-		// Search active boats
+		// Search active boats (a person overboard's own track is not a vessel)
+		inVessel := false
 		for _, boat := range si.allBoats {
+			if boat.PersonOverboard {
+				continue
+			}
 			if boat.BoundingBox.Min.X <= personCenter.X && personCenter.X <= boat.BoundingBox.Max.X &&
 				boat.BoundingBox.Min.Y <= personCenter.Y && personCenter.Y <= boat.BoundingBox.Max.Y {
 
@@ -767,9 +1077,15 @@ func (si *SpatialIntegration) detectP2ObjectsInP1Targets(detections []image.Rect
 
 				candidateBoats = append(candidateBoats, boat)
 				candidateDistances = append(candidateDistances, distance)
+				inVessel = inVessel || isVessel(boat)
 			}
 		}
 
+		// ORPHAN P2: Inside no vessel detected this frame - possibly a person in the water
+		if !inVessel {
+			orphans = append(orphans, orphanP2{rect: detection, center: personCenter, confidence: confidence})
+		}
+
 		// Assign person to closest boat (prevents PIP oscillation between nearby boats)
 		if len(candidateBoats) > 0 {
 			closestIndex := 0
@@ -784,6 +1100,13 @@ func (si *SpatialIntegration) detectP2ObjectsInP1Targets(detections []image.Rect
 
 			closestBoat := candidateBoats[closestIndex]
 
+			// ADAPTIVE P2: People on small boats are detected at lower confidence than on large ones
+			if effectiveConfidence := si.effectiveP2Confidence(closestBoat); confidence < effectiveConfidence {
+				si.debugMsgVerbose("ADAPTIVE_P2", fmt.Sprintf("👤❌ Person (conf: %.2f) below adaptive threshold %.2f for %dx%d boat",
+					confidence, effectiveConfidence, closestBoat.BoundingBox.Dx(), closestBoat.BoundingBox.Dy()), closestBoat.ID)
+				continue
+			}
+
 			// Debug message for multiple candidates (oscillation prevention)
 			if len(candidateBoats) > 1 {
 				var otherBoats []string
@@ -800,7 +1123,9 @@ func (si *SpatialIntegration) detectP2ObjectsInP1Targets(detections []image.Rect
 			closestBoat.HasP2Objects = true
 			closestBoat.P2Count++
 			closestBoat.P2Confidence = math.Max(closestBoat.P2Confidence, confidence)
+			closestBoat.p2ConfidenceSum += confidence
 			closestBoat.LastP2Seen = time.Now()
+			si.recordCalibrationSample(closestBoat, confidence, true)
 
 			// Store individual person position for enhanced tracking
 			closestBoat.P2Positions = append(closestBoat.P2Positions, personCenter)
@@ -837,6 +1162,7 @@ func (si *SpatialIntegration) detectP2ObjectsInP1Targets(detections []image.Rect
 
 			si.debugMsg("BOAT_WITH_PERSON", fmt.Sprintf("🎯 Boat has %d people (best conf: %.2f, quality: %.2f) - PRIORITY TARGET!",
 				boat.P2Count, boat.P2Confidence, boat.P2Quality), boat.ID)
+			si.reportPeopleOnBoard(boat)
 
 			// Determine if this boat should use P2-centric targeting
 			boat.UseP2Target = si.shouldUseP2Targeting(boat)
@@ -847,6 +1173,9 @@ func (si *SpatialIntegration) detectP2ObjectsInP1Targets(detections []image.Rect
 			}
 		}
 	}
+
+	// Person-overboard: orphans with no vessel near them
+	si.updateOverboardCandidates(orphans)
 }
 
 // calculateP2TrackingData computes enhanced tracking data for P2 objects in P1 targets
@@ -1013,17 +1342,16 @@ func (si *SpatialIntegration) calculateSpatialCoordinatesForPixel(pixelX, pixelY
 		Zoom: actualPos.Zoom,
 	}
 
-	// Calculate pixel offset from frame center
-	offsetX := pixelX - si.frameCenterX // Positive = target is right of center
-	offsetY := pixelY - si.frameCenterY // Positive = target is below center
+	// Calculate pixel offset from frame center (undistorted when a lens model is loaded)
+	offsetX, offsetY := si.undistortedOffset(pixelX, pixelY, currentSpatial.Zoom) // Positive = right of / below center
 
 	// Get calibrated conversion rates for current zoom level
 	panPixelsPerUnit := si.spatialTracker.InterpolatePanCalibration(currentSpatial.Zoom)
 	tiltPixelsPerUnit := si.spatialTracker.InterpolateTiltCalibration(currentSpatial.Zoom)
 
 	// Calculate PTZ adjustments needed to center the target
-	panAdjustment := float64(offsetX) / panPixelsPerUnit
-	tiltAdjustment := float64(offsetY) / tiltPixelsPerUnit
+	panAdjustment := offsetX / panPixelsPerUnit
+	tiltAdjustment := offsetY / tiltPixelsPerUnit
 
 	// Calculate target spatial coordinate
 	target := SpatialCoordinate{
@@ -1118,10 +1446,11 @@ func (si *SpatialIntegration) feedLockedBoatsWithClusterDetections(detections []
 	}
 }
 
-// findNearestBoat finds the closest existing boat to a detection using actual YOLO bounding box
-func (si *SpatialIntegration) findNearestBoat(detectionRect image.Rectangle, centerX, centerY int) *TrackedBoat {
+// matchingDistance returns the distance within which a detection may match an existing boat (before the
+// locked-boat and prediction allowances), along with the unclamped distance and camera-motion factor it came from
+func (si *SpatialIntegration) matchingDistance() (finalDistance, baseDistance, cameraMovingBonus float64) {
 	// SIMPLIFIED MATCHING DISTANCE - no more zoom scaling complexity
-	baseDistance := 200.0 // Base distance for fallback distance matching
+	baseDistance = 200.0 // Base distance for fallback distance matching
 
 	// ADAPTIVE DISTANCE: Increase matching distance after recent history clearing to maintain boat continuity
 	if !si.lastHistoryClear.IsZero() && time.Since(si.lastHistoryClear) < 10*time.Second {
@@ -1131,7 +1460,7 @@ func (si *SpatialIntegration) findNearestBoat(detectionRect image.Rectangle, cen
 	}
 
 	// Additional allowance if camera was recently moving (tracking artifacts)
-	cameraMovingBonus := 1.0
+	cameraMovingBonus = 1.0
 	if si.cameraStateManager != nil {
 		if !si.cameraStateManager.IsIdle() {
 			cameraMovingBonus = 1.5 // 50% more tolerance when camera is moving
@@ -1140,7 +1469,19 @@ func (si *SpatialIntegration) findNearestBoat(detectionRect image.Rectangle, cen
 	}
 
 	// Cap at reasonable limits for distance fallback
-	finalDistance := math.Max(100.0, math.Min(800.0, baseDistance)) // 100px min, 800px max
+	finalDistance = math.Max(100.0, math.Min(800.0, baseDistance)) // 100px min, 800px max
+	return finalDistance, baseDistance, cameraMovingBonus
+}
+
+// findNearestBoat finds the closest existing boat to a detection using actual YOLO bounding box
+func (si *SpatialIntegration) findNearestBoat(detectionRect image.Rectangle, centerX, centerY int) *TrackedBoat {
+	finalDistance, baseDistance, cameraMovingBonus := si.matchingDistance()
+
+	// A detection cut off by the frame has its center pulled inward: match it with more tolerance
+	edgeClipped := si.edgeSides(detectionRect) != 0
+	if edgeClipped {
+		finalDistance *= edgeGateFactor
+	}
 
 	// REDUCED CONSOLE SPAM: Only show matching details occasionally (full details in debug session files)
 	showMatchingDebug := (centerX+centerY)%500 < 50 // Show ~10% of matching attempts
@@ -1178,9 +1519,9 @@ func (si *SpatialIntegration) findNearestBoat(detectionRect image.Rectangle, cen
 
 		// STRATEGY 2: Predicted Position Matching (for moving boats)
 		if boat.PixelVelocity.X != 0 || boat.PixelVelocity.Y != 0 {
-			// Calculate 30-60 frame prediction (1-2 seconds at 30fps)
-			predictionTime1s := 1.0 // 30 frames ahead
-			predictionTime2s := 2.0 // 60 frames ahead
+			// Predict 1 and 2 seconds ahead (velocity is in pixels per second)
+			predictionTime1s := 1.0
+			predictionTime2s := 2.0
 
 			predicted30 := image.Point{
 				X: boat.CurrentPixel.X + int(boat.PixelVelocity.X*predictionTime1s),
@@ -1228,6 +1569,9 @@ func (si *SpatialIntegration) findNearestBoat(detectionRect image.Rectangle, cen
 					boat.ID, finalDistance, adjustedDistance))
 			}
 		}
+		if boat.EdgeClipped != 0 && !edgeClipped {
+			adjustedDistance *= edgeGateFactor // Boat coming fully into frame: its last center was pulled inward
+		}
 
 		if distance < adjustedDistance && (bestMatchBoat == nil || distance < minDistance) {
 			bestMatchBoat = boat
@@ -1249,7 +1593,7 @@ func (si *SpatialIntegration) findNearestBoat(detectionRect image.Rectangle, cen
 		for _, boat := range si.allBoats {
 			distance := allDistances[boat.ID]
 			status := "❌ too far"
-			lockStatus := fmt.Sprintf("(%d/%d det)", boat.DetectionCount, si.minDetectionsForLock)
+			lockStatus := fmt.Sprintf("(%d/%d det)", boat.DetectionCount, si.lockDetections(boat.Classification))
 			if boat.IsLocked {
 				lockStatus = "🔒 LOCKED"
 			}
@@ -1275,7 +1619,7 @@ func (si *SpatialIntegration) findNearestBoat(detectionRect image.Rectangle, cen
 
 	// Enhanced result logging
 	if nearestBoat != nil {
-		lockStatus := fmt.Sprintf("(%d/%d detections)", nearestBoat.DetectionCount, si.minDetectionsForLock)
+		lockStatus := fmt.Sprintf("(%d/%d detections)", nearestBoat.DetectionCount, si.lockDetections(nearestBoat.Classification))
 		if nearestBoat.IsLocked {
 			lockStatus = "🔒 LOCKED"
 		}
@@ -1300,14 +1644,17 @@ func (si *SpatialIntegration) updateExistingBoat(boat *TrackedBoat, centerX, cen
 	oldDetectionCount := boat.DetectionCount
 	oldConfidence := boat.Confidence
 
+	si.observeTrackQuality(boat, image.Point{X: centerX, Y: centerY}, area, className)
+
 	// Reset lost frames (boat was found)
 	boat.LostFrames = 0
 	boat.LastSeen = time.Now()
 	boat.DetectionCount++
 	boat.Confidence = math.Max(boat.Confidence, confidence)
+	si.recordCalibrationSample(boat, confidence, false)
 
 	// CLEAN SLATE TRANSITION: Reset contaminated early detection data when reaching lock threshold
-	justReachedLock := boat.DetectionCount == si.minDetectionsForLock && oldDetectionCount == si.minDetectionsForLock-1
+	justReachedLock := boat.DetectionCount == si.lockDetections(boat.Classification) && oldDetectionCount == si.lockDetections(boat.Classification)-1
 	if justReachedLock {
 		si.debugMsg("CLEAN_SLATE", fmt.Sprintf("🧹 Boat %s reached lock threshold (%d detections) - resetting to fresh coordinates (%d,%d)",
 			boat.ID, boat.DetectionCount, centerX, centerY), boat.ID)
@@ -1384,9 +1731,8 @@ func (si *SpatialIntegration) updateExistingBoat(boat *TrackedBoat, centerX, cen
 	}
 
 	// ENHANCED LOCK PROGRESSION DEBUG
-	meetsDetectionCriteria := boat.DetectionCount >= si.minDetectionsForLock
-	meetsConfidenceCriteria := boat.Confidence > 0.30
-	meetsEarlyLockCriteria := boat.Confidence >= 0.80 && boat.DetectionCount >= 1
+	meetsDetectionCriteria, meetsConfidenceCriteria := si.lockCriteria(boat)
+	meetsEarlyLockCriteria := meetsEarlyLockCriteria(boat)
 
 	lockStatus := "🔓 NOT LOCKED"
 	if boat.IsLocked {
@@ -1399,7 +1745,7 @@ func (si *SpatialIntegration) updateExistingBoat(boat *TrackedBoat, centerX, cen
 		// Show what's blocking the lock
 		blockers := []string{}
 		if !meetsDetectionCriteria {
-			needed := si.minDetectionsForLock - boat.DetectionCount
+			needed := si.lockDetections(boat.Classification) - boat.DetectionCount
 			blockers = append(blockers, fmt.Sprintf("need %d more detections", needed))
 		}
 		if !meetsConfidenceCriteria {
@@ -1409,7 +1755,7 @@ func (si *SpatialIntegration) updateExistingBoat(boat *TrackedBoat, centerX, cen
 	}
 
 	// Show detailed progression for boats close to locking
-	if boat.DetectionCount >= si.minDetectionsForLock-2 || boat.Confidence > 0.25 {
+	if boat.DetectionCount >= si.lockDetections(boat.Classification)-2 || boat.Confidence > 0.25 {
 		confidenceChange := ""
 		if boat.Confidence > oldConfidence {
 			confidenceChange = fmt.Sprintf(" (↑%.3f)", boat.Confidence-oldConfidence)
@@ -1472,6 +1818,7 @@ func (si *SpatialIntegration) updateExistingBoat(boat *TrackedBoat, centerX, cen
 	if len(boat.PixelHistory) > 20 {
 		boat.PixelHistory = boat.PixelHistory[1:]
 	}
+	si.recordCameraPosition(boat)
 
 	// Calculate velocity (always needed for targeting decisions)
 	si.calculateBoatVelocity(boat)
@@ -1495,12 +1842,6 @@ func (si *SpatialIntegration) calculateBoatVelocity(boat *TrackedBoat) {
 		return
 	}
 
-	// SIMPLE APPROACH: Only calculate velocity when camera is IDLE
-	if si.cameraStateManager != nil && !si.cameraStateManager.IsIdle() {
-		si.debugMsg("VELOCITY_CALC", "⏸️ Skipping velocity calculation - camera is MOVING")
-		return
-	}
-
 	// Use last few points for velocity calculation
 	historyCount := int(math.Min(5, float64(len(boat.PixelHistory))))
 	if historyCount < 2 {
@@ -1509,9 +1850,8 @@ func (si *SpatialIntegration) calculateBoatVelocity(boat *TrackedBoat) {
 
 	points := boat.PixelHistory[len(boat.PixelHistory)-historyCount:]
 
-	// Calculate time difference between actual history points
-	// Assume roughly 30fps for timing (33ms per frame)
-	frameTimeDiff := float64(historyCount-1) / 30.0 // Time span of history points in seconds
+	// Calculate time difference between actual history points at the measured update rate
+	frameTimeDiff := si.frameSeconds(historyCount - 1) // Time span of history points in seconds
 
 	if frameTimeDiff <= 0 {
 		return
@@ -1525,9 +1865,32 @@ func (si *SpatialIntegration) calculateBoatVelocity(boat *TrackedBoat) {
 	movementX := float64(endPoint.X - startPoint.X)
 	movementY := float64(endPoint.Y - startPoint.Y)
 
-	// Calculate velocity directly - NO COMPENSATION
-	boat.PixelVelocity.X = movementX / frameTimeDiff
-	boat.PixelVelocity.Y = movementY / frameTimeDiff
+	// EGO-MOTION: Remove the image shift caused by the camera's own pan/tilt over the same points. Without
+	// compensation only an IDLE camera gives a usable estimate.
+	cameraMoving := si.cameraStateManager != nil && !si.cameraStateManager.IsIdle()
+	if si.egoMotionEnabled {
+		shiftX, shiftY, ok := si.egoMotionShift(boat, historyCount)
+		if !ok && cameraMoving {
+			si.debugMsg("VELOCITY_CALC", "⏸️ Skipping velocity calculation - camera is MOVING without usable ego-motion data")
+			return
+		}
+		movementX -= shiftX
+		movementY -= shiftY
+	} else if cameraMoving {
+		si.debugMsg("VELOCITY_CALC", "⏸️ Skipping velocity calculation - camera is MOVING")
+		return
+	}
+
+	// Calculate velocity directly
+	velocityX := movementX / frameTimeDiff
+	velocityY := movementY / frameTimeDiff
+
+	// SANITY BOUNDS: A barge can't do 200 px/s - reject absurd estimates (usually ID swaps) before they feed prediction
+	if !si.plausibleVelocity(boat, velocityX, velocityY) {
+		return
+	}
+	boat.PixelVelocity.X = velocityX
+	boat.PixelVelocity.Y = velocityY
 
 	si.debugMsg("VELOCITY_CALC", fmt.Sprintf("✅ Boat %s SIMPLE velocity: (%.1f, %.1f) px/s over %.1fs (%d points)",
 		boat.ID, boat.PixelVelocity.X, boat.PixelVelocity.Y, frameTimeDiff, historyCount), boat.ID)
@@ -1550,10 +1913,11 @@ func (si *SpatialIntegration) updateBoatSpatialPosition(boat *TrackedBoat) {
 	si.debugMsg("SPATIAL_CALC", fmt.Sprintf("🎯 Using ACTUAL camera position: Pan=%.1f Tilt=%.1f Zoom=%.1f (not cached)",
 		currentSpatial.Pan, currentSpatial.Tilt, currentSpatial.Zoom))
 
-	// SIMPLE APPROACH: Always use current boat position (like July 6th version)
-	targetPixelX := boat.CurrentPixel.X
-	targetPixelY := boat.CurrentPixel.Y
+	// SIMPLE APPROACH: Always use current boat position (like July 6th version), or the frame border it is cut
+	// off by when its full size is unknown
+	targetPixelX, targetPixelY := si.edgeAimPoint(boat)
 	targetZoom := si.calculateOptimalZoom(boat, currentSpatial.Zoom) // Re-enable progressive zoom calculations
+	targetZoom = si.holdZoomAtEdge(boat, currentSpatial.Zoom, targetZoom)
 
 	// Calculate pixel offset from frame center (using predicted or current position)
 	offsetX := targetPixelX - si.frameCenterX // Positive = boat is right of center
@@ -1571,22 +1935,23 @@ func (si *SpatialIntegration) updateBoatSpatialPosition(boat *TrackedBoat) {
 	if panPixelsPerUnit <= 0 || tiltPixelsPerUnit <= 0 {
 		si.debugMsg("SPATIAL_BUG", fmt.Sprintf("🚨 INVALID CALIBRATION: pan=%.6f, tilt=%.6f (should be positive!)",
 			panPixelsPerUnit, tiltPixelsPerUnit))
-		// Use safe fallback values
-		panPixelsPerUnit = 15.0
-		tiltPixelsPerUnit = 12.0
-		si.debugMsg("SPATIAL_BUG", fmt.Sprintf("🛡️ Using fallback calibration: pan=%.1f, tilt=%.1f",
+		// Use the built-in calibration for this zoom level
+		panPixelsPerUnit, tiltPixelsPerUnit = calibration.DefaultTable().InterpolateAt(currentSpatial.Zoom)
+		si.debugMsg("SPATIAL_BUG", fmt.Sprintf("🛡️ Using built-in calibration: pan=%.2f, tilt=%.2f",
 			panPixelsPerUnit, tiltPixelsPerUnit))
 	}
 
-	// Calculate PTZ adjustments needed to center the boat (at predicted position)
-	panAdjustment := float64(offsetX) / panPixelsPerUnit
-	tiltAdjustment := float64(offsetY) / tiltPixelsPerUnit
+	// Calculate PTZ adjustments needed to center the boat (at predicted position), undistorting the offset
+	// when a lens model is loaded
+	correctedX, correctedY := si.undistortedOffset(targetPixelX, targetPixelY, currentSpatial.Zoom)
+	panAdjustment := correctedX / panPixelsPerUnit
+	tiltAdjustment := correctedY / tiltPixelsPerUnit
 
 	// Log detailed spatial calculation steps (calculation will be completed below)
 	si.logSpatialCalculationInProgress(boat, targetPixelX, targetPixelY, offsetX, offsetY,
 		currentSpatial, panPixelsPerUnit, tiltPixelsPerUnit, panAdjustment, tiltAdjustment)
 
-	si.debugMsgVerbose("CALIBRATION", fmt.Sprintf("Zoom=%.1f: Using %.2f px/pan-unit, %.2f px/tilt-unit (was hardcoded 15.0, 12.0)",
+	si.debugMsgVerbose("CALIBRATION", fmt.Sprintf("Zoom=%.1f: Using %.2f px/pan-unit, %.2f px/tilt-unit",
 		currentSpatial.Zoom, panPixelsPerUnit, tiltPixelsPerUnit))
 
 	// SAFETY CHECK: Prevent massive camera jumps due to calculation bugs
@@ -1638,23 +2003,24 @@ func (si *SpatialIntegration) updateBoatSpatialPosition(boat *TrackedBoat) {
 	// Simple console logging for final result
 	si.debugMsg("SPATIAL_CALC", fmt.Sprintf("Final → Pan:%.1f, Tilt:%.1f", targetPan, targetTilt), boat.ID)
 
-	// FINAL SAFETY CHECK: Ensure target coordinates are within reasonable PTZ limits
+	// FINAL SAFETY CHECK: Ensure target coordinates are within the camera's hardware range
 	// Prevent movements to completely invalid positions that could damage hardware
-	if targetPan < 0 || targetPan > 3600 {
-		si.debugMsg("SPATIAL_SAFETY", fmt.Sprintf("🚨 TARGET PAN OUT OF BOUNDS: %.1f (should be 0-3600) - CLAMPING", targetPan))
-		targetPan = math.Max(0, math.Min(3600, targetPan))
+	limits := si.hardwareLimits()
+	if targetPan < limits.HardMinPan || targetPan > limits.HardMaxPan {
+		si.debugMsg("SPATIAL_SAFETY", fmt.Sprintf("🚨 TARGET PAN OUT OF BOUNDS: %.1f (should be %.0f-%.0f) - CLAMPING", targetPan, limits.HardMinPan, limits.HardMaxPan))
+		targetPan = math.Max(limits.HardMinPan, math.Min(limits.HardMaxPan, targetPan))
 		si.debugMsg("SPATIAL_SAFETY", fmt.Sprintf("🛡️ Clamped target pan to: %.1f", targetPan))
 	}
 
-	if targetTilt < 0 || targetTilt > 900 {
-		si.debugMsg("SPATIAL_SAFETY", fmt.Sprintf("🚨 TARGET TILT OUT OF BOUNDS: %.1f (should be 0-900) - CLAMPING", targetTilt))
-		targetTilt = math.Max(0, math.Min(900, targetTilt))
+	if targetTilt < limits.HardMinTilt || targetTilt > limits.HardMaxTilt {
+		si.debugMsg("SPATIAL_SAFETY", fmt.Sprintf("🚨 TARGET TILT OUT OF BOUNDS: %.1f (should be %.0f-%.0f) - CLAMPING", targetTilt, limits.HardMinTilt, limits.HardMaxTilt))
+		targetTilt = math.Max(limits.HardMinTilt, math.Min(limits.HardMaxTilt, targetTilt))
 		si.debugMsg("SPATIAL_SAFETY", fmt.Sprintf("🛡️ Clamped target tilt to: %.1f", targetTilt))
 	}
 
-	if targetZoom < 10 || targetZoom > 120 {
-		si.debugMsg("SPATIAL_SAFETY", fmt.Sprintf("🚨 TARGET ZOOM OUT OF BOUNDS: %.1f (should be 10-120) - CLAMPING", targetZoom))
-		targetZoom = math.Max(10, math.Min(120, targetZoom))
+	if targetZoom < limits.HardMinZoom || targetZoom > limits.HardMaxZoom {
+		si.debugMsg("SPATIAL_SAFETY", fmt.Sprintf("🚨 TARGET ZOOM OUT OF BOUNDS: %.1f (should be %.0f-%.0f) - CLAMPING", targetZoom, limits.HardMinZoom, limits.HardMaxZoom))
+		targetZoom = math.Max(limits.HardMinZoom, math.Min(limits.HardMaxZoom, targetZoom))
 		si.debugMsg("SPATIAL_SAFETY", fmt.Sprintf("🛡️ Clamped target zoom to: %.1f", targetZoom))
 	}
 
@@ -1671,11 +2037,23 @@ func (si *SpatialIntegration) updateBoatSpatialPosition(boat *TrackedBoat) {
 		currentSpatial.Pan, currentSpatial.Tilt, currentSpatial.Zoom, targetPan, targetTilt, targetZoom), boat.ID)
 }
 
+// hardwareLimits is the camera's physical position range: discovered from the camera at startup when it
+// reports one, the built-in Hikvision range otherwise
+func (si *SpatialIntegration) hardwareLimits() ptz.PTZLimits {
+	if si.cameraStateManager == nil {
+		return ptz.DefaultLimits()
+	}
+	return si.cameraStateManager.GetLimits()
+}
+
 // calculateOptimalZoom determines the best zoom level for tracking a boat using PROGRESSIVE ZOOM
 func (si *SpatialIntegration) calculateOptimalZoom(boat *TrackedBoat, currentZoom float64) float64 {
-	// Zoom constraints
-	const minZoom = 10.0  // Minimum zoom level
-	const maxZoom = 120.0 // Maximum zoom level
+	if boat.PersonOverboard {
+		return si.overboardZoom(boat, currentZoom)
+	}
+
+	// Zoom constraints (the class may narrow them)
+	minZoom, maxZoom := si.zoomRange(boat.Classification)
 
 	// === PROGRESSIVE ZOOM SYSTEM ===
 	// Start conservative, increase gradually as tracking becomes more stable
@@ -1822,7 +2200,7 @@ func (si *SpatialIntegration) calculateOptimalZoom(boat *TrackedBoat, currentZoo
 		maxZoomChange = 5.0 // Very conservative changes for new boats
 	} else if boat.DetectionCount <= 6 {
 		maxZoomChange = 5.0 // Reduced from 15.0 - more conservative for building boats
-	} else if boat.DetectionCount >= 24 && boat.IsLocked { // SUPER LOCK: 24+ frames for MEGA ZOOM changes
+	} else if isSuperLocked(boat) { // SUPER LOCK: 24+ frames for MEGA ZOOM changes
 		maxZoomChange = 25.0 // MEGA ZOOM changes for SUPER LOCK boats (unchanged)
 	} else if boat.IsLocked {
 		maxZoomChange = 15.0 // Reduced from 25.0 - less aggressive for locked boats
@@ -1924,68 +2302,41 @@ func (si *SpatialIntegration) getZoomStage(detectionCount int, isLocked bool) st
 		return "INITIAL"
 	} else if detectionCount <= 6 {
 		return "BUILDING"
-	} else if detectionCount >= 24 && isLocked {
+	} else if detectionCount >= superLockDetections && isLocked {
 		return "SUPER_LOCK_MEGA_ZOOM"
 	} else if isLocked {
 		return "LOCKED"
-	} else if detectionCount >= 24 {
+	} else if detectionCount >= superLockDetections {
 		return "SUPER_LOCK_UNLOCKED"
 	} else {
 		return "UNLOCKED"
 	}
 }
 
-// generateNewObjectID creates a unified object ID in format: 20240125-12-30.001
+// generateNewObjectID creates a unified object ID, by default in format: 20240125-13-30.001
 func (si *SpatialIntegration) generateNewObjectID() string {
-	now := time.Now()
-
-	// Format: 20240125-12-30 (12-hour format)
-	currentMinute := now.Format("20060102-3-04") // 3 = 12-hour, 04 = minute
-
-	// Reset counter if new minute
-	if currentMinute != si.lastMinuteTimestamp {
-		si.lastMinuteTimestamp = currentMinute
-		si.currentMinuteCounter = 0
+	if si.objectIDs == nil {
+		si.objectIDs, _ = NewObjectIDGenerator(ObjectIDConfig{})
 	}
-
-	// Increment counters
-	si.currentMinuteCounter++
 	si.totalDetectedObjectsCounter++
 
-	// Return padded format: 20240125-12-30.001
-	return fmt.Sprintf("%s.%03d", currentMinute, si.currentMinuteCounter)
+	objectID, err := si.objectIDs.Next(time.Now())
+	if err != nil {
+		si.debugMsg("OBJECT_ID", fmt.Sprintf("⚠️ %v - IDs may repeat after a restart", err), objectID)
+	}
+	return objectID
 }
 
 // createNewTrackedObject creates a new tracked object (renamed from createNewBoat)
-func (si *SpatialIntegration) createNewTrackedObject(centerX, centerY int, area, confidence float64, className string) *TrackedBoat {
+func (si *SpatialIntegration) createNewTrackedObject(rect image.Rectangle, centerX, centerY int, area, confidence float64, className string) *TrackedBoat {
 	now := time.Now()
-	objectID := si.generateNewObjectID() // Use unified ID format: 20240125-12-30.001
+	objectID := si.generateNewObjectID() // Use unified ID format: 20240125-13-30.001
 
-	// Calculate P2 detection bounding box using MILITARY TARGET DIMENSIONS
-	// Match the military target overlay area for visual consistency and precision
-	baseWidth := int(math.Sqrt(area) * 0.8)  // Wider base estimation
-	baseHeight := int(math.Sqrt(area) * 0.6) // Taller base estimation
-
-	// Use military target expansion (20%) to match overlay dimensions exactly
-	bufferX := int(float64(baseWidth) * 0.2)  // 20% buffer horizontally (matches military target)
-	bufferY := int(float64(baseHeight) * 0.2) // 20% buffer vertically (matches military target)
-
-	expandedWidth := baseWidth + bufferX
-	expandedHeight := baseHeight + bufferY
-
-	// Apply bounds checking to keep bounding box within frame
-	minX := int(math.Max(0, float64(centerX-expandedWidth)))
-	minY := int(math.Max(0, float64(centerY-expandedHeight)))
-	maxX := int(math.Min(float64(si.frameWidth), float64(centerX+expandedWidth)))
-	maxY := int(math.Min(float64(si.frameHeight), float64(centerY+expandedHeight)))
-
-	boundingBox := image.Rectangle{
-		Min: image.Point{X: minX, Y: minY},
-		Max: image.Point{X: maxX, Y: maxY},
-	}
+	// The first box is the detection itself; the military target estimate takes over from the next detection
+	boundingBox := rect.Intersect(image.Rect(0, 0, si.frameWidth, si.frameHeight))
 
-	si.debugMsg("NEW_OBJECT_MILITARY", fmt.Sprintf("Object %s: base %dx%d + military target buffer %dx%d = P2 area %dx%d (matches overlay)",
-		objectID, baseWidth, baseHeight, bufferX, bufferY, expandedWidth, expandedHeight), objectID)
+	si.debugMsg("NEW_OBJECT", fmt.Sprintf("Object %s: detection box %dx%d at (%d,%d)",
+		objectID, boundingBox.Dx(), boundingBox.Dy(), boundingBox.Min.X, boundingBox.Min.Y), objectID)
 
 	boat := &TrackedBoat{
 		ID:               objectID,
@@ -2004,6 +2355,8 @@ func (si *SpatialIntegration) createNewTrackedObject(centerX, centerY int, area,
 		P2Confidence:     0.0,
 		P2Count:          0,
 	}
+	si.recordCalibrationSample(boat, confidence, false)
+	si.observeTrackQuality(boat, image.Point{X: centerX, Y: centerY}, area, className)
 
 	// Calculate initial spatial position if camera is IDLE
 	if si.cameraStateManager == nil || si.cameraStateManager.IsIdle() {
@@ -2186,6 +2539,21 @@ func (si *SpatialIntegration) createOrUpdateVirtualBoat(ghostBox *GhostP1Boundin
 
 // selectTargetBoat chooses which boat to actively track with the camera
 func (si *SpatialIntegration) selectTargetBoat() {
+	// Person overboard always wins over boats, cooldowns and recovery
+	if si.selectOverboardTarget() {
+		return
+	}
+
+	// An operator-pinned target overrides automatic selection while it is tracked
+	if si.selectPinnedTarget() {
+		return
+	}
+
+	// Tour mode takes turns between several lock-eligible boats
+	if si.selectTourTarget() {
+		return
+	}
+
 	// If we have a current target that's still valid, check if we should keep it
 	if si.targetBoat != nil {
 		// CRITICAL FIX: Only consider a boat truly "lost" if it's not being detected at all
@@ -2196,14 +2564,14 @@ func (si *SpatialIntegration) selectTargetBoat() {
 		}
 
 		// REDUCED tolerance for locked boats - but allow some frames for detection gaps
-		// Keep current target if it's locked and recently seen (allow up to 10 frames gap)
-		if si.targetBoat.IsLocked && si.targetBoat.LostFrames <= 10 {
-			return // Keep current target (400ms tolerance at 25fps)
+		// Keep current target if it's locked and recently seen (allow up to a third of a second gap)
+		if si.targetBoat.IsLocked && !si.lostLongerThan(si.targetBoat, gapToleranceTime) {
+			return // Keep current target
 		}
 
 		// REDUCED tolerance for locked boats during predictive tracking to prevent stale position tracking
-		// Allow up to 60 frames (2 seconds) for predictive tracking to help YOLO re-acquire
-		if si.targetBoat.IsLocked && si.targetBoat.LostFrames <= 60 {
+		// Allow up to 2 seconds for predictive tracking to help YOLO re-acquire
+		if si.targetBoat.IsLocked && !si.lostLongerThan(si.targetBoat, predictiveTrackTime) {
 			// CRITICAL FIX: Check if predicted position is reasonable before continuing tracking
 			offsetX := si.targetBoat.CurrentPixel.X - si.frameCenterX
 			offsetY := si.targetBoat.CurrentPixel.Y - si.frameCenterY
@@ -2219,13 +2587,13 @@ func (si *SpatialIntegration) selectTargetBoat() {
 				return
 			}
 
-			si.debugMsg("PREDICTIVE_KEEP", fmt.Sprintf("🔮 Keeping locked boat %s for predictive tracking (%d/60 frames lost) - position reasonable",
-				si.targetBoat.ID, si.targetBoat.LostFrames), si.targetBoat.ID)
+			si.debugMsg("PREDICTIVE_KEEP", fmt.Sprintf("🔮 Keeping locked boat %s for predictive tracking (%d/%d frames lost) - position reasonable",
+				si.targetBoat.ID, si.targetBoat.LostFrames, si.framesFor(predictiveTrackTime)), si.targetBoat.ID)
 			return // Keep target during predictive tracking period
 		}
 
-		// AGGRESSIVE switching when locked boat is clearly lost (60+ frames = 2+ seconds)
-		if si.targetBoat.IsLocked && si.targetBoat.LostFrames > 60 {
+		// AGGRESSIVE switching when locked boat is clearly lost (2+ seconds)
+		if si.targetBoat.IsLocked && si.lostLongerThan(si.targetBoat, predictiveTrackTime) {
 			si.debugMsg("TARGET_SWITCH", fmt.Sprintf("🔄 Locked boat %s lost for %d frames - ENTERING RECOVERY MODE",
 				si.targetBoat.ID, si.targetBoat.LostFrames), si.targetBoat.ID)
 
@@ -2242,7 +2610,7 @@ func (si *SpatialIntegration) selectTargetBoat() {
 
 		// Check if enough time has passed since last target switch (prevents rapid switching)
 		if si.frameCount-si.lastTargetSwitch < si.targetSwitchCooldown {
-			if si.targetBoat != nil && si.targetBoat.LostFrames <= 5 { // Allow some lost frames before switching
+			if si.targetBoat != nil && !si.lostLongerThan(si.targetBoat, briefLossTime) { // Allow some lost frames before switching
 				return // Keep current target during cooldown
 			}
 		}
@@ -2252,15 +2620,15 @@ func (si *SpatialIntegration) selectTargetBoat() {
 	var ghostBoats []string
 	var ghostBoatDetails []string
 	for id, boat := range si.allBoats {
-		if boat.LostFrames > 25 { // 1 second at 25fps - boat is clearly gone
+		if si.lostLongerThan(boat, staleTrackTime) { // Boat is clearly gone
 			// DETAILED GHOST ANALYSIS - what was lost?
 			lockStatus := "unlocked"
 			if boat.IsLocked {
 				lockStatus = "🔒 LOCKED"
-			} else if boat.DetectionCount >= si.minDetectionsForLock && boat.Confidence > 0.30 {
+			} else if si.meetsLockCriteria(boat) {
 				lockStatus = "🔓 ready-to-lock"
 			} else {
-				lockStatus = fmt.Sprintf("🔓 (%d/%d det)", boat.DetectionCount, si.minDetectionsForLock)
+				lockStatus = fmt.Sprintf("🔓 (%d/%d det)", boat.DetectionCount, si.lockDetections(boat.Classification))
 			}
 
 			ghostDetail := fmt.Sprintf("%s[%s,conf=%.2f,lost=%d]", id, lockStatus, boat.Confidence, boat.LostFrames)
@@ -2298,9 +2666,13 @@ func (si *SpatialIntegration) selectTargetBoat() {
 
 	for _, boat := range si.allBoats {
 		// FIXED: Allow boats with more lost frames to be selected if they're the best available
-		// Skip boats that are lost for too long (more than 25 frames = 1 second)
-		if boat.LostFrames > 25 {
-			si.debugMsg("TARGET_SELECTION", fmt.Sprintf("  %s: ❌ SKIPPED (lost %d frames > 25)", boat.ID, boat.LostFrames), boat.ID)
+		// Skip boats that are lost for too long
+		if stale := si.framesFor(staleTrackTime); boat.LostFrames > stale {
+			si.debugMsg("TARGET_SELECTION", fmt.Sprintf("  %s: ❌ SKIPPED (lost %d frames > %d)", boat.ID, boat.LostFrames, stale), boat.ID)
+			continue
+		}
+		if boat.Quarantined {
+			si.debugMsg("TARGET_SELECTION", fmt.Sprintf("  %s: ❌ SKIPPED (quarantined, quality %.2f)", boat.ID, boat.Quality), boat.ID)
 			continue
 		}
 
@@ -2308,9 +2680,8 @@ func (si *SpatialIntegration) selectTargetBoat() {
 		score := si.calculateTargetingScore(boat)
 
 		// ENHANCED DEBUG: Track all candidate analysis
-		meetsDetectionCriteria := boat.DetectionCount >= si.minDetectionsForLock
-		meetsConfidenceCriteria := boat.Confidence > 0.30
-		meetsEarlyLockCriteria := boat.Confidence >= 0.80 && boat.DetectionCount >= 1
+		meetsDetectionCriteria, meetsConfidenceCriteria := si.lockCriteria(boat)
+		meetsEarlyLockCriteria := meetsEarlyLockCriteria(boat)
 		canBeLocked := meetsEarlyLockCriteria || (meetsDetectionCriteria && meetsConfidenceCriteria)
 
 		lockability := "🔓 NOT LOCKABLE"
@@ -2323,7 +2694,7 @@ func (si *SpatialIntegration) selectTargetBoat() {
 		} else {
 			blockers := []string{}
 			if !meetsDetectionCriteria {
-				needed := si.minDetectionsForLock - boat.DetectionCount
+				needed := si.lockDetections(boat.Classification) - boat.DetectionCount
 				blockers = append(blockers, fmt.Sprintf("need %d more detections", needed))
 			}
 			if !meetsConfidenceCriteria {
@@ -2392,15 +2763,15 @@ func (si *SpatialIntegration) selectTargetBoat() {
 		si.lastTargetSwitch = si.frameCount
 
 		// DETAILED LOCK CRITERIA CHECK
-		meetsDetectionCriteria := bestBoat.DetectionCount >= si.minDetectionsForLock
-		meetsConfidenceCriteria := bestBoat.Confidence > 0.30
+		meetsDetectionCriteria, meetsConfidenceCriteria := si.lockCriteria(bestBoat)
 
 		si.debugMsg("LOCK_CHECK", fmt.Sprintf("🔍 Boat %s lock criteria: detections=%d≥%d?%v, confidence=%.3f>0.30?%v",
-			bestBoat.ID, bestBoat.DetectionCount, si.minDetectionsForLock, meetsDetectionCriteria,
+			bestBoat.ID, bestBoat.DetectionCount, si.lockDetections(bestBoat.Classification), meetsDetectionCriteria,
 			bestBoat.Confidence, meetsConfidenceCriteria), bestBoat.ID)
 
 		// FIXED: Only lock with mature targets (24+ detections + confidence), no early lock
-		if meetsDetectionCriteria && meetsConfidenceCriteria {
+		// Critical zones additionally require the secondary model to confirm the detection
+		if meetsDetectionCriteria && meetsConfidenceCriteria && si.ensembleAllowsLock(bestBoat) {
 			wasAlreadyLocked := si.targetBoat.IsLocked
 			si.targetBoat.IsLocked = true
 
@@ -2414,11 +2785,11 @@ func (si *SpatialIntegration) selectTargetBoat() {
 		} else {
 			// LOCK BLOCKED - Explain why
 			lockBlockers := []string{}
-			if bestBoat.Confidence < 0.30 && bestBoat.DetectionCount < si.minDetectionsForLock {
+			if bestBoat.Confidence < lockMinConfidence && bestBoat.DetectionCount < si.lockDetections(bestBoat.Classification) {
 				lockBlockers = append(lockBlockers, fmt.Sprintf("need %.2f confidence AND %d detections",
-					0.30, si.minDetectionsForLock-bestBoat.DetectionCount))
+					lockMinConfidence, si.lockDetections(bestBoat.Classification)-bestBoat.DetectionCount))
 			} else if !meetsDetectionCriteria {
-				lockBlockers = append(lockBlockers, fmt.Sprintf("need %d more detections", si.minDetectionsForLock-bestBoat.DetectionCount))
+				lockBlockers = append(lockBlockers, fmt.Sprintf("need %d more detections", si.lockDetections(bestBoat.Classification)-bestBoat.DetectionCount))
 			}
 			if !meetsConfidenceCriteria {
 				lockBlockers = append(lockBlockers, fmt.Sprintf("confidence %.3f too low", bestBoat.Confidence))
@@ -2430,9 +2801,9 @@ func (si *SpatialIntegration) selectTargetBoat() {
 				"LOCK_BLOCKED", 1, map[string]interface{}{
 					"boat_id":         bestBoat.ID,
 					"detections":      bestBoat.DetectionCount,
-					"min_detections":  si.minDetectionsForLock,
+					"min_detections":  si.lockDetections(bestBoat.Classification),
 					"confidence":      bestBoat.Confidence,
-					"min_confidence":  0.30,
+					"min_confidence":  lockMinConfidence,
 					"early_threshold": "DISABLED",
 					"blockers":        lockBlockers,
 				})
@@ -2447,7 +2818,7 @@ func (si *SpatialIntegration) selectTargetBoat() {
 
 		// Check if we need to record a lock loss for any remaining locked boats
 		for _, boat := range si.allBoats {
-			if boat.IsLocked && boat.LostFrames > 150 {
+			if boat.IsLocked && boat.LostFrames > si.maxLostFrames {
 				// We have a locked boat that just exceeded the threshold
 				si.lastLockLoss = time.Now()
 				si.lastLockedPosition = boat.CurrentSpatial
@@ -2509,12 +2880,30 @@ func (si *SpatialIntegration) calculateTargetingScore(boat *TrackedBoat) float64
 			boat.Classification, boat.ID, enhancementBonus, boat.P2Count, enhancementType), boat.ID)
 	}
 
-	// Combine scores - REBALANCED: Favor large P1 objects (mega yachts) over small P1 objects with P2 enhancements
-	totalScore := (detectionScore*0.15 + confidenceScore*0.15 + centerScore*0.10 + sizeScore*0.25 + stabilityBonus*0.15 + enhancementBonus*0.20) * lostFramesPenalty
+	// Bonus for recently detected objects (only weighed by strategies that favor newcomers)
+	newnessScore := newnessFactor(boat.FirstDetected)
+
+	// Combine scores with the selected strategy's weights (balanced favors large P1 objects over small ones with P2 enhancements)
+	strategy := si.scoring()
+	totalScore := strategy.Score(ScoreFactors{
+		Detections: detectionScore,
+		Confidence: confidenceScore,
+		Center:     centerScore,
+		Size:       sizeScore,
+		Stability:  stabilityBonus,
+		People:     enhancementBonus,
+		Newness:    newnessScore,
+		Presence:   lostFramesPenalty,
+	})
 
 	// Debug output to understand scoring decisions
-	si.debugMsg("SCORE_DEBUG", fmt.Sprintf("%s %s: det=%.2f(%.0f), conf=%.2f, center=%.2f, size=%.2f, stable=%.2f, p2bonus=%.2f, penalty=%.2f → TOTAL=%.3f",
-		boat.Classification, boat.ID, detectionScore, float64(boat.DetectionCount), confidenceScore, centerScore, sizeScore, stabilityBonus, enhancementBonus, lostFramesPenalty, totalScore), boat.ID)
+	si.debugMsg("SCORE_DEBUG", fmt.Sprintf("%s %s: det=%.2f(%.0f), conf=%.2f, center=%.2f, size=%.2f, stable=%.2f, p2bonus=%.2f, new=%.2f, penalty=%.2f → %s TOTAL=%.3f",
+		boat.Classification, boat.ID, detectionScore, float64(boat.DetectionCount), confidenceScore, centerScore, sizeScore, stabilityBonus, enhancementBonus, newnessScore, lostFramesPenalty, strategy.Name(), totalScore), boat.ID)
+
+	// Site-specific scoring plug-in builds on the built-in score
+	if si.targetScorer != nil {
+		return si.targetScorer.ScoreTarget(boat, si.scoringContext(boat, totalScore))
+	}
 
 	return totalScore
 }
@@ -2555,6 +2944,7 @@ func (si *SpatialIntegration) handlePostLockHoldover() {
 			AbsolutePan:  &roundedPan,
 			AbsoluteTilt: &roundedTilt,
 			AbsoluteZoom: &roundedZoom,
+			Source:       ptz.SourceRecovery,
 		}
 
 		// Use camera state manager if available, otherwise fall back to direct control
@@ -2611,8 +3001,7 @@ func (si *SpatialIntegration) updateCameraTracking() {
 	}
 
 	// Check if boat is locked for tracking (SECONDARY LOCK CHECK in camera tracking)
-	meetsDetectionCriteria := si.targetBoat.DetectionCount >= si.minDetectionsForLock
-	meetsConfidenceCriteria := si.targetBoat.Confidence > 0.30
+	meetsDetectionCriteria, meetsConfidenceCriteria := si.lockCriteria(si.targetBoat)
 
 	// DISABLED: Early lock feature that allowed camera movement with only 1 detection + high confidence
 	// This was causing premature camera movement in Mode 1, preventing targets from maturing
@@ -2621,7 +3010,7 @@ func (si *SpatialIntegration) updateCameraTracking() {
 	meetsEarlyLockCriteria := false // DISABLED: No early lock - camera only moves for mature targets (24+ detections)
 
 	si.debugMsg("CAMERA_LOCK_CHECK", fmt.Sprintf("🔍 Target boat %s: detections=%d≥%d?%v, confidence=%.3f>0.30?%v, earlyLock=DISABLED, currentlyLocked=%v",
-		si.targetBoat.ID, si.targetBoat.DetectionCount, si.minDetectionsForLock, meetsDetectionCriteria,
+		si.targetBoat.ID, si.targetBoat.DetectionCount, si.lockDetections(si.targetBoat.Classification), meetsDetectionCriteria,
 		si.targetBoat.Confidence, meetsConfidenceCriteria, si.targetBoat.IsLocked), si.targetBoat.ID)
 
 	// DUAL LOGGING: Camera lock check analysis
@@ -2630,7 +3019,7 @@ func (si *SpatialIntegration) updateCameraTracking() {
 		"CAMERA_LOCK_CHECK", 1, map[string]interface{}{
 			"boat_id":                   si.targetBoat.ID,
 			"detections":                si.targetBoat.DetectionCount,
-			"min_detections":            si.minDetectionsForLock,
+			"min_detections":            si.lockDetections(si.targetBoat.Classification),
 			"meets_detection_criteria":  meetsDetectionCriteria,
 			"confidence":                si.targetBoat.Confidence,
 			"meets_confidence_criteria": meetsConfidenceCriteria,
@@ -2640,7 +3029,8 @@ func (si *SpatialIntegration) updateCameraTracking() {
 		})
 
 	// FIXED: Only lock with mature targets (24+ detections + confidence), no early lock
-	if meetsDetectionCriteria && meetsConfidenceCriteria {
+	// Critical zones additionally require the secondary model to confirm the detection
+	if meetsDetectionCriteria && meetsConfidenceCriteria && si.ensembleAllowsLock(si.targetBoat) {
 		wasAlreadyLocked := si.targetBoat.IsLocked
 		si.targetBoat.IsLocked = true
 
@@ -2653,7 +3043,7 @@ func (si *SpatialIntegration) updateCameraTracking() {
 		}
 	} else {
 		si.debugMsg("CAMERA_LOCK_CHECK", fmt.Sprintf("🔓 Target boat %s does not meet lock criteria yet (need %.2f confidence OR %d detections)",
-			si.targetBoat.ID, 0.30, si.minDetectionsForLock), si.targetBoat.ID)
+			si.targetBoat.ID, lockMinConfidence, si.lockDetections(si.targetBoat.Classification)), si.targetBoat.ID)
 	}
 
 	// ONLY do predictive tracking if camera is IDLE and boat is locked
@@ -2661,7 +3051,7 @@ func (si *SpatialIntegration) updateCameraTracking() {
 		// Continue tracking even during movement - rate limiting prevents command flooding
 
 		// SIMPLIFIED PREDICTIVE TRACKING: Don't move camera for lost boats that are off-center
-		if si.targetBoat.LostFrames > 0 && si.targetBoat.LostFrames <= 60 { // Reduced to 2 seconds
+		if si.targetBoat.LostFrames > 0 && !si.lostLongerThan(si.targetBoat, predictiveTrackTime) { // Reduced to 2 seconds
 			// CRITICAL CHECK: Don't track to off-center positions
 			offsetX := si.targetBoat.CurrentPixel.X - si.frameCenterX
 			offsetY := si.targetBoat.CurrentPixel.Y - si.frameCenterY
@@ -2675,7 +3065,7 @@ func (si *SpatialIntegration) updateCameraTracking() {
 			}
 
 			// Only do simple prediction if position is reasonable and close to center
-			timeSinceLoss := float64(si.targetBoat.LostFrames) / 30.0 // Convert frames to seconds at 30fps
+			timeSinceLoss := si.frameSeconds(si.targetBoat.LostFrames) // Convert frames to seconds at the measured rate
 			si.debugMsg("PREDICTIVE_TRACK", fmt.Sprintf("🔮 Boat lost for %.1fs at reasonable position (%d,%d) - maintaining camera position",
 				timeSinceLoss, si.targetBoat.CurrentPixel.X, si.targetBoat.CurrentPixel.Y), si.targetBoat.ID)
 
@@ -2694,7 +3084,7 @@ func (si *SpatialIntegration) updateCameraTracking() {
 		si.smartPTZTracking()
 	} else {
 		si.debugMsg("CAMERA_TRACK", fmt.Sprintf("🔓 Boat not locked yet (%d/%d detections) - using basic centering only",
-			si.targetBoat.DetectionCount, si.minDetectionsForLock), si.targetBoat.ID)
+			si.targetBoat.DetectionCount, si.lockDetections(si.targetBoat.Classification)), si.targetBoat.ID)
 	}
 }
 
@@ -2764,12 +3154,14 @@ func (si *SpatialIntegration) GetTrackedObjects() map[int]*TrackedObject {
 
 	i := 0
 	for _, boat := range si.allBoats {
+		meetsDetections, meetsConfidence := si.lockCriteria(boat)
+
 		// Create TrackedObject for overlay with actual YOLO sizes
 		objects[i] = &TrackedObject{
 			ID:             i,       // Use int ID for compatibility
-			ObjectID:       boat.ID, // Unified object ID format: 20240125-12-30.001
-			CenterX:        boat.CurrentPixel.X,
-			CenterY:        boat.CurrentPixel.Y,
+			ObjectID:       boat.ID, // Unified object ID format: 20240125-13-30.001
+			CenterX:        boat.CurrentPixel.X + boat.interpolated.X,
+			CenterY:        boat.CurrentPixel.Y + boat.interpolated.Y,
 			Width:          boat.BoundingBox.Dx(), // Real YOLO width
 			Height:         boat.BoundingBox.Dy(), // Real YOLO height
 			Area:           boat.PixelArea,        // Real YOLO area
@@ -2779,6 +3171,12 @@ func (si *SpatialIntegration) GetTrackedObjects() map[int]*TrackedObject {
 			ClassName:      boat.Classification,
 			Confidence:     boat.Confidence,
 			DetectionCount: boat.DetectionCount,
+
+			State:              boat.State,
+			IsLocked:           boat.IsLocked,
+			RequiredDetections: si.lockDetections(boat.Classification),
+			MeetsDetections:    meetsDetections,
+			MeetsConfidence:    meetsConfidence,
 		}
 		i++
 	}
@@ -2841,7 +3239,7 @@ func (si *SpatialIntegration) GetDetailedTrackingMode() string {
 	}
 
 	if si.targetBoat.IsLocked {
-		if si.targetBoat.DetectionCount >= 24 {
+		if isSuperLocked(si.targetBoat) {
 			// Check if this is SUPER LOCK with P2-based targeting
 			if si.targetBoat.UseP2Target {
 				return "SUPER_LOCK_P2"
@@ -2864,7 +3262,7 @@ func (si *SpatialIntegration) GetDetailedTrackingMode() string {
 	}
 
 	// Not locked yet - show building progress
-	detectionProgress := fmt.Sprintf("TRACKING PHASE 1 (%d/%d)", si.targetBoat.DetectionCount, si.minDetectionsForLock)
+	detectionProgress := fmt.Sprintf("TRACKING PHASE 1 (%d/%d)", si.targetBoat.DetectionCount, si.lockDetections(si.targetBoat.Classification))
 	return detectionProgress
 }
 
@@ -3017,7 +3415,7 @@ func (si *SpatialIntegration) GetTrackingDecision() *TrackingDecision {
 	if si.targetBoat.IsLocked {
 		logic = append(logic, fmt.Sprintf("STATUS: LOCKED after %d detections (%.0f%% strength)", si.targetBoat.DetectionCount, si.targetBoat.LockStrength*100))
 	} else {
-		logic = append(logic, fmt.Sprintf("STATUS: Building lock (%d/%d detections needed)", si.targetBoat.DetectionCount, si.minDetectionsForLock))
+		logic = append(logic, fmt.Sprintf("STATUS: Building lock (%d/%d detections needed)", si.targetBoat.DetectionCount, si.lockDetections(si.targetBoat.Classification)))
 	}
 
 	logic = append(logic,
@@ -3027,6 +3425,10 @@ func (si *SpatialIntegration) GetTrackingDecision() *TrackingDecision {
 		fmt.Sprintf("Target: (%d,%d)", targetX, targetY),
 		predictionDescription,
 	)
+	speed := si.speedEstimate(si.targetBoat.ID)
+	if speed != nil {
+		logic = append(logic, fmt.Sprintf("Speed: %.1f kn (%.1f m/s) at ~%.0fm", speed.Knots, speed.MetersPerSecond, speed.RangeM))
+	}
 
 	// Add camera state information
 	if si.cameraStateManager != nil {
@@ -3054,6 +3456,7 @@ func (si *SpatialIntegration) GetTrackingDecision() *TrackingDecision {
 		DistanceFromCenter: distanceFromCenter,
 		TrackingEffort:     si.targetBoat.LockStrength,
 		Confidence:         si.targetBoat.Confidence,
+		Speed:              speed,
 	}
 }
 
@@ -3205,6 +3608,9 @@ func (si *SpatialIntegration) ClearTrackingHistory() {
 			si.targetBoat.ID, si.targetBoat.PixelVelocity.X, si.targetBoat.PixelVelocity.Y))
 	}
 
+	// Pending detections were in the old view - discard them
+	si.detectionFusion.reset()
+
 	// Track when we cleared history for adaptive boat matching
 	si.lastHistoryClear = time.Now()
 
@@ -3252,6 +3658,45 @@ func (si *SpatialIntegration) SetCameraStateManager(stateManager *ptz.CameraStat
 	si.debugMsg("SPATIAL_INTEGRATION", "Camera state manager integrated - commands will be coordinated")
 }
 
+// ConfigureCalibrationTable replaces the built-in pixels-per-unit calibration with a loaded table
+func (si *SpatialIntegration) ConfigureCalibrationTable(table *calibration.Table) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.spatialTracker.SetCalibrationTable(table)
+	si.debugMsg("CALIBRATION", fmt.Sprintf("📐 Using calibration table %s", table))
+}
+
+// ConfigureLensModel loads camera intrinsics so pixel offsets are undistorted before they are converted into
+// pan/tilt adjustments (nil keeps the linear mapping)
+func (si *SpatialIntegration) ConfigureLensModel(model *calibration.LensModel) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.lensModel = model
+	if model != nil {
+		si.debugMsg("CALIBRATION", fmt.Sprintf("🔍 Lens distortion correction using %s", model))
+	}
+}
+
+// undistortedOffset returns a pixel's offset from the frame center as seen through an ideal lens at the given
+// zoom; without a lens model (or intrinsics for this zoom) it is the plain pixel offset
+func (si *SpatialIntegration) undistortedOffset(pixelX, pixelY int, zoom float64) (float64, float64) {
+	offsetX := float64(pixelX - si.frameCenterX)
+	offsetY := float64(pixelY - si.frameCenterY)
+	if si.lensModel == nil {
+		return offsetX, offsetY
+	}
+
+	x, y := si.lensModel.Undistort(float64(pixelX), float64(pixelY), zoom, si.frameWidth, si.frameHeight)
+	centerX, centerY := si.lensModel.Undistort(float64(si.frameCenterX), float64(si.frameCenterY), zoom, si.frameWidth, si.frameHeight)
+	if x-centerX != offsetX || y-centerY != offsetY {
+		si.debugMsgVerbose("LENS_CORRECTION", fmt.Sprintf("🔍 Pixel offset (%.0f,%.0f) → (%.1f,%.1f) at zoom %.0f",
+			offsetX, offsetY, x-centerX, y-centerY, zoom))
+	}
+	return x - centerX, y - centerY
+}
+
 // GetCameraStateManager returns the camera state manager
 func (si *SpatialIntegration) GetCameraStateManager() *ptz.CameraStateManager {
 	si.mu.RLock()
@@ -3274,7 +3719,7 @@ func (si *SpatialIntegration) RecalculateSpatialPositions() {
 		oldSpatial := boat.CurrentSpatial
 
 		// Special handling for boats in predictive tracking
-		if boat.LostFrames > 10 {
+		if si.lostLongerThan(boat, gapToleranceTime) {
 			si.debugMsg("SPATIAL_RECALC", fmt.Sprintf("🔮 Recalculating for boat %s in predictive tracking mode (%d frames lost)",
 				boat.ID, boat.LostFrames), boat.ID)
 		}
@@ -3290,20 +3735,46 @@ func (si *SpatialIntegration) RecalculateSpatialPositions() {
 	si.debugMsg("SPATIAL_RECALC", fmt.Sprintf("✅ Recalculated spatial positions for %d boats", recalculated))
 }
 
+// GetLockedTarget returns the currently locked target (LOCK or SUPER LOCK) or nil when no lock is held
+func (si *SpatialIntegration) GetLockedTarget() *TrackedObject {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+
+	if si.targetBoat == nil || !si.targetBoat.IsLocked {
+		return nil
+	}
+
+	return &TrackedObject{
+		ID:             0,
+		ObjectID:       si.targetBoat.ID,
+		CenterX:        si.targetBoat.CurrentPixel.X,
+		CenterY:        si.targetBoat.CurrentPixel.Y,
+		Width:          si.targetBoat.BoundingBox.Dx(),
+		Height:         si.targetBoat.BoundingBox.Dy(),
+		Area:           si.targetBoat.PixelArea,
+		LastSeen:       si.targetBoat.LastSeen,
+		TrackedFrames:  si.targetBoat.DetectionCount,
+		LostFrames:     si.targetBoat.LostFrames,
+		ClassName:      si.targetBoat.Classification,
+		Confidence:     si.targetBoat.Confidence,
+		DetectionCount: si.targetBoat.DetectionCount,
+	}
+}
+
 // GetLockedTargetForPIP returns locked targets with P2 objects for PIP (SUPER LOCK 24+ ONLY)
 func (si *SpatialIntegration) GetLockedTargetForPIP() *TrackedObject {
 	si.mu.RLock()
 	defer si.mu.RUnlock()
 
 	// Only return target if it's SUPER LOCK (24+) AND has people detected OR recently had people
-	if si.targetBoat != nil && si.targetBoat.IsLocked && si.targetBoat.DetectionCount >= 24 {
+	if si.targetBoat != nil && isSuperLocked(si.targetBoat) {
 		// Check if we currently have people OR had people recently (for linger)
 		recentlyHadPeople := si.targetBoat.HasP2Objects ||
 			(!si.targetBoat.LastP2Seen.IsZero() && time.Since(si.targetBoat.LastP2Seen) <= 3*time.Second)
 
 		if recentlyHadPeople {
-			// Allow up to 5 lost frames for SUPER LOCK (more stable than regular LOCK)
-			if si.targetBoat.LostFrames <= 5 {
+			// Allow a few lost frames for SUPER LOCK (more stable than regular LOCK)
+			if !si.lostLongerThan(si.targetBoat, briefLossTime) {
 				personStatus := "CURRENT"
 				if !si.targetBoat.HasP2Objects {
 					timeSince := time.Since(si.targetBoat.LastP2Seen)
@@ -3577,6 +4048,12 @@ func (si *SpatialIntegration) executePTZMovement(target SpatialCoordinate, movem
 	roundedTilt := math.Round(target.Tilt)
 	roundedZoom := math.Round(target.Zoom)
 
+	objectID := si.getCurrentObjectID() // Safe object ID getter for logging
+	source := ptz.SourceTracking
+	if strings.HasPrefix(movementType, "RECOVERY") {
+		source = ptz.SourceRecovery
+	}
+
 	cmd := ptz.PTZCommand{
 		Command:      "absolutePosition",
 		Reason:       fmt.Sprintf("PTZ Predictive %s", movementType),
@@ -3584,11 +4061,12 @@ func (si *SpatialIntegration) executePTZMovement(target SpatialCoordinate, movem
 		AbsolutePan:  &roundedPan,
 		AbsoluteTilt: &roundedTilt,
 		AbsoluteZoom: &roundedZoom,
+		Source:       source,
+		ObjectID:     objectID,
 	}
 
 	// Send command through camera state manager for coordination
 	var success bool
-	objectID := si.getCurrentObjectID() // Safe object ID getter for logging
 
 	if si.cameraStateManager != nil {
 		success = si.cameraStateManager.SendCommand(cmd)
@@ -3753,6 +4231,13 @@ func (si *SpatialIntegration) ConfigureSmartPTZAdvanced(predictionTime, minVeloc
 		predictionTime, minVelocity, bufferFactor*100, pipelineLatency, centerTrigger*100))
 }
 
+// SetPipelineLatency changes only the latency compensation (seconds), e.g. to follow the measured latency
+func (si *SpatialIntegration) SetPipelineLatency(seconds float64) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	si.pipelineLatency = seconds
+}
+
 // GetSmartPTZConfig returns current smart PTZ configuration
 func (si *SpatialIntegration) GetSmartPTZConfig() (bool, float64, float64, float64) {
 	si.mu.RLock()
@@ -3818,6 +4303,15 @@ func (si *SpatialIntegration) logDebugMessage(message, messageType string, prior
 		}
 	}
 
+	// Offer to the event stream
+	if si.eventSink != nil {
+		objectID, _ := data["object_id"].(string)
+		if objectID == "" {
+			objectID, _ = data["boat_id"].(string)
+		}
+		si.eventSink(messageType, message, objectID, data)
+	}
+
 	// Send to debug files ONLY if debug mode is enabled and we have an active session
 	if si.debugManager != nil && si.targetBoat != nil {
 		if dm, ok := si.debugManager.(interface {
@@ -3867,6 +4361,7 @@ func (si *SpatialIntegration) prepareRecoveryData(lostBoat *TrackedBoat) {
 		LastKnownSpatialPos:  lostBoat.CurrentSpatial,
 		AverageDirection:     avgDirection,
 		AverageSpeedPixelSec: avgSpeed,
+		MaxSpeedPixelSec:     si.maxPlausibleSpeed(lostBoat.Classification, lostBoat.CurrentSpatial.Zoom),
 		LossTime:             time.Now(),
 		OriginalZoom:         lostBoat.CurrentSpatial.Zoom,
 		CurrentPhase:         RECOVERY_MOVE_TO_PREDICTED_1,
@@ -3875,6 +4370,12 @@ func (si *SpatialIntegration) prepareRecoveryData(lostBoat *TrackedBoat) {
 		PhaseStartTime:       time.Time{},         // Initialize to zero time
 		PhaseTarget:          SpatialCoordinate{}, // Initialize to zero coordinate
 		WaitingForArrival:    false,               // Start ready to send first command
+		Classification:       lostBoat.Classification,
+		FirstDetected:        lostBoat.FirstDetected,
+		DetectionCount:       lostBoat.DetectionCount,
+		Size:                 rebindSize(lostBoat.PixelArea, lostBoat.CurrentSpatial.Zoom),
+		Aspect:               boxAspect(lostBoat.BoundingBox.Dx(), lostBoat.BoundingBox.Dy()),
+		SpatialVelocity:      lostBoat.SpatialVelocity,
 	}
 
 	si.isInRecovery = true
@@ -3907,8 +4408,8 @@ func (si *SpatialIntegration) executeRecovery(detections []image.Rectangle) {
 		return
 	}
 
-	// Check for timeout
-	if time.Since(si.recoveryData.RecoveryStartTime) > si.recoveryTimeout {
+	// Check for timeout (the spiral search is bounded by its own positions)
+	if si.recoveryData.CurrentPhase != RECOVERY_SPIRAL_SEARCH && time.Since(si.recoveryData.RecoveryStartTime) > si.recoveryTimeout {
 		si.debugMsg("RECOVERY_TIMEOUT", fmt.Sprintf("⏰ Recovery timeout after %.1fs - returning to scanning", si.recoveryTimeout.Seconds()), si.recoveryData.ObjectID)
 		si.endRecovery()
 		return
@@ -3929,6 +4430,8 @@ func (si *SpatialIntegration) executeRecovery(detections []image.Rectangle) {
 		si.executeZoomOut()
 	case RECOVERY_MOVE_TO_PREDICTED_2:
 		si.executePredictiveMove2()
+	case RECOVERY_SPIRAL_SEARCH:
+		si.executeSpiralSearch()
 	case RECOVERY_COMPLETE:
 		si.endRecovery()
 	}
@@ -3945,8 +4448,8 @@ func (si *SpatialIntegration) executePredictiveMove1() {
 		// Clamp elapsed time (after 30 seconds, prediction becomes meaningless)
 		clampedElapsed := math.Min(elapsed.Seconds(), 30.0)
 
-		// Clamp average speed (max 300 pixels/second - a very fast boat)
-		clampedSpeed := math.Min(math.Abs(si.recoveryData.AverageSpeedPixelSec), 300.0)
+		// Clamp average speed to the lost object's class bound
+		clampedSpeed := math.Min(math.Abs(si.recoveryData.AverageSpeedPixelSec), si.recoveryData.MaxSpeedPixelSec)
 
 		// Simple 2x prediction - move twice as far as normal prediction
 		deltaX := clampedSpeed * clampedElapsed * math.Cos(si.recoveryData.AverageDirection) * 2.0
@@ -4045,8 +4548,8 @@ func (si *SpatialIntegration) executePredictiveMove2() {
 		// Clamp elapsed time (after 30 seconds, prediction becomes meaningless)
 		clampedElapsed := math.Min(elapsed.Seconds(), 30.0)
 
-		// Clamp average speed (max 300 pixels/second - a very fast boat)
-		clampedSpeed := math.Min(math.Abs(si.recoveryData.AverageSpeedPixelSec), 300.0)
+		// Clamp average speed to the lost object's class bound
+		clampedSpeed := math.Min(math.Abs(si.recoveryData.AverageSpeedPixelSec), si.recoveryData.MaxSpeedPixelSec)
 
 		// Simple 2x prediction - move twice as far as normal prediction
 		deltaX := clampedSpeed * clampedElapsed * math.Cos(si.recoveryData.AverageDirection) * 2.0
@@ -4115,13 +4618,13 @@ func (si *SpatialIntegration) executePredictiveMove2() {
 		minPhaseTime := 2 * time.Second // Minimum 2 seconds per phase
 
 		if cameraIdle && time.Since(si.recoveryData.PhaseStartTime) >= minPhaseTime {
-			si.debugMsg("RECOVERY_PHASE3", "✅ Camera is IDLE - final move complete, ending recovery", si.recoveryData.ObjectID)
-			si.recoveryData.CurrentPhase = RECOVERY_COMPLETE
+			si.debugMsg("RECOVERY_PHASE3", "✅ Camera is IDLE - final move complete", si.recoveryData.ObjectID)
+			si.recoveryData.CurrentPhase = si.afterPredictedMoves()
 			si.recoveryData.WaitingForArrival = false
 		} else if time.Since(si.recoveryData.PhaseStartTime) > 10*time.Second {
 			// Timeout protection - advance anyway after 10 seconds
-			si.debugMsg("RECOVERY_PHASE3", "⏰ Timeout waiting for camera - ending recovery", si.recoveryData.ObjectID)
-			si.recoveryData.CurrentPhase = RECOVERY_COMPLETE
+			si.debugMsg("RECOVERY_PHASE3", "⏰ Timeout waiting for camera - final move abandoned", si.recoveryData.ObjectID)
+			si.recoveryData.CurrentPhase = si.afterPredictedMoves()
 			si.recoveryData.WaitingForArrival = false
 		} else {
 			cameraState := "MOVING"
@@ -4156,7 +4659,7 @@ func (si *SpatialIntegration) executeZoomOut() {
 
 		si.executePTZMovement(zoomOutTarget, "RECOVERY: Zoom out 50%")
 
-		si.debugMsg("RECOVERY_PHASE2", fmt.Sprintf("📹 Zooming out 50%: %.0f → %.0f",
+		si.debugMsg("RECOVERY_PHASE2", fmt.Sprintf("📹 Zooming out 50%%: %.0f → %.0f",
 			si.recoveryData.OriginalZoom, newZoom), si.recoveryData.ObjectID)
 	}
 
@@ -4197,8 +4700,8 @@ func (si *SpatialIntegration) executeDirectionalSearch() {
 		// Clamp elapsed time (after 30 seconds, prediction becomes meaningless)
 		clampedElapsed := math.Min(elapsed.Seconds(), 30.0)
 
-		// Clamp average speed (max 300 pixels/second - a very fast boat)
-		clampedSpeed := math.Min(math.Abs(si.recoveryData.AverageSpeedPixelSec), 300.0)
+		// Clamp average speed to the lost object's class bound
+		clampedSpeed := math.Min(math.Abs(si.recoveryData.AverageSpeedPixelSec), si.recoveryData.MaxSpeedPixelSec)
 
 		// Move even further for the search (5x speed * 3x distance = 15x total movement)
 		speedMultiplier := 5.0 // Same aggressive speed prediction as Phase 1
@@ -4292,7 +4795,7 @@ func (si *SpatialIntegration) executeDirectionalSearch() {
 
 	// Linger complete - move on to final phase
 	si.debugMsg("RECOVERY_PHASE3", "✅ Directional search complete - no boat found", si.recoveryData.ObjectID)
-	si.recoveryData.CurrentPhase = RECOVERY_COMPLETE
+	si.recoveryData.CurrentPhase = si.afterPredictedMoves()
 }
 
 // resumeTrackingAfterRecovery resumes tracking when boat is found during recovery
@@ -4304,7 +4807,11 @@ func (si *SpatialIntegration) resumeTrackingAfterRecovery(detections []image.Rec
 		objectID = si.targetBoat.ID
 	}
 
-	si.debugMsg("RECOVERY_RESUME", fmt.Sprintf("🎉 Recovery SUCCESS! Found detections, resuming tracking: %s", objectID), objectID)
+	if si.recoveryData != nil && si.recoveryData.Rebound {
+		si.debugMsg("RECOVERY_RESUME", fmt.Sprintf("🎉 Recovery SUCCESS! Lost boat re-bound, resuming tracking: %s", objectID), objectID)
+	} else {
+		si.debugMsg("RECOVERY_RESUME", fmt.Sprintf("🎉 Recovery SUCCESS! Found detections, resuming tracking: %s", objectID), objectID)
+	}
 
 	// Clear recovery mode but KEEP targetBoat - it might still be valid for tracking
 	si.isInRecovery = false
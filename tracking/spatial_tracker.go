@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"rivercam/calibration"
 	"rivercam/ptz"
 )
 
@@ -56,6 +57,7 @@ type CustomScanPosition struct {
 	ID        int             `json:"id"`
 	Name      string          `json:"name"`
 	Position  ptz.PTZPosition `json:"position"`
+	Preset    string          `json:"preset,omitempty"` // Named preset whose position this waypoint takes
 	DwellTime int             `json:"dwell_time_seconds"`
 	Timestamp time.Time       `json:"timestamp"`
 }
@@ -68,6 +70,7 @@ type CustomScanningPattern struct {
 	UpdatedAt   time.Time            `json:"updated_at"`
 	Positions   []CustomScanPosition `json:"positions"`
 	TotalCount  int                  `json:"total_count"`
+	DwellJitter float64              `json:"dwell_jitter,omitempty"` // Random ± fraction applied to every dwell (0 = -scan-dwell-jitter)
 }
 
 // SpatialObject represents a detected object with spatial awareness
@@ -104,7 +107,7 @@ type SpatialTracker struct {
 	// PTZ controller and calibration
 	ptzCtrl            ptz.Controller
 	cameraStateManager *ptz.CameraStateManager // Camera state management for coordinated commands
-	calibration        *ZoomCalibration
+	calibration        *calibration.Table      // Pixels per PTZ unit by zoom (built-in or -calibration-file)
 
 	// Frame properties
 	frameWidth   int
@@ -127,9 +130,18 @@ type SpatialTracker struct {
 	customScanPattern     *CustomScanningPattern
 	scanPositionStartTime time.Time
 
+	// Named scan profiles (scanning.json is always one of them)
+	scanProfiles     map[string]*CustomScanningPattern
+	scanProfileOrder []string
+	scanDwellJitter  float64       // Default random ± dwell fraction for profiles without their own
+	currentDwell     time.Duration // Dwell drawn for the current position (set on arrival)
+
 	// Object classification
 	classificationRules map[string]ClassificationRule
 	p2MinConfidence     float64 // Confidence threshold for P2 (secondary) objects
+
+	// Operator control
+	scanningPaused bool // Hold position instead of stepping through the scan pattern
 }
 
 // ClassificationRule defines how to classify detected objects
@@ -141,13 +153,6 @@ type ClassificationRule struct {
 	LockThreshold    float64  // Confidence threshold for locking
 }
 
-// ZoomCalibration contains calibration data for different zoom levels
-type ZoomCalibration struct {
-	PanPixelsPerUnit  map[int]float64
-	TiltPixelsPerUnit map[int]float64
-	// NOTE: PTZ limits removed - now handled in Camera State Manager
-}
-
 // loadCustomScanningPattern loads the scanning pattern from scanning.json
 func loadCustomScanningPattern() (*CustomScanningPattern, error) {
 	data, err := os.ReadFile("scanning.json")
@@ -163,42 +168,8 @@ func loadCustomScanningPattern() (*CustomScanningPattern, error) {
 	return &pattern, nil
 }
 
-// NewSpatialTracker creates a new spatial awareness tracker
-func NewSpatialTracker(ptzCtrl ptz.Controller, frameWidth, frameHeight int, p1TrackList, p2TrackList []string, p1TrackAll, p2TrackAll bool, p1MinConfidence, p2MinConfidence float64) *SpatialTracker {
-	calibration := &ZoomCalibration{
-		// UPDATED: More accurate calibration data from detailed measurements
-		PanPixelsPerUnit: map[int]float64{
-			10:  4.87,  // Zoom 10: 4.87 pixels per pan unit (from calibration table)
-			20:  7.91,  // Zoom 20: 7.91 pixels per pan unit
-			30:  10.38, // Zoom 30: 10.38 pixels per pan unit
-			40:  12.74, // Zoom 40: 12.74 pixels per pan unit
-			50:  15.27, // Zoom 50: 15.27 pixels per pan unit
-			60:  18.41, // Zoom 60: 18.41 pixels per pan unit
-			70:  19.91, // Zoom 70: 19.91 pixels per pan unit
-			80:  21.68, // Zoom 80: 21.68 pixels per pan unit
-			90:  26.10, // Zoom 90: 26.10 pixels per pan unit
-			100: 27.71, // Zoom 100: 27.71 pixels per pan unit
-			110: 30.20, // Zoom 110: 30.20 pixels per pan unit
-			120: 36.32, // Zoom 120: 36.32 pixels per pan unit
-		},
-		TiltPixelsPerUnit: map[int]float64{
-			10:  5.00,  // Zoom 10: 5.00 pixels per tilt unit (from calibration table)
-			20:  7.76,  // Zoom 20: 7.76 pixels per tilt unit
-			30:  10.86, // Zoom 30: 10.86 pixels per tilt unit
-			40:  12.67, // Zoom 40: 12.67 pixels per tilt unit
-			50:  15.83, // Zoom 50: 15.83 pixels per tilt unit
-			60:  18.54, // Zoom 60: 18.54 pixels per tilt unit
-			70:  22.69, // Zoom 70: 22.69 pixels per tilt unit
-			80:  23.75, // Zoom 80: 23.75 pixels per tilt unit
-			90:  26.67, // Zoom 90: 26.67 pixels per tilt unit
-			100: 30.40, // Zoom 100: 30.40 pixels per tilt unit
-			110: 31.67, // Zoom 110: 31.67 pixels per tilt unit
-			120: 33.78, // Zoom 120: 33.78 pixels per tilt unit
-		},
-		// NOTE: PTZ limits removed - now handled in Camera State Manager
-	}
-
-	// Generate dynamic classification rules from P1 tracking list
+// buildClassificationRules creates a classification rule for every P1 class with its allowed P2 secondary classes
+func buildClassificationRules(p1TrackList, p2TrackList []string, p1TrackAll, p2TrackAll bool, p1MinConfidence float64) map[string]ClassificationRule {
 	classificationRules := make(map[string]ClassificationRule)
 
 	// Define all YOLO/COCO classes for P1 "all" support
@@ -247,6 +218,15 @@ func NewSpatialTracker(ptzCtrl ptz.Controller, frameWidth, frameHeight int, p1Tr
 		}
 	}
 
+	return classificationRules
+}
+
+// NewSpatialTracker creates a new spatial awareness tracker
+func NewSpatialTracker(ptzCtrl ptz.Controller, frameWidth, frameHeight int, p1TrackList, p2TrackList []string, p1TrackAll, p2TrackAll bool, p1MinConfidence, p2MinConfidence float64) *SpatialTracker {
+
+	// Generate dynamic classification rules from P1 tracking list
+	classificationRules := buildClassificationRules(p1TrackList, p2TrackList, p1TrackAll, p2TrackAll, p1MinConfidence)
+
 	// Load scanning pattern from scanning.json (required)
 	customPattern, err := loadCustomScanningPattern()
 	if err != nil {
@@ -268,7 +248,7 @@ func NewSpatialTracker(ptzCtrl ptz.Controller, frameWidth, frameHeight int, p1Tr
 
 	tracker := &SpatialTracker{
 		ptzCtrl:               ptzCtrl,
-		calibration:           calibration,
+		calibration:           calibration.DefaultTable(),
 		frameWidth:            frameWidth,
 		frameHeight:           frameHeight,
 		frameCenterX:          frameWidth / 2,
@@ -277,6 +257,8 @@ func NewSpatialTracker(ptzCtrl ptz.Controller, frameWidth, frameHeight int, p1Tr
 		scanningMode:          true,
 		scanPattern:           scanPattern,
 		customScanPattern:     customPattern,
+		scanProfiles:          map[string]*CustomScanningPattern{customPattern.Name: customPattern},
+		scanProfileOrder:      []string{customPattern.Name},
 		currentScanIndex:      0, // Start at first position
 		scanPositionStartTime: time.Now(),
 		classificationRules:   classificationRules,
@@ -447,78 +429,27 @@ func (st *SpatialTracker) pixelToSpatialCoordinate(detection image.Rectangle, cu
 	}
 }
 
-// interpolatePanCalibration gets pan pixels per unit for any zoom level
+// InterpolatePanCalibration gets pan pixels per unit for any zoom level
 func (st *SpatialTracker) InterpolatePanCalibration(zoomLevel float64) float64 {
-	// Get all available zoom levels sorted
-	zoomLevels := []int{10, 20, 30, 40, 50, 60, 70, 80, 90, 100, 110, 120}
-
-	// Handle edge cases
-	if zoomLevel <= float64(zoomLevels[0]) {
-		return st.calibration.PanPixelsPerUnit[zoomLevels[0]]
-	}
-	if zoomLevel >= float64(zoomLevels[len(zoomLevels)-1]) {
-		return st.calibration.PanPixelsPerUnit[zoomLevels[len(zoomLevels)-1]]
-	}
-
-	// Find the two closest zoom levels for interpolation
-	for i := 0; i < len(zoomLevels)-1; i++ {
-		lowerZoom := float64(zoomLevels[i])
-		upperZoom := float64(zoomLevels[i+1])
-
-		if zoomLevel >= lowerZoom && zoomLevel <= upperZoom {
-			// Linear interpolation between the two points
-			ratio := (zoomLevel - lowerZoom) / (upperZoom - lowerZoom)
-			lowerValue := st.calibration.PanPixelsPerUnit[zoomLevels[i]]
-			upperValue := st.calibration.PanPixelsPerUnit[zoomLevels[i+1]]
-
-			interpolated := lowerValue + ratio*(upperValue-lowerValue)
-
-			spatialDebugMsgVerbose("CALIBRATION", fmt.Sprintf("Pan interpolation: zoom %.1f between %d--%d, ratio %.3f, result %.2f px/unit",
-				zoomLevel, zoomLevels[i], zoomLevels[i+1], ratio, interpolated))
-
-			return interpolated
-		}
-	}
-
-	// Fallback (should not reach here)
-	return st.calibration.PanPixelsPerUnit[60]
+	panPixelsPerUnit, _ := st.calibration.InterpolateAt(zoomLevel)
+	spatialDebugMsgVerbose("CALIBRATION", fmt.Sprintf("Pan interpolation: zoom %.1f, result %.2f px/unit (%s)",
+		zoomLevel, panPixelsPerUnit, st.calibration.Source))
+	return panPixelsPerUnit
 }
 
-// interpolateTiltCalibration gets tilt pixels per unit for any zoom level
+// InterpolateTiltCalibration gets tilt pixels per unit for any zoom level
 func (st *SpatialTracker) InterpolateTiltCalibration(zoomLevel float64) float64 {
-	// Get all available zoom levels sorted
-	zoomLevels := []int{10, 20, 30, 40, 50, 60, 70, 80, 90, 100, 110, 120}
-
-	// Handle edge cases
-	if zoomLevel <= float64(zoomLevels[0]) {
-		return st.calibration.TiltPixelsPerUnit[zoomLevels[0]]
-	}
-	if zoomLevel >= float64(zoomLevels[len(zoomLevels)-1]) {
-		return st.calibration.TiltPixelsPerUnit[zoomLevels[len(zoomLevels)-1]]
-	}
-
-	// Find the two closest zoom levels for interpolation
-	for i := 0; i < len(zoomLevels)-1; i++ {
-		lowerZoom := float64(zoomLevels[i])
-		upperZoom := float64(zoomLevels[i+1])
-
-		if zoomLevel >= lowerZoom && zoomLevel <= upperZoom {
-			// Linear interpolation between the two points
-			ratio := (zoomLevel - lowerZoom) / (upperZoom - lowerZoom)
-			lowerValue := st.calibration.TiltPixelsPerUnit[zoomLevels[i]]
-			upperValue := st.calibration.TiltPixelsPerUnit[zoomLevels[i+1]]
-
-			interpolated := lowerValue + ratio*(upperValue-lowerValue)
-
-			spatialDebugMsgVerbose("CALIBRATION", fmt.Sprintf("Tilt interpolation: zoom %.1f between %d--%d, ratio %.3f, result %.2f px/unit",
-				zoomLevel, zoomLevels[i], zoomLevels[i+1], ratio, interpolated))
-
-			return interpolated
-		}
-	}
+	_, tiltPixelsPerUnit := st.calibration.InterpolateAt(zoomLevel)
+	spatialDebugMsgVerbose("CALIBRATION", fmt.Sprintf("Tilt interpolation: zoom %.1f, result %.2f px/unit (%s)",
+		zoomLevel, tiltPixelsPerUnit, st.calibration.Source))
+	return tiltPixelsPerUnit
+}
 
-	// Fallback (should not reach here)
-	return st.calibration.TiltPixelsPerUnit[60]
+// SetCalibrationTable replaces the built-in calibration with a loaded table
+func (st *SpatialTracker) SetCalibrationTable(table *calibration.Table) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.calibration = table
 }
 
 // classifyDetection creates a classification string based on detected objects
@@ -821,6 +752,10 @@ func (st *SpatialTracker) moveToPTZPosition(target SpatialCoordinate) {
 		AbsolutePan:  &roundedPan,
 		AbsoluteTilt: &roundedTilt,
 		AbsoluteZoom: &roundedZoom,
+		Source:       ptz.SourceScan,
+	}
+	if st.lockedObject != nil {
+		cmd.Source, cmd.ObjectID = ptz.SourceTracking, st.lockedObject.ID
 	}
 
 	spatialDebugMsg("SPATIAL", fmt.Sprintf("🚀 Sending command to move to Pan:%.0f Tilt:%.0f Zoom:%.0f (rounded from %.1f,%.1f,%.1f)",
@@ -1081,12 +1016,33 @@ func (st *SpatialTracker) SetScanningMode(enabled bool) {
 	}
 }
 
+// SetScanningPaused holds the camera in place while in scanning mode (operator pause)
+func (st *SpatialTracker) SetScanningPaused(paused bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.scanningPaused = paused
+}
+
+// SetClassificationRules replaces the P1/P2 classification rules at runtime
+func (st *SpatialTracker) SetClassificationRules(p1TrackList, p2TrackList []string, p1TrackAll, p2TrackAll bool, p1MinConfidence float64) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.classificationRules = buildClassificationRules(p1TrackList, p2TrackList, p1TrackAll, p2TrackAll, p1MinConfidence)
+}
+
+// SetP2MinConfidence replaces the P2 confidence threshold at runtime
+func (st *SpatialTracker) SetP2MinConfidence(p2MinConfidence float64) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.p2MinConfidence = p2MinConfidence
+}
+
 // ExecuteRiverScan executes one step of the river scanning pattern
 func (st *SpatialTracker) ExecuteRiverScan() {
 	st.mu.Lock()
 	defer st.mu.Unlock()
 
-	if !st.scanningMode {
+	if !st.scanningMode || st.scanningPaused {
 		return
 	}
 
@@ -1100,7 +1056,7 @@ func (st *SpatialTracker) ExecuteRiverScan() {
 }
 
 // GetCalibration returns the calibration data for external access
-func (st *SpatialTracker) GetCalibration() *ZoomCalibration {
+func (st *SpatialTracker) GetCalibration() *calibration.Table {
 	st.mu.Lock()
 	defer st.mu.Unlock()
 	return st.calibration
@@ -1185,8 +1141,9 @@ func (st *SpatialTracker) executeCustomScanPattern() {
 		if targetReached {
 			// Camera has reached target - start dwell timer
 			st.scanPositionStartTime = time.Now()
-			spatialDebugMsg("MIAMI_SCAN", fmt.Sprintf("✅ Arrived at Position %d: %s | Starting %ds dwell",
-				currentPosition.ID, currentPosition.Name, currentPosition.DwellTime))
+			st.currentDwell = st.scanDwell(currentPosition)
+			spatialDebugMsg("MIAMI_SCAN", fmt.Sprintf("✅ Arrived at Position %d: %s | Starting %.0fs dwell",
+				currentPosition.ID, currentPosition.Name, st.currentDwell.Seconds()))
 		} else {
 			// Need to move to target position - SEND THE ACTUAL COMMAND
 			spatialDebugMsg("MIAMI_SCAN", fmt.Sprintf("🎯 Moving to Position %d: %s | Current: Pan=%.0f Tilt=%.0f Zoom=%.0f → Target: Pan=%.0f Tilt=%.0f Zoom=%.0f",
@@ -1206,7 +1163,7 @@ func (st *SpatialTracker) executeCustomScanPattern() {
 	}
 
 	// We're in dwell mode - check if dwell time is complete
-	dwellDuration := time.Duration(currentPosition.DwellTime) * time.Second
+	dwellDuration := st.currentDwell
 	timeAtPosition := time.Since(st.scanPositionStartTime)
 
 	if timeAtPosition < dwellDuration {
@@ -0,0 +1,185 @@
+package tracking
+
+import (
+	"fmt"
+	"math"
+
+	"rivercam/calibration"
+)
+
+const (
+	// metersPerSecondToKnots converts m/s to knots (1 kn = 1852 m/h)
+	metersPerSecondToKnots = 3600.0 / 1852.0
+
+	// speedSmoothing is the weight of a new estimate in a boat's running speed; pixel velocity is noisy
+	// frame to frame and range amplifies the noise far from the camera
+	speedSmoothing = 0.2
+
+	// minSpeedDepressionDegrees keeps the height-based range finite: directions closer to the horizon than
+	// this give ranges too sensitive to a single tilt unit to trust
+	minSpeedDepressionDegrees = 0.5
+)
+
+// SpeedConfig is the geometry needed to turn angular motion into meters. With a camera height the range of
+// each boat follows from how far below the horizon it is; otherwise every boat is assumed to be
+// WaterDistanceM away (e.g. the far bank of a narrow channel). Boats near the horizon fall back to
+// WaterDistanceM when both are set.
+type SpeedConfig struct {
+	CameraHeightM  float64 // Lens height above the water (0: range from WaterDistanceM only)
+	TiltHorizon    float64 // Tilt reading (camera units) when level with the horizon
+	WaterDistanceM float64 // Fixed distance to the watched water line (0: height-based range only)
+}
+
+// SpeedEstimate is a boat's real-world speed over the water
+type SpeedEstimate struct {
+	MetersPerSecond float64 `json:"meters_per_second"`
+	Knots           float64 `json:"knots"`
+	RangeM          float64 `json:"range_m"` // Distance from the camera the speed was scaled with
+	Method          string  `json:"method"`  // "height" (ground projection) or "distance" (fixed water-line distance)
+}
+
+// speedStats is the running speed of one locked boat
+type speedStats struct {
+	current SpeedEstimate // Smoothed estimate
+	max     float64       // Highest smoothed speed (m/s)
+	sum     float64
+	samples int
+}
+
+// ConfigureSpeedEstimation enables real-world speed estimates for locked boats from their (ego-motion
+// compensated) pixel velocity, the PTZ calibration table and the camera geometry. A nil config disables it.
+// Each boat's speed summary is published as a SPEED event when its track ends.
+func (si *SpatialIntegration) ConfigureSpeedEstimation(config *SpeedConfig) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if config == nil || (config.CameraHeightM <= 0 && config.WaterDistanceM <= 0) {
+		si.speedConfig = nil
+		si.speeds = nil
+		return
+	}
+	si.speedConfig = config
+	si.speeds = make(map[string]*speedStats)
+
+	switch {
+	case config.CameraHeightM > 0 && config.WaterDistanceM > 0:
+		si.debugMsg("SPEED_CONFIG", fmt.Sprintf("🚤 Speed estimation from camera height %.1fm (horizon at tilt %.0f), %.0fm water line near the horizon",
+			config.CameraHeightM, config.TiltHorizon, config.WaterDistanceM))
+	case config.CameraHeightM > 0:
+		si.debugMsg("SPEED_CONFIG", fmt.Sprintf("🚤 Speed estimation from camera height %.1fm (horizon at tilt %.0f)",
+			config.CameraHeightM, config.TiltHorizon))
+	default:
+		si.debugMsg("SPEED_CONFIG", fmt.Sprintf("🚤 Speed estimation at a fixed %.0fm water line distance (motion across the view only)",
+			config.WaterDistanceM))
+	}
+}
+
+// estimateSpeed converts a boat's current pixel velocity into a speed over the water. ok is false when the
+// boat's range cannot be determined. Must be called with si.mu held.
+func (si *SpatialIntegration) estimateSpeed(boat *TrackedBoat) (estimate SpeedEstimate, ok bool) {
+	config := si.speedConfig
+	zoom := boat.CurrentSpatial.Zoom
+	panPixelsPerUnit := si.spatialTracker.InterpolatePanCalibration(zoom)
+	tiltPixelsPerUnit := si.spatialTracker.InterpolateTiltCalibration(zoom)
+	if panPixelsPerUnit <= 0 || tiltPixelsPerUnit <= 0 {
+		panPixelsPerUnit, tiltPixelsPerUnit = calibration.DefaultTable().InterpolateAt(zoom)
+	}
+	if panPixelsPerUnit <= 0 || tiltPixelsPerUnit <= 0 {
+		return SpeedEstimate{}, false
+	}
+
+	// Angular rates in radians per second (10 camera units = 1°); moving right is +pan, moving down is +tilt
+	panRate := boat.PixelVelocity.X / panPixelsPerUnit / 10 * math.Pi / 180
+	tiltRate := boat.PixelVelocity.Y / tiltPixelsPerUnit / 10 * math.Pi / 180
+
+	var metersPerSecond float64
+	depression := (boat.CurrentSpatial.Tilt - config.TiltHorizon) / 10
+	switch {
+	case config.CameraHeightM > 0 && depression >= minSpeedDepressionDegrees:
+		// Ground projection: range = h / tan(depression). Panning sweeps the range sideways; tilting down
+		// brings the boat closer at h / sin²(depression) per radian.
+		radians := depression * math.Pi / 180
+		estimate.RangeM = config.CameraHeightM / math.Tan(radians)
+		estimate.Method = "height"
+		across := estimate.RangeM * panRate
+		along := config.CameraHeightM / math.Pow(math.Sin(radians), 2) * tiltRate
+		metersPerSecond = math.Hypot(across, along)
+	case config.WaterDistanceM > 0:
+		// At a fixed distance only motion across the view can be measured; tilt is range, which is unknown
+		estimate.RangeM = config.WaterDistanceM
+		estimate.Method = "distance"
+		metersPerSecond = math.Abs(estimate.RangeM * panRate)
+	default:
+		return SpeedEstimate{}, false
+	}
+
+	estimate.MetersPerSecond = metersPerSecond
+	estimate.Knots = metersPerSecond * metersPerSecondToKnots
+	return estimate, true
+}
+
+// speedEstimate returns the smoothed speed of a boat (nil when none was estimated). Must be called with
+// si.mu held.
+func (si *SpatialIntegration) speedEstimate(objectID string) *SpeedEstimate {
+	stats, ok := si.speeds[objectID]
+	if !ok || stats.samples == 0 {
+		return nil
+	}
+	estimate := stats.current
+	return &estimate
+}
+
+// recordSpeeds updates the smoothed speed of every locked boat detected this frame and reports the boats that
+// stopped being tracked. Must be called with si.mu held, after the velocities were updated.
+func (si *SpatialIntegration) recordSpeeds() {
+	if si.speedConfig == nil {
+		return
+	}
+
+	for id, boat := range si.allBoats {
+		if !boat.IsLocked || boat.LostFrames > 0 {
+			continue
+		}
+		estimate, ok := si.estimateSpeed(boat)
+		if !ok {
+			continue
+		}
+		stats, exists := si.speeds[id]
+		if !exists {
+			stats = &speedStats{current: estimate}
+			si.speeds[id] = stats
+		} else {
+			smoothed := stats.current.MetersPerSecond + speedSmoothing*(estimate.MetersPerSecond-stats.current.MetersPerSecond)
+			estimate.MetersPerSecond = smoothed
+			estimate.Knots = smoothed * metersPerSecondToKnots
+			stats.current = estimate
+		}
+		stats.max = math.Max(stats.max, stats.current.MetersPerSecond)
+		stats.sum += stats.current.MetersPerSecond
+		stats.samples++
+	}
+
+	for id, stats := range si.speeds {
+		if _, tracked := si.allBoats[id]; tracked {
+			continue
+		}
+		delete(si.speeds, id)
+		si.reportSpeed(id, stats)
+	}
+}
+
+// reportSpeed publishes the speed summary of a boat that stopped being tracked
+func (si *SpatialIntegration) reportSpeed(objectID string, stats *speedStats) {
+	average := stats.sum / float64(stats.samples)
+	si.logDebugMessage(fmt.Sprintf("🚤 Speed while locked: avg %.1f kn (%.1f m/s), max %.1f kn at ~%.0fm",
+		average*metersPerSecondToKnots, average, stats.max*metersPerSecondToKnots, stats.current.RangeM), "SPEED", 1, map[string]interface{}{
+		"object_id":     objectID,
+		"avg_speed_ms":  average,
+		"max_speed_ms":  stats.max,
+		"avg_knots":     average * metersPerSecondToKnots,
+		"max_knots":     stats.max * metersPerSecondToKnots,
+		"last_range_m":  stats.current.RangeM,
+		"method":        stats.current.Method,
+		"speed_samples": stats.samples,
+	})
+}
@@ -0,0 +1,204 @@
+package tracking
+
+import (
+	"time"
+)
+
+// BoatStateSnapshot is a copy of a TrackedBoat's diagnostic fields, safe to serialize outside the lock
+type BoatStateSnapshot struct {
+	ID               string
+	Classification   string
+	Confidence       float64
+	FirstDetected    time.Time
+	LastSeen         time.Time
+	DetectionCount   int
+	LostFrames       int
+	IsLocked         bool
+	State            string // Lifecycle state (TENTATIVE, CONFIRMED, LOCKED, SUPER_LOCKED, COASTING)
+	LockStrength     float64
+	TrackingPriority float64
+	IsTarget         bool
+
+	CurrentPixel    struct{ X, Y int }
+	PredictedPixel  struct{ X, Y int }
+	PixelArea       float64
+	BoundingBox     struct{ MinX, MinY, MaxX, MaxY int }
+	PixelVelocity   struct{ X, Y float64 }
+	SpatialVelocity struct{ Pan, Tilt float64 }
+	PixelHistoryLen int
+
+	CurrentSpatial   SpatialCoordinate
+	PredictedSpatial SpatialCoordinate
+
+	HasP2Objects bool
+	P2Count      int
+	P2Confidence float64
+	P2Quality    float64
+	UseP2Target  bool
+	LastP2Seen   time.Time
+
+	Speed   *SpeedEstimate // Speed over the water while locked (speed estimation)
+	Quality *TrackQuality  // Track quality once measured
+
+	EdgeClipped string `json:",omitempty"` // Frame borders the boat is cut off by (e.g. "left+top")
+}
+
+// TrackingStateSnapshot is a point-in-time copy of the complete tracking state for diagnostics
+type TrackingStateSnapshot struct {
+	CapturedAt   time.Time
+	FrameCount   int
+	Mode         string
+	TargetID     string
+	Boats        []BoatStateSnapshot
+	IsInRecovery bool
+	Recovery     *RecoveryData
+
+	// Post-lock holdover
+	LastLockLoss        time.Time
+	HoldoverPositionSet bool
+	LastLockedPosition  SpatialCoordinate
+
+	// Last commands sent to the camera
+	LastSentPan  float64
+	LastSentTilt float64
+	LastSentZoom float64
+
+	// Configuration in effect
+	MinDetectionsForLock   int
+	MaxLostFrames          int
+	TargetSwitchCooldown   int
+	LastTargetSwitch       int
+	SmartPTZEnabled        bool
+	PTZPredictionTime      float64
+	PTZMinVelocity         float64
+	PTZBufferFactor        float64
+	PipelineLatency        float64
+	CenterTriggerThreshold float64
+	P1TrackList            []string
+	P2TrackList            []string
+	P1TrackAll             bool
+	P2TrackAll             bool
+	P1MinConfidence        float64
+	P2MinConfidence        float64
+	TotalDetectedObjects   int64
+
+	// Operator control
+	PinnedTargetID string
+	ScanningPaused bool
+	TourTargetID   string // Boat whose turn it is in tour mode ("" = no tour)
+
+	// Glare windows suppressing detections
+	GlareWindows []string
+}
+
+// SnapshotState copies the complete tracking state under the read lock so it can be dumped without stalling tracking
+func (si *SpatialIntegration) SnapshotState() *TrackingStateSnapshot {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+
+	snapshot := &TrackingStateSnapshot{
+		CapturedAt:             time.Now(),
+		FrameCount:             si.frameCount,
+		Mode:                   "SCANNING",
+		IsInRecovery:           si.isInRecovery,
+		LastLockLoss:           si.lastLockLoss,
+		HoldoverPositionSet:    si.holdoverPositionSet,
+		LastLockedPosition:     si.lastLockedPosition,
+		LastSentPan:            si.lastSentPan,
+		LastSentTilt:           si.lastSentTilt,
+		LastSentZoom:           si.lastSentZoom,
+		MinDetectionsForLock:   si.minDetectionsForLock,
+		MaxLostFrames:          si.maxLostFrames,
+		TargetSwitchCooldown:   si.targetSwitchCooldown,
+		LastTargetSwitch:       si.lastTargetSwitch,
+		SmartPTZEnabled:        si.smartPTZEnabled,
+		PTZPredictionTime:      si.ptzPredictionTime,
+		PTZMinVelocity:         si.ptzMinVelocity,
+		PTZBufferFactor:        si.ptzBufferFactor,
+		PipelineLatency:        si.pipelineLatency,
+		CenterTriggerThreshold: si.centerTriggerThreshold,
+		P1TrackList:            append([]string(nil), si.p1TrackList...),
+		P2TrackList:            append([]string(nil), si.p2TrackList...),
+		P1TrackAll:             si.p1TrackAll,
+		P2TrackAll:             si.p2TrackAll,
+		P1MinConfidence:        si.p1MinConfidence,
+		P2MinConfidence:        si.p2MinConfidence,
+		TotalDetectedObjects:   si.totalDetectedObjectsCounter,
+		PinnedTargetID:         si.pinnedTargetID,
+		ScanningPaused:         si.scanningPaused,
+		TourTargetID:           si.tourTargetID,
+		GlareWindows:           si.activeGlareWindows(),
+	}
+
+	if si.isInRecovery && si.recoveryData != nil {
+		recovery := *si.recoveryData
+		snapshot.Recovery = &recovery
+		snapshot.Mode = si.recoveryData.CurrentPhase.String()
+	}
+
+	if si.targetBoat != nil {
+		snapshot.TargetID = si.targetBoat.ID
+		snapshot.Mode = "TRACKING"
+		if si.targetBoat.IsLocked {
+			snapshot.Mode = "LOCK"
+		}
+	}
+
+	for _, boat := range si.allBoats {
+		snapshot.Boats = append(snapshot.Boats, si.snapshotBoat(boat))
+	}
+
+	return snapshot
+}
+
+// SnapshotBoats copies the tracked objects only, for per-frame consumers that don't need the complete state
+func (si *SpatialIntegration) SnapshotBoats() []BoatStateSnapshot {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+
+	boats := make([]BoatStateSnapshot, 0, len(si.allBoats))
+	for _, boat := range si.allBoats {
+		boats = append(boats, si.snapshotBoat(boat))
+	}
+	return boats
+}
+
+// snapshotBoat copies a boat's diagnostic fields. Must be called with si.mu held.
+func (si *SpatialIntegration) snapshotBoat(boat *TrackedBoat) BoatStateSnapshot {
+	boatSnapshot := BoatStateSnapshot{
+		ID:               boat.ID,
+		Classification:   boat.Classification,
+		Confidence:       boat.Confidence,
+		FirstDetected:    boat.FirstDetected,
+		LastSeen:         boat.LastSeen,
+		DetectionCount:   boat.DetectionCount,
+		LostFrames:       boat.LostFrames,
+		IsLocked:         boat.IsLocked,
+		State:            boat.State.String(),
+		LockStrength:     boat.LockStrength,
+		TrackingPriority: boat.TrackingPriority,
+		IsTarget:         boat == si.targetBoat,
+		PixelArea:        boat.PixelArea,
+		PixelVelocity:    boat.PixelVelocity,
+		SpatialVelocity:  boat.SpatialVelocity,
+		PixelHistoryLen:  len(boat.PixelHistory),
+		CurrentSpatial:   boat.CurrentSpatial,
+		PredictedSpatial: boat.PredictedSpatial,
+		HasP2Objects:     boat.HasP2Objects,
+		P2Count:          boat.P2Count,
+		P2Confidence:     boat.P2Confidence,
+		P2Quality:        boat.P2Quality,
+		UseP2Target:      boat.UseP2Target,
+		LastP2Seen:       boat.LastP2Seen,
+		Speed:            si.speedEstimate(boat.ID),
+		Quality:          boat.qualitySnapshot(),
+	}
+	if boat.EdgeClipped != 0 {
+		boatSnapshot.EdgeClipped = boat.EdgeClipped.String()
+	}
+	boatSnapshot.CurrentPixel.X, boatSnapshot.CurrentPixel.Y = boat.CurrentPixel.X, boat.CurrentPixel.Y
+	boatSnapshot.PredictedPixel.X, boatSnapshot.PredictedPixel.Y = boat.PredictedPixel.X, boat.PredictedPixel.Y
+	boatSnapshot.BoundingBox.MinX, boatSnapshot.BoundingBox.MinY = boat.BoundingBox.Min.X, boat.BoundingBox.Min.Y
+	boatSnapshot.BoundingBox.MaxX, boatSnapshot.BoundingBox.MaxY = boat.BoundingBox.Max.X, boat.BoundingBox.Max.Y
+	return boatSnapshot
+}
@@ -0,0 +1,120 @@
+package tracking
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DefaultTourDwell is how long the tour mode follows each lock-eligible boat before moving to the next
+const DefaultTourDwell = 20 * time.Second
+
+// tourMaxLostTime is how long a boat may go undetected and still take its turn in the tour
+const tourMaxLostTime = gapToleranceTime
+
+// TargetTourConfig enables the tour mode: while several boats are lock-eligible at once the camera dwells on
+// each in turn instead of only ever following the best-scored one
+type TargetTourConfig struct {
+	Dwell time.Duration // How long each boat is followed (0 disables the mode)
+}
+
+// ConfigureTargetTour enables (Dwell > 0) or disables the tour mode
+func (si *SpatialIntegration) ConfigureTargetTour(config TargetTourConfig) error {
+	if config.Dwell < 0 {
+		return fmt.Errorf("tour dwell must not be negative, got %v", config.Dwell)
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.targetTour = config
+	si.tourTargetID = ""
+	if config.Dwell > 0 {
+		si.debugMsg("TARGET_TOUR", fmt.Sprintf("🔄 Tour mode: dwell %v on each lock-eligible boat when several are tracked", config.Dwell))
+	}
+	return nil
+}
+
+// tourCandidates returns the boats eligible for the tour - locked or meeting the lock criteria and recently
+// detected - oldest first, so every boat keeps its place in the rotation. Must be called with si.mu held.
+func (si *SpatialIntegration) tourCandidates() []*TrackedBoat {
+	var candidates []*TrackedBoat
+	for _, boat := range si.allBoats {
+		if si.lostLongerThan(boat, tourMaxLostTime) || boat.PersonOverboard || boat.Quarantined {
+			continue
+		}
+		if boat.IsLocked || (si.meetsLockCriteria(boat) && si.ensembleAllowsLock(boat)) {
+			candidates = append(candidates, boat)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if !candidates[i].FirstDetected.Equal(candidates[j].FirstDetected) {
+			return candidates[i].FirstDetected.Before(candidates[j].FirstDetected)
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+	return candidates
+}
+
+// selectTourTarget cycles the locked target through the lock-eligible boats, Dwell on each. It returns false
+// when the mode is off or fewer than two boats are eligible, leaving selection to the scoring. Must be called
+// with si.mu held.
+func (si *SpatialIntegration) selectTourTarget() bool {
+	if si.targetTour.Dwell <= 0 {
+		return false
+	}
+	candidates := si.tourCandidates()
+	if len(candidates) < 2 {
+		if si.tourTargetID != "" {
+			si.debugMsg("TARGET_TOUR", fmt.Sprintf("🔄 Tour ended - %d lock-eligible boat(s) left", len(candidates)), si.tourTargetID)
+			si.tourTargetID = ""
+		}
+		return false
+	}
+
+	// The boat whose turn it is, or the current target when a tour starts so the camera doesn't jump
+	current := -1
+	turnID := si.tourTargetID
+	if turnID == "" {
+		if si.targetBoat != nil {
+			turnID = si.targetBoat.ID
+		}
+		si.tourSince = time.Now()
+		si.debugMsg("TARGET_TOUR", fmt.Sprintf("🔄 Tour started with %d lock-eligible boats", len(candidates)))
+	}
+	for i, boat := range candidates {
+		if boat.ID == turnID {
+			current = i
+			break
+		}
+	}
+
+	next := current
+	switch {
+	case current < 0: // The boat whose turn it was is gone - start over with the oldest
+		next = 0
+	case time.Since(si.tourSince) >= si.targetTour.Dwell:
+		next = (current + 1) % len(candidates)
+	}
+	if next != current {
+		si.tourSince = time.Now()
+		si.debugMsg("TARGET_TOUR", fmt.Sprintf("🔄 Tour: %s's turn (%d/%d) for %v",
+			candidates[next].ID, next+1, len(candidates), si.targetTour.Dwell), candidates[next].ID)
+	}
+
+	boat := candidates[next]
+	si.tourTargetID = boat.ID
+	if si.targetBoat != boat {
+		// The previous boat's turn is over: it goes back to an unlocked track until its next turn
+		if previous := si.targetBoat; previous != nil {
+			previous.IsLocked = false
+			previous.LockStrength = 0
+		}
+		si.targetBoat = boat
+		si.lastTargetSwitch = si.frameCount
+	}
+	boat.IsLocked = true
+	si.isInRecovery = false
+	si.recoveryData = nil
+	return true
+}
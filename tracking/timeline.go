@@ -0,0 +1,143 @@
+package tracking
+
+import (
+	"time"
+)
+
+// TimelineEventType identifies what happened to a tracked object at a point in time
+type TimelineEventType int
+
+const (
+	TimelineDetection TimelineEventType = iota // Object was detected this frame
+	TimelineLost                               // Object stopped being detected
+	TimelineLock                               // Object became LOCKED
+	TimelineSuperLock                          // Object reached SUPER LOCK
+	TimelineUnlock                             // Object lost its lock (target switch or removal)
+	TimelineRecovery                           // Recovery phase change for this object
+)
+
+func (t TimelineEventType) String() string {
+	switch t {
+	case TimelineDetection:
+		return "DETECTION"
+	case TimelineLost:
+		return "LOST"
+	case TimelineLock:
+		return "LOCK"
+	case TimelineSuperLock:
+		return "SUPER_LOCK"
+	case TimelineUnlock:
+		return "UNLOCK"
+	case TimelineRecovery:
+		return "RECOVERY"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// TimelineEvent is a single entry on a per-object timeline
+type TimelineEvent struct {
+	Time  time.Time
+	Type  TimelineEventType
+	Label string // Extra detail (e.g. recovery phase name)
+}
+
+// TimelineWindow is how much history is kept per object for the overlay timeline strip
+const TimelineWindow = 30 * time.Second
+
+// objectTimeline holds recent events plus the last observed state used to detect transitions
+type objectTimeline struct {
+	events        []TimelineEvent
+	wasLost       bool
+	wasLocked     bool
+	wasSuperLock  bool
+	lastRecovery  string
+	lastEventTime time.Time
+}
+
+// add appends an event to the timeline
+func (ot *objectTimeline) add(now time.Time, eventType TimelineEventType, label string) {
+	ot.events = append(ot.events, TimelineEvent{Time: now, Type: eventType, Label: label})
+	ot.lastEventTime = now
+}
+
+// recordTimelineEvents diffs current boat state against the previous frame and records transitions.
+// Must be called with si.mu held.
+func (si *SpatialIntegration) recordTimelineEvents() {
+	if si.timelines == nil {
+		si.timelines = make(map[string]*objectTimeline)
+	}
+
+	now := time.Now()
+
+	for id, boat := range si.allBoats {
+		timeline, exists := si.timelines[id]
+		if !exists {
+			timeline = &objectTimeline{}
+			si.timelines[id] = timeline
+		}
+
+		lost := boat.LostFrames > 0
+		if !lost {
+			timeline.add(now, TimelineDetection, "")
+		} else if !timeline.wasLost {
+			timeline.add(now, TimelineLost, "")
+		}
+		timeline.wasLost = lost
+
+		superLock := isSuperLocked(boat)
+		if boat.IsLocked && !timeline.wasLocked {
+			timeline.add(now, TimelineLock, "")
+		} else if !boat.IsLocked && timeline.wasLocked {
+			timeline.add(now, TimelineUnlock, "")
+		}
+		if superLock && !timeline.wasSuperLock {
+			timeline.add(now, TimelineSuperLock, "")
+		}
+		timeline.wasLocked = boat.IsLocked
+		timeline.wasSuperLock = superLock
+	}
+
+	// Recovery phases are tracked against the lost object's ID (it may no longer be in allBoats)
+	if si.isInRecovery && si.recoveryData != nil {
+		timeline, exists := si.timelines[si.recoveryData.ObjectID]
+		if !exists {
+			timeline = &objectTimeline{}
+			si.timelines[si.recoveryData.ObjectID] = timeline
+		}
+		phase := si.recoveryData.CurrentPhase.String()
+		if phase != timeline.lastRecovery {
+			timeline.add(now, TimelineRecovery, phase)
+			timeline.lastRecovery = phase
+		}
+	}
+
+	// Trim events outside the window and forget objects with no recent activity
+	cutoff := now.Add(-TimelineWindow)
+	for id, timeline := range si.timelines {
+		firstKept := 0
+		for firstKept < len(timeline.events) && timeline.events[firstKept].Time.Before(cutoff) {
+			firstKept++
+		}
+		timeline.events = timeline.events[firstKept:]
+
+		if _, tracked := si.allBoats[id]; !tracked && timeline.lastEventTime.Before(cutoff) {
+			delete(si.timelines, id)
+		}
+	}
+}
+
+// GetObjectTimeline returns a copy of the last TimelineWindow of events for an objectID
+func (si *SpatialIntegration) GetObjectTimeline(objectID string) []TimelineEvent {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+
+	timeline, exists := si.timelines[objectID]
+	if !exists {
+		return nil
+	}
+
+	events := make([]TimelineEvent, len(timeline.events))
+	copy(events, timeline.events)
+	return events
+}
@@ -0,0 +1,241 @@
+package tracking
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Track merging defaults
+const (
+	DefaultTrackMergeWindow    = 10 * time.Second // Longest gap between one track ending and the next starting
+	DefaultTrackMergeDistance  = 60.0             // PTZ units the next track may start from the predicted position
+	DefaultTrackMergeSizeRatio = 2.5              // Largest ratio between the two tracks' sizes at 1x zoom
+)
+
+// Track merge tuning
+const (
+	trackMergeMotionPoints = 4   // Path points used to estimate the velocity at either end of a track
+	trackMergeMinSpeed     = 2.0 // PTZ units/s below which a track counts as stationary (no direction to compare)
+	trackMergeSpeedRatio   = 4.0 // Largest ratio between the two tracks' speeds when both are moving
+	trackMergeDriftShare   = 0.5 // Extra start tolerance per unit of distance the boat covered during the gap
+)
+
+// TrackMergeConfig bounds how consistent two tracks must be to be joined as fragments of one boat
+type TrackMergeConfig struct {
+	Window       time.Duration // Longest gap between the end of one track and the start of the next
+	MaxDistance  float64       // PTZ units between the predicted and the actual start of the next track
+	MaxSizeRatio float64       // Largest size ratio at 1x zoom (0 = sizes are not compared)
+}
+
+// DefaultTrackMergeConfig returns the merge bounds used when nothing is configured
+func DefaultTrackMergeConfig() TrackMergeConfig {
+	return TrackMergeConfig{Window: DefaultTrackMergeWindow, MaxDistance: DefaultTrackMergeDistance, MaxSizeRatio: DefaultTrackMergeSizeRatio}
+}
+
+// Validate checks the merge bounds
+func (c TrackMergeConfig) Validate() error {
+	if c.Window <= 0 {
+		return fmt.Errorf("track merge window must be positive, got %v", c.Window)
+	}
+	if c.MaxDistance <= 0 {
+		return fmt.Errorf("track merge distance must be positive, got %v", c.MaxDistance)
+	}
+	if c.MaxSizeRatio != 0 && c.MaxSizeRatio < 1 {
+		return fmt.Errorf("track merge size ratio must be at least 1 (or 0 to ignore sizes), got %v", c.MaxSizeRatio)
+	}
+	return nil
+}
+
+// TrackMerge is one track joined to an earlier track of the same boat
+type TrackMerge struct {
+	ObjectID   string  `json:"object_id"`   // ID the tracker gave the later fragment
+	MergedInto string  `json:"merged_into"` // ID of the first fragment, which the boat is counted under
+	Gap        float64 `json:"gap_s"`       // Seconds between the two fragments
+	Distance   float64 `json:"distance"`    // PTZ units between the predicted and the actual start
+}
+
+// trackMotion is the position and velocity (PTZ units/s) at one end of a track
+type trackMotion struct {
+	at         time.Time
+	pan, tilt  float64
+	vPan       float64
+	vTilt      float64
+	hasHeading bool
+}
+
+// MatchTrackFragments reports whether next can be the same boat as earlier after a detection dropout: same
+// classification, next starts within the window after earlier ended, near where earlier was heading, moving
+// the same way at a similar speed and of a similar size. The returned merge describes the match.
+func MatchTrackFragments(earlier, next TrackRecord, config TrackMergeConfig) (TrackMerge, bool) {
+	merge := TrackMerge{ObjectID: next.ObjectID, MergedInto: earlier.ObjectID}
+	if earlier.MergedInto != "" {
+		merge.MergedInto = earlier.MergedInto
+	}
+	if earlier.ObjectID == next.ObjectID || earlier.Classification != next.Classification {
+		return merge, false
+	}
+	gap := next.FirstSeen.Sub(earlier.LastSeen)
+	if gap < 0 || gap > config.Window {
+		return merge, false // Overlapping tracks are two boats
+	}
+	merge.Gap = gap.Seconds()
+
+	end, ok := endMotion(earlier.Path)
+	if !ok {
+		return merge, false
+	}
+	start, ok := startMotion(next.Path)
+	if !ok {
+		return merge, false
+	}
+
+	// Where the earlier boat should be by the time the next track started
+	elapsed := math.Max(0, start.at.Sub(end.at).Seconds())
+	predictedPan := end.pan + end.vPan*elapsed
+	predictedTilt := end.tilt + end.vTilt*elapsed
+	merge.Distance = math.Hypot(panDifference(start.pan, predictedPan), start.tilt-predictedTilt)
+	covered := math.Hypot(end.vPan, end.vTilt) * elapsed
+	if merge.Distance > config.MaxDistance+trackMergeDriftShare*covered {
+		return merge, false
+	}
+
+	// Moving boats must keep their direction and roughly their speed
+	if end.hasHeading && start.hasHeading {
+		endSpeed, startSpeed := math.Hypot(end.vPan, end.vTilt), math.Hypot(start.vPan, start.vTilt)
+		if endSpeed >= trackMergeMinSpeed && startSpeed >= trackMergeMinSpeed {
+			if end.vPan*start.vPan+end.vTilt*start.vTilt <= 0 {
+				return merge, false
+			}
+			if math.Max(endSpeed, startSpeed)/math.Min(endSpeed, startSpeed) > trackMergeSpeedRatio {
+				return merge, false
+			}
+		}
+	}
+
+	if config.MaxSizeRatio > 0 && earlier.Size > 0 && next.Size > 0 &&
+		math.Max(earlier.Size, next.Size)/math.Min(earlier.Size, next.Size) > config.MaxSizeRatio {
+		return merge, false
+	}
+	return merge, true
+}
+
+// BestTrackFragment returns the earlier track next most likely continues (the closest match to its predicted
+// start) and its index in candidates, or -1 if none matches
+func BestTrackFragment(candidates []TrackRecord, next TrackRecord, config TrackMergeConfig) (TrackMerge, int) {
+	var best TrackMerge
+	bestIndex := -1
+	for i, candidate := range candidates {
+		merge, ok := MatchTrackFragments(candidate, next, config)
+		if ok && (bestIndex < 0 || merge.Distance < best.Distance) {
+			best, bestIndex = merge, i
+		}
+	}
+	return best, bestIndex
+}
+
+// endMotion is the last position of a path and the velocity leading up to it
+func endMotion(path []TrackPoint) (trackMotion, bool) {
+	if len(path) == 0 {
+		return trackMotion{}, false
+	}
+	last := path[len(path)-1]
+	from := path[max(0, len(path)-trackMergeMotionPoints)]
+	return motionBetween(from, last, last), true
+}
+
+// startMotion is the first position of a path and the velocity leaving it
+func startMotion(path []TrackPoint) (trackMotion, bool) {
+	if len(path) == 0 {
+		return trackMotion{}, false
+	}
+	first := path[0]
+	to := path[min(len(path)-1, trackMergeMotionPoints-1)]
+	return motionBetween(first, to, first), true
+}
+
+// motionBetween is the motion at point given the velocity from one path point to another
+func motionBetween(from, to, point TrackPoint) trackMotion {
+	motion := trackMotion{at: point.Time, pan: point.Pan, tilt: point.Tilt}
+	if seconds := to.Time.Sub(from.Time).Seconds(); seconds > 0 {
+		motion.vPan = panDifference(to.Pan, from.Pan) / seconds
+		motion.vTilt = (to.Tilt - from.Tilt) / seconds
+		motion.hasHeading = true
+	}
+	return motion
+}
+
+// panDifference is a - b across the pan wrap
+func panDifference(a, b float64) float64 {
+	diff := a - b
+	if diff > 1800 { // Pan wraps at 3600
+		diff -= 3600
+	} else if diff < -1800 {
+		diff += 3600
+	}
+	return diff
+}
+
+// ConfigureTrackMerging joins tracks the tracker split into several IDs after detection dropouts: when a new
+// track continues one that ended within the window, its record is stored under the first fragment's ID and
+// a TRACK_MERGE event is published. Needs the track recorder.
+func (si *SpatialIntegration) ConfigureTrackMerging(config TrackMergeConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if si.trackEnded == nil {
+		return fmt.Errorf("track merging needs the track recorder")
+	}
+	si.trackMerge = &config
+	si.endedTracks = nil
+
+	si.debugMsg("TRACK_RECORDER", fmt.Sprintf("🔗 Merging track fragments within %v (start within %.0f PTZ units of the prediction, size ratio ≤ %.1f)",
+		config.Window, config.MaxDistance, config.MaxSizeRatio))
+	return nil
+}
+
+// mergeTrackFragment checks once whether a new recording continues a recently ended track, as soon as its
+// start motion is known (or when it ends first). Must be called with si.mu held.
+func (si *SpatialIntegration) mergeTrackFragment(recording *trackRecording, ended bool) {
+	if si.trackMerge == nil || recording.mergeChecked {
+		return
+	}
+	if !ended && len(recording.record.Path) < trackMergeMotionPoints {
+		return
+	}
+	recording.mergeChecked = true
+
+	merge, index := BestTrackFragment(si.endedTracks, recording.record, *si.trackMerge)
+	if index < 0 {
+		return
+	}
+	recording.record.MergedInto = merge.MergedInto
+	si.endedTracks = append(si.endedTracks[:index], si.endedTracks[index+1:]...) // A boat continues only once
+	si.logDebugMessage(fmt.Sprintf("🔗 %s continues %s after a %.1fs dropout (%.0f PTZ units from the predicted start)",
+		merge.ObjectID, merge.MergedInto, merge.Gap, merge.Distance), "TRACK_MERGE", 1, map[string]interface{}{
+		"object_id":   merge.ObjectID,
+		"merged_into": merge.MergedInto,
+		"gap_s":       merge.Gap,
+		"distance":    merge.Distance,
+	})
+}
+
+// rememberEndedTrack keeps a finished record as a merge candidate for the window. Must be called with si.mu
+// held.
+func (si *SpatialIntegration) rememberEndedTrack(record TrackRecord) {
+	if si.trackMerge == nil {
+		return
+	}
+	cutoff := time.Now().Add(-si.trackMerge.Window)
+	kept := si.endedTracks[:0]
+	for _, ended := range si.endedTracks {
+		if ended.LastSeen.After(cutoff) {
+			kept = append(kept, ended)
+		}
+	}
+	si.endedTracks = append(kept, record)
+}
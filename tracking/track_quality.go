@@ -0,0 +1,183 @@
+package tracking
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"time"
+)
+
+// Track quality defaults
+const (
+	DefaultTrackQualityMin   = 0.35            // Quality below which a track is quarantined
+	DefaultTrackQualityGrace = 3 * time.Second // How long a quarantined track may recover before it is removed
+)
+
+const (
+	trackQualitySmoothing  = 0.15 // Weight of each detection in the smoothed quality components
+	trackQualityMinSamples = 10   // Detections a track needs before its quality is judged
+	trackQualityHysteresis = 0.10 // Quality above the minimum that releases a quarantined track
+	trackQualityStillStep  = 0.10 // Steps shorter than this share of the box size are noise, not motion
+
+	// Weights of the components in the quality score
+	trackQualitySmoothWeight = 0.4
+	trackQualityMotionWeight = 0.3
+	trackQualityClassWeight  = 0.3
+)
+
+// TrackQualityConfig configures the pruning of bad tracks, e.g. a track that alternates between two boats or
+// jumps around after a mismatch
+type TrackQualityConfig struct {
+	MinQuality float64       // Tracks below this quality (0-1) are quarantined (0 disables pruning)
+	Grace      time.Duration // A track still quarantined this long is removed
+}
+
+// trackQuality is the running quality measurement of one track
+type trackQuality struct {
+	samples     int
+	smoothness  float64     // Closeness of each detection to the constant-velocity continuation, in box sizes
+	consistency float64     // Similarity of consecutive steps: reversals and sudden speed changes score low
+	stability   float64     // Share of detections with the same class as the one before
+	last, prev  image.Point // Last two detection centers (raw, before smoothing)
+	class       string      // Class of the last detection
+	quarantined time.Time   // When the track was quarantined
+	resync      bool        // The camera moved since the last sample: re-anchor before measuring steps
+}
+
+// TrackQuality is a track's quality score and its components, for the state dump
+type TrackQuality struct {
+	Score       float64 `json:"score"`
+	Smoothness  float64 `json:"smoothness"`
+	Consistency float64 `json:"consistency"`
+	Stability   float64 `json:"stability"`
+	Samples     int     `json:"samples"`
+	Quarantined bool    `json:"quarantined"`
+}
+
+// ConfigureTrackQuality sets when low-quality tracks are quarantined - kept and fed with detections, but never
+// selected, ranked or locked - and removed. The quality of every track is measured either way.
+func (si *SpatialIntegration) ConfigureTrackQuality(config TrackQualityConfig) error {
+	if config.MinQuality < 0 || config.MinQuality >= 1 {
+		return fmt.Errorf("minimum track quality must be between 0 and 1, got %.2f", config.MinQuality)
+	}
+	if config.Grace < 0 {
+		return fmt.Errorf("track quality grace must not be negative, got %v", config.Grace)
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.trackQuality = config
+	if config.MinQuality > 0 {
+		si.debugMsg("TRACK_QUALITY", fmt.Sprintf("🩺 Track quality pruning: quarantine below %.2f, remove after %v quarantined",
+			config.MinQuality, config.Grace))
+	}
+	return nil
+}
+
+// observeTrackQuality folds a detection matched to a track into its quality. Centers are pixel positions, so
+// detections taken while the camera pans, tilts or zooms are skipped: the frame moving under the boat would
+// read as erratic motion. Must be called with si.mu held.
+func (si *SpatialIntegration) observeTrackQuality(boat *TrackedBoat, center image.Point, area float64, className string) {
+	q := boat.quality
+	if q == nil {
+		q = &trackQuality{smoothness: 1, consistency: 1, stability: 1, last: center, prev: center, class: className}
+		boat.quality = q
+		boat.Quality = 1
+		return
+	}
+	if si.cameraStateManager != nil && !si.cameraStateManager.IsIdle() {
+		q.resync = true
+		return
+	}
+	if q.resync {
+		// First detection after the camera stopped: restart the step history from here
+		q.prev, q.last, q.class, q.resync = center, center, className, false
+		return
+	}
+
+	size := math.Max(math.Sqrt(area), 1)
+	step := center.Sub(q.last)
+	previousStep := q.last.Sub(q.prev)
+	change := pointLength(step.Sub(previousStep)) // Distance from the constant-velocity continuation
+
+	smoothness := 1 - math.Min(1, change/size)
+	consistency := 1.0
+	if motion := pointLength(step) + pointLength(previousStep); motion > size*trackQualityStillStep {
+		consistency = 1 - math.Min(1, change/motion)
+	}
+	stability := 0.0
+	if className == q.class {
+		stability = 1
+	}
+
+	q.samples++
+	q.smoothness += (smoothness - q.smoothness) * trackQualitySmoothing
+	q.consistency += (consistency - q.consistency) * trackQualitySmoothing
+	q.stability += (stability - q.stability) * trackQualitySmoothing
+	q.prev, q.last, q.class = q.last, center, className
+	boat.Quality = q.score()
+}
+
+func (q *trackQuality) score() float64 {
+	return trackQualitySmoothWeight*q.smoothness + trackQualityMotionWeight*q.consistency + trackQualityClassWeight*q.stability
+}
+
+func pointLength(p image.Point) float64 {
+	return math.Hypot(float64(p.X), float64(p.Y))
+}
+
+// pruneLowQualityTracks quarantines tracks whose quality falls below the minimum, releases those that recover
+// and removes those still quarantined after the grace period. A quarantined target loses its lock, so target
+// selection moves on. Person-overboard and operator-pinned tracks are exempt. Must be called with si.mu held,
+// before target selection.
+func (si *SpatialIntegration) pruneLowQualityTracks() {
+	minQuality := si.trackQuality.MinQuality
+	if minQuality <= 0 {
+		return
+	}
+	now := time.Now()
+	for id, boat := range si.allBoats {
+		q := boat.quality
+		if q == nil || q.samples < trackQualityMinSamples || boat.PersonOverboard || id == si.pinnedTargetID {
+			continue
+		}
+
+		switch {
+		case !boat.Quarantined && boat.Quality < minQuality:
+			boat.Quarantined = true
+			q.quarantined = now
+			si.debugMsg("TRACK_QUALITY", fmt.Sprintf("🩺 Quarantined %s: quality %.2f < %.2f (smoothness %.2f, consistency %.2f, class stability %.2f)",
+				id, boat.Quality, minQuality, q.smoothness, q.consistency, q.stability), id)
+			if boat == si.targetBoat {
+				boat.IsLocked = false
+				boat.LockStrength = 0
+				si.targetBoat = nil
+				si.debugMsg("TRACK_QUALITY", "🩺 Quarantined track was the target - releasing the camera", id)
+			}
+		case boat.Quarantined && boat.Quality >= minQuality+trackQualityHysteresis:
+			boat.Quarantined = false
+			si.debugMsg("TRACK_QUALITY", fmt.Sprintf("🩺 Released %s from quarantine: quality %.2f after %v",
+				id, boat.Quality, now.Sub(q.quarantined).Round(100*time.Millisecond)), id)
+		case boat.Quarantined && now.Sub(q.quarantined) >= si.trackQuality.Grace:
+			delete(si.allBoats, id)
+			si.debugMsg("TRACK_QUALITY", fmt.Sprintf("🗑️ Removed %s: quality %.2f for %v", id, boat.Quality, si.trackQuality.Grace), id)
+		}
+	}
+}
+
+// qualitySnapshot returns a track's quality for the state dump (nil before its first detection)
+func (boat *TrackedBoat) qualitySnapshot() *TrackQuality {
+	q := boat.quality
+	if q == nil {
+		return nil
+	}
+	return &TrackQuality{
+		Score:       boat.Quality,
+		Smoothness:  q.smoothness,
+		Consistency: q.consistency,
+		Stability:   q.stability,
+		Samples:     q.samples,
+		Quarantined: boat.Quarantined,
+	}
+}
@@ -0,0 +1,82 @@
+package tracking
+
+import (
+	"image"
+	"testing"
+)
+
+// observeTrack feeds a track's detection centers (all the same class and size) into its quality
+func observeTrack(si *SpatialIntegration, centers []image.Point, classes []string) *TrackedBoat {
+	boat := &TrackedBoat{}
+	for i, center := range centers {
+		className := "boat"
+		if classes != nil {
+			className = classes[i%len(classes)]
+		}
+		si.observeTrackQuality(boat, center, 100*100, className)
+	}
+	return boat
+}
+
+func TestTrackQualitySteadyMotion(t *testing.T) {
+	si := &SpatialIntegration{}
+	var centers []image.Point
+	for i := 0; i < 30; i++ {
+		centers = append(centers, image.Pt(100+i*20, 400+i*5))
+	}
+
+	boat := observeTrack(si, centers, nil)
+	if boat.Quality < 0.95 {
+		t.Errorf("steady track quality = %.2f, want ≥ 0.95", boat.Quality)
+	}
+	if boat.quality.samples != len(centers)-1 {
+		t.Errorf("samples = %d, want %d", boat.quality.samples, len(centers)-1)
+	}
+}
+
+func TestTrackQualityAlternatingBoats(t *testing.T) {
+	si := &SpatialIntegration{}
+	var centers []image.Point
+	for i := 0; i < 30; i++ {
+		if i%2 == 0 {
+			centers = append(centers, image.Pt(300, 400))
+		} else {
+			centers = append(centers, image.Pt(700, 420))
+		}
+	}
+
+	boat := observeTrack(si, centers, nil)
+	if boat.Quality >= DefaultTrackQualityMin {
+		t.Errorf("alternating track quality = %.2f, want < %.2f", boat.Quality, DefaultTrackQualityMin)
+	}
+}
+
+func TestTrackQualityClassFlapping(t *testing.T) {
+	si := &SpatialIntegration{}
+	var centers []image.Point
+	for i := 0; i < 30; i++ {
+		centers = append(centers, image.Pt(100+i*20, 400))
+	}
+
+	steady := observeTrack(si, centers, nil)
+	flapping := observeTrack(si, centers, []string{"boat", "surfboard"})
+	if flapping.quality.stability > 0.1 {
+		t.Errorf("flapping class stability = %.2f, want ≤ 0.1", flapping.quality.stability)
+	}
+	if flapping.Quality >= steady.Quality {
+		t.Errorf("flapping quality %.2f should be below steady quality %.2f", flapping.Quality, steady.Quality)
+	}
+}
+
+func TestTrackQualityStillTrackIsNotInconsistent(t *testing.T) {
+	si := &SpatialIntegration{}
+	var centers []image.Point
+	for i := 0; i < 30; i++ {
+		centers = append(centers, image.Pt(500+i%2, 400)) // One-pixel detection noise on a moored boat
+	}
+
+	boat := observeTrack(si, centers, nil)
+	if boat.quality.consistency < 0.99 {
+		t.Errorf("still track consistency = %.2f, want ≈ 1 (noise below the still step)", boat.quality.consistency)
+	}
+}
@@ -0,0 +1,184 @@
+package tracking
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// DefaultTrackPathInterval is how often a tracked object's spatial position is added to its recorded path
+const DefaultTrackPathInterval = time.Second
+
+// TrackPoint is one sample of a tracked object's path in camera coordinates
+type TrackPoint struct {
+	Time       time.Time `json:"time"`
+	Pan        float64   `json:"pan"`
+	Tilt       float64   `json:"tilt"`
+	Zoom       float64   `json:"zoom"`
+	Confidence float64   `json:"confidence"`
+}
+
+// TrackRecord is the lifecycle summary of one tracked object, handed to the track recorder once the object
+// stops being tracked
+type TrackRecord struct {
+	ObjectID       string            `json:"object_id"`
+	Classification string            `json:"classification"`
+	FirstSeen      time.Time         `json:"first_seen"`
+	LastSeen       time.Time         `json:"last_seen"`
+	Detections     int               `json:"detections"`
+	MinConfidence  float64           `json:"min_confidence"`
+	MaxConfidence  float64           `json:"max_confidence"`
+	AvgConfidence  float64           `json:"avg_confidence"`        // Mean of the per-frame confidences while the object was detected
+	MaxZoom        float64           `json:"max_zoom"`              // Highest zoom the camera was asked for while following the object
+	MaxPeople      int               `json:"max_people"`            // Most P2 objects (people) seen on board at once
+	Locked         bool              `json:"locked"`                // Was locked for camera tracking at some point
+	SuperLocked    bool              `json:"super_locked"`          // Reached SUPER LOCK at some point
+	Occupancy      *OccupancySummary `json:"occupancy,omitempty"`   // People on board while locked (occupancy analytics)
+	Size           float64           `json:"size,omitempty"`        // Mean bounding box size (square root of the area) in pixels at 1x zoom
+	MergedInto     string            `json:"merged_into,omitempty"` // First fragment's ID when this track continues one that was split by a detection dropout
+	Path           []TrackPoint      `json:"path,omitempty"`
+}
+
+// trackRecording accumulates a TrackRecord while its object is tracked
+type trackRecording struct {
+	record        TrackRecord
+	confidenceSum float64
+	samples       int
+	sizeSum       float64
+	sizeSamples   int
+	lastPoint     time.Time
+	mergeChecked  bool
+}
+
+// ConfigureTrackRecorder enables lifecycle recording of every tracked object. onTrackEnded receives each
+// finished record (on its own goroutine) when the object is removed from tracking.
+func (si *SpatialIntegration) ConfigureTrackRecorder(pathInterval time.Duration, onTrackEnded func(TrackRecord)) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if pathInterval <= 0 {
+		pathInterval = DefaultTrackPathInterval
+	}
+	si.trackPathInterval = pathInterval
+	si.trackEnded = onTrackEnded
+	si.trackRecordings = make(map[string]*trackRecording)
+
+	si.debugMsg("TRACK_RECORDER", fmt.Sprintf("🗃️ Recording track lifecycles (path sampled every %v)", pathInterval))
+}
+
+// recordTracks updates the recordings of all tracked objects and finishes the ones that were removed this
+// frame. Must be called with si.mu held, after updateTrackLifecycles.
+func (si *SpatialIntegration) recordTracks() {
+	if si.trackEnded == nil {
+		return
+	}
+
+	now := time.Now()
+	for id, boat := range si.allBoats {
+		recording, exists := si.trackRecordings[id]
+		if !exists {
+			recording = &trackRecording{record: TrackRecord{
+				ObjectID:      id,
+				FirstSeen:     boat.FirstDetected,
+				MinConfidence: math.Inf(1),
+			}}
+			si.trackRecordings[id] = recording
+		}
+
+		record := &recording.record
+		record.Classification = boat.Classification
+		record.LastSeen = boat.LastSeen
+		record.Detections = boat.DetectionCount
+		record.Locked = record.Locked || boat.IsLocked
+		record.SuperLocked = record.SuperLocked || boat.State == TrackSuperLocked
+		if boat.P2Count > record.MaxPeople {
+			record.MaxPeople = boat.P2Count
+		}
+		if boat.CurrentSpatial.Zoom > record.MaxZoom {
+			record.MaxZoom = boat.CurrentSpatial.Zoom
+		}
+		if occupancy := si.occupancySummary(id); occupancy != nil {
+			record.Occupancy = occupancy
+		}
+
+		// Confidence statistics only count frames the object was actually detected in
+		if boat.LostFrames == 0 {
+			record.MinConfidence = math.Min(record.MinConfidence, boat.Confidence)
+			record.MaxConfidence = math.Max(record.MaxConfidence, boat.Confidence)
+			recording.confidenceSum += boat.Confidence
+			recording.samples++
+			if area := boat.BoundingBox.Dx() * boat.BoundingBox.Dy(); area > 0 {
+				recording.sizeSum += math.Sqrt(float64(area)) * 10 / math.Max(10, boat.CurrentSpatial.Zoom) // Zoom 10 is 1x
+				recording.sizeSamples++
+			}
+		}
+
+		if now.Sub(recording.lastPoint) >= si.trackPathInterval && (boat.CurrentSpatial.Pan != 0 || boat.CurrentSpatial.Tilt != 0) {
+			record.Path = append(record.Path, TrackPoint{
+				Time:       now,
+				Pan:        boat.CurrentSpatial.Pan,
+				Tilt:       boat.CurrentSpatial.Tilt,
+				Zoom:       boat.CurrentSpatial.Zoom,
+				Confidence: boat.Confidence,
+			})
+			recording.lastPoint = now
+		}
+		record.Size = recording.size()
+		si.mergeTrackFragment(recording, false)
+	}
+
+	for id, recording := range si.trackRecordings {
+		if _, tracked := si.allBoats[id]; tracked {
+			continue
+		}
+		if si.isInRecovery && si.recoveryData != nil && si.recoveryData.ObjectID == id {
+			continue // The lost boat's recording continues if recovery re-binds it
+		}
+		delete(si.trackRecordings, id)
+		si.mergeTrackFragment(recording, true)
+		record := recording.finish()
+		si.rememberEndedTrack(record)
+		si.debugMsgVerbose("TRACK_RECORDER", fmt.Sprintf("🗃️ Track ended after %v (%d detections, %d path points)",
+			record.LastSeen.Sub(record.FirstSeen).Round(time.Second), record.Detections, len(record.Path)), id)
+		go si.trackEnded(record)
+	}
+}
+
+// finish completes the derived statistics of a recording
+func (r *trackRecording) finish() TrackRecord {
+	record := r.record
+	if r.samples > 0 {
+		record.AvgConfidence = r.confidenceSum / float64(r.samples)
+	} else {
+		record.MinConfidence = 0
+	}
+	return record
+}
+
+// size is the mean bounding box size at 1x zoom so far (0 before the object was detected)
+func (r *trackRecording) size() float64 {
+	if r.sizeSamples == 0 {
+		return 0
+	}
+	return r.sizeSum / float64(r.sizeSamples)
+}
+
+// FlushTrackRecords finishes the recordings of every object still being tracked and hands them to the
+// recorder synchronously, so they are stored before shutdown
+func (si *SpatialIntegration) FlushTrackRecords() {
+	si.mu.Lock()
+	var records []TrackRecord
+	for id, recording := range si.trackRecordings {
+		records = append(records, recording.finish())
+		delete(si.trackRecordings, id)
+	}
+	onTrackEnded := si.trackEnded
+	si.mu.Unlock()
+
+	if onTrackEnded == nil {
+		return
+	}
+	for _, record := range records {
+		onTrackEnded(record)
+	}
+}
@@ -0,0 +1,68 @@
+package tracking
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Velocity sanity bounds. Speeds are pixels/second at the widest zoom (camera zoom 10 = 1x) and scale
+// linearly with optical zoom, since the same vessel crosses more pixels per second when zoomed in.
+const (
+	DefaultMaxPixelSpeed  = 300.0 // Bound for classes without an explicit entry (the old global clamp)
+	velocityReferenceZoom = 10.0  // Camera zoom value the bounds are specified at
+)
+
+// DefaultClassMaxSpeeds returns the built-in per-class maximum plausible speeds (px/s at 1x zoom)
+func DefaultClassMaxSpeeds() map[string]float64 {
+	return map[string]float64{
+		"boat":      250, // Fast runabouts; barges and ships are far slower
+		"surfboard": 150, // Paddle boards, kayaks and jet skis that YOLO calls surfboards
+		"person":    60,  // Swimmers and people moving on deck
+	}
+}
+
+// ConfigureVelocityBounds sets the per-class maximum plausible speeds (px/s at 1x zoom). Classes not in
+// maxSpeeds use fallback. Velocity estimates above the bound are rejected before they feed prediction and recovery.
+func (si *SpatialIntegration) ConfigureVelocityBounds(maxSpeeds map[string]float64, fallback float64) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.classMaxSpeeds = maxSpeeds
+	si.defaultMaxSpeed = fallback
+
+	classes := make([]string, 0, len(maxSpeeds))
+	for class, speed := range maxSpeeds {
+		classes = append(classes, fmt.Sprintf("%s=%.0f", class, speed))
+	}
+	sort.Strings(classes)
+	si.debugMsg("VELOCITY_BOUNDS", fmt.Sprintf("🚤 Max plausible speeds (px/s at 1x zoom): %s, other=%.0f", strings.Join(classes, " "), fallback))
+}
+
+// maxPlausibleSpeed returns the speed bound for a class at the given camera zoom. Must be called with si.mu held.
+func (si *SpatialIntegration) maxPlausibleSpeed(className string, zoom float64) float64 {
+	bound, exists := si.classMaxSpeeds[className]
+	if !exists {
+		bound = si.defaultMaxSpeed
+		if bound <= 0 {
+			bound = DefaultMaxPixelSpeed
+		}
+	}
+	return bound * math.Max(1.0, zoom/velocityReferenceZoom)
+}
+
+// plausibleVelocity reports whether a velocity estimate is within the class bound at the current zoom.
+// Must be called with si.mu held.
+func (si *SpatialIntegration) plausibleVelocity(boat *TrackedBoat, velocityX, velocityY float64) bool {
+	zoom := si.ptzCtrl.GetCurrentPosition().Zoom
+	speed := math.Sqrt(velocityX*velocityX + velocityY*velocityY)
+	bound := si.maxPlausibleSpeed(boat.Classification, zoom)
+	if speed <= bound {
+		return true
+	}
+
+	si.debugMsg("VELOCITY_BOUNDS", fmt.Sprintf("🚫 Rejected %s velocity %.0f px/s for %s (max %.0f px/s at zoom %.0f) - keeping (%.1f, %.1f)",
+		boat.Classification, speed, boat.ID, bound, zoom, boat.PixelVelocity.X, boat.PixelVelocity.Y), boat.ID)
+	return false
+}
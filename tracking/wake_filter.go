@@ -0,0 +1,206 @@
+package tracking
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+)
+
+// Wake filter aggressiveness levels
+const (
+	WakeFilterOff    = "off"
+	WakeFilterLow    = "low"
+	WakeFilterMedium = "medium"
+	WakeFilterHigh   = "high"
+)
+
+const (
+	wakeSignatureSize = 8   // Detection regions are compared as 8x8 grids of mean brightness
+	foamMinBrightness = 180 // Darkest channel of a foam pixel (0-255)
+	foamMaxSpread     = 40  // Largest channel difference of a foam pixel: foam is white, not colored
+	wakeSampleTarget  = 48  // Pixels sampled along each axis of a detection for the white ratio
+	wakeMinHistory    = 3   // Detections needed before the filter judges a track (jitter needs two steps)
+)
+
+// wakeThresholds are the cue limits of one aggressiveness level. A new track is rejected as a wake when at
+// least cuesNeeded of the available cues exceed their limit and one of them is texture or jitter: a white
+// hull in sunlight is as bright as foam, so whiteness alone never rejects.
+type wakeThresholds struct {
+	whiteRatio float64 // Fraction of foam-white pixels in the box
+	texture    float64 // Mean frame-to-frame change of the box's brightness pattern (0-1)
+	jitter     float64 // Mean deviation of the box's frame-to-frame motion from its average, in box diagonals
+	cuesNeeded int
+}
+
+var wakeLevels = map[string]wakeThresholds{
+	WakeFilterLow:    {whiteRatio: 0.55, texture: 0.12, jitter: 0.5, cuesNeeded: 2},
+	WakeFilterMedium: {whiteRatio: 0.40, texture: 0.08, jitter: 0.35, cuesNeeded: 2},
+	WakeFilterHigh:   {whiteRatio: 0.30, texture: 0.06, jitter: 0.25, cuesNeeded: 1},
+}
+
+// wakeMeasure is what the wake filter measured of one detection
+type wakeMeasure struct {
+	whiteRatio float64
+	signature  []float64 // Mean-removed brightness grid (nil when the frame was unavailable)
+}
+
+// ConfigureWakeFilter sets how aggressively new tracks are checked for boat wakes and foam before they are
+// created: "off", "low", "medium" or "high". The checks are the share of foam-white pixels in the box, how
+// much the box's texture churns from frame to frame, and how erratically the box moves while detection
+// fusion confirms it.
+func (si *SpatialIntegration) ConfigureWakeFilter(level string) error {
+	level = strings.ToLower(strings.TrimSpace(level))
+	if level == "" {
+		level = WakeFilterOff
+	}
+	if _, ok := wakeLevels[level]; !ok && level != WakeFilterOff {
+		return fmt.Errorf("unknown wake filter level %q (expected off, low, medium or high)", level)
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.wakeFilterLevel = level
+	if level != WakeFilterOff {
+		limits := wakeLevels[level]
+		si.debugMsg("WAKE_FILTER", fmt.Sprintf("🌊 Wake/foam filter %s: reject new tracks with %d+ of white ratio ≥%.2f, texture churn ≥%.2f, motion jitter ≥%.2f",
+			level, limits.cuesNeeded, limits.whiteRatio, limits.texture, limits.jitter))
+	}
+	return nil
+}
+
+// wakeFilterEnabled reports whether new tracks are checked for wakes. Must be called with si.mu held.
+func (si *SpatialIntegration) wakeFilterEnabled() bool {
+	return si.wakeFilterLevel != "" && si.wakeFilterLevel != WakeFilterOff
+}
+
+// measureWake samples a detection region of the current frame (BGR, frameWidth x frameHeight). Must be
+// called with si.mu held during UpdateTracking.
+func (si *SpatialIntegration) measureWake(rect image.Rectangle) wakeMeasure {
+	width, height := si.frameWidth, si.frameHeight
+	if len(si.currentFrame) != width*height*3 {
+		return wakeMeasure{}
+	}
+	rect = rect.Intersect(image.Rect(0, 0, width, height))
+	if rect.Dx() < wakeSignatureSize || rect.Dy() < wakeSignatureSize {
+		return wakeMeasure{}
+	}
+
+	stepX := max(1, rect.Dx()/wakeSampleTarget)
+	stepY := max(1, rect.Dy()/wakeSampleTarget)
+	var signature [wakeSignatureSize * wakeSignatureSize]float64
+	var counts [wakeSignatureSize * wakeSignatureSize]int
+	white, samples := 0, 0
+	for y := rect.Min.Y; y < rect.Max.Y; y += stepY {
+		row := (y - rect.Min.Y) * wakeSignatureSize / rect.Dy()
+		for x := rect.Min.X; x < rect.Max.X; x += stepX {
+			i := (y*width + x) * 3
+			b, g, r := int(si.currentFrame[i]), int(si.currentFrame[i+1]), int(si.currentFrame[i+2])
+			low, high := min(b, g, r), max(b, g, r)
+			if low >= foamMinBrightness && high-low <= foamMaxSpread {
+				white++
+			}
+			samples++
+
+			cell := row*wakeSignatureSize + (x-rect.Min.X)*wakeSignatureSize/rect.Dx()
+			signature[cell] += float64(b+g+r) / 3
+			counts[cell]++
+		}
+	}
+
+	measure := wakeMeasure{whiteRatio: float64(white) / float64(samples), signature: make([]float64, len(signature))}
+	mean := 0.0
+	for i := range signature {
+		if counts[i] > 0 {
+			signature[i] /= float64(counts[i])
+		}
+		mean += signature[i]
+	}
+	mean /= float64(len(signature))
+	for i := range signature {
+		measure.signature[i] = signature[i] - mean // Lighting changes don't count as texture change
+	}
+	return measure
+}
+
+// isWake decides whether the detections that confirmed a new track (oldest first) look like a wake rather
+// than a boat. Tracks with fewer than wakeMinHistory detections are let through. Must be called with si.mu
+// held.
+func (si *SpatialIntegration) isWake(history []fusionCandidate) (bool, string) {
+	limits, ok := wakeLevels[si.wakeFilterLevel]
+	if !ok || len(history) < wakeMinHistory {
+		return false, ""
+	}
+
+	var cues []string
+	available := 0
+	motionCue := false // Texture or jitter exceeded its limit
+
+	// Foam is bright and colorless
+	whiteSum, whiteSamples := 0.0, 0
+	for _, candidate := range history {
+		if candidate.wake.signature != nil {
+			whiteSum += candidate.wake.whiteRatio
+			whiteSamples++
+		}
+	}
+	if whiteSamples > 0 {
+		available++
+		if white := whiteSum / float64(whiteSamples); white >= limits.whiteRatio {
+			cues = append(cues, fmt.Sprintf("white %.2f", white))
+		}
+	}
+
+	// Foam churns: its brightness pattern changes from frame to frame where a hull's stays put
+	changeSum, changes := 0.0, 0
+	for i := 1; i < len(history); i++ {
+		previous, current := history[i-1].wake.signature, history[i].wake.signature
+		if previous == nil || current == nil {
+			continue
+		}
+		diff := 0.0
+		for j := range current {
+			diff += math.Abs(current[j] - previous[j])
+		}
+		changeSum += diff / float64(len(current)) / 255
+		changes++
+	}
+	if changes > 0 {
+		available++
+		if texture := changeSum / float64(changes); texture >= limits.texture {
+			cues = append(cues, fmt.Sprintf("texture %.2f", texture))
+			motionCue = true
+		}
+	}
+
+	// Wake detections jump around the foam instead of moving steadily like a hull
+	available++
+	var steps []image.Point
+	for i := 1; i < len(history); i++ {
+		steps = append(steps, history[i].center.Sub(history[i-1].center))
+	}
+	meanX, meanY := 0.0, 0.0
+	for _, step := range steps {
+		meanX += float64(step.X)
+		meanY += float64(step.Y)
+	}
+	meanX /= float64(len(steps))
+	meanY /= float64(len(steps))
+	deviation := 0.0
+	for _, step := range steps {
+		deviation += math.Hypot(float64(step.X)-meanX, float64(step.Y)-meanY)
+	}
+	last := history[len(history)-1].rect
+	diagonal := math.Max(1, math.Hypot(float64(last.Dx()), float64(last.Dy())))
+	if jitter := deviation / float64(len(steps)) / diagonal; jitter >= limits.jitter {
+		cues = append(cues, fmt.Sprintf("jitter %.2f", jitter))
+		motionCue = true
+	}
+
+	needed := min(limits.cuesNeeded, available)
+	if needed == 0 || len(cues) < needed || !motionCue {
+		return false, ""
+	}
+	return true, strings.Join(cues, ", ")
+}
@@ -0,0 +1,101 @@
+package tracking
+
+import (
+	"image"
+	"testing"
+)
+
+// wakeHistory builds the detections that confirmed a track: 60x40 boxes at the given centers with the given
+// white ratio, and a brightness signature per detection (nil = frame unavailable)
+func wakeHistory(centers []image.Point, whiteRatio float64, signatures [][]float64) []fusionCandidate {
+	history := make([]fusionCandidate, len(centers))
+	for i, center := range centers {
+		history[i] = fusionCandidate{
+			rect:   image.Rect(center.X-30, center.Y-20, center.X+30, center.Y+20),
+			center: center,
+			wake:   wakeMeasure{whiteRatio: whiteRatio},
+		}
+		if signatures != nil {
+			history[i].wake.signature = signatures[i%len(signatures)]
+		}
+	}
+	return history
+}
+
+// wakeSignature returns a mean-removed brightness grid: a checkerboard of ±value
+func wakeSignature(value float64) []float64 {
+	signature := make([]float64, wakeSignatureSize*wakeSignatureSize)
+	for i := range signature {
+		if i%2 == 0 {
+			signature[i] = value
+		} else {
+			signature[i] = -value
+		}
+	}
+	return signature
+}
+
+func steadyCenters(n int) []image.Point {
+	centers := make([]image.Point, n)
+	for i := range centers {
+		centers[i] = image.Pt(200+i*10, 300)
+	}
+	return centers
+}
+
+func jitteryCenters(n int) []image.Point {
+	centers := make([]image.Point, n)
+	for i := range centers {
+		if i%2 == 0 {
+			centers[i] = image.Pt(200, 300)
+		} else {
+			centers[i] = image.Pt(260, 340)
+		}
+	}
+	return centers
+}
+
+func TestIsWake(t *testing.T) {
+	still := [][]float64{wakeSignature(20)}
+	churning := [][]float64{wakeSignature(60), wakeSignature(-60)}
+
+	tests := []struct {
+		name    string
+		level   string
+		history []fusionCandidate
+		want    bool
+	}{
+		{"filter off", WakeFilterOff, wakeHistory(jitteryCenters(5), 1, churning), false},
+		{"too little history", WakeFilterHigh, wakeHistory(jitteryCenters(wakeMinHistory-1), 1, churning), false},
+		{"steady hull", WakeFilterMedium, wakeHistory(steadyCenters(5), 0.1, still), false},
+		{"white hull moving steadily", WakeFilterHigh, wakeHistory(steadyCenters(5), 0.9, still), false},
+		{"churning foam", WakeFilterMedium, wakeHistory(steadyCenters(5), 0.9, churning), true},
+		{"churning but dark at medium", WakeFilterMedium, wakeHistory(steadyCenters(5), 0.1, churning), false},
+		{"jitter without a frame at high", WakeFilterHigh, wakeHistory(jitteryCenters(5), 0, nil), true},
+		{"jitter without a frame at medium", WakeFilterMedium, wakeHistory(jitteryCenters(5), 0, nil), true},
+		{"white and jittery", WakeFilterLow, wakeHistory(jitteryCenters(5), 0.9, still), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			si := &SpatialIntegration{wakeFilterLevel: test.level}
+			got, cues := si.isWake(test.history)
+			if got != test.want {
+				t.Errorf("isWake = %v (%s), want %v", got, cues, test.want)
+			}
+			if got && cues == "" {
+				t.Errorf("a rejected track must name its cues")
+			}
+		})
+	}
+}
+
+func TestConfigureWakeFilter(t *testing.T) {
+	si := &SpatialIntegration{}
+	if err := si.ConfigureWakeFilter("bogus"); err == nil {
+		t.Errorf("unknown level accepted")
+	}
+	if err := si.ConfigureWakeFilter(""); err != nil || si.wakeFilterEnabled() {
+		t.Errorf("empty level: err %v, enabled %v; want off", err, si.wakeFilterEnabled())
+	}
+}
@@ -0,0 +1,89 @@
+package tracking
+
+import (
+	"fmt"
+	"image"
+)
+
+// ClassZone is an area (spatial pan/tilt coordinates) with its own P1/P2 class lists, e.g. "boat,kayak" in
+// the main channel but "person" next to a dock. Detections inside the zone are classified by its lists
+// instead of the global -p1-track/-p2-track lists.
+type ClassZone struct {
+	Name             string
+	MinPan, MaxPan   float64
+	MinTilt, MaxTilt float64
+	Classes          TrackLists
+}
+
+// Contains reports whether a spatial coordinate lies inside the zone
+func (z ClassZone) Contains(coord SpatialCoordinate) bool {
+	return coord.Pan >= z.MinPan && coord.Pan <= z.MaxPan && coord.Tilt >= z.MinTilt && coord.Tilt <= z.MaxTilt
+}
+
+// Roles reports whether a class is a P1 target and/or a P2 enhancement under these lists. With P1All every
+// class is P1 and none is P2; with P2All every non-P1 class is P2.
+func (l TrackLists) Roles(className string) (p1, p2 bool) {
+	if l.P1All {
+		return true, false
+	}
+	for _, class := range l.P1 {
+		if class == className {
+			return true, false
+		}
+	}
+	if l.P2All {
+		return false, true
+	}
+	for _, class := range l.P2 {
+		if class == className {
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// ConfigureClassZones sets the zones with their own class lists (nil: the global lists apply everywhere).
+// The first matching zone wins where zones overlap.
+func (si *SpatialIntegration) ConfigureClassZones(zones []ClassZone) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.classZones = zones
+	for _, zone := range zones {
+		si.debugMsg("CLASS_ZONE", fmt.Sprintf("🏷️ Class zone %s: pan %.0f-%.0f tilt %.0f-%.0f tracks P1=%s P2=%s",
+			zone.Name, zone.MinPan, zone.MaxPan, zone.MinTilt, zone.MaxTilt,
+			describeClasses(zone.Classes.P1, zone.Classes.P1All), describeClasses(zone.Classes.P2, zone.Classes.P2All)))
+	}
+}
+
+// DetectionRoles classifies a detection by the class policy of the zone its center falls in, falling back to
+// the global P1/P2 lists outside every class zone. zone is "" when the global lists applied.
+func (si *SpatialIntegration) DetectionRoles(rect image.Rectangle, className string) (p1, p2 bool, zone string) {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	return si.detectionRoles(rect, className)
+}
+
+// detectionRoles is DetectionRoles for callers already holding si.mu
+func (si *SpatialIntegration) detectionRoles(rect image.Rectangle, className string) (p1, p2 bool, zone string) {
+	if len(si.classZones) > 0 {
+		center := si.calculateSpatialCoordinatesForPixel(rect.Min.X+rect.Dx()/2, rect.Min.Y+rect.Dy()/2)
+		for _, classZone := range si.classZones {
+			if classZone.Contains(center) {
+				p1, p2 = classZone.Classes.Roles(className)
+				return p1, p2, classZone.Name
+			}
+		}
+	}
+	return si.isP1Object(className), si.isP2Object(className), ""
+}
+
+func describeClasses(classes []string, all bool) string {
+	if all {
+		return "all"
+	}
+	if len(classes) == 0 {
+		return "none"
+	}
+	return fmt.Sprintf("%v", classes)
+}
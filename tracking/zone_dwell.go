@@ -0,0 +1,207 @@
+package tracking
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DefaultLoiterThreshold is how long a vessel must stay in one river zone to count as a loiterer
+const DefaultLoiterThreshold = 10 * time.Minute
+
+// RiverZone is a named stretch of river (spatial pan/tilt coordinates) that dwell time is accumulated for,
+// e.g. "no-wake" for the no-wake zone
+type RiverZone struct {
+	Name             string
+	MinPan, MaxPan   float64
+	MinTilt, MaxTilt float64
+}
+
+// Contains reports whether a spatial coordinate lies inside the zone
+func (z RiverZone) Contains(coord SpatialCoordinate) bool {
+	return coord.Pan >= z.MinPan && coord.Pan <= z.MaxPan && coord.Tilt >= z.MinTilt && coord.Tilt <= z.MaxTilt
+}
+
+// ZoneDailyStats is the dwell summary of one river zone for one calendar day (local time)
+type ZoneDailyStats struct {
+	Date              string  `json:"date"` // 2006-01-02
+	Zone              string  `json:"zone"`
+	Transits          int     `json:"transits"`             // Completed visits (vessel left the zone or stopped being tracked)
+	TotalDwellSeconds float64 `json:"total_dwell_seconds"`  // Sum of completed visit durations
+	AvgTransitSeconds float64 `json:"avg_transit_seconds"`  // TotalDwellSeconds / Transits
+	MaxDwellSeconds   float64 `json:"max_dwell_seconds"`    // Longest completed visit
+	Loiterers         int     `json:"loiterers"`            // Visits that reached the loiter threshold
+	Occupancy         int     `json:"occupancy"`            // Vessels in the zone right now (today only)
+	Partial           bool    `json:"partial,omitempty"`    // Day was still in progress when the summary was taken
+	LoiterThreshold   float64 `json:"loiter_threshold_sec"` // Threshold the loiterer count was taken with
+}
+
+// zoneVisit is one vessel's current stay in a zone
+type zoneVisit struct {
+	zone     string
+	entered  time.Time
+	lastSeen time.Time
+	loiterer bool // Already counted as a loiterer
+}
+
+// ConfigureZoneDwell enables dwell accounting for the given river zones. onDayClosed receives the summaries
+// of a finished day (on its own goroutine) at local midnight so they can be stored.
+func (si *SpatialIntegration) ConfigureZoneDwell(zones []RiverZone, loiterThreshold time.Duration, onDayClosed func([]ZoneDailyStats)) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if loiterThreshold <= 0 {
+		loiterThreshold = DefaultLoiterThreshold
+	}
+	si.dwellZones = zones
+	si.loiterThreshold = loiterThreshold
+	si.dwellDayClosed = onDayClosed
+	si.zoneVisits = make(map[string]*zoneVisit)
+	si.dwellDay = time.Now().Format("2006-01-02")
+	si.dwellStats = make(map[string]*ZoneDailyStats)
+
+	for _, zone := range zones {
+		si.debugMsg("ZONE_DWELL", fmt.Sprintf("⏱️ Dwell zone %s: pan %.0f-%.0f tilt %.0f-%.0f (loiter after %v)",
+			zone.Name, zone.MinPan, zone.MaxPan, zone.MinTilt, zone.MaxTilt, loiterThreshold))
+	}
+}
+
+// dwellZoneFor returns the name of the river zone a boat is in ("" when outside every zone). Must be called with si.mu held.
+func (si *SpatialIntegration) dwellZoneFor(boat *TrackedBoat) string {
+	spatial := si.calculateSpatialCoordinatesForPixel(boat.CurrentPixel.X, boat.CurrentPixel.Y)
+	for _, zone := range si.dwellZones {
+		if zone.Contains(spatial) {
+			return zone.Name
+		}
+	}
+	return ""
+}
+
+// recordZoneDwell opens, extends and closes zone visits for the tracked vessels. Lost boats keep their visit
+// open without extending it; a visit closes when the boat is seen in another zone or is removed.
+// Must be called with si.mu held.
+func (si *SpatialIntegration) recordZoneDwell() {
+	if len(si.dwellZones) == 0 {
+		return
+	}
+
+	now := time.Now()
+	si.rollDwellDay(now)
+
+	for id, boat := range si.allBoats {
+		if boat.LostFrames > 0 || boat.PersonOverboard {
+			continue
+		}
+
+		zone := si.dwellZoneFor(boat)
+		visit, inVisit := si.zoneVisits[id]
+		if inVisit && visit.zone != zone {
+			si.closeZoneVisit(id, visit)
+			inVisit = false
+		}
+		if zone == "" {
+			continue
+		}
+		if !inVisit {
+			visit = &zoneVisit{zone: zone, entered: now}
+			si.zoneVisits[id] = visit
+			si.debugMsgVerbose("ZONE_DWELL", fmt.Sprintf("➡️ %s entered zone %s", id, zone), id)
+		}
+		visit.lastSeen = now
+
+		if !visit.loiterer && now.Sub(visit.entered) >= si.loiterThreshold {
+			visit.loiterer = true
+			si.dwellStatsFor(zone).Loiterers++
+			si.debugMsg("ZONE_DWELL", fmt.Sprintf("🕰️ %s %s loitering in zone %s for %v", boat.Classification, id, zone,
+				now.Sub(visit.entered).Round(time.Second)), id)
+		}
+	}
+
+	// Boats that are no longer tracked end their visit at the last time they were seen
+	for id, visit := range si.zoneVisits {
+		if _, tracked := si.allBoats[id]; !tracked {
+			si.closeZoneVisit(id, visit)
+		}
+	}
+}
+
+// closeZoneVisit adds a finished visit to the day's statistics. Must be called with si.mu held.
+func (si *SpatialIntegration) closeZoneVisit(id string, visit *zoneVisit) {
+	delete(si.zoneVisits, id)
+
+	dwell := visit.lastSeen.Sub(visit.entered).Seconds()
+	stats := si.dwellStatsFor(visit.zone)
+	stats.Transits++
+	stats.TotalDwellSeconds += dwell
+	stats.AvgTransitSeconds = stats.TotalDwellSeconds / float64(stats.Transits)
+	if dwell > stats.MaxDwellSeconds {
+		stats.MaxDwellSeconds = dwell
+	}
+
+	si.debugMsgVerbose("ZONE_DWELL", fmt.Sprintf("⬅️ %s left zone %s after %.0fs", id, visit.zone, dwell), id)
+}
+
+// dwellStatsFor returns today's statistics for a zone, creating them on first use. Must be called with si.mu held.
+func (si *SpatialIntegration) dwellStatsFor(zone string) *ZoneDailyStats {
+	stats, exists := si.dwellStats[zone]
+	if !exists {
+		stats = &ZoneDailyStats{Date: si.dwellDay, Zone: zone, LoiterThreshold: si.loiterThreshold.Seconds()}
+		si.dwellStats[zone] = stats
+	}
+	return stats
+}
+
+// rollDwellDay hands the finished day's summaries to the day-closed callback at local midnight. Visits that
+// span midnight stay open and are counted on the day they end. Must be called with si.mu held.
+func (si *SpatialIntegration) rollDwellDay(now time.Time) {
+	today := now.Format("2006-01-02")
+	if today == si.dwellDay {
+		return
+	}
+
+	closed := si.dwellSummaryLocked(false)
+	si.debugMsg("ZONE_DWELL", fmt.Sprintf("📅 Closing dwell statistics for %s (%d zone(s))", si.dwellDay, len(closed)))
+
+	si.dwellDay = today
+	si.dwellStats = make(map[string]*ZoneDailyStats)
+	for _, visit := range si.zoneVisits {
+		visit.loiterer = false // Loiterers are counted again on the new day
+	}
+
+	if si.dwellDayClosed != nil && len(closed) > 0 {
+		go si.dwellDayClosed(closed)
+	}
+}
+
+// dwellSummaryLocked returns a copy of the current day's statistics for every configured zone, sorted by
+// zone name. Must be called with si.mu held.
+func (si *SpatialIntegration) dwellSummaryLocked(partial bool) []ZoneDailyStats {
+	summary := make([]ZoneDailyStats, 0, len(si.dwellZones))
+	for _, zone := range si.dwellZones {
+		stats := *si.dwellStatsFor(zone.Name)
+		stats.Partial = partial
+		stats.Occupancy = 0
+		if partial {
+			for _, visit := range si.zoneVisits {
+				if visit.zone == zone.Name {
+					stats.Occupancy++
+				}
+			}
+		}
+		summary = append(summary, stats)
+	}
+	sort.Slice(summary, func(i, j int) bool { return summary[i].Zone < summary[j].Zone })
+	return summary
+}
+
+// GetZoneDwellSummary returns the statistics of the day in progress for every river zone
+func (si *SpatialIntegration) GetZoneDwellSummary() []ZoneDailyStats {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if len(si.dwellZones) == 0 {
+		return nil
+	}
+	si.rollDwellDay(time.Now())
+	return si.dwellSummaryLocked(true)
+}